@@ -27,6 +27,7 @@ import (
 
 	"github.com/pulumi/pulumi/pkg/v2/backend/display"
 	"github.com/pulumi/pulumi/pkg/v2/engine"
+	"github.com/pulumi/pulumi/pkg/v2/resource/deploy"
 	"github.com/pulumi/pulumi/sdk/v2/go/common/apitype"
 	"github.com/pulumi/pulumi/sdk/v2/go/common/diag/colors"
 	"github.com/pulumi/pulumi/sdk/v2/go/common/resource"
@@ -43,9 +44,10 @@ type ApplierOptions struct {
 	ShowLink bool
 }
 
-// Applier applies the changes specified by this update operation against the target stack.
+// Applier applies the changes specified by this update operation against the target stack. For a preview, it also
+// returns the update plan that the preview recorded.
 type Applier func(ctx context.Context, kind apitype.UpdateKind, stack Stack, op UpdateOperation,
-	opts ApplierOptions, events chan<- engine.Event) (engine.ResourceChanges, result.Result)
+	opts ApplierOptions, events chan<- engine.Event) (*deploy.UpdatePlan, engine.ResourceChanges, result.Result)
 
 func ActionLabel(kind apitype.UpdateKind, dryRun bool) string {
 	v := updateTextMap[kind]
@@ -68,6 +70,8 @@ var updateTextMap = map[apitype.UpdateKind]struct {
 	apitype.RefreshUpdate: {"refresh", "Refreshing"},
 	apitype.DestroyUpdate: {"destroy", "Destroying"},
 	apitype.ImportUpdate:  {"import", "Importing"},
+
+	apitype.ResourceImportUpdate: {"import", "Importing"},
 }
 
 type response string
@@ -111,7 +115,7 @@ func PreviewThenPrompt(ctx context.Context, kind apitype.UpdateKind, stack Stack
 		ShowLink: false,
 	}
 
-	changes, res := apply(ctx, kind, stack, op, opts, eventsChannel)
+	_, changes, res := apply(ctx, kind, stack, op, opts, eventsChannel)
 	if res != nil {
 		close(eventsChannel)
 		return changes, res
@@ -208,7 +212,8 @@ func PreviewThenPromptThenExecute(ctx context.Context, kind apitype.UpdateKind,
 		DryRun:   false,
 		ShowLink: true,
 	}
-	return apply(ctx, kind, stack, op, opts, nil /*events*/)
+	_, changes, res := apply(ctx, kind, stack, op, opts, nil /*events*/)
+	return changes, res
 }
 
 func createDiff(updateKind apitype.UpdateKind, events []engine.Event, displayOpts display.Options) string {
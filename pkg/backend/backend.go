@@ -78,6 +78,13 @@ type StackReference interface {
 	Name() tokens.QName
 }
 
+// ProjectStackReference is implemented by stack references that know the project to which their stack belongs.
+type ProjectStackReference interface {
+	StackReference
+	// Project returns the name of the stack's project, or false if the project is not known.
+	Project() (tokens.PackageName, bool)
+}
+
 // PolicyPackReference is an opaque type that refers to a PolicyPack managed by a backend. The CLI
 // uses the ParsePolicyPackReference method to turn a string like "myOrg/mySecurityRules" into a
 // PolicyPackReference that can be used to interact with the PolicyPack via the backend.
@@ -152,23 +159,30 @@ type Backend interface {
 
 	RenameStack(ctx context.Context, stack Stack, newName tokens.QName) error
 
-	// Preview shows what would be updated given the current workspace's contents.
-	Preview(ctx context.Context, stack Stack, op UpdateOperation) (engine.ResourceChanges, result.Result)
+	// Preview shows what would be updated given the current workspace's contents, and returns the previewed
+	// operations as an update plan.
+	Preview(ctx context.Context, stack Stack, op UpdateOperation) (*deploy.UpdatePlan, engine.ResourceChanges, result.Result)
 	// Update updates the target stack with the current workspace's contents (config and code).
 	Update(ctx context.Context, stack Stack, op UpdateOperation) (engine.ResourceChanges, result.Result)
 	// Refresh refreshes the stack's state from the cloud provider.
 	Refresh(ctx context.Context, stack Stack, op UpdateOperation) (engine.ResourceChanges, result.Result)
 	// Destroy destroys all of this stack's resources.
 	Destroy(ctx context.Context, stack Stack, op UpdateOperation) (engine.ResourceChanges, result.Result)
+	// Import adopts existing cloud resources into the stack without running its program.
+	Import(ctx context.Context, stack Stack, op UpdateOperation) (engine.ResourceChanges, result.Result)
 	// Watch watches the project's working directory for changes and automatically updates the active stack.
 	Watch(ctx context.Context, stack Stack, op UpdateOperation) result.Result
+	// DetectDrift reports the stack's resources whose actual state differs from its checkpoint, without modifying
+	// the checkpoint.
+	DetectDrift(ctx context.Context, stack Stack, op UpdateOperation) ([]ResourceDrift, result.Result)
 
 	// Query against the resource outputs in a stack's state checkpoint.
 	Query(ctx context.Context, op QueryOperation) result.Result
 
 	// GetHistory returns all updates for the stack. The returned UpdateInfo slice will be in
-	// descending order (newest first).
-	GetHistory(ctx context.Context, stackRef StackReference) ([]UpdateInfo, error)
+	// descending order (newest first). The per-resource summaries of each update are only guaranteed to be
+	// populated if includeResources is true, as reading them may be expensive.
+	GetHistory(ctx context.Context, stackRef StackReference, includeResources bool) ([]UpdateInfo, error)
 	// GetLogs fetches a list of log entries for the given stack, with optional filtering/querying.
 	GetLogs(ctx context.Context, stack Stack, cfg StackConfiguration,
 		query operations.LogQuery) ([]operations.LogEntry, error)
@@ -210,6 +224,9 @@ type UpdateOperation struct {
 	SecretsManager     secrets.Manager
 	StackConfiguration StackConfiguration
 	Scopes             CancellationScopeSource
+
+	// Imports is the set of resources to adopt during an import operation.
+	Imports []deploy.Import
 }
 
 // QueryOperation configures a query operation.
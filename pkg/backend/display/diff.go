@@ -116,6 +116,8 @@ func RenderDiffEvent(action apitype.UpdateKind, event engine.Event,
 		return renderDiffDiagEvent(event.Payload().(engine.DiagEventPayload), opts)
 	case engine.PolicyViolationEvent:
 		return renderDiffPolicyViolationEvent(event.Payload().(engine.PolicyViolationEventPayload), opts)
+	case engine.PolicyRemediationEvent:
+		return renderPolicyRemediationEvent(event.Payload().(engine.PolicyRemediationEventPayload), 0, opts)
 
 	default:
 		contract.Failf("unknown event type '%s'", event.Type)
@@ -134,6 +136,20 @@ func renderDiffPolicyViolationEvent(payload engine.PolicyViolationEventPayload,
 	return opts.Color.Colorize(payload.Prefix + payload.Message)
 }
 
+// renderPolicyRemediationEvent renders a line naming the policy that remediated a resource, followed by the changes
+// that the policy made to the resource's inputs.
+func renderPolicyRemediationEvent(payload engine.PolicyRemediationEventPayload, indent int, opts Options) string {
+	var b bytes.Buffer
+	fprintfIgnoreError(&b, "%s%s[%s]  %s v%s %s %s (%s: %s)\n",
+		engine.GetIndentationString(indent), colors.SpecInfo, apitype.Remediate,
+		payload.PolicyPackName, payload.PolicyPackVersion, colors.Reset,
+		payload.PolicyName, payload.ResourceURN.Name(), payload.ResourceURN.Type())
+	if diff := payload.Before.Diff(payload.After); diff != nil {
+		engine.PrintObjectDiff(&b, *diff, nil /*include*/, payload.Planning, indent+1, true /*summary*/, payload.Debug)
+	}
+	return opts.Color.Colorize(b.String())
+}
+
 func renderStdoutColorEvent(payload engine.StdoutEventPayload, opts Options) string {
 	return opts.Color.Colorize(payload.Message)
 }
@@ -24,6 +24,7 @@ import (
 	"github.com/pulumi/pulumi/pkg/v2/engine"
 	"github.com/pulumi/pulumi/pkg/v2/resource/deploy"
 	"github.com/pulumi/pulumi/sdk/v2/go/common/apitype"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/diag"
 	"github.com/pulumi/pulumi/sdk/v2/go/common/resource"
 	"github.com/pulumi/pulumi/sdk/v2/go/common/tokens"
 	"github.com/pulumi/pulumi/sdk/v2/go/common/util/contract"
@@ -41,10 +42,19 @@ func ShowEvents(
 		events, done = startEventLogger(events, done, opts.EventLogPath)
 	}
 
+	if opts.JSONDiff {
+		ShowJSONDiffEvents(events, done, opts)
+		return
+	}
+
 	if opts.JSONDisplay {
-		// TODO[pulumi/pulumi#2390]: enable JSON display for real deployments.
-		contract.Assertf(isPreview, "JSON display only available in preview mode")
-		ShowJSONEvents(op, action, events, done, opts)
+		// Standalone previews are rendered as a single document. All other operations, including the previews that
+		// precede them, stream their events as they happen.
+		if action == apitype.PreviewUpdate {
+			ShowJSONEvents(op, action, events, done, opts)
+		} else {
+			ShowStreamingJSONEvents(events, done, opts)
+		}
 		return
 	}
 
@@ -58,11 +68,31 @@ func ShowEvents(
 			"directly instead of through ShowEvents")
 	case DisplayWatch:
 		ShowWatchEvents(op, action, events, done, opts)
+	case DisplayNone:
+		ShowDiagnosticEvents(events, done, opts)
 	default:
 		contract.Failf("Unknown display type %d", opts.Type)
 	}
 }
 
+// ShowDiagnosticEvents displays the error and warning diagnostics among the engine events on stderr, ignoring all
+// other events.
+func ShowDiagnosticEvents(events <-chan engine.Event, done chan<- bool, opts Options) {
+	defer close(done)
+
+	for event := range events {
+		switch event.Type {
+		case engine.CancelEvent:
+			return
+		case engine.DiagEvent:
+			payload := event.Payload().(engine.DiagEventPayload)
+			if payload.Severity == diag.Error || payload.Severity == diag.Warning {
+				fprintIgnoreError(os.Stderr, renderDiffDiagEvent(payload, opts))
+			}
+		}
+	}
+}
+
 func startEventLogger(events <-chan engine.Event, done chan<- bool, path string) (<-chan engine.Event, chan<- bool) {
 	// Before moving further, attempt to open the log file.
 	logFile, err := os.Create(path)
@@ -67,6 +67,27 @@ func ConvertEngineEvent(e engine.Event) (apitype.EngineEvent, error) {
 			EnforcementLevel:     string(p.EnforcementLevel),
 		}
 
+	case engine.PolicyRemediationEvent:
+		p, ok := e.Payload().(engine.PolicyRemediationEventPayload)
+		if !ok {
+			return apiEvent, eventTypePayloadMismatch
+		}
+		encrypter := config.BlindingCrypter
+		before, err := stack.SerializeProperties(p.Before, encrypter, false /* showSecrets */)
+		contract.IgnoreError(err)
+		after, err := stack.SerializeProperties(p.After, encrypter, false /* showSecrets */)
+		contract.IgnoreError(err)
+		apiEvent.PolicyRemediationEvent = &apitype.PolicyRemediationEvent{
+			ResourceURN:          string(p.ResourceURN),
+			Color:                string(p.Color),
+			PolicyName:           p.PolicyName,
+			PolicyPackName:       p.PolicyPackName,
+			PolicyPackVersion:    p.PolicyPackVersion,
+			PolicyPackVersionTag: p.PolicyPackVersion,
+			Before:               before,
+			After:                after,
+		}
+
 	case engine.PreludeEvent:
 		p, ok := e.Payload().(engine.PreludeEventPayload)
 		if !ok {
@@ -95,6 +116,7 @@ func ConvertEngineEvent(e engine.Event) (apitype.EngineEvent, error) {
 			MaybeCorrupt:    p.MaybeCorrupt,
 			DurationSeconds: int(p.Duration.Seconds()),
 			ResourceChanges: changes,
+			Resources:       convertResourceSummaries(p.Resources),
 			PolicyPacks:     p.PolicyPacks,
 		}
 
@@ -136,6 +158,20 @@ func ConvertEngineEvent(e engine.Event) (apitype.EngineEvent, error) {
 	return apiEvent, nil
 }
 
+func convertResourceSummaries(resources []engine.ResourceSummary) []apitype.ResourceSummary {
+	var summaries []apitype.ResourceSummary
+	for _, r := range resources {
+		summaries = append(summaries, apitype.ResourceSummary{
+			URN:                  string(r.URN),
+			Type:                 string(r.Type),
+			Op:                   apitype.OpType(r.Op),
+			DurationMilliseconds: r.Duration.Milliseconds(),
+			Error:                r.Error,
+		})
+	}
+	return summaries
+}
+
 func convertStepEventMetadata(md engine.StepEventMetadata) apitype.StepEventMetadata {
 	keys := make([]string, len(md.Keys))
 	for i, v := range md.Keys {
@@ -17,6 +17,9 @@ package display
 import (
 	"encoding/json"
 	"fmt"
+	"io"
+	"os"
+	"sync/atomic"
 	"time"
 
 	"github.com/pulumi/pulumi/pkg/v2/engine"
@@ -86,7 +89,7 @@ func stateForJSONOutput(s *resource.State, opts Options) *resource.State {
 	return resource.NewState(s.Type, s.URN, s.Custom, s.Delete, s.ID, inputs,
 		outputs, s.Parent, s.Protect, s.External, s.Dependencies, s.InitErrors, s.Provider,
 		s.PropertyDependencies, s.PendingReplacement, s.AdditionalSecretOutputs, s.Aliases, &s.CustomTimeouts,
-		s.ImportID)
+		s.ImportID, s.ReplaceOnChanges, s.RetainOnDelete)
 }
 
 // ShowJSONEvents renders engine events from a preview into a well-formed JSON document. Note that this does not
@@ -175,6 +178,8 @@ func ShowJSONEvents(op string, action apitype.UpdateKind, events <-chan engine.E
 
 				digest.Steps = append(digest.Steps, step)
 			}
+		case engine.PolicyRemediationEvent:
+			// Remediations have already been applied to the inputs recorded in each step's new state.
 		case engine.ResourceOutputsEvent, engine.ResourceOperationFailed:
 			// Because we are only JSON serializing previews, we don't need to worry about outputs
 			// resolving or operations failing. In the future, if we serialize actual deployments, we will
@@ -198,6 +203,47 @@ func ShowJSONEvents(op string, action apitype.UpdateKind, events <-chan engine.E
 	fmt.Println(string(out))
 }
 
+// streamSequence is the sequence number of the next event written by ShowStreamingJSONEvents. It is shared by all
+// streams in the process so that the events of a preview and of the update that follows it are totally ordered.
+var streamSequence int64
+
+// ShowStreamingJSONEvents renders engine events as a stream of JSON objects, one per line, each of which is an
+// apitype.EngineEvent. Events are written to stdout as soon as they arrive, so that the stream can be consumed while
+// the operation is still running. Debug diagnostics are omitted unless debug output is enabled.
+func ShowStreamingJSONEvents(events <-chan engine.Event, done chan<- bool, opts Options) {
+	streamJSONEvents(os.Stdout, events, done, opts)
+}
+
+func streamJSONEvents(w io.Writer, events <-chan engine.Event, done chan<- bool, opts Options) {
+	// Ensure we close the done channel before exiting.
+	defer func() { close(done) }()
+
+	encoder := json.NewEncoder(w)
+	for e := range events {
+		if e.Type == engine.DiagEvent && !opts.Debug {
+			if p := e.Payload().(engine.DiagEventPayload); p.Severity == diag.Debug {
+				continue
+			}
+		}
+
+		apiEvent, err := ConvertEngineEvent(e)
+		if err != nil {
+			logging.V(7).Infof("not streaming event of type %s: %v", e.Type, err)
+			continue
+		}
+		apiEvent.Sequence = int(atomic.AddInt64(&streamSequence, 1) - 1)
+		apiEvent.Timestamp = int(time.Now().Unix())
+		if err = encoder.Encode(apiEvent); err != nil {
+			logging.V(7).Infof("failed to write event: %v", err)
+		}
+
+		// In the event of cancelation, stop streaming immediately.
+		if e.Type == engine.CancelEvent {
+			return
+		}
+	}
+}
+
 // previewDigest is a JSON-serializable overview of a preview operation.
 type previewDigest struct {
 	// Config contains a map of configuration keys/values used during the preview. Any secrets will be blinded.
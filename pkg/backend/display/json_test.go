@@ -0,0 +1,61 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package display
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pulumi/pulumi/pkg/v2/engine"
+	"github.com/pulumi/pulumi/pkg/v2/resource/deploy"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/apitype"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/diag"
+)
+
+func TestStreamJSONEvents(t *testing.T) {
+	events, done := make(chan engine.Event), make(chan bool)
+	var buf bytes.Buffer
+	go streamJSONEvents(&buf, events, done, Options{})
+
+	events <- engine.NewEvent(engine.DiagEvent, engine.DiagEventPayload{Message: "debug", Severity: diag.Debug})
+	events <- engine.NewEvent(engine.DiagEvent, engine.DiagEventPayload{Message: "warning", Severity: diag.Warning})
+	events <- engine.NewEvent(engine.SummaryEvent, engine.SummaryEventPayload{
+		ResourceChanges: engine.ResourceChanges{deploy.OpCreate: 2},
+	})
+	close(events)
+	<-done
+
+	// Each event is written on its own line; debug diagnostics are omitted.
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if !assert.Len(t, lines, 2) {
+		t.FailNow()
+	}
+
+	var diagEvent, summaryEvent apitype.EngineEvent
+	assert.NoError(t, json.Unmarshal([]byte(lines[0]), &diagEvent))
+	assert.NoError(t, json.Unmarshal([]byte(lines[1]), &summaryEvent))
+
+	if assert.NotNil(t, diagEvent.DiagnosticEvent) {
+		assert.Equal(t, "warning", diagEvent.DiagnosticEvent.Message)
+	}
+	if assert.NotNil(t, summaryEvent.SummaryEvent) {
+		assert.Equal(t, 2, summaryEvent.SummaryEvent.ResourceChanges[string(deploy.OpCreate)])
+	}
+	assert.Equal(t, diagEvent.Sequence+1, summaryEvent.Sequence)
+}
@@ -0,0 +1,205 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package display
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+
+	"github.com/pulumi/pulumi/pkg/v2/engine"
+	"github.com/pulumi/pulumi/pkg/v2/resource/deploy"
+	"github.com/pulumi/pulumi/pkg/v2/resource/stack"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/diag"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/diag/colors"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/resource"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/resource/config"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/resource/plugin"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/tokens"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/util/contract"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/util/logging"
+)
+
+// ShowJSONDiffEvents renders the engine events from a preview as a single JSON document that describes, for each
+// resource, the operation that the engine intends to perform and the property-level differences that cause it. Secret
+// values are masked. Like ShowJSONEvents, nothing is written until the event stream is closed.
+func ShowJSONDiffEvents(events <-chan engine.Event, done chan<- bool, opts Options) {
+	showJSONDiffEvents(os.Stdout, events, done, opts)
+}
+
+func showJSONDiffEvents(w io.Writer, events <-chan engine.Event, done chan<- bool, opts Options) {
+	// Ensure we close the done channel before exiting.
+	defer func() { close(done) }()
+
+	var digest previewDiff
+	for e := range events {
+		// In the event of cancelation, break out of the loop immediately.
+		if e.Type == engine.CancelEvent {
+			break
+		}
+
+		switch e.Type {
+		case engine.DiagEvent:
+			// Skip any ephemeral or debug messages, and elide all colorization.
+			p := e.Payload().(engine.DiagEventPayload)
+			if !p.Ephemeral && p.Severity != diag.Debug {
+				digest.Diagnostics = append(digest.Diagnostics, previewDiagnostic{
+					URN:      p.URN,
+					Message:  colors.Never.Colorize(p.Prefix + p.Message),
+					Severity: p.Severity,
+				})
+			}
+		case engine.ResourcePreEvent:
+			if m := e.Payload().(engine.ResourcePreEventPayload).Metadata; shouldShow(m, opts) {
+				digest.Resources = append(digest.Resources, newResourceDiff(m))
+			}
+		case engine.SummaryEvent:
+			p := e.Payload().(engine.SummaryEventPayload)
+			digest.ChangeSummary = p.ResourceChanges
+		}
+	}
+
+	out, err := json.MarshalIndent(&digest, "", "    ")
+	contract.Assertf(err == nil, "unexpected JSON error: %v", err)
+	_, err = fmt.Fprintln(w, string(out))
+	contract.IgnoreError(err)
+}
+
+// newResourceDiff describes the changes that the given step makes to its resource.
+func newResourceDiff(step engine.StepEventMetadata) resourceDiff {
+	diff := resourceDiff{
+		URN:         step.URN,
+		Type:        step.Type,
+		Op:          step.Op,
+		ReplaceKeys: step.Keys,
+	}
+	if step.Old == nil || step.New == nil {
+		return diff
+	}
+
+	// Old values are taken from a step's outputs, or from its inputs for input diffs; new values are always taken
+	// from its inputs. This matches the textual rendering of detailed diffs.
+	oldInputs := resource.NewObjectProperty(MassageSecrets(step.Old.Inputs, false))
+	oldOutputs := resource.NewObjectProperty(MassageSecrets(step.Old.Outputs, false))
+	newInputs := resource.NewObjectProperty(MassageSecrets(step.New.Inputs, false))
+
+	if step.DetailedDiff != nil {
+		for path, pdiff := range step.DetailedDiff {
+			elements, err := resource.ParsePropertyPath(path)
+			if err != nil {
+				logging.V(7).Infof("not adding diff for invalid property path %q: %v", path, err)
+				continue
+			}
+			olds := oldOutputs
+			if pdiff.InputDiff {
+				olds = oldInputs
+			}
+			diff.Diffs = append(diff.Diffs, newPropertyValueDiff(path, pdiff.Kind, pdiff.InputDiff,
+				elements, olds, newInputs))
+		}
+	} else {
+		// Without a detailed diff from the provider, describe each top-level property that differs.
+		replaces := make(map[resource.PropertyKey]bool)
+		for _, k := range step.Keys {
+			replaces[k] = true
+		}
+		for _, k := range step.Diffs {
+			elements := resource.PropertyPath{string(k)}
+			_, hasOld := elements.Get(oldOutputs)
+			_, hasNew := elements.Get(newInputs)
+
+			kind := plugin.DiffUpdate
+			switch {
+			case !hasOld && hasNew:
+				kind = plugin.DiffAdd
+			case hasOld && !hasNew:
+				kind = plugin.DiffDelete
+			}
+			if replaces[k] {
+				kind = kind.AsReplace()
+			}
+			diff.Diffs = append(diff.Diffs, newPropertyValueDiff(string(k), kind, false,
+				elements, oldOutputs, newInputs))
+		}
+	}
+
+	sort.Slice(diff.Diffs, func(i, j int) bool { return diff.Diffs[i].Path < diff.Diffs[j].Path })
+	return diff
+}
+
+// newPropertyValueDiff describes a difference of the given kind in the property at the given path.
+func newPropertyValueDiff(path string, kind plugin.DiffKind, inputDiff bool, elements resource.PropertyPath,
+	olds, news resource.PropertyValue) propertyValueDiff {
+
+	diff := propertyValueDiff{Path: path, Kind: kind.String(), InputDiff: inputDiff}
+	if old, ok := elements.Get(olds); ok {
+		diff.Old = serializeDiffValue(old)
+	}
+	if new, ok := elements.Get(news); ok {
+		diff.New = serializeDiffValue(new)
+	}
+	return diff
+}
+
+// serializeDiffValue converts a property value, whose secrets must already have been masked, to its JSON form.
+func serializeDiffValue(v resource.PropertyValue) interface{} {
+	result, err := stack.SerializePropertyValue(v, config.NewPanicCrypter(), false /* showSecrets */)
+	if err != nil {
+		logging.V(7).Infof("not adding property value as there was an error serializing: %s", err)
+		return nil
+	}
+	return result
+}
+
+// previewDiff is a JSON-serializable description of the changes that a preview would make.
+type previewDiff struct {
+	// Resources lists the resources that would change, in the order in which the engine would change them.
+	Resources []resourceDiff `json:"resources"`
+	// Diagnostics contains a record of all warnings/errors that took place during the preview. Note that
+	// ephemeral and debug messages are omitted from this list, as they are meant for display purposes only.
+	Diagnostics []previewDiagnostic `json:"diagnostics,omitempty"`
+	// ChangeSummary contains a map of count per operation (create, update, etc).
+	ChangeSummary engine.ResourceChanges `json:"changeSummary,omitempty"`
+}
+
+// resourceDiff describes the changes that a preview would make to a single resource.
+type resourceDiff struct {
+	// URN is the resource being affected.
+	URN resource.URN `json:"urn"`
+	// Type is the type of the resource.
+	Type tokens.Type `json:"type"`
+	// Op is the kind of operation being performed.
+	Op deploy.StepOp `json:"op"`
+	// ReplaceKeys is a list of keys that are causing replacement (for replacement steps only).
+	ReplaceKeys []resource.PropertyKey `json:"replaceKeys,omitempty"`
+	// Diffs lists the changed properties, sorted by path.
+	Diffs []propertyValueDiff `json:"diffs,omitempty"`
+}
+
+// propertyValueDiff describes the change to a single property value.
+type propertyValueDiff struct {
+	// Path is the path to the property, e.g. "tags.env" or "rules[0].port".
+	Path string `json:"path"`
+	// Kind is the kind of difference, e.g. "update" or "add-replace".
+	Kind string `json:"kind"`
+	// InputDiff is true if this is a difference between old and new inputs instead of old state and new inputs.
+	InputDiff bool `json:"inputDiff"`
+	// Old is the old value of the property, if it had one. Secrets are masked.
+	Old interface{} `json:"old,omitempty"`
+	// New is the new value of the property, if it has one. Secrets are masked.
+	New interface{} `json:"new,omitempty"`
+}
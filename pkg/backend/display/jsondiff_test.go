@@ -0,0 +1,135 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package display
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pulumi/pulumi/pkg/v2/engine"
+	"github.com/pulumi/pulumi/pkg/v2/resource/deploy"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/resource"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/resource/plugin"
+)
+
+func TestShowJSONDiffEvents(t *testing.T) {
+	urn := resource.URN("urn:pulumi:stack::project::pkgA:m:typA::resA")
+	old := &engine.StepEventStateMetadata{
+		Inputs: resource.PropertyMap{
+			"size":     resource.NewNumberProperty(1),
+			"password": resource.MakeSecret(resource.NewStringProperty("hunter2")),
+			"tags": resource.NewObjectProperty(resource.PropertyMap{
+				"env": resource.NewStringProperty("dev"),
+			}),
+		},
+	}
+	old.Outputs = old.Inputs
+	new := &engine.StepEventStateMetadata{
+		Inputs: resource.PropertyMap{
+			"size":     resource.NewNumberProperty(2),
+			"password": resource.MakeSecret(resource.NewStringProperty("hunter3")),
+			"tags": resource.NewObjectProperty(resource.PropertyMap{
+				"env":   resource.NewStringProperty("prod"),
+				"owner": resource.NewStringProperty("me"),
+			}),
+		},
+	}
+
+	events, done := make(chan engine.Event), make(chan bool)
+	var buf bytes.Buffer
+	go showJSONDiffEvents(&buf, events, done, Options{JSONDisplay: true, JSONDiff: true})
+
+	events <- engine.NewEvent(engine.ResourcePreEvent, engine.ResourcePreEventPayload{
+		Metadata: engine.StepEventMetadata{
+			Op:   deploy.OpReplace,
+			URN:  urn,
+			Type: urn.Type(),
+			Keys: []resource.PropertyKey{"size"},
+			Old:  old,
+			New:  new,
+			DetailedDiff: map[string]plugin.PropertyDiff{
+				"size":       {Kind: plugin.DiffUpdateReplace},
+				"password":   {Kind: plugin.DiffUpdate},
+				"tags.env":   {Kind: plugin.DiffUpdate, InputDiff: true},
+				"tags.owner": {Kind: plugin.DiffAdd},
+			},
+			Logical: true,
+		},
+	})
+	events <- engine.NewEvent(engine.SummaryEvent, engine.SummaryEventPayload{
+		ResourceChanges: engine.ResourceChanges{deploy.OpReplace: 1},
+	})
+	close(events)
+	<-done
+
+	var digest map[string]interface{}
+	if !assert.NoError(t, json.Unmarshal(buf.Bytes(), &digest)) {
+		t.FailNow()
+	}
+	assert.Equal(t, map[string]interface{}{"replace": float64(1)}, digest["changeSummary"])
+
+	resources := digest["resources"].([]interface{})
+	if !assert.Len(t, resources, 1) {
+		t.FailNow()
+	}
+	res := resources[0].(map[string]interface{})
+	assert.Equal(t, string(urn), res["urn"])
+	assert.Equal(t, "pkgA:m:typA", res["type"])
+	assert.Equal(t, "replace", res["op"])
+	assert.Equal(t, []interface{}{"size"}, res["replaceKeys"])
+
+	// Diffs are sorted by path, and secrets are masked.
+	assert.Equal(t, []interface{}{
+		map[string]interface{}{"path": "password", "kind": "update", "inputDiff": false,
+			"old": "[secret]", "new": "[secret]"},
+		map[string]interface{}{"path": "size", "kind": "update-replace", "inputDiff": false,
+			"old": float64(1), "new": float64(2)},
+		map[string]interface{}{"path": "tags.env", "kind": "update", "inputDiff": true,
+			"old": "dev", "new": "prod"},
+		map[string]interface{}{"path": "tags.owner", "kind": "add", "inputDiff": false,
+			"new": "me"},
+	}, res["diffs"])
+}
+
+func TestJSONDiffWithoutDetailedDiff(t *testing.T) {
+	old := &engine.StepEventStateMetadata{
+		Outputs: resource.PropertyMap{
+			"size": resource.NewNumberProperty(1),
+			"name": resource.NewStringProperty("a"),
+		},
+	}
+	new := &engine.StepEventStateMetadata{
+		Inputs: resource.PropertyMap{
+			"size":  resource.NewNumberProperty(2),
+			"color": resource.NewStringProperty("red"),
+		},
+	}
+
+	diff := newResourceDiff(engine.StepEventMetadata{
+		Op:    deploy.OpReplace,
+		Keys:  []resource.PropertyKey{"name"},
+		Diffs: []resource.PropertyKey{"size", "name", "color"},
+		Old:   old,
+		New:   new,
+	})
+	assert.Equal(t, []propertyValueDiff{
+		{Path: "color", Kind: "add", New: "red"},
+		{Path: "name", Kind: "delete-replace", Old: "a"},
+		{Path: "size", Kind: "update", Old: float64(1), New: float64(2)},
+	}, diff.Diffs)
+}
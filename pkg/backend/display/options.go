@@ -28,6 +28,8 @@ const (
 	DisplayQuery
 	// DisplayQuery displays query output.
 	DisplayWatch
+	// DisplayNone displays only error and warning diagnostics, leaving stdout free for a command's own output.
+	DisplayNone
 )
 
 // Options controls how the output of events are rendered
@@ -41,7 +43,8 @@ type Options struct {
 	SummaryDiff          bool                // true if diff display should be summarized.
 	IsInteractive        bool                // true if we should display things interactively.
 	Type                 Type                // type of display (rich diff, progress, or query).
-	JSONDisplay          bool                // true if we should emit the entire diff as JSON.
+	JSONDisplay          bool                // true if we should emit events as JSON.
+	JSONDiff             bool                // true to render a JSON preview as a structured diff.
 	EventLogPath         string              // the path to the file to use for logging events, if any.
 	Debug                bool                // true to enable debug output.
 }
@@ -197,6 +197,8 @@ func getEventUrnAndMetadata(event engine.Event) (resource.URN, *engine.StepEvent
 		return event.Payload().(engine.DiagEventPayload).URN, nil
 	case engine.PolicyViolationEvent:
 		return event.Payload().(engine.PolicyViolationEventPayload).ResourceURN, nil
+	case engine.PolicyRemediationEvent:
+		return event.Payload().(engine.PolicyRemediationEventPayload).ResourceURN, nil
 	default:
 		return "", nil
 	}
@@ -680,6 +682,7 @@ func (display *ProgressDisplay) processEndSteps() {
 	// Render several "sections" of output based on available data as applicable.
 	display.writeBlankLine()
 	wroteDiagnosticHeader := display.printDiagnostics()
+	display.printPolicyRemediations()
 	wrotePolicyViolations := display.printPolicyViolations()
 	display.printOutputs()
 	// If no policies violated, print policy packs applied.
@@ -758,6 +761,35 @@ func (display *ProgressDisplay) printDiagnostics() bool {
 	return wroteDiagnosticHeader
 }
 
+// printPolicyRemediations prints a new "Policy Remediations:" section with all of the remediations grouped by
+// resource, in the order in which they were applied. If no policy remediations were applied, prints nothing.
+func (display *ProgressDisplay) printPolicyRemediations() {
+	var rows []ResourceRow
+	for _, row := range display.eventUrnToResourceRow {
+		if len(row.PolicyRemediationPayloads()) > 0 {
+			rows = append(rows, row)
+		}
+	}
+	if len(rows) == 0 {
+		return
+	}
+	sort.SliceStable(rows, func(i, j int) bool {
+		return rows[i].DisplayOrderIndex() < rows[j].DisplayOrderIndex()
+	})
+
+	display.writeSimpleMessage(
+		display.opts.Color.Colorize(colors.SpecHeadline + "Policy Remediations:" + colors.Reset))
+	for _, row := range rows {
+		for _, payload := range row.PolicyRemediationPayloads() {
+			msg := renderPolicyRemediationEvent(payload, 1, display.opts)
+			for _, line := range splitIntoDisplayableLines(msg) {
+				display.writeSimpleMessage(strings.TrimRightFunc(line, unicode.IsSpace))
+			}
+		}
+	}
+	display.writeBlankLine()
+}
+
 // printPolicyViolations prints a new "Policy Violation:" section with all of the violations
 // grouped by policy pack. If no policy violations were encountered, prints nothing.
 func (display *ProgressDisplay) printPolicyViolations() bool {
@@ -1036,7 +1068,8 @@ func (display *ProgressDisplay) processNormalEvent(event engine.Event) {
 	// Always show row if there's a policy violation event. Policy violations prevent resource
 	// registration, so if we don't show the row, the violation gets attributed to the stack
 	// resource rather than the resources whose policy failed.
-	hideRowIfUnnecessary = hideRowIfUnnecessary || event.Type == engine.PolicyViolationEvent
+	hideRowIfUnnecessary = hideRowIfUnnecessary || event.Type == engine.PolicyViolationEvent ||
+		event.Type == engine.PolicyRemediationEvent
 	if !hideRowIfUnnecessary {
 		row.SetHideRowIfUnnecessary(false)
 	}
@@ -1071,6 +1104,9 @@ func (display *ProgressDisplay) processNormalEvent(event engine.Event) {
 	} else if event.Type == engine.PolicyViolationEvent {
 		// also record this policy violation so we print it at the end.
 		row.RecordPolicyViolationEvent(event)
+	} else if event.Type == engine.PolicyRemediationEvent {
+		// also record this policy remediation so we print it at the end.
+		row.RecordPolicyRemediationEvent(event)
 	} else {
 		contract.Failf("Unhandled event type '%s'", event.Type)
 	}
@@ -1173,7 +1209,7 @@ func (display *ProgressDisplay) getStepDoneDescription(step engine.StepEventMeta
 	if display.isPreview {
 		// During a preview, when we transition to done, we'll print out summary text describing the step instead of a
 		// past-tense verb describing the step that was performed.
-		return op.Color() + display.getPreviewDoneText(step) + colors.Reset
+		return op.Color() + display.getPreviewDoneText(step) + getRetainedSuffix(step) + colors.Reset
 	}
 
 	getDescription := func() string {
@@ -1240,7 +1276,7 @@ func (display *ProgressDisplay) getStepDoneDescription(step engine.StepEventMeta
 		return makeError(getDescription())
 	}
 
-	return op.Color() + getDescription() + colors.Reset
+	return op.Color() + getDescription() + getRetainedSuffix(step) + colors.Reset
 }
 
 func (display *ProgressDisplay) getPreviewText(step engine.StepEventMetadata) string {
@@ -1387,7 +1423,19 @@ func (display *ProgressDisplay) getStepInProgressDescription(step engine.StepEve
 		contract.Failf("Unrecognized resource step op: %v", op)
 		return ""
 	}
-	return op.Color() + getDescription() + colors.Reset
+	return op.Color() + getDescription() + getRetainedSuffix(step) + colors.Reset
+}
+
+// getRetainedSuffix returns a marker for steps that delete a resource with the retainOnDelete option set. Such steps
+// only remove the resource from the stack; the resource itself is left in place.
+func getRetainedSuffix(step engine.StepEventMetadata) string {
+	if step.Op != deploy.OpDelete && step.Op != deploy.OpDeleteReplaced {
+		return ""
+	}
+	if step.Old == nil || step.Old.State == nil || !step.Old.State.RetainOnDelete {
+		return ""
+	}
+	return " [retain]"
 }
 
 func writeString(b io.StringWriter, s string) {
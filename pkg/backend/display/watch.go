@@ -71,6 +71,10 @@ func ShowWatchEvents(op string, action apitype.UpdateKind, events <-chan engine.
 				PrintfWithWatchPrefix(time.Now(), string(p.Metadata.URN.Name()),
 					"done %s %s\n", p.Metadata.Op, p.Metadata.URN.Type())
 			}
+		case engine.PolicyRemediationEvent:
+			p := e.Payload().(engine.PolicyRemediationEventPayload)
+			PrintfWithWatchPrefix(time.Now(), string(p.ResourceURN.Name()),
+				"remediated by policy %s (%s)\n", p.PolicyName, p.PolicyPackName)
 		case engine.ResourceOperationFailed:
 			p := e.Payload().(engine.ResourceOperationFailedPayload)
 			if shouldShow(p.Metadata, opts) {
@@ -0,0 +1,94 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package backend
+
+import (
+	"context"
+	"sort"
+
+	"github.com/pulumi/pulumi/pkg/v2/engine"
+	"github.com/pulumi/pulumi/pkg/v2/resource/deploy"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/apitype"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/resource"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/tokens"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/util/result"
+)
+
+// ResourceDrift describes a resource whose actual state differs from the state recorded in its stack's checkpoint.
+type ResourceDrift struct {
+	URN     resource.URN         // the resource's URN.
+	Type    tokens.Type          // the resource's type.
+	ID      resource.ID          // the resource's ID.
+	Deleted bool                 // true if the resource no longer exists.
+	Old     resource.PropertyMap // the outputs recorded in the checkpoint.
+	New     resource.PropertyMap // the outputs read from the provider, if the resource still exists.
+	Diff    *resource.ObjectDiff // the difference between the recorded and actual outputs, if the resource exists.
+}
+
+// DetectDrift reads the current state of each of the stack's resources from its provider and reports the resources
+// whose state differs from the stack's checkpoint. This is a refresh performed as a preview, so the checkpoint is
+// never modified. The returned drift is sorted by URN.
+func DetectDrift(ctx context.Context, stack Stack, op UpdateOperation, apply Applier) ([]ResourceDrift, result.Result) {
+	events := make(chan engine.Event)
+	eventsDone := make(chan bool)
+
+	var drift []ResourceDrift
+	go func() {
+		for e := range events {
+			if e.Type != engine.ResourceOutputsEvent {
+				continue
+			}
+			if d, ok := resourceDrift(e.Payload().(engine.ResourceOutputsEventPayload).Metadata); ok {
+				drift = append(drift, d)
+			}
+		}
+		close(eventsDone)
+	}()
+
+	_, _, res := apply(ctx, apitype.RefreshUpdate, stack, op, ApplierOptions{DryRun: true}, events)
+	close(events)
+	<-eventsDone
+	if res != nil {
+		return nil, res
+	}
+
+	sort.Slice(drift, func(i, j int) bool { return drift[i].URN < drift[j].URN })
+	return drift, nil
+}
+
+// resourceDrift returns the drift described by the outputs event for a refresh step, if any.
+func resourceDrift(step engine.StepEventMetadata) (ResourceDrift, bool) {
+	if step.Old == nil || step.Old.State == nil {
+		return ResourceDrift{}, false
+	}
+	old := step.Old.State
+
+	switch step.Op {
+	case deploy.OpDelete:
+		return ResourceDrift{URN: old.URN, Type: old.Type, ID: old.ID, Deleted: true, Old: old.Outputs}, true
+	case deploy.OpUpdate:
+		if step.New == nil || step.New.State == nil {
+			return ResourceDrift{}, false
+		}
+		new := step.New.State
+		diff := old.Outputs.Diff(new.Outputs)
+		if diff == nil {
+			return ResourceDrift{}, false
+		}
+		return ResourceDrift{URN: old.URN, Type: old.Type, ID: new.ID, Old: old.Outputs, New: new.Outputs, Diff: diff}, true
+	default:
+		return ResourceDrift{}, false
+	}
+}
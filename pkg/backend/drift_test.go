@@ -0,0 +1,55 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package backend
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pulumi/pulumi/pkg/v2/engine"
+	"github.com/pulumi/pulumi/pkg/v2/resource/deploy"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/resource"
+)
+
+func TestResourceDrift(t *testing.T) {
+	urn := resource.NewURN("stack", "proj", "", "pkg:m:typ", "res")
+	state := func(outputs resource.PropertyMap) *engine.StepEventStateMetadata {
+		return &engine.StepEventStateMetadata{
+			State: &resource.State{Type: "pkg:m:typ", URN: urn, ID: "id", Custom: true, Outputs: outputs},
+		}
+	}
+	old := resource.PropertyMap{"foo": resource.NewStringProperty("bar")}
+
+	// Unchanged resources have not drifted.
+	_, ok := resourceDrift(engine.StepEventMetadata{Op: deploy.OpSame, Old: state(old), New: state(old)})
+	assert.False(t, ok)
+
+	// Changed resources report the difference in their outputs.
+	new := resource.PropertyMap{"foo": resource.NewStringProperty("baz")}
+	d, ok := resourceDrift(engine.StepEventMetadata{Op: deploy.OpUpdate, Old: state(old), New: state(new)})
+	assert.True(t, ok)
+	assert.Equal(t, urn, d.URN)
+	assert.False(t, d.Deleted)
+	if assert.NotNil(t, d.Diff) {
+		assert.True(t, d.Diff.Updated("foo"))
+	}
+
+	// Deleted resources are reported as such.
+	d, ok = resourceDrift(engine.StepEventMetadata{Op: deploy.OpDelete, Old: state(old)})
+	assert.True(t, ok)
+	assert.True(t, d.Deleted)
+	assert.Nil(t, d.Diff)
+}
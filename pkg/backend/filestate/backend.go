@@ -29,6 +29,7 @@ import (
 	"time"
 
 	"github.com/pkg/errors"
+	uuid "github.com/satori/go.uuid"
 	"gocloud.dev/blob"
 	_ "gocloud.dev/blob/azureblob" // driver for azblob://
 	_ "gocloud.dev/blob/fileblob"  // driver for file://
@@ -43,6 +44,7 @@ import (
 	"github.com/pulumi/pulumi/pkg/v2/resource/deploy"
 	"github.com/pulumi/pulumi/pkg/v2/resource/edit"
 	"github.com/pulumi/pulumi/pkg/v2/resource/stack"
+	"github.com/pulumi/pulumi/pkg/v2/secrets"
 	"github.com/pulumi/pulumi/pkg/v2/util/validation"
 	"github.com/pulumi/pulumi/sdk/v2/go/common/apitype"
 	"github.com/pulumi/pulumi/sdk/v2/go/common/diag"
@@ -61,6 +63,17 @@ import (
 type Backend interface {
 	backend.Backend
 	local() // at the moment, no local specific info, so just use a marker function.
+
+	// CancelCurrentUpdate breaks any advisory locks held on the given stack, e.g. by a crashed update.
+	CancelCurrentUpdate(ctx context.Context, stackRef backend.StackReference) error
+
+	// RestoreStack makes the checkpoint recorded for the given version of a stack its current checkpoint. The
+	// checkpoint's secrets are re-encrypted using the given secrets manager.
+	RestoreStack(ctx context.Context, stack backend.Stack, version int, sm secrets.Manager) error
+
+	// Upgrade migrates a bucket that stores all of its stacks in a single namespace to the project-scoped layout.
+	// Stacks whose project cannot be determined from their resources are moved into the given default project.
+	Upgrade(ctx context.Context, defaultProject tokens.PackageName) error
 }
 
 type localBackend struct {
@@ -74,20 +87,74 @@ type localBackend struct {
 
 	bucket Bucket
 	mutex  sync.Mutex
+
+	// lockID is the unique identifier of the advisory stack locks taken by this backend instance.
+	lockID string
+
+	// projectMode is true if the bucket stores stacks in project-scoped namespaces, i.e. at
+	// `.pulumi/stacks/<project>/<stack>.json`, rather than in a single namespace shared by all projects.
+	projectMode bool
+	// currentProject is the project in the current workspace, if any.
+	currentProject *workspace.Project
 }
 
 type localBackendReference struct {
-	name tokens.QName
+	name    tokens.QName
+	project tokens.PackageName // the stack's project; empty for stacks that are not stored in a project namespace.
+	b       *localBackend
 }
 
 func (r localBackendReference) String() string {
-	return string(r.name)
+	// If the project is the current project, we can elide it.
+	if r.project == "" || (r.b != nil && r.b.currentProject != nil && r.project == r.b.currentProject.Name) {
+		return string(r.name)
+	}
+	return fmt.Sprintf("%s/%s", r.project, r.name)
 }
 
 func (r localBackendReference) Name() tokens.QName {
 	return r.name
 }
 
+// Project returns the stack's project if the stack is stored in a project namespace.
+func (r localBackendReference) Project() (tokens.PackageName, bool) {
+	return r.project, r.project != ""
+}
+
+// FullyQualifiedName returns the name under which the stack is stored in the backend's bucket, which includes the
+// stack's project if it has one.
+func (r localBackendReference) FullyQualifiedName() tokens.QName {
+	if r.project == "" {
+		return r.name
+	}
+	return tokens.QName(fmt.Sprintf("%s/%s", r.project, r.name))
+}
+
+// qualifiedStackName returns the name under which the referenced stack is stored in the backend's bucket.
+func qualifiedStackName(ref backend.StackReference) tokens.QName {
+	if r, ok := ref.(localBackendReference); ok {
+		return r.FullyQualifiedName()
+	}
+	return ref.Name()
+}
+
+// splitQualifiedStackName splits the name under which a stack is stored into the stack's project, which may be
+// empty, and the stack's name. Note that tokens.QName's accessors cannot be used here, as stack and project names may
+// contain dashes.
+func splitQualifiedStackName(qualifiedName tokens.QName) (tokens.PackageName, tokens.QName) {
+	s := string(qualifiedName)
+	if ix := strings.LastIndex(s, tokens.QNameDelimiter); ix != -1 {
+		return tokens.PackageName(s[:ix]), tokens.QName(s[ix+1:])
+	}
+	return "", qualifiedName
+}
+
+// newReference returns a reference to the stack that is stored under the given name in the backend's bucket.
+func (b *localBackend) newReference(qualifiedName tokens.QName) localBackendReference {
+	project, name := splitQualifiedStackName(qualifiedName)
+	return localBackendReference{name: name, project: project, b: b}
+}
+
 func IsFileStateBackendURL(urlstr string) bool {
 	u, err := url.Parse(urlstr)
 	if err != nil {
@@ -100,6 +167,18 @@ func IsFileStateBackendURL(urlstr string) bool {
 const FilePathPrefix = "file://"
 
 func New(d diag.Sink, originalURL string) (Backend, error) {
+	// When running inside a project directory, remember the project so that references to its stacks may elide it.
+	currentProject, err := workspace.DetectProject()
+	if err != nil {
+		currentProject = nil
+	}
+	return NewForProject(d, originalURL, currentProject)
+}
+
+// NewForProject creates a backend for the given URL whose current project is the given project rather than the one
+// detected from the working directory. This allows callers that do not run inside a project directory, such as the
+// Automation API, to refer to stacks by their unqualified names.
+func NewForProject(d diag.Sink, originalURL string, currentProject *workspace.Project) (Backend, error) {
 	if !IsFileStateBackendURL(originalURL) {
 		return nil, errors.Errorf("local URL %s has an illegal prefix; expected one of: %s",
 			originalURL, strings.Join(blob.DefaultURLMux().BucketSchemes(), ", "))
@@ -142,12 +221,19 @@ func New(d diag.Sink, originalURL string) (Backend, error) {
 		}
 	}
 
-	return &localBackend{
-		d:           d,
-		originalURL: originalURL,
-		url:         u,
-		bucket:      &wrappedBucket{bucket: bucket},
-	}, nil
+	b := &localBackend{
+		d:              d,
+		originalURL:    originalURL,
+		url:            u,
+		bucket:         &wrappedBucket{bucket: bucket},
+		lockID:         uuid.NewV4().String(),
+		currentProject: currentProject,
+	}
+
+	if b.projectMode, err = b.detectProjectMode(); err != nil {
+		return nil, err
+	}
+	return b, nil
 }
 
 // massageBlobPath takes the path the user provided and converts it to an appropriate form go-cloud
@@ -241,19 +327,63 @@ func (b *localBackend) SupportsOrganizations() bool {
 	return false
 }
 
+// ParseStackReference parses a reference of the form `[<project>/]<stack>`. If the project is omitted, it is inferred
+// from the current workspace. Buckets that do not use the project-scoped layout only accept unqualified stack names.
 func (b *localBackend) ParseStackReference(stackRefName string) (backend.StackReference, error) {
-	return localBackendReference{name: tokens.QName(stackRefName)}, nil
+	project, name, err := b.parseStackName(stackRefName)
+	if err != nil {
+		return nil, err
+	}
+
+	if b.projectMode && project == "" {
+		currentProject := b.currentProject
+		if currentProject == nil {
+			detected, projectErr := workspace.DetectProject()
+			if projectErr != nil {
+				return nil, projectErr
+			}
+			currentProject = detected
+		}
+		project = string(currentProject.Name)
+	}
+
+	return localBackendReference{
+		name:    tokens.QName(name),
+		project: tokens.PackageName(project),
+		b:       b,
+	}, nil
+}
+
+func (b *localBackend) parseStackName(s string) (string, string, error) {
+	split := strings.Split(s, "/")
+	switch len(split) {
+	case 1:
+		return "", split[0], nil
+	case 2:
+		if !b.projectMode {
+			return "", "", errors.Errorf("could not parse stack name '%s': this backend does not store stacks "+
+				"by project; run `pulumi state upgrade` to enable project-scoped stack names", s)
+		}
+		return split[0], split[1], nil
+	default:
+		return "", "", errors.Errorf("could not parse stack name '%s'", s)
+	}
 }
 
 // ValidateStackName verifies the stack name is valid for the local backend. We use the same rules as the
 // httpstate backend.
 func (b *localBackend) ValidateStackName(stackName string) error {
-	if strings.Contains(stackName, "/") {
-		return errors.New("stack names may not contain slashes")
+	project, name, err := b.parseStackName(stackName)
+	if err != nil {
+		return err
+	}
+
+	if project != "" && !tokens.IsPackageName(project) {
+		return errors.New("project names may only contain alphanumeric, hyphens, underscores, or periods")
 	}
 
 	validNameRegex := regexp.MustCompile("^[A-Za-z0-9_.-]{1,100}$")
-	if !validNameRegex.MatchString(stackName) {
+	if !validNameRegex.MatchString(name) {
 		return errors.New("stack names may only contain alphanumeric, hyphens, underscores, or periods")
 	}
 
@@ -270,20 +400,20 @@ func (b *localBackend) CreateStack(ctx context.Context, stackRef backend.StackRe
 
 	contract.Requiref(opts == nil, "opts", "local stacks do not support any options")
 
-	stackName := stackRef.Name()
+	stackName := qualifiedStackName(stackRef)
 	if stackName == "" {
 		return nil, errors.New("invalid empty stack name")
 	}
 
 	if _, _, err := b.getStack(stackName); err == nil {
-		return nil, &backend.StackAlreadyExistsError{StackName: string(stackName)}
+		return nil, &backend.StackAlreadyExistsError{StackName: stackRef.String()}
 	}
 
 	tags, err := backend.GetEnvironmentTagsForCurrentStack()
 	if err != nil {
 		return nil, errors.Wrap(err, "getting stack tags")
 	}
-	if err = validation.ValidateStackProperties(string(stackName), tags); err != nil {
+	if err = validation.ValidateStackProperties(string(stackRef.Name()), tags); err != nil {
 		return nil, errors.Wrap(err, "validating stack properties")
 	}
 
@@ -291,6 +421,9 @@ func (b *localBackend) CreateStack(ctx context.Context, stackRef backend.StackRe
 	if err != nil {
 		return nil, err
 	}
+	if err = b.saveStackTags(stackName, tags); err != nil {
+		return nil, err
+	}
 
 	stack := newStack(stackRef, file, nil, b)
 	fmt.Printf("Created stack '%s'\n", stack.Ref())
@@ -299,7 +432,7 @@ func (b *localBackend) CreateStack(ctx context.Context, stackRef backend.StackRe
 }
 
 func (b *localBackend) GetStack(ctx context.Context, stackRef backend.StackReference) (backend.Stack, error) {
-	stackName := stackRef.Name()
+	stackName := qualifiedStackName(stackRef)
 	snapshot, path, err := b.getStack(stackName)
 	switch {
 	case gcerrors.Code(errors.Cause(err)) == gcerrors.NotFound:
@@ -312,17 +445,31 @@ func (b *localBackend) GetStack(ctx context.Context, stackRef backend.StackRefer
 }
 
 func (b *localBackend) ListStacks(
-	ctx context.Context, _ backend.ListStacksFilter) ([]backend.StackSummary, error) {
+	ctx context.Context, filter backend.ListStacksFilter) ([]backend.StackSummary, error) {
 	stacks, err := b.getLocalStacks()
 	if err != nil {
 		return nil, err
 	}
 
-	// Note that the provided stack filter is not honored, since fields like
-	// organizations and tags aren't persisted in the local backend.
+	// Note that the organization filter is not honored, since the local backend has no organizations. The project
+	// filter is only honored if stacks are stored by project.
 	var results []backend.StackSummary
 	for _, stackName := range stacks {
-		stack, err := b.GetStack(ctx, localBackendReference{name: stackName})
+		ref := b.newReference(stackName)
+		if b.projectMode && filter.Project != nil && string(ref.project) != *filter.Project {
+			continue
+		}
+		if filter.TagName != nil || filter.TagValue != nil {
+			tags, err := b.getStackTags(stackName)
+			if err != nil {
+				return nil, err
+			}
+			if !matchesTagFilter(tags, filter.TagName, filter.TagValue) {
+				continue
+			}
+		}
+
+		stack, err := b.GetStack(ctx, ref)
 		if err != nil {
 			return nil, err
 		}
@@ -335,7 +482,7 @@ func (b *localBackend) ListStacks(
 }
 
 func (b *localBackend) RemoveStack(ctx context.Context, stack backend.Stack, force bool) (bool, error) {
-	stackName := stack.Ref().Name()
+	stackName := qualifiedStackName(stack.Ref())
 	snapshot, _, err := b.getStack(stackName)
 	if err != nil {
 		return false, err
@@ -350,45 +497,70 @@ func (b *localBackend) RemoveStack(ctx context.Context, stack backend.Stack, for
 }
 
 func (b *localBackend) RenameStack(ctx context.Context, stack backend.Stack, newName tokens.QName) error {
-	stackName := stack.Ref().Name()
+	stackName := qualifiedStackName(stack.Ref())
 	snap, _, err := b.getStack(stackName)
 	if err != nil {
 		return err
 	}
 
+	// The new name may move the stack to a different project. If it does not name a project, the stack stays in
+	// its current project.
+	oldProject, _ := splitQualifiedStackName(stackName)
+	newProject, newStackName, err := b.parseStackName(string(newName))
+	if err != nil {
+		return err
+	}
+	newRef := localBackendReference{name: tokens.QName(newStackName), project: tokens.PackageName(newProject), b: b}
+	if newRef.project == "" {
+		newRef.project = oldProject
+	}
+	newQualifiedName := newRef.FullyQualifiedName()
+
 	// Ensure the destination stack does not already exist.
-	hasExisting, err := b.bucket.Exists(ctx, b.stackPath(newName))
+	hasExisting, err := b.bucket.Exists(ctx, b.stackPath(newQualifiedName))
 	if err != nil {
 		return err
 	}
 	if hasExisting {
-		return errors.Errorf("a stack named %s already exists", newName)
+		return errors.Errorf("a stack named %s already exists", newRef)
 	}
 
-	// If we have a snapshot, we need to rename the URNs inside it to use the new stack name.
+	// If we have a snapshot, we need to rename the URNs inside it to use the new stack name and project.
 	if snap != nil {
-		if err = edit.RenameStack(snap, newName, ""); err != nil {
+		var renamedProject tokens.PackageName
+		if newRef.project != oldProject {
+			renamedProject = newRef.project
+		}
+		if err = edit.RenameStack(snap, newRef.name, renamedProject); err != nil {
 			return err
 		}
 	}
 
 	// Now save the snapshot with a new name (we pass nil to re-use the existing secrets manager from the snapshot).
-	if _, err = b.saveStack(newName, snap, nil); err != nil {
+	if _, err = b.saveStack(newQualifiedName, snap, nil); err != nil {
 		return err
 	}
 
 	// To remove the old stack, just make a backup of the file and don't write out anything new.
 	file := b.stackPath(stackName)
 	backupTarget(b.bucket, file)
+	if err = b.removeJournal(stackName); err != nil {
+		return err
+	}
+
+	// Carry the stack's tags over to its new name.
+	if err = b.renameStackTags(stackName, newQualifiedName); err != nil {
+		return err
+	}
 
 	// And rename the histoy folder as well.
-	return b.renameHistory(stackName, newName)
+	return b.renameHistory(stackName, newQualifiedName)
 }
 
 func (b *localBackend) GetLatestConfiguration(ctx context.Context,
 	stack backend.Stack) (config.Map, error) {
 
-	hist, err := b.GetHistory(ctx, stack.Ref())
+	hist, err := b.GetHistory(ctx, stack.Ref(), false /*includeResources*/)
 	if err != nil {
 		return nil, err
 	}
@@ -408,7 +580,7 @@ func (b *localBackend) PackPolicies(
 }
 
 func (b *localBackend) Preview(ctx context.Context, stack backend.Stack,
-	op backend.UpdateOperation) (engine.ResourceChanges, result.Result) {
+	op backend.UpdateOperation) (*deploy.UpdatePlan, engine.ResourceChanges, result.Result) {
 	// We can skip PreviewThenPromptThenExecute and just go straight to Execute.
 	opts := backend.ApplierOptions{
 		DryRun:   true,
@@ -432,6 +604,11 @@ func (b *localBackend) Destroy(ctx context.Context, stack backend.Stack,
 	return backend.PreviewThenPromptThenExecute(ctx, apitype.DestroyUpdate, stack, op, b.apply)
 }
 
+func (b *localBackend) Import(ctx context.Context, stack backend.Stack,
+	op backend.UpdateOperation) (engine.ResourceChanges, result.Result) {
+	return backend.PreviewThenPromptThenExecute(ctx, apitype.ResourceImportUpdate, stack, op, b.apply)
+}
+
 func (b *localBackend) Query(ctx context.Context, op backend.QueryOperation) result.Result {
 
 	return b.query(ctx, op, nil /*events*/)
@@ -442,33 +619,47 @@ func (b *localBackend) Watch(ctx context.Context, stack backend.Stack,
 	return backend.Watch(ctx, b, stack, op, b.apply)
 }
 
+func (b *localBackend) DetectDrift(ctx context.Context, stack backend.Stack,
+	op backend.UpdateOperation) ([]backend.ResourceDrift, result.Result) {
+	return backend.DetectDrift(ctx, stack, op, b.apply)
+}
+
 // apply actually performs the provided type of update on a locally hosted stack.
 func (b *localBackend) apply(
 	ctx context.Context, kind apitype.UpdateKind, stack backend.Stack,
 	op backend.UpdateOperation, opts backend.ApplierOptions,
-	events chan<- engine.Event) (engine.ResourceChanges, result.Result) {
+	events chan<- engine.Event) (*deploy.UpdatePlan, engine.ResourceChanges, result.Result) {
 
 	stackRef := stack.Ref()
-	stackName := stackRef.Name()
+	stackName := qualifiedStackName(stackRef)
 	actionLabel := backend.ActionLabel(kind, opts.DryRun)
 
-	if !(op.Opts.Display.JSONDisplay || op.Opts.Display.Type == display.DisplayWatch) {
+	if !(op.Opts.Display.JSONDisplay || op.Opts.Display.Type == display.DisplayWatch ||
+		op.Opts.Display.Type == display.DisplayNone) {
 		// Print a banner so it's clear this is a local deployment.
 		fmt.Printf(op.Opts.Display.Color.Colorize(
 			colors.SpecHeadline+"%s (%s):"+colors.Reset+"\n"), actionLabel, stackRef)
 	}
 
+	// Take an advisory lock on the stack for the duration of any operation that may modify its checkpoint.
+	if !opts.DryRun {
+		if err := b.Lock(ctx, stackName); err != nil {
+			return nil, nil, result.FromError(err)
+		}
+		defer b.Unlock(ctx, stackName)
+	}
+
 	// Start the update.
 	update, err := b.newUpdate(stackName, op)
 	if err != nil {
-		return nil, result.FromError(err)
+		return nil, nil, result.FromError(err)
 	}
 
 	// Spawn a display loop to show events on the CLI.
 	displayEvents := make(chan engine.Event)
 	displayDone := make(chan bool)
 	go display.ShowEvents(
-		strings.ToLower(actionLabel), kind, stackName, op.Proj.Name,
+		strings.ToLower(actionLabel), kind, stackRef.Name(), op.Proj.Name,
 		displayEvents, displayDone, op.Opts.Display, opts.DryRun)
 
 	// Create a separate event channel for engine events that we'll pipe to both listening streams.
@@ -476,11 +667,17 @@ func (b *localBackend) apply(
 
 	scope := op.Scopes.NewScope(engineEvents, opts.DryRun)
 	eventsDone := make(chan bool)
+	var resourceSummaries []engine.ResourceSummary
 	go func() {
 		// Pull in all events from the engine and send them to the two listeners.
 		for e := range engineEvents {
 			displayEvents <- e
 
+			// Keep the per-resource summary of the update for its history entry.
+			if e.Type == engine.SummaryEvent {
+				resourceSummaries = e.Payload().(engine.SummaryEventPayload).Resources
+			}
+
 			// If the caller also wants to see the events, stream them there also.
 			if events != nil {
 				events <- e
@@ -502,17 +699,20 @@ func (b *localBackend) apply(
 
 	// Perform the update
 	start := time.Now().Unix()
+	var plan *deploy.UpdatePlan
 	var changes engine.ResourceChanges
 	var updateRes result.Result
 	switch kind {
 	case apitype.PreviewUpdate:
-		changes, updateRes = engine.Update(update, engineCtx, op.Opts.Engine, true)
+		plan, changes, updateRes = engine.Preview(update, engineCtx, op.Opts.Engine)
 	case apitype.UpdateUpdate:
 		changes, updateRes = engine.Update(update, engineCtx, op.Opts.Engine, opts.DryRun)
 	case apitype.RefreshUpdate:
 		changes, updateRes = engine.Refresh(update, engineCtx, op.Opts.Engine, opts.DryRun)
 	case apitype.DestroyUpdate:
 		changes, updateRes = engine.Destroy(update, engineCtx, op.Opts.Engine, opts.DryRun)
+	case apitype.ResourceImportUpdate:
+		changes, updateRes = engine.Import(update, engineCtx, op.Opts.Engine, op.Imports, opts.DryRun)
 	default:
 		contract.Failf("Unrecognized update kind: %s", kind)
 	}
@@ -544,7 +744,8 @@ func (b *localBackend) apply(
 		// IDEA: it would be nice to populate the *Deployment, so that addToHistory below doesn't need to
 		//     rudely assume it knows where the checkpoint file is on disk as it makes a copy of it.  This isn't
 		//     trivial to achieve today given the event driven nature of plan-walking, however.
-		ResourceChanges: changes,
+		ResourceChanges:   changes,
+		ResourceSummaries: resourceSummaries,
 	}
 
 	var saveErr error
@@ -552,20 +753,28 @@ func (b *localBackend) apply(
 	if !opts.DryRun {
 		saveErr = b.addToHistory(stackName, info)
 		backupErr = b.backupStack(stackName)
+
+		// As the service does, refresh the stack's tags from the environment with each update.
+		if saveErr == nil {
+			var tags map[apitype.StackTagName]string
+			if tags, saveErr = backend.GetMergedStackTags(ctx, stack); saveErr == nil {
+				saveErr = b.saveStackTags(stackName, tags)
+			}
+		}
 	}
 
 	if updateRes != nil {
 		// We swallow saveErr and backupErr as they are less important than the updateErr.
-		return changes, updateRes
+		return plan, changes, updateRes
 	}
 
 	if saveErr != nil {
 		// We swallow backupErr as it is less important than the saveErr.
-		return changes, result.FromError(errors.Wrap(saveErr, "saving update info"))
+		return plan, changes, result.FromError(errors.Wrap(saveErr, "saving update info"))
 	}
 
 	if backupErr != nil {
-		return changes, result.FromError(errors.Wrap(backupErr, "saving backup"))
+		return plan, changes, result.FromError(errors.Wrap(backupErr, "saving backup"))
 	}
 
 	// Make sure to print a link to the stack's checkpoint before exiting.
@@ -594,7 +803,7 @@ func (b *localBackend) apply(
 				colors.Underline+colors.BrightBlue+"%s"+colors.Reset+"\n"), link)
 	}
 
-	return changes, nil
+	return plan, changes, nil
 }
 
 // query executes a query program against the resource outputs of a locally hosted stack.
@@ -604,8 +813,10 @@ func (b *localBackend) query(ctx context.Context, op backend.QueryOperation,
 	return backend.RunQuery(ctx, b, op, callerEventsOpt, b.newQuery)
 }
 
-func (b *localBackend) GetHistory(ctx context.Context, stackRef backend.StackReference) ([]backend.UpdateInfo, error) {
-	stackName := stackRef.Name()
+func (b *localBackend) GetHistory(ctx context.Context, stackRef backend.StackReference,
+	includeResources bool) ([]backend.UpdateInfo, error) {
+
+	stackName := qualifiedStackName(stackRef)
 	updates, err := b.getHistory(stackName)
 	if err != nil {
 		return nil, err
@@ -616,7 +827,7 @@ func (b *localBackend) GetHistory(ctx context.Context, stackRef backend.StackRef
 func (b *localBackend) GetLogs(ctx context.Context, stack backend.Stack, cfg backend.StackConfiguration,
 	query operations.LogQuery) ([]operations.LogEntry, error) {
 
-	stackName := stack.Ref().Name()
+	stackName := qualifiedStackName(stack.Ref())
 	target, err := b.getTarget(stackName, cfg.Config, cfg.Decrypter)
 	if err != nil {
 		return nil, err
@@ -647,7 +858,7 @@ func GetLogsForTarget(target *deploy.Target, query operations.LogQuery) ([]opera
 func (b *localBackend) ExportDeployment(ctx context.Context,
 	stk backend.Stack) (*apitype.UntypedDeployment, error) {
 
-	stackName := stk.Ref().Name()
+	stackName := qualifiedStackName(stk.Ref())
 	snap, _, err := b.getStack(stackName)
 	if err != nil {
 		return nil, err
@@ -676,12 +887,17 @@ func (b *localBackend) ExportDeployment(ctx context.Context,
 func (b *localBackend) ImportDeployment(ctx context.Context, stk backend.Stack,
 	deployment *apitype.UntypedDeployment) error {
 
-	stackName := stk.Ref().Name()
+	stackName := qualifiedStackName(stk.Ref())
 	_, _, err := b.getStack(stackName)
 	if err != nil {
 		return err
 	}
 
+	if err = b.Lock(ctx, stackName); err != nil {
+		return err
+	}
+	defer b.Unlock(ctx, stackName)
+
 	snap, err := stack.DeserializeUntypedDeployment(deployment, stack.DefaultSecretsProvider)
 	if err != nil {
 		return err
@@ -691,6 +907,38 @@ func (b *localBackend) ImportDeployment(ctx context.Context, stk backend.Stack,
 	return err
 }
 
+func (b *localBackend) RestoreStack(ctx context.Context, stk backend.Stack, version int, sm secrets.Manager) error {
+	stackName := qualifiedStackName(stk.Ref())
+
+	// Take the lock before reading the history so that no update can add to it while the version is being restored.
+	if err := b.Lock(ctx, stackName); err != nil {
+		return err
+	}
+	defer b.Unlock(ctx, stackName)
+
+	snap, err := b.getHistoricalSnapshot(stackName, version)
+	if err != nil {
+		return err
+	}
+
+	startTime := time.Now().Unix()
+	if err = b.backupStack(stackName); err != nil {
+		return errors.Wrap(err, "backing up the current checkpoint")
+	}
+	if _, err = b.saveStack(stackName, snap, sm); err != nil {
+		return err
+	}
+
+	// Record the restore in the stack's history so that it, too, can be restored.
+	return b.addToHistory(stackName, backend.UpdateInfo{
+		Kind:      apitype.RestoreUpdate,
+		StartTime: startTime,
+		Message:   fmt.Sprintf("Restored version %d", version),
+		Result:    backend.SucceededResult,
+		EndTime:   time.Now().Unix(),
+	})
+}
+
 func (b *localBackend) Logout() error {
 	return workspace.DeleteAccount(b.originalURL)
 }
@@ -703,13 +951,39 @@ func (b *localBackend) CurrentUser() (string, error) {
 	return user.Username, nil
 }
 
+// getLocalStacks returns the names under which the backend's stacks are stored. If the backend stores stacks by
+// project, these names are qualified by the stacks' projects.
 func (b *localBackend) getLocalStacks() ([]tokens.QName, error) {
+	if !b.projectMode {
+		return b.getLocalStacksIn(b.stackPath(""), "")
+	}
+
+	projects, err := listBucket(b.bucket, b.stackPath(""))
+	if err != nil {
+		return nil, errors.Wrap(err, "error listing stacks")
+	}
+
 	var stacks []tokens.QName
+	for _, project := range projects {
+		// Each project's stacks are stored in a directory named after the project.
+		if !project.IsDir {
+			continue
+		}
+		projectName := tokens.PackageName(path.Base(project.Key))
+		projectStacks, err := b.getLocalStacksIn(filepath.Join(b.stackPath(""), string(projectName)), projectName)
+		if err != nil {
+			return nil, err
+		}
+		stacks = append(stacks, projectStacks...)
+	}
+	return stacks, nil
+}
 
-	// Read the stack directory.
-	path := b.stackPath("")
+// getLocalStacksIn returns the names under which the stacks in the given directory are stored.
+func (b *localBackend) getLocalStacksIn(dir string, project tokens.PackageName) ([]tokens.QName, error) {
+	var stacks []tokens.QName
 
-	files, err := listBucket(b.bucket, path)
+	files, err := listBucket(b.bucket, dir)
 	if err != nil {
 		return nil, errors.Wrap(err, "error listing stacks")
 	}
@@ -728,7 +1002,8 @@ func (b *localBackend) getLocalStacks() ([]tokens.QName, error) {
 		}
 
 		// Read in this stack's information.
-		name := tokens.QName(stackfn[:len(stackfn)-len(ext)])
+		name := localBackendReference{name: tokens.QName(stackfn[:len(stackfn)-len(ext)]), project: project}.
+			FullyQualifiedName()
 		_, _, err := b.getStack(name)
 		if err != nil {
 			logging.V(5).Infof("error reading stack: %v (%v) skipping", name, err)
@@ -745,14 +1020,15 @@ func (b *localBackend) getLocalStacks() ([]tokens.QName, error) {
 func (b *localBackend) GetStackTags(ctx context.Context,
 	stack backend.Stack) (map[apitype.StackTagName]string, error) {
 
-	// The local backend does not currently persist tags.
-	return nil, errors.New("stack tags not supported in --local mode")
+	return b.getStackTags(qualifiedStackName(stack.Ref()))
 }
 
 // UpdateStackTags updates the stacks's tags, replacing all existing tags.
 func (b *localBackend) UpdateStackTags(ctx context.Context,
 	stack backend.Stack, tags map[apitype.StackTagName]string) error {
 
-	// The local backend does not currently persist tags.
-	return errors.New("stack tags not supported in --local mode")
+	if err := validation.ValidateStackProperties(string(stack.Ref().Name()), tags); err != nil {
+		return errors.Wrap(err, "validating stack properties")
+	}
+	return b.saveStackTags(qualifiedStackName(stack.Ref()), tags)
 }
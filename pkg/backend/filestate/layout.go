@@ -0,0 +1,231 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filestate
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+	"gocloud.dev/gcerrors"
+
+	"github.com/pulumi/pulumi/sdk/v2/go/common/encoding"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/tokens"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/util/logging"
+)
+
+// metaFile is the name of the file that records the layout of a bucket.
+const metaFile = "meta.yaml"
+
+// projectModeVersion is the layout version of buckets that store stacks in project-scoped namespaces.
+const projectModeVersion = 1
+
+// pulumiMeta is the contents of a bucket's meta file.
+type pulumiMeta struct {
+	// Version is the layout version of the bucket. Buckets without a meta file store all of their stacks in a
+	// single namespace.
+	Version int `json:"version" yaml:"version"`
+}
+
+func (b *localBackend) metaPath() string {
+	return filepath.Join(b.StateDir(), metaFile)
+}
+
+// readMeta reads the bucket's meta file, returning nil if the bucket does not have one.
+func (b *localBackend) readMeta() (*pulumiMeta, error) {
+	byts, err := b.bucket.ReadAll(context.TODO(), b.metaPath())
+	if err != nil {
+		if gcerrors.Code(errors.Cause(err)) == gcerrors.NotFound {
+			return nil, nil
+		}
+		return nil, errors.Wrap(err, "reading bucket metadata")
+	}
+
+	var meta pulumiMeta
+	if err = encoding.YAML.Unmarshal(byts, &meta); err != nil {
+		return nil, errors.Wrap(err, "reading bucket metadata")
+	}
+	return &meta, nil
+}
+
+// writeMeta records the given layout version in the bucket's meta file.
+func (b *localBackend) writeMeta(version int) error {
+	byts, err := encoding.YAML.Marshal(&pulumiMeta{Version: version})
+	if err != nil {
+		return errors.Wrap(err, "serializing bucket metadata")
+	}
+	return b.bucket.WriteAll(context.TODO(), b.metaPath(), byts, nil)
+}
+
+// detectProjectMode returns true if the bucket stores its stacks in project-scoped namespaces. Buckets that do not
+// contain any stacks yet are initialized to use project-scoped namespaces; buckets that already contain stacks in a
+// single namespace keep using it until they are upgraded.
+func (b *localBackend) detectProjectMode() (bool, error) {
+	meta, err := b.readMeta()
+	if err != nil {
+		return false, err
+	}
+	if meta != nil {
+		if meta.Version > projectModeVersion {
+			return false, errors.Errorf(
+				"the bucket at %s uses layout version %d, which is newer than this version of the CLI supports; "+
+					"please upgrade the CLI", b.originalURL, meta.Version)
+		}
+		return meta.Version >= projectModeVersion, nil
+	}
+
+	legacyStacks, err := b.getLegacyStackFiles()
+	if err != nil {
+		return false, err
+	}
+	if len(legacyStacks) > 0 {
+		return false, nil
+	}
+
+	if err = b.writeMeta(projectModeVersion); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// getLegacyStackFiles returns the names of the stack files stored in the bucket's single stack namespace.
+func (b *localBackend) getLegacyStackFiles() ([]string, error) {
+	files, err := listBucket(b.bucket, b.stackPath(""))
+	if err != nil {
+		// The stack directory doesn't exist until the first stack has been created.
+		if gcerrors.Code(errors.Cause(err)) == gcerrors.NotFound {
+			return nil, nil
+		}
+		return nil, errors.Wrap(err, "error listing stacks")
+	}
+
+	var names []string
+	for _, file := range files {
+		if file.IsDir {
+			continue
+		}
+		if _, has := encoding.Marshalers[filepath.Ext(objectName(file))]; has {
+			names = append(names, objectName(file))
+		}
+	}
+	return names, nil
+}
+
+// Upgrade migrates a bucket that stores all of its stacks in a single namespace to the project-scoped layout.
+// Stacks whose project cannot be determined from their resources are moved into the given default project.
+func (b *localBackend) Upgrade(ctx context.Context, defaultProject tokens.PackageName) error {
+	if b.projectMode {
+		return nil
+	}
+
+	stacks, err := b.getLocalStacks()
+	if err != nil {
+		return err
+	}
+
+	// Determine the project of each stack before moving anything, so that a failure leaves the bucket untouched.
+	projects := make(map[tokens.QName]tokens.PackageName)
+	var unknown []string
+	for _, name := range stacks {
+		if err := b.checkForLock(ctx, name); err != nil {
+			return err
+		}
+
+		snap, _, err := b.getStack(name)
+		if err != nil {
+			return errors.Wrapf(err, "reading stack '%s'", name)
+		}
+
+		project := defaultProject
+		if snap != nil && len(snap.Resources) > 0 {
+			project = snap.Resources[0].URN.Project()
+		}
+		if project == "" {
+			unknown = append(unknown, string(name))
+			continue
+		}
+		projects[name] = project
+	}
+	if len(unknown) > 0 {
+		return errors.Errorf("could not determine the project of the following stacks: %s; "+
+			"run this command from a project directory to move them into that project", strings.Join(unknown, ", "))
+	}
+
+	// The bucket is only marked as upgraded once every stack has moved. Stacks that have not moved yet remain in the
+	// single namespace, so a failed upgrade can simply be run again.
+	for i, name := range stacks {
+		qualifiedName := tokens.QName(fmt.Sprintf("%s/%s", projects[name], name))
+		if err := b.upgradeStack(ctx, name, qualifiedName); err != nil {
+			remaining := make([]string, len(stacks)-i)
+			for j, name := range stacks[i:] {
+				remaining[j] = string(name)
+			}
+			return errors.Wrapf(err, "moving stack '%s'; the following stacks were not migrated: %s",
+				name, strings.Join(remaining, ", "))
+		}
+	}
+
+	if err = b.writeMeta(projectModeVersion); err != nil {
+		return err
+	}
+	b.projectMode = true
+	return nil
+}
+
+// upgradeStack moves the given stack's checkpoint, tags, history, backups, and journal to its project-scoped name. The
+// stack is locked while it is moved so that no other process can update it concurrently.
+func (b *localBackend) upgradeStack(ctx context.Context, name, qualifiedName tokens.QName) error {
+	if err := b.Lock(ctx, name); err != nil {
+		return err
+	}
+	defer b.Unlock(ctx, name)
+
+	logging.V(5).Infof("moving stack %s to %s", name, qualifiedName)
+	if err := renameObject(b.bucket, b.stackPath(name), b.stackPath(qualifiedName)); err != nil {
+		return err
+	}
+	if err := b.renameStackTags(name, qualifiedName); err != nil {
+		return err
+	}
+	for _, dir := range []func(tokens.QName) string{b.historyDirectory, b.backupDirectory, b.journalDirectory} {
+		if err := b.moveObjects(dir(name), dir(qualifiedName)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// moveObjects moves all of the objects in the given directory to the destination directory.
+func (b *localBackend) moveObjects(source, dest string) error {
+	files, err := listBucket(b.bucket, source)
+	if err != nil {
+		if gcerrors.Code(errors.Cause(err)) == gcerrors.NotFound {
+			return nil
+		}
+		return errors.Wrapf(err, "listing %s", source)
+	}
+
+	for _, file := range files {
+		if file.IsDir {
+			continue
+		}
+		if err := renameObject(b.bucket, file.Key, filepath.Join(dest, objectName(file))); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,177 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filestate
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pulumi/pulumi/pkg/v2/resource/deploy"
+	"github.com/pulumi/pulumi/pkg/v2/secrets/b64"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/apitype"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/resource"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/tokens"
+)
+
+func TestNewBucketUsesProjectMode(t *testing.T) {
+	dir, err := ioutil.TempDir("", "filestate-layout")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	b := newTestBackend(t, dir)
+	assert.True(t, b.projectMode)
+
+	ref, err := b.ParseStackReference("proj/dev")
+	assert.NoError(t, err)
+	assert.Equal(t, tokens.QName("dev"), ref.Name())
+	assert.Equal(t, "proj/dev", ref.String())
+	assert.Equal(t, tokens.QName("proj/dev"), qualifiedStackName(ref))
+
+	// Stack names may contain dashes, which must survive the round trip through the qualified name.
+	project, name := splitQualifiedStackName("my-proj/my-stack")
+	assert.Equal(t, tokens.PackageName("my-proj"), project)
+	assert.Equal(t, tokens.QName("my-stack"), name)
+
+	_, err = b.ParseStackReference("a/b/c")
+	assert.Error(t, err)
+}
+
+func TestStackTags(t *testing.T) {
+	dir, err := ioutil.TempDir("", "filestate-tags")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	b := newTestBackend(t, dir)
+	stackName := tokens.QName("proj/dev")
+
+	tags, err := b.getStackTags(stackName)
+	assert.NoError(t, err)
+	assert.Empty(t, tags)
+
+	assert.NoError(t, b.saveStackTags(stackName, map[apitype.StackTagName]string{"owner": "infra"}))
+	assert.NoError(t, b.renameStackTags(stackName, "proj/prod"))
+	tags, err = b.getStackTags("proj/prod")
+	assert.NoError(t, err)
+	assert.Equal(t, map[apitype.StackTagName]string{"owner": "infra"}, tags)
+
+	name, value, other := "owner", "infra", "app"
+	assert.True(t, matchesTagFilter(tags, &name, nil))
+	assert.True(t, matchesTagFilter(tags, &name, &value))
+	assert.True(t, matchesTagFilter(tags, nil, &value))
+	assert.False(t, matchesTagFilter(tags, &other, nil))
+	assert.False(t, matchesTagFilter(tags, &name, &other))
+
+	assert.NoError(t, b.removeStackTags("proj/prod"))
+	tags, err = b.getStackTags("proj/prod")
+	assert.NoError(t, err)
+	assert.Empty(t, tags)
+}
+
+func TestUpgrade(t *testing.T) {
+	dir, err := ioutil.TempDir("", "filestate-upgrade")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	// Create a bucket that stores its stacks in a single namespace.
+	legacy := newTestBackend(t, dir)
+	legacy.projectMode = false
+	assert.NoError(t, legacy.bucket.Delete(context.Background(), legacy.metaPath()))
+
+	sm := b64.NewBase64SecretsManager()
+	snap := deploy.NewSnapshot(deploy.Manifest{}, sm, []*resource.State{{
+		Type: "pkgA:m:typA",
+		URN:  resource.NewURN("dev", "proj", "", "pkgA:m:typA", "a"),
+	}}, nil)
+	_, err = legacy.saveStack("dev", snap, sm)
+	assert.NoError(t, err)
+	_, err = legacy.saveStack("empty", deploy.NewSnapshot(deploy.Manifest{}, sm, nil, nil), sm)
+	assert.NoError(t, err)
+
+	// The bucket stays in its legacy layout until it is upgraded.
+	b := newTestBackend(t, dir)
+	assert.False(t, b.projectMode)
+	_, err = b.ParseStackReference("proj/dev")
+	assert.Error(t, err)
+
+	// Stacks without resources cannot be placed without a default project.
+	assert.Error(t, b.Upgrade(context.Background(), ""))
+	assert.False(t, b.projectMode)
+
+	assert.NoError(t, b.Upgrade(context.Background(), "other"))
+	assert.True(t, b.projectMode)
+
+	stacks, err := b.getLocalStacks()
+	assert.NoError(t, err)
+	assert.Equal(t, []tokens.QName{"other/empty", "proj/dev"}, stacks)
+
+	// The upgrade is recorded in the bucket.
+	assert.True(t, newTestBackend(t, dir).projectMode)
+}
+
+func TestUpgradeFailure(t *testing.T) {
+	dir, err := ioutil.TempDir("", "filestate-upgrade")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	legacy := newTestBackend(t, dir)
+	legacy.projectMode = false
+	assert.NoError(t, legacy.bucket.Delete(context.Background(), legacy.metaPath()))
+
+	sm := b64.NewBase64SecretsManager()
+	for _, name := range []tokens.QName{"a", "b", "c"} {
+		_, err = legacy.saveStack(name, deploy.NewSnapshot(deploy.Manifest{}, sm, nil, nil), sm)
+		assert.NoError(t, err)
+	}
+
+	// Stacks locked by another process are not moved.
+	b := newTestBackend(t, dir)
+	assert.NoError(t, legacy.Lock(context.Background(), "b"))
+	err = b.Upgrade(context.Background(), "proj")
+	assert.IsType(t, StackLockedError{}, errors.Cause(err))
+	legacy.Unlock(context.Background(), "b")
+
+	// Block the destination of the second stack so that moving it fails.
+	blocker := filepath.Join(dir, b.stackPath("proj/b"))
+	assert.NoError(t, os.MkdirAll(filepath.Join(blocker, "blocker"), 0700))
+	err = b.Upgrade(context.Background(), "proj")
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "the following stacks were not migrated: b, c")
+	}
+
+	// The bucket is not marked as upgraded, and the stacks that were not moved stay in place, unlocked.
+	assert.False(t, b.projectMode)
+	assert.False(t, newTestBackend(t, dir).projectMode)
+	stacks, err := b.getLocalStacks()
+	assert.NoError(t, err)
+	assert.Equal(t, []tokens.QName{"b", "c"}, stacks)
+	for _, name := range stacks {
+		locks, err := b.listLocks(context.Background(), name)
+		assert.NoError(t, err)
+		assert.Empty(t, locks)
+	}
+
+	// Once the problem is fixed, the upgrade can be run again.
+	assert.NoError(t, os.RemoveAll(blocker))
+	assert.NoError(t, b.Upgrade(context.Background(), "proj"))
+	stacks, err = b.getLocalStacks()
+	assert.NoError(t, err)
+	assert.Equal(t, []tokens.QName{"proj/a", "proj/b", "proj/c"}, stacks)
+}
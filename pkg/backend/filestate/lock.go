@@ -0,0 +1,206 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filestate
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"time"
+
+	"github.com/pkg/errors"
+	user "github.com/tweekmonster/luser"
+	"gocloud.dev/gcerrors"
+
+	"github.com/pulumi/pulumi/pkg/v2/backend"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/diag"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/tokens"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/util/contract"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/util/fsutil"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/workspace"
+)
+
+// lockContent is the contents of an advisory lock object written to the bucket while an operation is in progress.
+type lockContent struct {
+	Pid       int       `json:"pid"`
+	Username  string    `json:"username"`
+	Hostname  string    `json:"hostname"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+func newLockContent() (*lockContent, error) {
+	u, err := user.Current()
+	if err != nil {
+		return nil, err
+	}
+	hostname, err := os.Hostname()
+	if err != nil {
+		return nil, err
+	}
+	return &lockContent{
+		Pid:       os.Getpid(),
+		Username:  u.Username,
+		Hostname:  hostname,
+		Timestamp: time.Now(),
+	}, nil
+}
+
+// String returns a description of the lock's owner suitable for display.
+func (l *lockContent) String() string {
+	return fmt.Sprintf("created by %v@%v (pid %v) at %v",
+		l.Username, l.Hostname, l.Pid, l.Timestamp.Format(time.RFC3339))
+}
+
+// StackLockedError is returned when an operation is attempted against a stack that is locked by another process.
+type StackLockedError struct {
+	StackName tokens.QName
+	Locks     []string // descriptions of the locks currently held on the stack.
+}
+
+func (e StackLockedError) Error() string {
+	msg := fmt.Sprintf("the stack '%v' is currently locked by %v lock(s). Either wait for the other "+
+		"process(es) to end or run `pulumi cancel` to break the lock(s).", e.StackName, len(e.Locks))
+	for _, l := range e.Locks {
+		msg += "\n  " + l
+	}
+	return msg
+}
+
+// checkForLock returns an error if any lock other than the one owned by this backend exists for the given stack.
+func (b *localBackend) checkForLock(ctx context.Context, stackName tokens.QName) error {
+	locks, err := b.listLocks(ctx, stackName)
+	if err != nil {
+		return err
+	}
+
+	ownLock := b.lockPath(stackName)
+	var descriptions []string
+	for _, key := range locks {
+		if key == filepath.ToSlash(ownLock) {
+			continue
+		}
+
+		description := key
+		if content, err := b.readLock(ctx, key); err == nil {
+			description = fmt.Sprintf("%v: %v", key, content)
+		}
+		descriptions = append(descriptions, description)
+	}
+
+	if len(descriptions) > 0 {
+		return StackLockedError{StackName: stackName, Locks: descriptions}
+	}
+	return nil
+}
+
+// listLocks returns the keys of all lock objects currently present for the given stack.
+func (b *localBackend) listLocks(ctx context.Context, stackName tokens.QName) ([]string, error) {
+	files, err := listBucket(b.bucket, b.lockDir(stackName))
+	if err != nil {
+		// The lock directory doesn't exist until a stack has been locked for the first time.
+		if gcerrors.Code(errors.Cause(err)) == gcerrors.NotFound {
+			return nil, nil
+		}
+		return nil, errors.Wrap(err, "listing stack locks")
+	}
+
+	var keys []string
+	for _, file := range files {
+		if file.IsDir {
+			continue
+		}
+		keys = append(keys, file.Key)
+	}
+	return keys, nil
+}
+
+func (b *localBackend) readLock(ctx context.Context, key string) (*lockContent, error) {
+	byts, err := b.bucket.ReadAll(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	var content lockContent
+	if err = json.Unmarshal(byts, &content); err != nil {
+		return nil, err
+	}
+	return &content, nil
+}
+
+// Lock acquires an advisory lock on the given stack. Buckets offer no atomic create-if-absent primitive, so the
+// lock is taken by first checking for existing locks, writing our own, and then checking again; if another process
+// raced us, our lock is removed and an error is returned.
+func (b *localBackend) Lock(ctx context.Context, stackName tokens.QName) error {
+	if err := b.checkForLock(ctx, stackName); err != nil {
+		return err
+	}
+
+	content, err := newLockContent()
+	if err != nil {
+		return err
+	}
+	byts, err := json.Marshal(content)
+	if err != nil {
+		return err
+	}
+	if err = b.bucket.WriteAll(ctx, b.lockPath(stackName), byts, nil); err != nil {
+		return errors.Wrap(err, "writing stack lock")
+	}
+
+	if err = b.checkForLock(ctx, stackName); err != nil {
+		b.Unlock(ctx, stackName)
+		return err
+	}
+	return nil
+}
+
+// Unlock releases the lock held by this backend on the given stack, if any.
+func (b *localBackend) Unlock(ctx context.Context, stackName tokens.QName) {
+	if err := b.bucket.Delete(ctx, b.lockPath(stackName)); err != nil {
+		b.d.Errorf(diag.Message("",
+			"there was a problem deleting the lock at %v, manual clean up may be required: %v"),
+			path.Join(b.url, b.lockPath(stackName)), err)
+	}
+}
+
+// CancelCurrentUpdate breaks every lock held on the given stack, regardless of its owner.
+func (b *localBackend) CancelCurrentUpdate(ctx context.Context, stackRef backend.StackReference) error {
+	stackName := qualifiedStackName(stackRef)
+	locks, err := b.listLocks(ctx, stackName)
+	if err != nil {
+		return err
+	}
+	if len(locks) == 0 {
+		return errors.Errorf("the stack '%v' is not locked", stackName)
+	}
+
+	for _, key := range locks {
+		if err := b.bucket.Delete(ctx, key); err != nil {
+			return errors.Wrapf(err, "deleting lock %v", key)
+		}
+	}
+	return nil
+}
+
+func (b *localBackend) lockDir(stack tokens.QName) string {
+	contract.Require(stack != "", "stack")
+	return filepath.Join(b.StateDir(), workspace.LockDir, fsutil.QnamePath(stack))
+}
+
+func (b *localBackend) lockPath(stack tokens.QName) string {
+	return filepath.Join(b.lockDir(stack), b.lockID+".json")
+}
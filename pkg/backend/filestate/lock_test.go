@@ -0,0 +1,70 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filestate
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pulumi/pulumi/sdk/v2/go/common/tokens"
+)
+
+func newTestBackend(t *testing.T, dir string) *localBackend {
+	b, err := New(nil, FilePathPrefix+dir)
+	if err != nil {
+		t.Fatalf("Initializing new filestate backend: %v", err)
+	}
+	lb, ok := b.(*localBackend)
+	if !ok {
+		t.Fatalf("backend wasn't of type localBackend?")
+	}
+	return lb
+}
+
+func TestStackLocking(t *testing.T) {
+	dir, err := ioutil.TempDir("", "filestate-lock")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	ctx := context.Background()
+	stackName := tokens.QName("dev")
+	first, second := newTestBackend(t, dir), newTestBackend(t, dir)
+
+	// The first backend takes the lock, which blocks the second one.
+	assert.NoError(t, first.Lock(ctx, stackName))
+	err = second.Lock(ctx, stackName)
+	assert.Error(t, err)
+	assert.IsType(t, StackLockedError{}, err)
+
+	// Other stacks are unaffected.
+	assert.NoError(t, second.Lock(ctx, tokens.QName("prod")))
+	second.Unlock(ctx, tokens.QName("prod"))
+
+	// Once released, the lock may be taken by someone else.
+	first.Unlock(ctx, stackName)
+	assert.NoError(t, second.Lock(ctx, stackName))
+
+	// Cancelling breaks a lock held by another backend.
+	assert.NoError(t, first.CancelCurrentUpdate(ctx, localBackendReference{name: stackName}))
+	assert.NoError(t, first.Lock(ctx, stackName))
+	first.Unlock(ctx, stackName)
+
+	// Cancelling an unlocked stack is an error.
+	assert.Error(t, first.CancelCurrentUpdate(ctx, localBackendReference{name: stackName}))
+}
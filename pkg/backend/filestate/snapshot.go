@@ -15,6 +15,7 @@
 package filestate
 
 import (
+	"github.com/pulumi/pulumi/pkg/v2/backend"
 	"github.com/pulumi/pulumi/pkg/v2/resource/deploy"
 	"github.com/pulumi/pulumi/pkg/v2/secrets"
 	"github.com/pulumi/pulumi/sdk/v2/go/common/tokens"
@@ -38,6 +39,17 @@ func (sp *localSnapshotPersister) Save(snapshot *deploy.Snapshot) error {
 
 }
 
+func (sp *localSnapshotPersister) AppendJournal(entries []backend.JournalEntry) error {
+	return sp.backend.appendJournal(sp.name, entries)
+}
+
+func (sp *localSnapshotPersister) ResetJournal() error {
+	// saveStack discards the journal whenever it writes a checkpoint, so there is nothing left to do.
+	return nil
+}
+
+var _ backend.JournalPersister = (*localSnapshotPersister)(nil)
+
 func (b *localBackend) newSnapshotPersister(stackName tokens.QName, sm secrets.Manager) *localSnapshotPersister {
 	return &localSnapshotPersister{name: stackName, backend: b, sm: sm}
 }
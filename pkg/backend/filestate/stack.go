@@ -64,7 +64,9 @@ func (s *localStack) Rename(ctx context.Context, newName tokens.QName) error {
 	return backend.RenameStack(ctx, s, newName)
 }
 
-func (s *localStack) Preview(ctx context.Context, op backend.UpdateOperation) (engine.ResourceChanges, result.Result) {
+func (s *localStack) Preview(ctx context.Context,
+	op backend.UpdateOperation) (*deploy.UpdatePlan, engine.ResourceChanges, result.Result) {
+
 	return backend.PreviewStack(ctx, s, op)
 }
 
@@ -80,10 +82,20 @@ func (s *localStack) Destroy(ctx context.Context, op backend.UpdateOperation) (e
 	return backend.DestroyStack(ctx, s, op)
 }
 
+func (s *localStack) Import(ctx context.Context, op backend.UpdateOperation) (engine.ResourceChanges, result.Result) {
+	return backend.ImportStack(ctx, s, op)
+}
+
 func (s *localStack) Watch(ctx context.Context, op backend.UpdateOperation) result.Result {
 	return backend.WatchStack(ctx, s, op)
 }
 
+func (s *localStack) DetectDrift(ctx context.Context,
+	op backend.UpdateOperation) ([]backend.ResourceDrift, result.Result) {
+
+	return backend.DetectStackDrift(ctx, s, op)
+}
+
 func (s *localStack) GetLogs(ctx context.Context, cfg backend.StackConfiguration,
 	query operations.LogQuery) ([]operations.LogEntry, error) {
 	return backend.GetStackLogs(ctx, s, cfg, query)
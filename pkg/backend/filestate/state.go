@@ -114,8 +114,9 @@ func (b *localBackend) getTarget(stackName tokens.QName, cfg config.Map, dec con
 	if err != nil {
 		return nil, err
 	}
+	_, name := splitQualifiedStackName(stackName)
 	return &deploy.Target{
-		Name:      stackName,
+		Name:      name,
 		Config:    cfg,
 		Decrypter: dec,
 		Snapshot:  snapshot,
@@ -140,6 +141,15 @@ func (b *localBackend) getStack(name tokens.QName) (*deploy.Snapshot, string, er
 		return nil, "", err
 	}
 
+	// If an update was interrupted before it could compact its journal, replay the journal into the snapshot.
+	journal, err := b.getJournal(name)
+	if err != nil {
+		return nil, file, errors.Wrap(err, "failed to load journal")
+	}
+	if snapshot, err = backend.ReplayJournal(snapshot, journal); err != nil {
+		return nil, file, errors.Wrap(err, "failed to replay journal")
+	}
+
 	// Ensure the snapshot passes verification before returning it, to catch bugs early.
 	if !DisableIntegrityChecking {
 		if verifyerr := snapshot.VerifyIntegrity(); verifyerr != nil {
@@ -172,7 +182,8 @@ func (b *localBackend) saveStack(name tokens.QName, snap *deploy.Snapshot, sm se
 	if filepath.Ext(file) == "" {
 		file = file + ext
 	}
-	chk, err := stack.SerializeCheckpoint(name, snap, sm, false /* showSecrets */)
+	_, stackName := splitQualifiedStackName(name)
+	chk, err := stack.SerializeCheckpoint(stackName, snap, sm, false /* showSecrets */)
 	if err != nil {
 		return "", errors.Wrap(err, "serializaing checkpoint")
 	}
@@ -220,6 +231,11 @@ func (b *localBackend) saveStack(name tokens.QName, snap *deploy.Snapshot, sm se
 
 	logging.V(7).Infof("Saved stack %s checkpoint to: %s (backup=%s)", name, file, bck)
 
+	// The new checkpoint subsumes any journal of the previous checkpoint, so discard it.
+	if err = b.removeJournal(name); err != nil {
+		return "", errors.Wrap(err, "An IO error occurred while removing the snapshot journal")
+	}
+
 	// And if we are retaining historical checkpoint information, write it out again
 	if cmdutil.IsTruthy(os.Getenv("PULUMI_RETAIN_CHECKPOINTS")) {
 		if err = b.bucket.WriteAll(context.TODO(), fmt.Sprintf("%v.%v", file, time.Now().UnixNano()), byts, nil); err != nil {
@@ -249,6 +265,13 @@ func (b *localBackend) removeStack(name tokens.QName) error {
 	file := b.stackPath(name)
 	backupTarget(b.bucket, file)
 
+	if err := b.removeJournal(name); err != nil {
+		return err
+	}
+	if err := b.removeStackTags(name); err != nil {
+		return err
+	}
+
 	historyDir := b.historyDirectory(name)
 	return removeAllByPrefix(b.bucket, historyDir)
 }
@@ -310,9 +333,77 @@ func (b *localBackend) backupDirectory(stack tokens.QName) string {
 	return filepath.Join(b.StateDir(), workspace.BackupDir, fsutil.QnamePath(stack))
 }
 
-// getHistory returns locally stored update history. The first element of the result will be
-// the most recent update record.
-func (b *localBackend) getHistory(name tokens.QName) ([]backend.UpdateInfo, error) {
+func (b *localBackend) journalDirectory(stack tokens.QName) string {
+	contract.Require(stack != "", "stack")
+	return filepath.Join(b.StateDir(), workspace.JournalDir, fsutil.QnamePath(stack))
+}
+
+// appendJournal appends the given entries to the stack's snapshot journal. Each batch of entries is written to its
+// own file, named after the sequence number of its first entry, so that appending never rewrites existing files.
+func (b *localBackend) appendJournal(name tokens.QName, entries []backend.JournalEntry) error {
+	contract.Require(name != "", "name")
+	contract.Require(len(entries) > 0, "len(entries) > 0")
+
+	byts, err := json.Marshal(entries)
+	if err != nil {
+		return errors.Wrap(err, "serializing journal entries")
+	}
+
+	file := path.Join(b.journalDirectory(name), fmt.Sprintf("%010d.json", entries[0].Sequence))
+	return b.bucket.WriteAll(context.TODO(), file, byts, nil)
+}
+
+// getJournal returns the entries of the stack's snapshot journal, if any, in order.
+func (b *localBackend) getJournal(name tokens.QName) ([]backend.JournalEntry, error) {
+	contract.Require(name != "", "name")
+
+	files, err := listBucket(b.bucket, b.journalDirectory(name))
+	if err != nil {
+		// Journals only exist while updates are in progress or after they have been interrupted.
+		if gcerrors.Code(errors.Cause(err)) == gcerrors.NotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	// listBucket returns the files sorted by name, and because of how we name files, earlier entries come first.
+	var journal []backend.JournalEntry
+	for _, file := range files {
+		byts, err := b.bucket.ReadAll(context.TODO(), file.Key)
+		if err != nil {
+			return nil, errors.Wrapf(err, "reading journal file %s", file.Key)
+		}
+		var entries []backend.JournalEntry
+		if err = json.Unmarshal(byts, &entries); err != nil {
+			return nil, errors.Wrapf(err, "reading journal file %s", file.Key)
+		}
+		journal = append(journal, entries...)
+	}
+	return journal, nil
+}
+
+// removeJournal discards the stack's snapshot journal.
+func (b *localBackend) removeJournal(name tokens.QName) error {
+	contract.Require(name != "", "name")
+
+	files, err := listBucket(b.bucket, b.journalDirectory(name))
+	if err != nil {
+		if gcerrors.Code(errors.Cause(err)) == gcerrors.NotFound {
+			return nil
+		}
+		return err
+	}
+	for _, file := range files {
+		if err = b.bucket.Delete(context.TODO(), file.Key); err != nil {
+			return errors.Wrapf(err, "deleting journal file %s", file.Key)
+		}
+	}
+	return nil
+}
+
+// listHistoryFiles returns the paths of the stack's update records, oldest first. The version of the stack produced
+// by each update is the update record's index in this list plus one.
+func (b *localBackend) listHistoryFiles(name tokens.QName) ([]string, error) {
 	contract.Require(name != "", "name")
 
 	dir := b.historyDirectory(name)
@@ -325,18 +416,30 @@ func (b *localBackend) getHistory(name tokens.QName) ([]backend.UpdateInfo, erro
 		return nil, err
 	}
 
-	var updates []backend.UpdateInfo
+	// listBucket returns the array sorted by file name, and because of how we name files, older updates come before
+	// newer ones. Only the history files are of interest here, not the checkpoints.
+	var historyFiles []string
+	for _, file := range allFiles {
+		if strings.HasSuffix(file.Key, ".history.json") {
+			historyFiles = append(historyFiles, file.Key)
+		}
+	}
+	return historyFiles, nil
+}
 
-	// listBucket returns the array sorted by file name, but because of how we name files, older updates come before
-	// newer ones. Loop backwards so we added the newest updates to the array we will return first.
-	for i := len(allFiles) - 1; i >= 0; i-- {
-		file := allFiles[i]
-		filepath := file.Key
+// getHistory returns locally stored update history. The first element of the result will be
+// the most recent update record.
+func (b *localBackend) getHistory(name tokens.QName) ([]backend.UpdateInfo, error) {
+	historyFiles, err := b.listHistoryFiles(name)
+	if err != nil {
+		return nil, err
+	}
 
-		// Open all of the history files, ignoring the checkpoints.
-		if !strings.HasSuffix(filepath, ".history.json") {
-			continue
-		}
+	var updates []backend.UpdateInfo
+
+	// Loop backwards so we added the newest updates to the array we will return first.
+	for i := len(historyFiles) - 1; i >= 0; i-- {
+		filepath := historyFiles[i]
 
 		var update backend.UpdateInfo
 		b, err := b.bucket.ReadAll(context.TODO(), filepath)
@@ -347,6 +450,7 @@ func (b *localBackend) getHistory(name tokens.QName) ([]backend.UpdateInfo, erro
 		if err != nil {
 			return nil, errors.Wrapf(err, "reading history file %s", filepath)
 		}
+		update.Version = i + 1
 
 		updates = append(updates, update)
 	}
@@ -354,6 +458,37 @@ func (b *localBackend) getHistory(name tokens.QName) ([]backend.UpdateInfo, erro
 	return updates, nil
 }
 
+// getHistoricalSnapshot loads the checkpoint recorded for the given version of a stack and verifies its integrity.
+func (b *localBackend) getHistoricalSnapshot(name tokens.QName, version int) (*deploy.Snapshot, error) {
+	historyFiles, err := b.listHistoryFiles(name)
+	if err != nil {
+		return nil, err
+	}
+	if version < 1 || version > len(historyFiles) {
+		return nil, errors.Errorf("stack '%s' has no version %d", name, version)
+	}
+
+	checkpointFile := strings.TrimSuffix(historyFiles[version-1], ".history.json") + ".checkpoint.json"
+	byts, err := b.bucket.ReadAll(context.TODO(), checkpointFile)
+	if err != nil {
+		return nil, errors.Wrapf(err, "reading checkpoint file %s", checkpointFile)
+	}
+	chk, err := stack.UnmarshalVersionedCheckpointToLatestCheckpoint(byts)
+	if err != nil {
+		return nil, errors.Wrapf(err, "reading checkpoint file %s", checkpointFile)
+	}
+	snapshot, err := stack.DeserializeCheckpoint(chk)
+	if err != nil {
+		return nil, err
+	}
+
+	// Never restore a checkpoint that fails verification, regardless of DisableIntegrityChecking.
+	if err = snapshot.VerifyIntegrity(); err != nil {
+		return nil, errors.Wrapf(err, "%s: snapshot integrity failure; refusing to restore it", checkpointFile)
+	}
+	return snapshot, nil
+}
+
 func (b *localBackend) renameHistory(oldName tokens.QName, newName tokens.QName) error {
 	contract.Require(oldName != "", "oldName")
 	contract.Require(newName != "", "newName")
@@ -376,7 +511,8 @@ func (b *localBackend) renameHistory(oldName tokens.QName, newName tokens.QName)
 
 		// The filename format is <stack-name>-<timestamp>.[checkpoint|history].json, we need to change
 		// the stack name part but retain the other parts.
-		newFileName := string(newName) + fileName[strings.LastIndex(fileName, "-"):]
+		_, newStackName := splitQualifiedStackName(newName)
+		newFileName := string(newStackName) + fileName[strings.LastIndex(fileName, "-"):]
 		newBlob := path.Join(newHistory, newFileName)
 
 		if err := b.bucket.Copy(context.TODO(), newBlob, oldBlob, nil); err != nil {
@@ -397,7 +533,8 @@ func (b *localBackend) addToHistory(name tokens.QName, update backend.UpdateInfo
 	dir := b.historyDirectory(name)
 
 	// Prefix for the update and checkpoint files.
-	pathPrefix := path.Join(dir, fmt.Sprintf("%s-%d", name, time.Now().UnixNano()))
+	_, stackName := splitQualifiedStackName(name)
+	pathPrefix := path.Join(dir, fmt.Sprintf("%s-%d", stackName, time.Now().UnixNano()))
 
 	// Save the history file.
 	byts, err := json.MarshalIndent(&update, "", "    ")
@@ -0,0 +1,172 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filestate
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pulumi/pulumi/pkg/v2/backend"
+	"github.com/pulumi/pulumi/pkg/v2/resource/deploy"
+	"github.com/pulumi/pulumi/pkg/v2/secrets/b64"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/apitype"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/resource"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/tokens"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/workspace"
+)
+
+func TestRestoreStack(t *testing.T) {
+	dir, err := ioutil.TempDir("", "filestate-restore")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	ctx := context.Background()
+	b := newTestBackend(t, dir)
+	stackName := tokens.QName("dev")
+	sm := b64.NewBase64SecretsManager()
+
+	newSnapshot := func(names ...string) *deploy.Snapshot {
+		var resources []*resource.State
+		for _, name := range names {
+			resources = append(resources, &resource.State{
+				Type: "pkgA:m:typA",
+				URN:  resource.NewURN(stackName, "proj", "", "pkgA:m:typA", tokens.QName(name)),
+			})
+		}
+		return deploy.NewSnapshot(deploy.Manifest{}, sm, resources, nil)
+	}
+
+	// Record two versions of the stack.
+	for _, snap := range []*deploy.Snapshot{newSnapshot("a"), newSnapshot("a", "b")} {
+		_, err = b.saveStack(stackName, snap, sm)
+		assert.NoError(t, err)
+		assert.NoError(t, b.addToHistory(stackName, backend.UpdateInfo{Kind: apitype.UpdateUpdate}))
+	}
+
+	history, err := b.GetHistory(ctx, localBackendReference{name: stackName}, false /*includeResources*/)
+	assert.NoError(t, err)
+	if assert.Len(t, history, 2) {
+		assert.Equal(t, 2, history[0].Version)
+		assert.Equal(t, 1, history[1].Version)
+	}
+
+	stk, err := b.GetStack(ctx, localBackendReference{name: stackName})
+	assert.NoError(t, err)
+
+	// The stack is locked before its history is read.
+	other := newTestBackend(t, dir)
+	assert.NoError(t, other.Lock(ctx, stackName))
+	assert.IsType(t, StackLockedError{}, b.RestoreStack(ctx, stk, 3, sm))
+	other.Unlock(ctx, stackName)
+
+	// Versions that do not exist cannot be restored, and the lock is released.
+	assert.Error(t, b.RestoreStack(ctx, stk, 3, sm))
+	locks, err := b.listLocks(ctx, stackName)
+	assert.NoError(t, err)
+	assert.Empty(t, locks)
+
+	// Restoring the first version replaces the current checkpoint and is recorded as a new version.
+	assert.NoError(t, b.RestoreStack(ctx, stk, 1, sm))
+	snap, _, err := b.getStack(stackName)
+	assert.NoError(t, err)
+	assert.Len(t, snap.Resources, 1)
+
+	history, err = b.GetHistory(ctx, localBackendReference{name: stackName}, false /*includeResources*/)
+	assert.NoError(t, err)
+	if assert.Len(t, history, 3) {
+		assert.Equal(t, 3, history[0].Version)
+		assert.Equal(t, apitype.RestoreUpdate, history[0].Kind)
+	}
+}
+
+type testRegisterResourceEvent struct {
+	deploy.SourceEvent
+}
+
+func (testRegisterResourceEvent) Goal() *resource.Goal               { return nil }
+func (testRegisterResourceEvent) Done(result *deploy.RegisterResult) {}
+
+func TestGetStackReplaysJournal(t *testing.T) {
+	dir, err := ioutil.TempDir("", "filestate-journal")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	b := newTestBackend(t, dir)
+	stackName := tokens.QName("dev")
+	sm := b64.NewBase64SecretsManager()
+
+	newResource := func(name string) *resource.State {
+		return &resource.State{
+			Type:    "pkgA:m:typA",
+			URN:     resource.NewURN(stackName, "proj", "", "pkgA:m:typA", tokens.QName(name)),
+			Inputs:  resource.PropertyMap{},
+			Outputs: resource.PropertyMap{},
+		}
+	}
+	a := newResource("a")
+	_, err = b.saveStack(stackName, deploy.NewSnapshot(deploy.Manifest{}, sm, []*resource.State{a}, nil), sm)
+	assert.NoError(t, err)
+
+	base, _, err := b.getStack(stackName)
+	assert.NoError(t, err)
+	manager := backend.NewSnapshotManager(&localSnapshotPersister{name: stackName, backend: b, sm: sm}, base)
+
+	// Create a resource and register its outputs, then begin creating another resource. The update is killed before
+	// the second create finishes, so the manager is never closed and never compacts its journal.
+	resB := newResource("b")
+	createB := deploy.NewCreateStep(nil, testRegisterResourceEvent{}, resB)
+	mutation, err := manager.BeginMutation(createB)
+	assert.NoError(t, err)
+	assert.NoError(t, mutation.End(createB, true))
+	assert.NoError(t, manager.RegisterResourceOutputs(createB, resource.PropertyMap{
+		"foo": resource.NewStringProperty("bar"),
+	}))
+	resC := newResource("c")
+	_, err = manager.BeginMutation(deploy.NewCreateStep(nil, testRegisterResourceEvent{}, resC))
+	assert.NoError(t, err)
+
+	// The mutations after the first are recorded in the stack's journal rather than in its checkpoint.
+	journalDir := filepath.Join(dir, workspace.BookkeepingDir, workspace.JournalDir, "dev")
+	files, err := ioutil.ReadDir(journalDir)
+	assert.NoError(t, err)
+	assert.NotEmpty(t, files)
+	chk, err := b.getCheckpoint(stackName)
+	assert.NoError(t, err)
+	assert.Len(t, chk.Latest.Resources, 1)
+
+	// A new backend replays the journal onto the checkpoint.
+	snap, _, err := newTestBackend(t, dir).getStack(stackName)
+	assert.NoError(t, err)
+	if assert.Len(t, snap.Resources, 2) {
+		assert.Equal(t, resB.URN, snap.Resources[0].URN)
+		assert.Equal(t, resource.NewStringProperty("bar"), snap.Resources[0].Outputs["foo"])
+		assert.Equal(t, a.URN, snap.Resources[1].URN)
+	}
+	if assert.Len(t, snap.PendingOperations, 1) {
+		assert.Equal(t, resC.URN, snap.PendingOperations[0].Resource.URN)
+		assert.Equal(t, resource.OperationTypeCreating, snap.PendingOperations[0].Type)
+	}
+
+	// Saving a new checkpoint discards the journal.
+	_, err = b.saveStack(stackName, snap, sm)
+	assert.NoError(t, err)
+	files, err = ioutil.ReadDir(journalDir)
+	assert.True(t, os.IsNotExist(err) || len(files) == 0)
+}
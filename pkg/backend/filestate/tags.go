@@ -0,0 +1,98 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filestate
+
+import (
+	"context"
+	"encoding/json"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+	"gocloud.dev/gcerrors"
+
+	"github.com/pulumi/pulumi/sdk/v2/go/common/apitype"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/tokens"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/util/contract"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/util/fsutil"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/workspace"
+)
+
+// tagsPath returns the path of the file that holds the given stack's tags.
+func (b *localBackend) tagsPath(stack tokens.QName) string {
+	contract.Require(stack != "", "stack")
+	return filepath.Join(b.StateDir(), workspace.TagDir, fsutil.QnamePath(stack)+".json")
+}
+
+// getStackTags returns the given stack's tags. Stacks that have never been tagged have no tags.
+func (b *localBackend) getStackTags(name tokens.QName) (map[apitype.StackTagName]string, error) {
+	file := b.tagsPath(name)
+	byts, err := b.bucket.ReadAll(context.TODO(), file)
+	if err != nil {
+		if gcerrors.Code(errors.Cause(err)) == gcerrors.NotFound {
+			return map[apitype.StackTagName]string{}, nil
+		}
+		return nil, errors.Wrapf(err, "reading stack tags from %s", file)
+	}
+
+	tags := make(map[apitype.StackTagName]string)
+	if err = json.Unmarshal(byts, &tags); err != nil {
+		return nil, errors.Wrapf(err, "reading stack tags from %s", file)
+	}
+	return tags, nil
+}
+
+// saveStackTags replaces the given stack's tags.
+func (b *localBackend) saveStackTags(name tokens.QName, tags map[apitype.StackTagName]string) error {
+	byts, err := json.MarshalIndent(tags, "", "    ")
+	if err != nil {
+		return errors.Wrap(err, "serializing stack tags")
+	}
+	return b.bucket.WriteAll(context.TODO(), b.tagsPath(name), byts, nil)
+}
+
+// removeStackTags removes the given stack's tags, if it has any.
+func (b *localBackend) removeStackTags(name tokens.QName) error {
+	file := b.tagsPath(name)
+	exists, err := b.bucket.Exists(context.TODO(), file)
+	if err != nil || !exists {
+		return err
+	}
+	return b.bucket.Delete(context.TODO(), file)
+}
+
+// renameStackTags moves the tags of the given stack to its new name, if it has any.
+func (b *localBackend) renameStackTags(oldName, newName tokens.QName) error {
+	file := b.tagsPath(oldName)
+	exists, err := b.bucket.Exists(context.TODO(), file)
+	if err != nil || !exists {
+		return err
+	}
+	return renameObject(b.bucket, file, b.tagsPath(newName))
+}
+
+// matchesTagFilter returns true if any of the given tags matches the given tag name and value filters. An empty or
+// missing filter matches any tag name or value.
+func matchesTagFilter(tags map[apitype.StackTagName]string, tagName, tagValue *string) bool {
+	for name, value := range tags {
+		if tagName != nil && *tagName != "" && string(name) != *tagName {
+			continue
+		}
+		if tagValue != nil && value != *tagValue {
+			continue
+		}
+		return true
+	}
+	return false
+}
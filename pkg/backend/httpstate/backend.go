@@ -128,8 +128,16 @@ func New(d diag.Sink, cloudURL string) (Backend, error) {
 	if err != nil {
 		return nil, errors.Wrap(err, "getting stored credentials")
 	}
-	apiToken := account.AccessToken
+	return newCloudBackend(d, cloudURL, account.AccessToken), nil
+}
 
+// NewWithAccessToken creates a new Pulumi backend for the given cloud API URL that authenticates with the given access
+// token rather than with stored credentials. The token is not validated or saved.
+func NewWithAccessToken(d diag.Sink, cloudURL, accessToken string) Backend {
+	return newCloudBackend(d, ValueOrDefaultURL(cloudURL), accessToken)
+}
+
+func newCloudBackend(d diag.Sink, cloudURL, apiToken string) *cloudBackend {
 	// When stringifying backend references, we take the current project (if present) into account.
 	currentProject, err := workspace.DetectProject()
 	if err != nil {
@@ -141,7 +149,7 @@ func New(d diag.Sink, cloudURL string) (Backend, error) {
 		url:            cloudURL,
 		client:         client.NewClient(cloudURL, apiToken, d),
 		currentProject: currentProject,
-	}, nil
+	}
 }
 
 // loginWithBrowser uses a web-browser to log into the cloud and returns the cloud backend for it.
@@ -797,7 +805,7 @@ func (b *cloudBackend) RenameStack(ctx context.Context, stack backend.Stack, new
 }
 
 func (b *cloudBackend) Preview(ctx context.Context, stack backend.Stack,
-	op backend.UpdateOperation) (engine.ResourceChanges, result.Result) {
+	op backend.UpdateOperation) (*deploy.UpdatePlan, engine.ResourceChanges, result.Result) {
 	// We can skip PreviewtThenPromptThenExecute, and just go straight to Execute.
 	opts := backend.ApplierOptions{
 		DryRun:   true,
@@ -822,11 +830,21 @@ func (b *cloudBackend) Destroy(ctx context.Context, stack backend.Stack,
 	return backend.PreviewThenPromptThenExecute(ctx, apitype.DestroyUpdate, stack, op, b.apply)
 }
 
+func (b *cloudBackend) Import(ctx context.Context, stack backend.Stack,
+	op backend.UpdateOperation) (engine.ResourceChanges, result.Result) {
+	return backend.PreviewThenPromptThenExecute(ctx, apitype.ResourceImportUpdate, stack, op, b.apply)
+}
+
 func (b *cloudBackend) Watch(ctx context.Context, stack backend.Stack,
 	op backend.UpdateOperation) result.Result {
 	return backend.Watch(ctx, b, stack, op, b.apply)
 }
 
+func (b *cloudBackend) DetectDrift(ctx context.Context, stack backend.Stack,
+	op backend.UpdateOperation) ([]backend.ResourceDrift, result.Result) {
+	return backend.DetectDrift(ctx, stack, op, b.apply)
+}
+
 func (b *cloudBackend) Query(ctx context.Context, op backend.QueryOperation) result.Result {
 	return b.query(ctx, op, nil /*events*/)
 }
@@ -894,11 +912,12 @@ func (b *cloudBackend) createAndStartUpdate(
 func (b *cloudBackend) apply(
 	ctx context.Context, kind apitype.UpdateKind, stack backend.Stack,
 	op backend.UpdateOperation, opts backend.ApplierOptions,
-	events chan<- engine.Event) (engine.ResourceChanges, result.Result) {
+	events chan<- engine.Event) (*deploy.UpdatePlan, engine.ResourceChanges, result.Result) {
 
 	actionLabel := backend.ActionLabel(kind, opts.DryRun)
 
-	if !(op.Opts.Display.JSONDisplay || op.Opts.Display.Type == display.DisplayWatch) {
+	if !(op.Opts.Display.JSONDisplay || op.Opts.Display.Type == display.DisplayWatch ||
+		op.Opts.Display.Type == display.DisplayNone) {
 		// Print a banner so it's clear this is going to the cloud.
 		fmt.Printf(op.Opts.Display.Color.Colorize(
 			colors.SpecHeadline+"%s (%s):"+colors.Reset+"\n"), actionLabel, stack.Ref())
@@ -908,7 +927,7 @@ func (b *cloudBackend) apply(
 	update, version, token, err :=
 		b.createAndStartUpdate(ctx, kind, stack, &op, opts.DryRun)
 	if err != nil {
-		return nil, result.FromError(err)
+		return nil, nil, result.FromError(err)
 	}
 
 	if opts.ShowLink && !op.Opts.Display.JSONDisplay {
@@ -943,12 +962,12 @@ func (b *cloudBackend) query(ctx context.Context, op backend.QueryOperation,
 func (b *cloudBackend) runEngineAction(
 	ctx context.Context, kind apitype.UpdateKind, stackRef backend.StackReference,
 	op backend.UpdateOperation, update client.UpdateIdentifier, token string,
-	callerEventsOpt chan<- engine.Event, dryRun bool) (engine.ResourceChanges, result.Result) {
+	callerEventsOpt chan<- engine.Event, dryRun bool) (*deploy.UpdatePlan, engine.ResourceChanges, result.Result) {
 
 	contract.Assertf(token != "", "persisted actions require a token")
 	u, err := b.newUpdate(ctx, stackRef, op, update, token)
 	if err != nil {
-		return nil, result.FromError(err)
+		return nil, nil, result.FromError(err)
 	}
 
 	// displayEvents renders the event to the console and Pulumi service. The processor for the
@@ -997,17 +1016,20 @@ func (b *cloudBackend) runEngineAction(
 		engineCtx.ParentSpan = parentSpan.Context()
 	}
 
+	var plan *deploy.UpdatePlan
 	var changes engine.ResourceChanges
 	var res result.Result
 	switch kind {
 	case apitype.PreviewUpdate:
-		changes, res = engine.Update(u, engineCtx, op.Opts.Engine, true)
+		plan, changes, res = engine.Preview(u, engineCtx, op.Opts.Engine)
 	case apitype.UpdateUpdate:
 		changes, res = engine.Update(u, engineCtx, op.Opts.Engine, dryRun)
 	case apitype.RefreshUpdate:
 		changes, res = engine.Refresh(u, engineCtx, op.Opts.Engine, dryRun)
 	case apitype.DestroyUpdate:
 		changes, res = engine.Destroy(u, engineCtx, op.Opts.Engine, dryRun)
+	case apitype.ResourceImportUpdate:
+		changes, res = engine.Import(u, engineCtx, op.Opts.Engine, op.Imports, dryRun)
 	default:
 		contract.Failf("Unrecognized update kind: %s", kind)
 	}
@@ -1033,7 +1055,7 @@ func (b *cloudBackend) runEngineAction(
 		res = result.Merge(res, result.FromError(errors.Wrap(completeErr, "failed to complete update")))
 	}
 
-	return changes, res
+	return plan, changes, res
 }
 
 func (b *cloudBackend) CancelCurrentUpdate(ctx context.Context, stackRef backend.StackReference) error {
@@ -1061,7 +1083,9 @@ func (b *cloudBackend) CancelCurrentUpdate(ctx context.Context, stackRef backend
 	return b.client.CancelUpdate(ctx, updateID)
 }
 
-func (b *cloudBackend) GetHistory(ctx context.Context, stackRef backend.StackReference) ([]backend.UpdateInfo, error) {
+func (b *cloudBackend) GetHistory(ctx context.Context, stackRef backend.StackReference,
+	includeResources bool) ([]backend.UpdateInfo, error) {
+
 	stack, err := b.getCloudStackIdentifier(stackRef)
 	if err != nil {
 		return nil, err
@@ -1081,15 +1105,26 @@ func (b *cloudBackend) GetHistory(ctx context.Context, stackRef backend.StackRef
 			return nil, errors.Wrap(err, "converting configuration")
 		}
 
+		// The per-resource summaries are not part of the update's history entry, so read them from the summary event
+		// that the update recorded. This requires reading all of the update's events, so only do so if asked.
+		var summaries []engine.ResourceSummary
+		if includeResources && update.Result != apitype.InProgressResult {
+			if summaries, err = b.getResourceSummaries(ctx, stack, update.Version); err != nil {
+				return nil, errors.Wrapf(err, "reading the resource summaries of version %d", update.Version)
+			}
+		}
+
 		beUpdates = append(beUpdates, backend.UpdateInfo{
-			Kind:            update.Kind,
-			Message:         update.Message,
-			Environment:     update.Environment,
-			Config:          cfg,
-			Result:          backend.UpdateResult(update.Result),
-			StartTime:       update.StartTime,
-			EndTime:         update.EndTime,
-			ResourceChanges: convertResourceChanges(update.ResourceChanges),
+			Kind:              update.Kind,
+			Message:           update.Message,
+			Environment:       update.Environment,
+			Config:            cfg,
+			Result:            backend.UpdateResult(update.Result),
+			StartTime:         update.StartTime,
+			EndTime:           update.EndTime,
+			ResourceChanges:   convertResourceChanges(update.ResourceChanges),
+			ResourceSummaries: summaries,
+			Version:           update.Version,
 		})
 	}
 
@@ -1124,6 +1159,53 @@ func convertResourceChanges(changes map[apitype.OpType]int) engine.ResourceChang
 	return b
 }
 
+// getResourceSummaries returns the per-resource summaries of the update that produced the given version of a stack, as
+// reported by the update's summary event.
+func (b *cloudBackend) getResourceSummaries(ctx context.Context, stack client.StackIdentifier,
+	version int) ([]engine.ResourceSummary, error) {
+
+	var events []apitype.EngineEvent
+	var continuationToken *string
+	for {
+		resp, err := b.client.GetUpdateEngineEvents(ctx, stack, version, continuationToken)
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, resp.Events...)
+		if resp.ContinuationToken == nil || len(resp.Events) == 0 {
+			break
+		}
+		continuationToken = resp.ContinuationToken
+	}
+	return resourceSummariesFromEvents(events), nil
+}
+
+// resourceSummariesFromEvents returns the per-resource summaries carried by the summary event among the given engine
+// events, if there is one.
+func resourceSummariesFromEvents(events []apitype.EngineEvent) []engine.ResourceSummary {
+	for i := len(events) - 1; i >= 0; i-- {
+		if summary := events[i].SummaryEvent; summary != nil {
+			return convertResourceSummaries(summary.Resources)
+		}
+	}
+	return nil
+}
+
+// convertResourceSummaries converts the apitype version of the per-resource summaries into the internal version.
+func convertResourceSummaries(summaries []apitype.ResourceSummary) []engine.ResourceSummary {
+	var b []engine.ResourceSummary
+	for _, s := range summaries {
+		b = append(b, engine.ResourceSummary{
+			URN:      resource.URN(s.URN),
+			Type:     tokens.Type(s.Type),
+			Op:       deploy.StepOp(s.Op),
+			Duration: time.Duration(s.DurationMilliseconds) * time.Millisecond,
+			Error:    s.Error,
+		})
+	}
+	return b
+}
+
 // convertResourceChanges converts the apitype version of config.Map into the internal version.
 func convertConfig(apiConfig map[string]apitype.ConfigValue) (config.Map, error) {
 	c := make(config.Map)
@@ -0,0 +1,144 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package httpstate
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pulumi/pulumi/pkg/v2/backend/httpstate/client"
+	"github.com/pulumi/pulumi/pkg/v2/engine"
+	"github.com/pulumi/pulumi/pkg/v2/resource/deploy"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/apitype"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/resource"
+)
+
+func TestResourceSummariesFromEvents(t *testing.T) {
+	// Updates without a summary event have no summaries.
+	assert.Nil(t, resourceSummariesFromEvents(nil))
+	assert.Nil(t, resourceSummariesFromEvents([]apitype.EngineEvent{
+		{Sequence: 0, StdoutEvent: &apitype.StdoutEngineEvent{Message: "hello"}},
+	}))
+
+	events := []apitype.EngineEvent{
+		{Sequence: 0, PreludeEvent: &apitype.PreludeEvent{}},
+		{Sequence: 1, StdoutEvent: &apitype.StdoutEngineEvent{Message: "hello"}},
+		{Sequence: 2, SummaryEvent: &apitype.SummaryEvent{
+			Resources: []apitype.ResourceSummary{
+				{
+					URN:                  "urn:pulumi:test::test::pkgA:m:typA::resA",
+					Type:                 "pkgA:m:typA",
+					Op:                   apitype.OpCreate,
+					DurationMilliseconds: 1500,
+				},
+				{
+					URN:                  "urn:pulumi:test::test::pkgA:m:typA::resB",
+					Type:                 "pkgA:m:typA",
+					Op:                   apitype.OpUpdate,
+					DurationMilliseconds: 20,
+					Error:                "oh no",
+				},
+			},
+		}},
+	}
+	assert.Equal(t, []engine.ResourceSummary{
+		{
+			URN:      resource.URN("urn:pulumi:test::test::pkgA:m:typA::resA"),
+			Type:     "pkgA:m:typA",
+			Op:       deploy.OpCreate,
+			Duration: 1500 * time.Millisecond,
+		},
+		{
+			URN:      resource.URN("urn:pulumi:test::test::pkgA:m:typA::resB"),
+			Type:     "pkgA:m:typA",
+			Op:       deploy.OpUpdate,
+			Duration: 20 * time.Millisecond,
+			Error:    "oh no",
+		},
+	}, resourceSummariesFromEvents(events))
+}
+
+func TestGetHistoryRequests(t *testing.T) {
+	var requests []string
+	eventsStatus := http.StatusOK
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.URL.Path)
+
+		var resp interface{}
+		switch r.URL.Path {
+		case "/api/stacks/owner/project/stack/updates":
+			resp = apitype.GetHistoryResponse{
+				Updates: []apitype.UpdateInfo{
+					{Kind: apitype.UpdateUpdate, Result: apitype.SucceededResult, Version: 2},
+					{Kind: apitype.UpdateUpdate, Result: apitype.SucceededResult, Version: 1},
+				},
+			}
+		case "/api/stacks/owner/project/stack/updates/2/events", "/api/stacks/owner/project/stack/updates/1/events":
+			if eventsStatus != http.StatusOK {
+				w.WriteHeader(eventsStatus)
+				return
+			}
+			resp = apitype.GetUpdateEventsResponse{
+				Events: []apitype.EngineEvent{{SummaryEvent: &apitype.SummaryEvent{
+					Resources: []apitype.ResourceSummary{{
+						URN:  "urn:pulumi:stack::project::pkgA:m:typA::resA",
+						Type: "pkgA:m:typA",
+						Op:   apitype.OpCreate,
+					}},
+				}}},
+			}
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		assert.NoError(t, json.NewEncoder(w).Encode(resp))
+	}))
+	defer server.Close()
+
+	b := &cloudBackend{url: server.URL, client: client.NewClient(server.URL, "token", nil)}
+	ref := cloudBackendReference{name: "stack", project: "project", owner: "owner", b: b}
+
+	// A plain history request reads only the stack's updates.
+	updates, err := b.GetHistory(context.Background(), ref, false /*includeResources*/)
+	assert.NoError(t, err)
+	assert.Len(t, updates, 2)
+	assert.Nil(t, updates[0].ResourceSummaries)
+	assert.Equal(t, []string{"/api/stacks/owner/project/stack/updates"}, requests)
+
+	// Asking for the per-resource summaries also reads the events of each update.
+	requests = nil
+	updates, err = b.GetHistory(context.Background(), ref, true /*includeResources*/)
+	assert.NoError(t, err)
+	assert.Len(t, updates, 2)
+	assert.Len(t, requests, 3)
+	for _, update := range updates {
+		assert.Equal(t, []engine.ResourceSummary{{
+			URN:  "urn:pulumi:stack::project::pkgA:m:typA::resA",
+			Type: "pkgA:m:typA",
+			Op:   deploy.OpCreate,
+		}}, update.ResourceSummaries)
+	}
+
+	// Failures to read the events are reported rather than yielding empty summaries.
+	eventsStatus = http.StatusNotFound
+	_, err = b.GetHistory(context.Background(), ref, true /*includeResources*/)
+	assert.Error(t, err)
+}
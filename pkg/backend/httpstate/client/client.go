@@ -466,7 +466,7 @@ func (pc *Client) CreateUpdate(
 	// Create the initial update object.
 	var endpoint string
 	switch kind {
-	case apitype.UpdateUpdate:
+	case apitype.UpdateUpdate, apitype.ResourceImportUpdate:
 		endpoint = "update"
 	case apitype.PreviewUpdate:
 		endpoint = "preview"
@@ -790,6 +790,25 @@ func (pc *Client) GetUpdateEvents(ctx context.Context, update UpdateIdentifier,
 	return results, nil
 }
 
+// GetUpdateEngineEvents returns the engine events recorded by the update that produced the given version of the
+// indicated stack, taking an optional continuation token from a previous call.
+func (pc *Client) GetUpdateEngineEvents(ctx context.Context, stack StackIdentifier, version int,
+	continuationToken *string) (apitype.GetUpdateEventsResponse, error) {
+
+	queryObj := struct {
+		ContinuationToken *string `url:"continuationToken,omitempty"`
+	}{
+		ContinuationToken: continuationToken,
+	}
+
+	var resp apitype.GetUpdateEventsResponse
+	path := getStackPath(stack, "updates", strconv.Itoa(version), "events")
+	if err := pc.restCall(ctx, "GET", path, queryObj, nil, &resp); err != nil {
+		return apitype.GetUpdateEventsResponse{}, err
+	}
+	return resp, nil
+}
+
 // RenewUpdateLease renews the indicated update lease for the given duration.
 func (pc *Client) RenewUpdateLease(ctx context.Context, update UpdateIdentifier, token string,
 	duration time.Duration) (string, error) {
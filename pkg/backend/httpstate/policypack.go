@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"io/ioutil"
 	"os"
+	"os/exec"
 	"path/filepath"
 	"strconv"
 	"strings"
@@ -21,6 +22,7 @@ import (
 	"github.com/pulumi/pulumi/sdk/v2/go/common/tokens"
 	"github.com/pulumi/pulumi/sdk/v2/go/common/util/archive"
 	"github.com/pulumi/pulumi/sdk/v2/go/common/util/contract"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/util/executable"
 	"github.com/pulumi/pulumi/sdk/v2/go/common/util/logging"
 	"github.com/pulumi/pulumi/sdk/v2/go/common/util/result"
 	"github.com/pulumi/pulumi/sdk/v2/go/common/workspace"
@@ -301,6 +303,10 @@ func installRequiredPolicy(finalDir string, tarball []byte) error {
 		if err := completePythonInstall(finalDir, projPath, proj); err != nil {
 			return err
 		}
+	} else if strings.EqualFold(proj.Runtime.Name(), "go") {
+		if err := completeGoInstall(finalDir); err != nil {
+			return err
+		}
 	}
 
 	fmt.Println("Finished installing policy pack")
@@ -334,3 +340,23 @@ func completePythonInstall(finalDir, projPath string, proj *workspace.PolicyPack
 
 	return nil
 }
+
+func completeGoInstall(finalDir string) error {
+	gobin, err := executable.FindExecutable("go")
+	if err != nil {
+		return errors.Wrap(err, "failed to install dependencies of policy pack")
+	}
+
+	cmd := exec.Command(gobin, "mod", "download")
+	cmd.Dir = finalDir
+	cmd.Env = os.Environ()
+	cmd.Stdout, cmd.Stderr = os.Stderr, os.Stderr
+	if err := cmd.Run(); err != nil {
+		return errors.Wrapf(
+			err,
+			"failed to install dependencies of policy pack; you may need to re-run `go mod download` "+
+				"in %q before this policy pack works", finalDir)
+	}
+
+	return nil
+}
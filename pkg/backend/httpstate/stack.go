@@ -69,6 +69,10 @@ func (c cloudBackendReference) Name() tokens.QName {
 	return c.name
 }
 
+func (c cloudBackendReference) Project() (tokens.PackageName, bool) {
+	return tokens.PackageName(c.project), c.project != ""
+}
+
 // cloudStack is a cloud stack descriptor.
 type cloudStack struct {
 	// ref is the stack's unique name.
@@ -140,7 +144,9 @@ func (s *cloudStack) Rename(ctx context.Context, newName tokens.QName) error {
 	return backend.RenameStack(ctx, s, newName)
 }
 
-func (s *cloudStack) Preview(ctx context.Context, op backend.UpdateOperation) (engine.ResourceChanges, result.Result) {
+func (s *cloudStack) Preview(ctx context.Context,
+	op backend.UpdateOperation) (*deploy.UpdatePlan, engine.ResourceChanges, result.Result) {
+
 	return backend.PreviewStack(ctx, s, op)
 }
 
@@ -156,10 +162,20 @@ func (s *cloudStack) Destroy(ctx context.Context, op backend.UpdateOperation) (e
 	return backend.DestroyStack(ctx, s, op)
 }
 
+func (s *cloudStack) Import(ctx context.Context, op backend.UpdateOperation) (engine.ResourceChanges, result.Result) {
+	return backend.ImportStack(ctx, s, op)
+}
+
 func (s *cloudStack) Watch(ctx context.Context, op backend.UpdateOperation) result.Result {
 	return backend.WatchStack(ctx, s, op)
 }
 
+func (s *cloudStack) DetectDrift(ctx context.Context,
+	op backend.UpdateOperation) ([]backend.ResourceDrift, result.Result) {
+
+	return backend.DetectStackDrift(ctx, s, op)
+}
+
 func (s *cloudStack) GetLogs(ctx context.Context, cfg backend.StackConfiguration,
 	query operations.LogQuery) ([]operations.LogEntry, error) {
 	return backend.GetStackLogs(ctx, s, cfg, query)
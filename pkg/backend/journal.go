@@ -0,0 +1,457 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package backend
+
+import (
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/pulumi/pulumi/pkg/v2/resource/deploy"
+	"github.com/pulumi/pulumi/pkg/v2/resource/stack"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/apitype"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/resource"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/resource/config"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/util/contract"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/util/logging"
+)
+
+// defaultJournalCompactionInterval is the number of journal entries after which a SnapshotManager compacts its
+// journal into a full checkpoint.
+const defaultJournalCompactionInterval = 256
+
+// JournalPersister is an optional interface implemented by snapshot persisters that are able to persist a journal of
+// snapshot mutations. Rather than saving the entire snapshot after each mutation, a SnapshotManager that is given a
+// JournalPersister appends a small entry per mutation to the journal and periodically compacts the journal by saving
+// a full snapshot with Save.
+//
+// The journal of a stack always applies to the stack's current checkpoint: the first entry of a journal identifies
+// the checkpoint on which it is based, and journals that do not apply to the current checkpoint must be ignored. A
+// journal can be replayed into a snapshot with ReplayJournal.
+type JournalPersister interface {
+	SnapshotPersister
+
+	// AppendJournal persists the given entries at the end of the stack's journal.
+	AppendJournal(entries []JournalEntry) error
+	// ResetJournal discards the stack's journal. It is called after the journal has been compacted into a checkpoint
+	// that was persisted with Save.
+	ResetJournal() error
+}
+
+// JournalEntryKind describes the kind of a journal entry.
+type JournalEntryKind string
+
+const (
+	// JournalEntryBase is the kind of the first entry in every journal, which identifies the checkpoint to which the
+	// journal applies.
+	JournalEntryBase JournalEntryKind = "base"
+	// JournalEntryBegin is the kind of an entry that records the beginning of a step.
+	JournalEntryBegin JournalEntryKind = "begin"
+	// JournalEntryEnd is the kind of an entry that records the end of a step.
+	JournalEntryEnd JournalEntryKind = "end"
+	// JournalEntryOutputs is the kind of an entry that records the registration of a resource's outputs.
+	JournalEntryOutputs JournalEntryKind = "outputs"
+)
+
+// JournalState records the value of a resource state referenced by a journal.
+//
+// Within a journal, resource states are identified by integer IDs. The IDs 1 through N refer to the N resources of
+// the checkpoint on which the journal is based, in order; the remaining IDs are introduced by the journal's entries.
+type JournalState struct {
+	// ID is the ID of the resource state.
+	ID int `json:"id"`
+	// State is the value of the resource state at the time the entry was recorded.
+	State apitype.ResourceV3 `json:"state"`
+}
+
+// JournalOperation records an operation that was begun on a resource state.
+type JournalOperation struct {
+	// ID is the ID of the resource state that is the subject of the operation.
+	ID int `json:"id"`
+	// Type is the type of the operation.
+	Type resource.OperationType `json:"type"`
+}
+
+// JournalEntry records a single mutation of a stack's snapshot.
+type JournalEntry struct {
+	// Sequence is the position of this entry in its journal, starting at 1.
+	Sequence int `json:"sequence"`
+	// Kind is the kind of this entry.
+	Kind JournalEntryKind `json:"kind"`
+	// Op is the operation of the step that produced this entry, if any.
+	Op deploy.StepOp `json:"op,omitempty"`
+	// URN is the URN of the resource on which the step that produced this entry operated, if any.
+	URN resource.URN `json:"urn,omitempty"`
+
+	// Checkpoint is the time recorded in the manifest of the checkpoint to which the journal applies. Only set for
+	// base entries.
+	Checkpoint *time.Time `json:"checkpoint,omitempty"`
+	// NewResources is the number of resources at the start of the checkpoint's resource list that were produced by
+	// the update that wrote the checkpoint. Only set for base entries.
+	NewResources int `json:"newResources,omitempty"`
+	// Operations are the IDs of the resource states of the checkpoint's pending operations, in order. Only set for
+	// base entries.
+	Operations []int `json:"operations,omitempty"`
+
+	// States are the values of the resource states that were introduced or changed by this mutation.
+	States []JournalState `json:"states,omitempty"`
+	// New are the IDs of the resource states that were added to the snapshot by this mutation.
+	New []int `json:"new,omitempty"`
+	// Done are the IDs of the resource states that were removed from the snapshot by this mutation.
+	Done []int `json:"done,omitempty"`
+	// Pending are the operations that were begun by this mutation.
+	Pending []JournalOperation `json:"pending,omitempty"`
+	// Complete are the IDs of the resource states whose operations were completed by this mutation.
+	Complete []int `json:"complete,omitempty"`
+}
+
+// snapshotJournal tracks the state of a SnapshotManager's journal. It is only accessed by the manager's mutation
+// service loop, and therefore requires no synchronization of its own.
+type snapshotJournal struct {
+	persister JournalPersister  // The persister that persists the journal.
+	enc       config.Encrypter  // The encrypter used to serialize resource states.
+	interval  int               // The number of entries after which the journal is compacted.
+	compacted bool              // True once the journal has been compacted at least once.
+	base      []*resource.State // The base resources of the manager at the time of the last compaction.
+	ids       map[*resource.State]int
+	nextID    int
+	sequence  int            // The sequence number of the last recorded entry.
+	pending   []JournalEntry // Entries that have been recorded but not yet persisted.
+	current   *JournalEntry  // The entry that is currently being recorded, if any.
+	err       error          // The first error encountered while recording the current entry.
+}
+
+func newSnapshotJournal(persister JournalPersister) (*snapshotJournal, error) {
+	enc := config.Encrypter(config.NewPanicCrypter())
+	if sm := persister.SecretsManager(); sm != nil {
+		e, err := sm.Encrypter()
+		if err != nil {
+			return nil, errors.Wrap(err, "getting encrypter for snapshot journal")
+		}
+		enc = e
+	}
+
+	return &snapshotJournal{
+		persister: persister,
+		enc:       enc,
+		interval:  defaultJournalCompactionInterval,
+	}, nil
+}
+
+// mustCompact returns true if the next write of the journal must be a compaction. This is the case if the journal
+// has never been compacted, if it has grown past the compaction interval, or if the engine has rebuilt the base
+// resources in memory, in which case the journal's resource state IDs no longer apply.
+func (j *snapshotJournal) mustCompact(base *deploy.Snapshot) bool {
+	if !j.compacted || j.sequence >= j.interval {
+		return true
+	}
+
+	var resources []*resource.State
+	if base != nil {
+		resources = base.Resources
+	}
+	if len(resources) != len(j.base) {
+		return true
+	}
+	for i, res := range resources {
+		if res != j.base[i] {
+			return true
+		}
+	}
+	return false
+}
+
+// dirty returns true if any entries have been recorded since the last compaction.
+func (j *snapshotJournal) dirty() bool {
+	return j.compacted && j.sequence > 1
+}
+
+// reset starts a new journal based on the given snapshot, which has just been persisted. newResources is the number
+// of resources at the start of the snapshot that were produced by the current update, and base is the manager's
+// base snapshot.
+func (j *snapshotJournal) reset(snap *deploy.Snapshot, newResources int, base *deploy.Snapshot) {
+	j.ids, j.nextID = make(map[*resource.State]int), 1
+	for _, res := range snap.Resources {
+		j.ids[res] = j.nextID
+		j.nextID++
+	}
+
+	var operations []int
+	for _, op := range snap.PendingOperations {
+		id, has := j.ids[op.Resource]
+		if !has {
+			id = j.nextID
+			j.ids[op.Resource] = id
+			j.nextID++
+		}
+		operations = append(operations, id)
+	}
+
+	j.base = nil
+	if base != nil {
+		j.base = make([]*resource.State, len(base.Resources))
+		copy(j.base, base.Resources)
+	}
+
+	checkpoint := snap.Manifest.Time
+	j.compacted, j.sequence, j.pending, j.current = true, 1, nil, nil
+	j.pending = []JournalEntry{{
+		Sequence:     1,
+		Kind:         JournalEntryBase,
+		Checkpoint:   &checkpoint,
+		NewResources: newResources,
+		Operations:   operations,
+	}}
+}
+
+// begin starts recording an entry for a mutation of the given kind.
+func (j *snapshotJournal) begin(kind JournalEntryKind, step deploy.Step) {
+	contract.Assert(j.current == nil)
+
+	j.current, j.err = &JournalEntry{Kind: kind}, nil
+	if step != nil {
+		j.current.Op, j.current.URN = step.Op(), step.URN()
+	}
+}
+
+// end finishes recording the current entry. The values of the step's old and new states are recorded if the journal
+// is tracking them, as the engine may have changed them in place. The entry is only recorded if the journal has
+// been compacted at least once, as the first compaction captures all prior mutations.
+func (j *snapshotJournal) end(step deploy.Step) error {
+	contract.Assert(j.current != nil)
+
+	if step != nil {
+		if old := step.Old(); old != nil {
+			j.recordState(old, false)
+		}
+		if new := step.New(); new != nil {
+			j.recordState(new, false)
+		}
+	}
+
+	entry, err := j.current, j.err
+	j.current, j.err = nil, nil
+	if err != nil {
+		return err
+	}
+
+	if j.compacted {
+		j.sequence++
+		entry.Sequence = j.sequence
+		j.pending = append(j.pending, *entry)
+	}
+	return nil
+}
+
+// flush persists any entries that have been recorded but not yet persisted.
+func (j *snapshotJournal) flush() error {
+	if len(j.pending) == 0 {
+		return nil
+	}
+	if err := j.persister.AppendJournal(j.pending); err != nil {
+		return errors.Wrap(err, "failed to append to snapshot journal")
+	}
+	j.pending = nil
+	return nil
+}
+
+// recordState records the current value of the given resource state in the current entry and returns the state's ID.
+// If the state is not yet tracked by the journal, it is assigned a new ID if create is true; otherwise it is ignored.
+func (j *snapshotJournal) recordState(state *resource.State, create bool) (int, bool) {
+	if j.current == nil || !j.compacted {
+		return 0, false
+	}
+
+	id, has := j.ids[state]
+	if !has {
+		if !create {
+			return 0, false
+		}
+		id = j.nextID
+		j.ids[state] = id
+		j.nextID++
+	}
+
+	for _, s := range j.current.States {
+		if s.ID == id {
+			return id, true
+		}
+	}
+
+	serialized, err := stack.SerializeResource(state, j.enc, false /* showSecrets */)
+	if err != nil {
+		if j.err == nil {
+			j.err = errors.Wrapf(err, "serializing resource %s", state.URN)
+		}
+		return 0, false
+	}
+	j.current.States = append(j.current.States, JournalState{ID: id, State: serialized})
+	return id, true
+}
+
+// recordNew records that the given resource state was added to the snapshot.
+func (j *snapshotJournal) recordNew(state *resource.State) {
+	if id, ok := j.recordState(state, true); ok {
+		j.current.New = append(j.current.New, id)
+	}
+}
+
+// recordDone records that the given resource state was removed from the snapshot.
+func (j *snapshotJournal) recordDone(state *resource.State) {
+	if j.current == nil || !j.compacted {
+		return
+	}
+	if id, has := j.ids[state]; has {
+		j.current.Done = append(j.current.Done, id)
+	}
+}
+
+// recordOperationPending records that an operation was begun on the given resource state.
+func (j *snapshotJournal) recordOperationPending(state *resource.State, op resource.OperationType) {
+	if id, ok := j.recordState(state, true); ok {
+		j.current.Pending = append(j.current.Pending, JournalOperation{ID: id, Type: op})
+	}
+}
+
+// recordOperationComplete records that the operations on the given resource state were completed.
+func (j *snapshotJournal) recordOperationComplete(state *resource.State) {
+	if j.current == nil || !j.compacted {
+		return
+	}
+	if id, has := j.ids[state]; has {
+		j.current.Complete = append(j.current.Complete, id)
+	}
+}
+
+// ReplayJournal applies the given journal to the snapshot of the checkpoint on which it is based and returns the
+// resulting snapshot. Journals that do not apply to the given snapshot are ignored, in which case the snapshot is
+// returned unchanged. Replay stops at the first gap in the journal's sequence numbers.
+func ReplayJournal(base *deploy.Snapshot, journal []JournalEntry) (*deploy.Snapshot, error) {
+	if base == nil || len(journal) == 0 {
+		return base, nil
+	}
+
+	header := journal[0]
+	if header.Kind != JournalEntryBase || header.Sequence != 1 || header.Checkpoint == nil ||
+		!header.Checkpoint.Equal(base.Manifest.Time) {
+		logging.V(7).Infof("ReplayJournal: ignoring journal that does not apply to the checkpoint")
+		return base, nil
+	}
+	if header.NewResources > len(base.Resources) || len(header.Operations) != len(base.PendingOperations) {
+		return nil, errors.New("journal does not match its checkpoint")
+	}
+
+	dec, enc := config.Decrypter(config.NewPanicCrypter()), config.Encrypter(config.NewPanicCrypter())
+	if base.SecretsManager != nil {
+		d, err := base.SecretsManager.Decrypter()
+		if err != nil {
+			return nil, err
+		}
+		e, err := base.SecretsManager.Encrypter()
+		if err != nil {
+			return nil, err
+		}
+		dec, enc = d, e
+	}
+
+	// Copy the checkpoint's resource states so that the base snapshot is not modified by replay.
+	states := make(map[int]*resource.State)
+	copyState := func(res *resource.State) *resource.State {
+		c := *res
+		return &c
+	}
+	var news, olds []*resource.State
+	for i, res := range base.Resources {
+		c := copyState(res)
+		states[i+1] = c
+		if i < header.NewResources {
+			news = append(news, c)
+		} else {
+			olds = append(olds, c)
+		}
+	}
+	var operations []JournalOperation
+	for i, op := range base.PendingOperations {
+		id := header.Operations[i]
+		if _, has := states[id]; !has {
+			states[id] = copyState(op.Resource)
+		}
+		operations = append(operations, JournalOperation{ID: id, Type: op.Type})
+	}
+
+	dones, completeOps := make(map[int]bool), make(map[int]bool)
+	for i, entry := range journal[1:] {
+		if entry.Sequence != i+2 {
+			logging.V(7).Infof("ReplayJournal: stopping at gap in journal after entry %d", i+1)
+			break
+		}
+
+		for _, s := range entry.States {
+			res, err := stack.DeserializeResource(s.State, dec, enc)
+			if err != nil {
+				return nil, errors.Wrapf(err, "journal entry %d", entry.Sequence)
+			}
+			if existing, has := states[s.ID]; has {
+				*existing = *res
+			} else {
+				states[s.ID] = res
+			}
+		}
+
+		lookup := func(id int) (*resource.State, error) {
+			res, has := states[id]
+			if !has {
+				return nil, errors.Errorf("journal entry %d refers to unknown resource state %d", entry.Sequence, id)
+			}
+			return res, nil
+		}
+		for _, id := range entry.New {
+			res, err := lookup(id)
+			if err != nil {
+				return nil, err
+			}
+			news = append(news, res)
+		}
+		for _, id := range entry.Done {
+			dones[id] = true
+		}
+		for _, op := range entry.Pending {
+			if _, err := lookup(op.ID); err != nil {
+				return nil, err
+			}
+			operations = append(operations, op)
+		}
+		for _, id := range entry.Complete {
+			completeOps[id] = true
+		}
+	}
+
+	// Assemble the snapshot in the same order as the SnapshotManager: new resources first, followed by the base
+	// resources that are not done.
+	resources := news
+	for i, res := range olds {
+		if !dones[header.NewResources+i+1] {
+			resources = append(resources, res)
+		}
+	}
+	var ops []resource.Operation
+	for _, op := range operations {
+		if !completeOps[op.ID] {
+			ops = append(ops, resource.NewOperation(states[op.ID], op.Type))
+		}
+	}
+
+	return deploy.NewSnapshot(base.Manifest, base.SecretsManager, resources, ops), nil
+}
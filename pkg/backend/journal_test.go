@@ -0,0 +1,207 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package backend
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pulumi/pulumi/pkg/v2/resource/deploy"
+	"github.com/pulumi/pulumi/pkg/v2/resource/stack"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/resource"
+)
+
+type MockJournalPersister struct {
+	MockStackPersister
+	Journal []JournalEntry
+}
+
+func (m *MockJournalPersister) AppendJournal(entries []JournalEntry) error {
+	m.Journal = append(m.Journal, entries...)
+	return nil
+}
+
+func (m *MockJournalPersister) ResetJournal() error {
+	m.Journal = nil
+	return nil
+}
+
+// SerializingJournalPersister is a MockJournalPersister that serializes each snapshot it saves, as a real persister
+// would.
+type SerializingJournalPersister struct {
+	MockJournalPersister
+}
+
+func (m *SerializingJournalPersister) Save(snap *deploy.Snapshot) error {
+	if _, err := stack.SerializeDeployment(snap, m.SecretsManager(), false /* showSecrets */); err != nil {
+		return err
+	}
+	return m.MockJournalPersister.Save(snap)
+}
+
+func journalURNs(snap *deploy.Snapshot) []resource.URN {
+	var urns []resource.URN
+	for _, res := range snap.Resources {
+		urns = append(urns, res.URN)
+	}
+	return urns
+}
+
+func TestJournalReplay(t *testing.T) {
+	a := NewResource("a")
+	b := NewResource("b", a.URN)
+	c := NewResource("c")
+	snap := NewSnapshot([]*resource.State{a, b, c})
+
+	sp := &MockJournalPersister{}
+	manager := NewSnapshotManager(sp, snap)
+
+	applyStep := func(step deploy.Step) {
+		mutation, err := manager.BeginMutation(step)
+		if !assert.NoError(t, err) {
+			t.FailNow()
+		}
+		if !assert.NoError(t, mutation.End(step, true)) {
+			t.FailNow()
+		}
+	}
+
+	// The first write compacts the journal.
+	aPrime := NewResource(string(a.URN))
+	aPrime.Outputs = resource.PropertyMap{"foo": resource.NewStringProperty("bar")}
+	applyStep(deploy.NewSameStep(nil, MockRegisterResourceEvent{}, a, aPrime))
+	assert.Len(t, sp.SavedSnapshots, 1)
+	assert.Empty(t, sp.Journal)
+
+	// Subsequent writes append to the journal.
+	d := NewResource("d", a.URN)
+	createD := deploy.NewCreateStep(nil, &MockRegisterResourceEvent{}, d)
+	applyStep(createD)
+	outputs := resource.PropertyMap{"baz": resource.NewNumberProperty(42)}
+	assert.NoError(t, manager.RegisterResourceOutputs(createD, outputs))
+
+	bPrime := NewResource(string(b.URN), a.URN)
+	applyStep(deploy.NewUpdateStep(nil, &MockRegisterResourceEvent{}, b, bPrime, nil, nil, nil, nil))
+	applyStep(deploy.NewDeleteStep(nil, c))
+
+	// Leave a create in flight.
+	e := NewResource("e")
+	_, err := manager.BeginMutation(deploy.NewCreateStep(nil, &MockRegisterResourceEvent{}, e))
+	assert.NoError(t, err)
+
+	assert.Len(t, sp.SavedSnapshots, 1)
+	if !assert.NotEmpty(t, sp.Journal) {
+		t.FailNow()
+	}
+	assert.Equal(t, JournalEntryBase, sp.Journal[0].Kind)
+
+	// Replaying the journal onto the last checkpoint reproduces the current snapshot.
+	replayed, err := ReplayJournal(sp.LastSnap(), sp.Journal)
+	assert.NoError(t, err)
+	assert.NoError(t, replayed.VerifyIntegrity())
+	assert.Equal(t, []resource.URN{a.URN, d.URN, b.URN}, journalURNs(replayed))
+	assert.Equal(t, resource.NewNumberProperty(42), replayed.Resources[1].Outputs["baz"])
+	if assert.Len(t, replayed.PendingOperations, 1) {
+		assert.Equal(t, e.URN, replayed.PendingOperations[0].Resource.URN)
+		assert.Equal(t, resource.OperationTypeCreating, replayed.PendingOperations[0].Type)
+	}
+
+	// Replay does not modify the checkpoint.
+	assert.Equal(t, []resource.URN{a.URN, b.URN, c.URN}, journalURNs(sp.LastSnap()))
+
+	// Journals that do not apply to the checkpoint are ignored.
+	stale := NewSnapshot([]*resource.State{NewResource("a")})
+	stale.Manifest.Time = stale.Manifest.Time.Add(time.Second)
+	ignored, err := ReplayJournal(stale, sp.Journal)
+	assert.NoError(t, err)
+	assert.Equal(t, stale, ignored)
+
+	// Closing the manager compacts the journal.
+	assert.NoError(t, manager.Close())
+	assert.Len(t, sp.SavedSnapshots, 2)
+	assert.Empty(t, sp.Journal)
+	assert.Equal(t, []resource.URN{a.URN, d.URN, b.URN}, journalURNs(sp.LastSnap()))
+	assert.Len(t, sp.LastSnap().PendingOperations, 1)
+}
+
+func TestJournalCompactsRebuiltBase(t *testing.T) {
+	a := NewResource("a")
+	b := NewResource("b")
+	snap := NewSnapshot([]*resource.State{a, b})
+
+	sp := &MockJournalPersister{}
+	manager := NewSnapshotManager(sp, snap)
+
+	step := deploy.NewDeleteStep(nil, a)
+	mutation, err := manager.BeginMutation(step)
+	assert.NoError(t, err)
+	assert.Len(t, sp.SavedSnapshots, 1)
+
+	// The engine rebuilds the base snapshot in memory, e.g. after a refresh. The journal's resource state IDs no
+	// longer apply, so the next write must be a compaction.
+	snap.Resources = []*resource.State{a, NewResource("b")}
+
+	assert.NoError(t, mutation.End(step, true))
+	assert.Len(t, sp.SavedSnapshots, 2)
+	assert.Len(t, sp.Journal, 0)
+
+	assert.NoError(t, manager.Close())
+}
+
+func TestJournalConcurrentCreates(t *testing.T) {
+	snap := NewSnapshot(nil)
+
+	sp := &SerializingJournalPersister{}
+	manager := NewSnapshotManager(sp, snap)
+
+	// Compact the journal after every entry so that snapshots are serialized while creates are in flight.
+	manager.journal.interval = 1
+
+	var wg sync.WaitGroup
+	for i := 0; i < 16; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+
+			res := NewResource(fmt.Sprintf("res%d", i))
+			step := deploy.NewCreateStep(nil, &MockRegisterResourceEvent{}, res)
+			mutation, err := manager.BeginMutation(step)
+			if !assert.NoError(t, err) {
+				return
+			}
+
+			// Like CreateStep.Apply, set the results of the create on its new state while other mutations are
+			// persisting the snapshot.
+			res.ID = resource.ID(fmt.Sprintf("id%d", i))
+			res.Outputs = resource.PropertyMap{"index": resource.NewNumberProperty(float64(i))}
+
+			assert.NoError(t, mutation.End(step, true))
+		}(i)
+	}
+	wg.Wait()
+
+	assert.NoError(t, manager.Close())
+	last := sp.LastSnap()
+	assert.Len(t, last.Resources, 16)
+	assert.Empty(t, last.PendingOperations)
+	for _, res := range last.Resources {
+		assert.NotEmpty(t, res.ID)
+		assert.NotNil(t, res.Outputs["index"])
+	}
+}
@@ -47,7 +47,7 @@ type MockBackend struct {
 	GetStackCrypterF        func(StackReference) (config.Crypter, error)
 	QueryF                  func(context.Context, QueryOperation) result.Result
 	GetLatestConfigurationF func(context.Context, Stack) (config.Map, error)
-	GetHistoryF             func(context.Context, StackReference) ([]UpdateInfo, error)
+	GetHistoryF             func(context.Context, StackReference, bool) ([]UpdateInfo, error)
 	GetStackTagsF           func(context.Context, Stack) (map[apitype.StackTagName]string, error)
 	UpdateStackTagsF        func(context.Context, Stack, map[apitype.StackTagName]string) error
 	ExportDeploymentF       func(context.Context, Stack) (*apitype.UntypedDeployment, error)
@@ -55,15 +55,19 @@ type MockBackend struct {
 	LogoutF                 func() error
 	CurrentUserF            func() (string, error)
 	PreviewF                func(context.Context, Stack,
-		UpdateOperation) (engine.ResourceChanges, result.Result)
+		UpdateOperation) (*deploy.UpdatePlan, engine.ResourceChanges, result.Result)
 	UpdateF func(context.Context, Stack,
 		UpdateOperation) (engine.ResourceChanges, result.Result)
 	RefreshF func(context.Context, Stack,
 		UpdateOperation) (engine.ResourceChanges, result.Result)
 	DestroyF func(context.Context, Stack,
 		UpdateOperation) (engine.ResourceChanges, result.Result)
+	ImportF func(context.Context, Stack,
+		UpdateOperation) (engine.ResourceChanges, result.Result)
 	WatchF func(context.Context, Stack,
 		UpdateOperation) result.Result
+	DetectDriftF func(context.Context, Stack,
+		UpdateOperation) ([]ResourceDrift, result.Result)
 	GetLogsF func(context.Context, Stack, StackConfiguration,
 		operations.LogQuery) ([]operations.LogEntry, error)
 }
@@ -172,7 +176,7 @@ func (be *MockBackend) GetStackCrypter(stackRef StackReference) (config.Crypter,
 }
 
 func (be *MockBackend) Preview(ctx context.Context, stack Stack,
-	op UpdateOperation) (engine.ResourceChanges, result.Result) {
+	op UpdateOperation) (*deploy.UpdatePlan, engine.ResourceChanges, result.Result) {
 
 	if be.PreviewF != nil {
 		return be.PreviewF(ctx, stack, op)
@@ -207,6 +211,15 @@ func (be *MockBackend) Destroy(ctx context.Context, stack Stack,
 	panic("not implemented")
 }
 
+func (be *MockBackend) Import(ctx context.Context, stack Stack,
+	op UpdateOperation) (engine.ResourceChanges, result.Result) {
+
+	if be.ImportF != nil {
+		return be.ImportF(ctx, stack, op)
+	}
+	panic("not implemented")
+}
+
 func (be *MockBackend) Watch(ctx context.Context, stack Stack,
 	op UpdateOperation) result.Result {
 
@@ -216,6 +229,15 @@ func (be *MockBackend) Watch(ctx context.Context, stack Stack,
 	panic("not implemented")
 }
 
+func (be *MockBackend) DetectDrift(ctx context.Context, stack Stack,
+	op UpdateOperation) ([]ResourceDrift, result.Result) {
+
+	if be.DetectDriftF != nil {
+		return be.DetectDriftF(ctx, stack, op)
+	}
+	panic("not implemented")
+}
+
 func (be *MockBackend) Query(ctx context.Context, op QueryOperation) result.Result {
 
 	if be.QueryF != nil {
@@ -224,9 +246,11 @@ func (be *MockBackend) Query(ctx context.Context, op QueryOperation) result.Resu
 	panic("not implemented")
 }
 
-func (be *MockBackend) GetHistory(ctx context.Context, stackRef StackReference) ([]UpdateInfo, error) {
+func (be *MockBackend) GetHistory(ctx context.Context, stackRef StackReference,
+	includeResources bool) ([]UpdateInfo, error) {
+
 	if be.GetHistoryF != nil {
-		return be.GetHistoryF(ctx, stackRef)
+		return be.GetHistoryF(ctx, stackRef, includeResources)
 	}
 	panic("not implemented")
 }
@@ -304,19 +328,21 @@ func (be *MockBackend) CurrentUser() (string, error) {
 //
 
 type MockStack struct {
-	RefF      func() StackReference
-	ConfigF   func() config.Map
-	SnapshotF func(ctx context.Context) (*deploy.Snapshot, error)
-	BackendF  func() Backend
-	PreviewF  func(ctx context.Context, op UpdateOperation) (engine.ResourceChanges, result.Result)
-	UpdateF   func(ctx context.Context, op UpdateOperation) (engine.ResourceChanges, result.Result)
-	RefreshF  func(ctx context.Context, op UpdateOperation) (engine.ResourceChanges, result.Result)
-	DestroyF  func(ctx context.Context, op UpdateOperation) (engine.ResourceChanges, result.Result)
-	WatchF    func(ctx context.Context, op UpdateOperation) result.Result
-	QueryF    func(ctx context.Context, op UpdateOperation) result.Result
-	RemoveF   func(ctx context.Context, force bool) (bool, error)
-	RenameF   func(ctx context.Context, newName tokens.QName) error
-	GetLogsF  func(ctx context.Context, cfg StackConfiguration,
+	RefF         func() StackReference
+	ConfigF      func() config.Map
+	SnapshotF    func(ctx context.Context) (*deploy.Snapshot, error)
+	BackendF     func() Backend
+	PreviewF     func(ctx context.Context, op UpdateOperation) (*deploy.UpdatePlan, engine.ResourceChanges, result.Result)
+	UpdateF      func(ctx context.Context, op UpdateOperation) (engine.ResourceChanges, result.Result)
+	RefreshF     func(ctx context.Context, op UpdateOperation) (engine.ResourceChanges, result.Result)
+	DestroyF     func(ctx context.Context, op UpdateOperation) (engine.ResourceChanges, result.Result)
+	ImportF      func(ctx context.Context, op UpdateOperation) (engine.ResourceChanges, result.Result)
+	WatchF       func(ctx context.Context, op UpdateOperation) result.Result
+	DetectDriftF func(ctx context.Context, op UpdateOperation) ([]ResourceDrift, result.Result)
+	QueryF       func(ctx context.Context, op UpdateOperation) result.Result
+	RemoveF      func(ctx context.Context, force bool) (bool, error)
+	RenameF      func(ctx context.Context, newName tokens.QName) error
+	GetLogsF     func(ctx context.Context, cfg StackConfiguration,
 		query operations.LogQuery) ([]operations.LogEntry, error)
 	ExportDeploymentF func(ctx context.Context) (*apitype.UntypedDeployment, error)
 	ImportDeploymentF func(ctx context.Context, deployment *apitype.UntypedDeployment) error
@@ -352,7 +378,9 @@ func (ms *MockStack) Backend() Backend {
 	panic("not implemented")
 }
 
-func (ms *MockStack) Preview(ctx context.Context, op UpdateOperation) (engine.ResourceChanges, result.Result) {
+func (ms *MockStack) Preview(ctx context.Context,
+	op UpdateOperation) (*deploy.UpdatePlan, engine.ResourceChanges, result.Result) {
+
 	if ms.PreviewF != nil {
 		return ms.PreviewF(ctx, op)
 	}
@@ -380,6 +408,13 @@ func (ms *MockStack) Destroy(ctx context.Context, op UpdateOperation) (engine.Re
 	panic("not implemented")
 }
 
+func (ms *MockStack) Import(ctx context.Context, op UpdateOperation) (engine.ResourceChanges, result.Result) {
+	if ms.ImportF != nil {
+		return ms.ImportF(ctx, op)
+	}
+	panic("not implemented")
+}
+
 func (ms *MockStack) Watch(ctx context.Context, op UpdateOperation) result.Result {
 	if ms.WatchF != nil {
 		return ms.WatchF(ctx, op)
@@ -387,6 +422,13 @@ func (ms *MockStack) Watch(ctx context.Context, op UpdateOperation) result.Resul
 	panic("not implemented")
 }
 
+func (ms *MockStack) DetectDrift(ctx context.Context, op UpdateOperation) ([]ResourceDrift, result.Result) {
+	if ms.DetectDriftF != nil {
+		return ms.DetectDriftF(ctx, op)
+	}
+	panic("not implemented")
+}
+
 func (ms *MockStack) Query(ctx context.Context, op UpdateOperation) result.Result {
 	if ms.QueryF != nil {
 		return ms.QueryF(ctx, op)
@@ -46,11 +46,15 @@ type SnapshotPersister interface {
 // of the current plan, and a "new" list of resources, which consists of the resources that were operated upon
 // by the current plan.
 //
-// Important to note is that, although this SnapshotManager is designed to be easily convertible into a thread-safe
-// implementation, the code as it is today is *not thread safe*. In particular, it is not legal for there to be
-// more than one `SnapshotMutation` active at any point in time. This is because this SnapshotManager invalidates
-// the last persisted snapshot in `BeginSnapshot`. This is designed to match existing behavior and will not
-// be the state of things going forward.
+// Mutations are serialized by the manager, so any number of `SnapshotMutation`s may be active at once: each step
+// records its pending operation when it begins and retires it when it ends, so the persisted snapshot always
+// reflects the set of steps that are in flight. Because the engine sets the results of a step on its new state while
+// the step is in flight, pending operations refer to a copy of the state taken when the step began. Registered
+// resource outputs are set by the manager itself for the same reason.
+//
+// If the persister is a JournalPersister, the manager does not save the entire snapshot after each mutation.
+// Instead, it appends an entry that describes the mutation to the stack's journal, and periodically compacts the
+// journal by saving the entire snapshot. The snapshot is always compacted when the manager is closed.
 //
 // The resources stored in the `resources` slice are pointers to resource objects allocated by the engine.
 // This is subtle and a little confusing. The reason for this is that the engine directly mutates resource objects
@@ -59,10 +63,12 @@ type SnapshotManager struct {
 	persister        SnapshotPersister        // The persister responsible for invalidating and persisting the snapshot
 	baseSnapshot     *deploy.Snapshot         // The base snapshot for this plan
 	resources        []*resource.State        // The list of resources operated upon by this plan
-	operations       []resource.Operation     // The set of operations known to be outstanding in this plan
+	operations       []pendingOperation       // The set of operations known to be outstanding in this plan
 	dones            map[*resource.State]bool // The set of resources that have been operated upon already by this plan
 	completeOps      map[*resource.State]bool // The set of resources that have completed their operation
+	pendingOps       map[*resource.State]int  // The index of the latest operation begun on each resource
 	doVerify         bool                     // If true, verify the snapshot before persisting it
+	journal          *snapshotJournal         // The journal of mutations, if the persister supports journaling
 	mutationRequests chan<- mutationRequest   // The queue of mutation requests, to be retired serially by the manager
 	cancel           chan bool                // A channel used to request cancellation of any new mutation requests.
 	done             <-chan error             // A channel that sends a single result when the manager has shut down.
@@ -70,7 +76,16 @@ type SnapshotManager struct {
 
 var _ engine.SnapshotManager = (*SnapshotManager)(nil)
 
+// pendingOperation is an operation that was begun on a resource state. The operation refers to a copy of the state
+// that is owned by the manager, which is persisted in place of the engine's state.
+type pendingOperation struct {
+	state     *resource.State    // The engine's resource state
+	operation resource.Operation // The operation, which refers to the manager's copy of the state
+}
+
 type mutationRequest struct {
+	kind    JournalEntryKind
+	step    deploy.Step
 	mutator func() bool
 	result  chan<- error
 }
@@ -95,12 +110,15 @@ func (sm *SnapshotManager) Close() error {
 // meaningful changes (see sameSnapshotMutation.mustWrite for details). Any elided writes
 // are flushed by the next non-elided write or the next call to Close.
 //
+// The kind and step of the mutation are recorded in the journal entry for the mutation, if the manager is
+// journaling. The step may be nil.
+//
 // You should never observe or mutate the global snapshot without using this function unless
 // you have a very good justification.
-func (sm *SnapshotManager) mutate(mutator func() bool) error {
+func (sm *SnapshotManager) mutate(kind JournalEntryKind, step deploy.Step, mutator func() bool) error {
 	result := make(chan error)
 	select {
-	case sm.mutationRequests <- mutationRequest{mutator: mutator, result: result}:
+	case sm.mutationRequests <- mutationRequest{kind: kind, step: step, mutator: mutator, result: result}:
 		return <-result
 	case <-sm.cancel:
 		return errors.New("snapshot manager closed")
@@ -111,15 +129,14 @@ func (sm *SnapshotManager) mutate(mutator func() bool) error {
 // completed. This is accomplished by doing an in-place mutation of the resources currently
 // resident in the snapshot.
 //
-// Due to the way this is currently implemented, the engine directly mutates output properties
-// on the resource State object that it created. Since we are storing pointers to these objects
-// in the `resources` slice, we need only to do a no-op mutation in order to flush these new
-// mutations to disk.
-//
-// Note that this is completely not thread-safe and defeats the purpose of having a `mutate` callback
-// entirely, but the hope is that this state of things will not be permament.
-func (sm *SnapshotManager) RegisterResourceOutputs(step deploy.Step) error {
-	return sm.mutate(func() bool { return true })
+// Since we are storing pointers to the engine's resource State objects in the `resources` slice,
+// we need only to set the outputs of the step's new state in order to flush them to disk. This is
+// done by the mutation itself so that concurrent mutations never observe the outputs being written.
+func (sm *SnapshotManager) RegisterResourceOutputs(step deploy.Step, outputs resource.PropertyMap) error {
+	return sm.mutate(JournalEntryOutputs, step, func() bool {
+		step.New().Outputs = outputs
+		return true
+	})
 }
 
 // BeginMutation signals to the SnapshotManager that the engine intends to mutate the global snapshot
@@ -250,7 +267,7 @@ func (ssm *sameSnapshotMutation) End(step deploy.Step, successful bool) error {
 	contract.Require(step.Op() == deploy.OpSame, "step.Op() == deploy.OpSame")
 	contract.Assert(successful)
 	logging.V(9).Infof("SnapshotManager: sameSnapshotMutation.End(..., %v)", successful)
-	return ssm.manager.mutate(func() bool {
+	return ssm.manager.mutate(JournalEntryEnd, step, func() bool {
 		sameStep := step.(*deploy.SameStep)
 
 		ssm.manager.markDone(step.Old())
@@ -281,7 +298,7 @@ func (ssm *sameSnapshotMutation) End(step deploy.Step, successful bool) error {
 
 func (sm *SnapshotManager) doCreate(step deploy.Step) (engine.SnapshotMutation, error) {
 	logging.V(9).Infof("SnapshotManager.doCreate(%s)", step.URN())
-	err := sm.mutate(func() bool {
+	err := sm.mutate(JournalEntryBegin, step, func() bool {
 		sm.markOperationPending(step.New(), resource.OperationTypeCreating)
 		return true
 	})
@@ -299,7 +316,7 @@ type createSnapshotMutation struct {
 func (csm *createSnapshotMutation) End(step deploy.Step, successful bool) error {
 	contract.Require(step != nil, "step != nil")
 	logging.V(9).Infof("SnapshotManager: createSnapshotMutation.End(..., %v)", successful)
-	return csm.manager.mutate(func() bool {
+	return csm.manager.mutate(JournalEntryEnd, step, func() bool {
 		csm.manager.markOperationComplete(step.New())
 		if successful {
 			// There is some very subtle behind-the-scenes magic here that
@@ -325,7 +342,7 @@ func (csm *createSnapshotMutation) End(step deploy.Step, successful bool) error
 
 func (sm *SnapshotManager) doUpdate(step deploy.Step) (engine.SnapshotMutation, error) {
 	logging.V(9).Infof("SnapshotManager.doUpdate(%s)", step.URN())
-	err := sm.mutate(func() bool {
+	err := sm.mutate(JournalEntryBegin, step, func() bool {
 		sm.markOperationPending(step.New(), resource.OperationTypeUpdating)
 		return true
 	})
@@ -343,7 +360,7 @@ type updateSnapshotMutation struct {
 func (usm *updateSnapshotMutation) End(step deploy.Step, successful bool) error {
 	contract.Require(step != nil, "step != nil")
 	logging.V(9).Infof("SnapshotManager: updateSnapshotMutation.End(..., %v)", successful)
-	return usm.manager.mutate(func() bool {
+	return usm.manager.mutate(JournalEntryEnd, step, func() bool {
 		usm.manager.markOperationComplete(step.New())
 		if successful {
 			usm.manager.markDone(step.Old())
@@ -355,7 +372,7 @@ func (usm *updateSnapshotMutation) End(step deploy.Step, successful bool) error
 
 func (sm *SnapshotManager) doDelete(step deploy.Step) (engine.SnapshotMutation, error) {
 	logging.V(9).Infof("SnapshotManager.doDelete(%s)", step.URN())
-	err := sm.mutate(func() bool {
+	err := sm.mutate(JournalEntryBegin, step, func() bool {
 		sm.markOperationPending(step.Old(), resource.OperationTypeDeleting)
 		return true
 	})
@@ -373,7 +390,7 @@ type deleteSnapshotMutation struct {
 func (dsm *deleteSnapshotMutation) End(step deploy.Step, successful bool) error {
 	contract.Require(step != nil, "step != nil")
 	logging.V(9).Infof("SnapshotManager: deleteSnapshotMutation.End(..., %v)", successful)
-	return dsm.manager.mutate(func() bool {
+	return dsm.manager.mutate(JournalEntryEnd, step, func() bool {
 		dsm.manager.markOperationComplete(step.Old())
 		if successful {
 			contract.Assert(!step.Old().Protect)
@@ -396,7 +413,7 @@ func (rsm *replaceSnapshotMutation) End(step deploy.Step, successful bool) error
 
 func (sm *SnapshotManager) doRead(step deploy.Step) (engine.SnapshotMutation, error) {
 	logging.V(9).Infof("SnapshotManager.doRead(%s)", step.URN())
-	err := sm.mutate(func() bool {
+	err := sm.mutate(JournalEntryBegin, step, func() bool {
 		sm.markOperationPending(step.New(), resource.OperationTypeReading)
 		return true
 	})
@@ -414,7 +431,7 @@ type readSnapshotMutation struct {
 func (rsm *readSnapshotMutation) End(step deploy.Step, successful bool) error {
 	contract.Require(step != nil, "step != nil")
 	logging.V(9).Infof("SnapshotManager: readSnapshotMutation.End(..., %v)", successful)
-	return rsm.manager.mutate(func() bool {
+	return rsm.manager.mutate(JournalEntryEnd, step, func() bool {
 		rsm.manager.markOperationComplete(step.New())
 		if successful {
 			if step.Old() != nil {
@@ -435,7 +452,7 @@ func (rsm *refreshSnapshotMutation) End(step deploy.Step, successful bool) error
 	contract.Require(step != nil, "step != nil")
 	contract.Require(step.Op() == deploy.OpRefresh, "step.Op() == deploy.OpRefresh")
 	logging.V(9).Infof("SnapshotManager: refreshSnapshotMutation.End(..., %v)", successful)
-	return rsm.manager.mutate(func() bool {
+	return rsm.manager.mutate(JournalEntryEnd, step, func() bool {
 		// We always elide refreshes. The expectation is that all of these run before any actual mutations and that
 		// some other component will rewrite the base snapshot in-memory, so there's no action the snapshot
 		// manager needs to take other than to remember that the base snapshot--and therefore the actual snapshot--may
@@ -451,7 +468,7 @@ type removePendingReplaceSnapshotMutation struct {
 func (rsm *removePendingReplaceSnapshotMutation) End(step deploy.Step, successful bool) error {
 	contract.Require(step != nil, "step != nil")
 	contract.Require(step.Op() == deploy.OpRemovePendingReplace, "step.Op() == deploy.OpRemovePendingReplace")
-	return rsm.manager.mutate(func() bool {
+	return rsm.manager.mutate(JournalEntryEnd, step, func() bool {
 		res := step.Old()
 		contract.Assert(res.PendingReplacement)
 		rsm.manager.markDone(res)
@@ -461,7 +478,7 @@ func (rsm *removePendingReplaceSnapshotMutation) End(step deploy.Step, successfu
 
 func (sm *SnapshotManager) doImport(step deploy.Step) (engine.SnapshotMutation, error) {
 	logging.V(9).Infof("SnapshotManager.doImport(%s)", step.URN())
-	err := sm.mutate(func() bool {
+	err := sm.mutate(JournalEntryBegin, step, func() bool {
 		sm.markOperationPending(step.New(), resource.OperationTypeImporting)
 		return true
 	})
@@ -481,7 +498,7 @@ func (ism *importSnapshotMutation) End(step deploy.Step, successful bool) error
 	contract.Require(step.Op() == deploy.OpImport || step.Op() == deploy.OpImportReplacement,
 		"step.Op() == deploy.OpImport || step.Op() == deploy.OpImportReplacement")
 
-	return ism.manager.mutate(func() bool {
+	return ism.manager.mutate(JournalEntryEnd, step, func() bool {
 		ism.manager.markOperationComplete(step.New())
 		if successful {
 			ism.manager.markNew(step.New())
@@ -495,6 +512,9 @@ func (ism *importSnapshotMutation) End(step deploy.Step, successful bool) error
 func (sm *SnapshotManager) markDone(state *resource.State) {
 	contract.Assert(state != nil)
 	sm.dones[state] = true
+	if sm.journal != nil {
+		sm.journal.recordDone(state)
+	}
 	logging.V(9).Infof("Marked old state snapshot as done: %v", state.URN)
 }
 
@@ -504,13 +524,25 @@ func (sm *SnapshotManager) markDone(state *resource.State) {
 func (sm *SnapshotManager) markNew(state *resource.State) {
 	contract.Assert(state != nil)
 	sm.resources = append(sm.resources, state)
+	if sm.journal != nil {
+		sm.journal.recordNew(state)
+	}
 	logging.V(9).Infof("Appended new state snapshot to be written: %v", state.URN)
 }
 
 // markOperationPending marks a resource as undergoing an operation that will now be considered pending.
 func (sm *SnapshotManager) markOperationPending(state *resource.State, op resource.OperationType) {
 	contract.Assert(state != nil)
-	sm.operations = append(sm.operations, resource.NewOperation(state, op))
+
+	// The engine writes the results of the operation to the state while other mutations may be persisting the
+	// snapshot, so the pending operation refers to a copy of the state as of the start of the operation.
+	copied := *state
+	operation := resource.NewOperation(&copied, op)
+	sm.pendingOps[state] = len(sm.operations)
+	sm.operations = append(sm.operations, pendingOperation{state: state, operation: operation})
+	if sm.journal != nil && !sm.completeOps[state] {
+		sm.journal.recordOperationPending(operation.Resource, op)
+	}
 	logging.V(9).Infof("SnapshotManager.markPendingOperation(%s, %s)", state.URN, string(op))
 }
 
@@ -518,6 +550,11 @@ func (sm *SnapshotManager) markOperationPending(state *resource.State, op resour
 func (sm *SnapshotManager) markOperationComplete(state *resource.State) {
 	contract.Assert(state != nil)
 	sm.completeOps[state] = true
+	if sm.journal != nil {
+		if i, has := sm.pendingOps[state]; has {
+			sm.journal.recordOperationComplete(sm.operations[i].operation.Resource)
+		}
+	}
 	logging.V(9).Infof("SnapshotManager.markOperationComplete(%s)", state.URN)
 }
 
@@ -569,8 +606,8 @@ func (sm *SnapshotManager) snap() *deploy.Snapshot {
 	// Record any pending operations, if there are any outstanding that have not completed yet.
 	var operations []resource.Operation
 	for _, op := range sm.operations {
-		if !sm.completeOps[op.Resource] {
-			operations = append(operations, op)
+		if !sm.completeOps[op.state] {
+			operations = append(operations, op.operation)
 		}
 	}
 
@@ -584,8 +621,18 @@ func (sm *SnapshotManager) snap() *deploy.Snapshot {
 	return deploy.NewSnapshot(manifest, sm.persister.SecretsManager(), resources, operations)
 }
 
-// saveSnapshot persists the current snapshot and optionally verifies it afterwards.
+// saveSnapshot persists the current snapshot and optionally verifies it afterwards. If the manager is journaling,
+// this appends any outstanding journal entries instead, unless the journal must be compacted.
 func (sm *SnapshotManager) saveSnapshot() error {
+	if sm.journal != nil && !sm.journal.mustCompact(sm.baseSnapshot) {
+		return sm.journal.flush()
+	}
+	return sm.writeSnapshot()
+}
+
+// writeSnapshot persists the entire current snapshot and optionally verifies it afterwards. If the manager is
+// journaling, this compacts the journal.
+func (sm *SnapshotManager) writeSnapshot() error {
 	snap := sm.snap()
 	if err := snap.NormalizeURNReferences(); err != nil {
 		return errors.Wrap(err, "failed to normalize URN references")
@@ -598,9 +645,36 @@ func (sm *SnapshotManager) saveSnapshot() error {
 			return errors.Wrapf(err, "failed to verify snapshot")
 		}
 	}
+	if sm.journal != nil {
+		if err := sm.journal.persister.ResetJournal(); err != nil {
+			return errors.Wrap(err, "failed to reset snapshot journal")
+		}
+		sm.journal.reset(snap, len(sm.resources), sm.baseSnapshot)
+	}
 	return nil
 }
 
+// apply applies a single mutation request, recording it in the journal if the manager is journaling, and returns
+// true if the corresponding write was elided.
+func (sm *SnapshotManager) apply(request mutationRequest) (bool, error) {
+	if sm.journal == nil {
+		if !request.mutator() {
+			return true, nil
+		}
+		return false, sm.saveSnapshot()
+	}
+
+	sm.journal.begin(request.kind, request.step)
+	write := request.mutator()
+	if err := sm.journal.end(request.step); err != nil {
+		return !write, err
+	}
+	if !write {
+		return true, nil
+	}
+	return false, sm.saveSnapshot()
+}
+
 // NewSnapshotManager creates a new SnapshotManager for the given stack name, using the given persister
 // and base snapshot.
 //
@@ -615,12 +689,24 @@ func NewSnapshotManager(persister SnapshotPersister, baseSnap *deploy.Snapshot)
 		baseSnapshot:     baseSnap,
 		dones:            make(map[*resource.State]bool),
 		completeOps:      make(map[*resource.State]bool),
+		pendingOps:       make(map[*resource.State]int),
 		doVerify:         true,
 		mutationRequests: mutationRequests,
 		cancel:           cancel,
 		done:             done,
 	}
 
+	// If the persister supports journaling, append mutations to its journal rather than saving the entire snapshot
+	// after each one. If the journal cannot be created, fall back to saving the entire snapshot.
+	if journaler, ok := persister.(JournalPersister); ok {
+		journal, err := newSnapshotJournal(journaler)
+		if err != nil {
+			logging.V(7).Infof("SnapshotManager: not journaling: %v", err)
+		} else {
+			manager.journal = journal
+		}
+	}
+
 	go func() {
 		// True if we have elided writes since the last actual write.
 		hasElidedWrites := false
@@ -630,22 +716,21 @@ func NewSnapshotManager(persister SnapshotPersister, baseSnap *deploy.Snapshot)
 		for {
 			select {
 			case request := <-mutationRequests:
-				var err error
-				if request.mutator() {
-					err = manager.saveSnapshot()
-					hasElidedWrites = false
-				} else {
-					hasElidedWrites = true
-				}
+				elided, err := manager.apply(request)
+				hasElidedWrites = elided
 				request.result <- err
 			case <-cancel:
 				break serviceLoop
 			}
 		}
 
-		// If we still have elided writes once the channel has closed, flush the snapshot.
+		// If we still have elided writes once the channel has closed, flush the snapshot. If the manager is
+		// journaling, compact the journal so that the stack's checkpoint is complete.
 		var err error
-		if hasElidedWrites {
+		if manager.journal != nil && manager.journal.dirty() {
+			logging.V(9).Infof("SnapshotManager: compacting journal...")
+			err = manager.writeSnapshot()
+		} else if hasElidedWrites {
 			logging.V(9).Infof("SnapshotManager: flushing elided writes...")
 			err = manager.saveSnapshot()
 		}
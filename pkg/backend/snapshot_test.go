@@ -887,7 +887,7 @@ func TestRegisterOutputs(t *testing.T) {
 
 	// The step here is not important.
 	step := deploy.NewSameStep(nil, nil, resourceA, resourceA)
-	err := manager.RegisterResourceOutputs(step)
+	err := manager.RegisterResourceOutputs(step, resourceA.Outputs)
 	if !assert.NoError(t, err) {
 		t.FailNow()
 	}
@@ -40,15 +40,19 @@ type Stack interface {
 	Backend() Backend                                       // the backend this stack belongs to.
 
 	// Preview changes to this stack.
-	Preview(ctx context.Context, op UpdateOperation) (engine.ResourceChanges, result.Result)
+	Preview(ctx context.Context, op UpdateOperation) (*deploy.UpdatePlan, engine.ResourceChanges, result.Result)
 	// Update this stack.
 	Update(ctx context.Context, op UpdateOperation) (engine.ResourceChanges, result.Result)
 	// Refresh this stack's state from the cloud provider.
 	Refresh(ctx context.Context, op UpdateOperation) (engine.ResourceChanges, result.Result)
 	// Destroy this stack's resources.
 	Destroy(ctx context.Context, op UpdateOperation) (engine.ResourceChanges, result.Result)
+	// Import existing cloud resources into this stack.
+	Import(ctx context.Context, op UpdateOperation) (engine.ResourceChanges, result.Result)
 	// Watch this stack.
 	Watch(ctx context.Context, op UpdateOperation) result.Result
+	// Detect drift between this stack's checkpoint and its resources' actual state.
+	DetectDrift(ctx context.Context, op UpdateOperation) ([]ResourceDrift, result.Result)
 
 	// remove this stack.
 	Remove(ctx context.Context, force bool) (bool, error)
@@ -73,7 +77,9 @@ func RenameStack(ctx context.Context, s Stack, newName tokens.QName) error {
 }
 
 // PreviewStack previews changes to this stack.
-func PreviewStack(ctx context.Context, s Stack, op UpdateOperation) (engine.ResourceChanges, result.Result) {
+func PreviewStack(ctx context.Context, s Stack,
+	op UpdateOperation) (*deploy.UpdatePlan, engine.ResourceChanges, result.Result) {
+
 	return s.Backend().Preview(ctx, s, op)
 }
 
@@ -92,12 +98,22 @@ func DestroyStack(ctx context.Context, s Stack, op UpdateOperation) (engine.Reso
 	return s.Backend().Destroy(ctx, s, op)
 }
 
+// ImportStack adopts existing cloud resources into the stack's state.
+func ImportStack(ctx context.Context, s Stack, op UpdateOperation) (engine.ResourceChanges, result.Result) {
+	return s.Backend().Import(ctx, s, op)
+}
+
 // WatchStack watches the projects working directory for changes and automatically updates the
 // active stack.
 func WatchStack(ctx context.Context, s Stack, op UpdateOperation) result.Result {
 	return s.Backend().Watch(ctx, s, op)
 }
 
+// DetectStackDrift reports the stack's resources whose actual state differs from its checkpoint.
+func DetectStackDrift(ctx context.Context, s Stack, op UpdateOperation) ([]ResourceDrift, result.Result) {
+	return s.Backend().DetectDrift(ctx, s, op)
+}
+
 // GetLatestConfiguration returns the configuration for the most recent deployment of the stack.
 func GetLatestConfiguration(ctx context.Context, s Stack) (config.Map, error) {
 	return s.Backend().GetLatestConfiguration(ctx, s)
@@ -107,4 +107,11 @@ type UpdateInfo struct {
 	Result          UpdateResult           `json:"result"`
 	EndTime         int64                  `json:"endTime"`
 	ResourceChanges engine.ResourceChanges `json:"resourceChanges,omitempty"`
+
+	// ResourceSummaries records the operation performed on each changed resource, how long it took, and why it failed,
+	// if it did.
+	ResourceSummaries []engine.ResourceSummary `json:"resourceSummaries,omitempty"`
+
+	// Version is the version of the stack produced by the update, if known. Versions are numbered from 1.
+	Version int `json:"version,omitempty"`
 }
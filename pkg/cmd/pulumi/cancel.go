@@ -15,13 +15,16 @@
 package main
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/pulumi/pulumi/sdk/v2/go/common/util/result"
 
 	"github.com/spf13/cobra"
 
+	"github.com/pulumi/pulumi/pkg/v2/backend"
 	"github.com/pulumi/pulumi/pkg/v2/backend/display"
+	"github.com/pulumi/pulumi/pkg/v2/backend/filestate"
 	"github.com/pulumi/pulumi/pkg/v2/backend/httpstate"
 	"github.com/pulumi/pulumi/sdk/v2/go/common/diag/colors"
 	"github.com/pulumi/pulumi/sdk/v2/go/common/util/cmdutil"
@@ -37,6 +40,8 @@ func newCancelCmd() *cobra.Command {
 		Long: "Cancel a stack's currently running update, if any.\n" +
 			"\n" +
 			"This command cancels the update currently being applied to a stack if any exists.\n" +
+			"For self-managed backends, this breaks the lock held on the stack by the running\n" +
+			"(or crashed) update, but cannot stop a process that is still running.\n" +
 			"Note that this operation is _very dangerous_, and may leave the stack in an\n" +
 			"inconsistent state if a resource operation was pending when the update was canceled.\n" +
 			"\n" +
@@ -61,10 +66,18 @@ func newCancelCmd() *cobra.Command {
 				return result.FromError(err)
 			}
 
-			// Ensure that we are targeting the Pulumi cloud.
-			backend, ok := s.Backend().(httpstate.Backend)
-			if !ok {
-				return result.Error("the `cancel` command is not supported for local stacks")
+			// Both the Pulumi cloud and self-managed backends know how to cancel a stack's current update.
+			type canceler interface {
+				CancelCurrentUpdate(ctx context.Context, stackRef backend.StackReference) error
+			}
+			var be canceler
+			switch b := s.Backend().(type) {
+			case httpstate.Backend:
+				be = b
+			case filestate.Backend:
+				be = b
+			default:
+				return result.Error("the `cancel` command is not supported for this backend")
 			}
 
 			// Ensure the user really wants to do this.
@@ -76,7 +89,7 @@ func newCancelCmd() *cobra.Command {
 			}
 
 			// Cancel the update.
-			if err := backend.CancelCurrentUpdate(commandContext(), s.Ref()); err != nil {
+			if err := be.CancelCurrentUpdate(commandContext(), s.Ref()); err != nil {
 				return result.FromError(err)
 			}
 
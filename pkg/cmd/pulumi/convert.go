@@ -0,0 +1,384 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/pulumi/pulumi/pkg/v2/codegen/hcl2"
+	"github.com/pulumi/pulumi/pkg/v2/codegen/hcl2/syntax"
+	"github.com/pulumi/pulumi/pkg/v2/codegen/schema"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/diag/colors"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/encoding"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/resource/plugin"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/tokens"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/util/cmdutil"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/util/contract"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/util/result"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/workspace"
+)
+
+func newConvertCmd() *cobra.Command {
+	var language string
+	var outDir string
+	var force bool
+
+	cmd := &cobra.Command{
+		Use:   "convert",
+		Args:  cmdutil.NoArgs,
+		Short: "Convert a Pulumi program written in PCL to another language",
+		Long: "Convert a Pulumi program written in PCL to another language.\n" +
+			"\n" +
+			"The `.pp` files in the current project are bound using the schemas of the packages\n" +
+			"they reference and translated into a program in the language given by `--language`.\n" +
+			"The result is written to the directory given by `--out` as a runnable project,\n" +
+			"including a `Pulumi.yaml` file and the package manifest for the target language.\n" +
+			"\n" +
+			"Supported languages are `dotnet`, `go`, `nodejs` (TypeScript), and `python`.",
+		Run: cmdutil.RunResultFunc(func(cmd *cobra.Command, args []string) result.Result {
+			if language == "" {
+				return result.Error("--language must be specified")
+			}
+			if outDir == "" {
+				return result.Error("--out must be specified")
+			}
+
+			proj, root, err := readProject()
+			if err != nil {
+				return result.FromError(err)
+			}
+
+			if !force {
+				if err = os.MkdirAll(outDir, 0700); err != nil {
+					return result.FromError(err)
+				}
+				if err = errorIfNotEmptyDirectory(outDir); err != nil {
+					return result.FromError(err)
+				}
+			}
+
+			ctx, err := plugin.NewContext(cmdutil.Diag(), cmdutil.Diag(), nil, nil, root, nil, nil)
+			if err != nil {
+				return result.FromError(err)
+			}
+			defer contract.IgnoreClose(ctx)
+
+			files, err := convertProgram(proj, root, language, schema.NewPluginLoader(ctx.Host), os.Stderr)
+			if err != nil {
+				return result.FromError(err)
+			}
+
+			if err = writeConvertedProject(outDir, files); err != nil {
+				return result.FromError(err)
+			}
+
+			fmt.Printf("Converted project %s to %s in %s\n", proj.Name, language, outDir)
+			return nil
+		}),
+	}
+
+	cmd.PersistentFlags().StringVarP(
+		&language, "language", "l", "",
+		"The language of the converted program: dotnet, go, nodejs, or python")
+	cmd.PersistentFlags().StringVarP(
+		&outDir, "out", "o", "",
+		"The directory to which the converted project is written")
+	cmd.PersistentFlags().BoolVarP(
+		&force, "force", "f", false,
+		"Write the converted project even if the output directory is not empty")
+
+	return cmd
+}
+
+// convertProgram binds the PCL program in the given project root and generates a project in the given language. The
+// result maps the path of each of the project's files to its contents. Diagnostics are written to diagWriter.
+func convertProgram(proj *workspace.Project, root, language string, loader schema.Loader,
+	diagWriter io.Writer) (map[string][]byte, error) {
+
+	generateProgram, err := getGenerateProgram(language)
+	if err != nil {
+		return nil, err
+	}
+
+	parser, err := parsePCLFiles(root)
+	if err != nil {
+		return nil, err
+	}
+	color := cmdutil.GetGlobalColorization() != colors.Never
+	if len(parser.Diagnostics) != 0 {
+		if err = parser.NewDiagnosticWriter(diagWriter, 0, color).WriteDiagnostics(parser.Diagnostics); err != nil {
+			return nil, err
+		}
+	}
+	if parser.Diagnostics.HasErrors() {
+		return nil, errors.New("failed to parse the program")
+	}
+
+	program, diags, err := hcl2.BindProgram(parser.Files, hcl2.Loader(loader))
+	if err != nil {
+		return nil, errors.Wrap(err, "binding the program")
+	}
+	if err = writeProgramDiagnostics(program, parser, diagWriter, diags, color); err != nil {
+		return nil, err
+	}
+	if diags.HasErrors() {
+		return nil, errors.New("failed to bind the program")
+	}
+
+	files, diags, err := generateProgram(program)
+	if err != nil {
+		return nil, errors.Wrapf(err, "generating the %s program", language)
+	}
+	if err = writeProgramDiagnostics(program, parser, diagWriter, diags, color); err != nil {
+		return nil, err
+	}
+	if diags.HasErrors() {
+		return nil, errors.Errorf("failed to generate the %s program", language)
+	}
+
+	manifests, err := generateProjectManifests(proj, language, program.Packages())
+	if err != nil {
+		return nil, err
+	}
+	for path, contents := range manifests {
+		files[path] = contents
+	}
+	return files, nil
+}
+
+// parsePCLFiles parses the `.pp` files in the given directory.
+func parsePCLFiles(dir string) (*syntax.Parser, error) {
+	infos, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	parser := syntax.NewParser()
+	for _, info := range infos {
+		if info.IsDir() || filepath.Ext(info.Name()) != ".pp" {
+			continue
+		}
+
+		path := filepath.Join(dir, info.Name())
+		f, err := os.Open(path)
+		if err != nil {
+			return nil, err
+		}
+		err = parser.ParseFile(f, info.Name())
+		contract.IgnoreClose(f)
+		if err != nil {
+			return nil, errors.Wrapf(err, "reading %s", path)
+		}
+	}
+	if len(parser.Files) == 0 {
+		return nil, errors.Errorf("no .pp files found in %s", dir)
+	}
+	return parser, nil
+}
+
+// writeProgramDiagnostics writes any diagnostics produced while binding or generating a program to w.
+func writeProgramDiagnostics(program *hcl2.Program, parser *syntax.Parser, w io.Writer, diags hcl.Diagnostics,
+	color bool) error {
+
+	if len(diags) == 0 {
+		return nil
+	}
+	if program != nil {
+		return program.NewDiagnosticWriter(w, 0, color).WriteDiagnostics(diags)
+	}
+	return parser.NewDiagnosticWriter(w, 0, color).WriteDiagnostics(diags)
+}
+
+// generateProjectManifests returns the `Pulumi.yaml` file and the package manifests for a project in the given
+// language that uses the given packages.
+func generateProjectManifests(proj *workspace.Project, language string,
+	packages []*schema.Package) (map[string][]byte, error) {
+
+	project := &workspace.Project{
+		Name:        proj.Name,
+		Description: proj.Description,
+		Runtime:     workspace.NewProjectRuntimeInfo(language, nil),
+	}
+	projectBytes, err := encoding.YAML.Marshal(project)
+	if err != nil {
+		return nil, err
+	}
+	files := map[string][]byte{"Pulumi.yaml": projectBytes}
+
+	switch language {
+	case "dotnet":
+		files[string(proj.Name)+".csproj"] = generateCSProj(packages)
+		files["Program.cs"] = []byte(dotnetProgram)
+	case "go":
+		files["go.mod"] = generateGoMod(proj.Name, packages)
+	case "nodejs":
+		packageJSON, err := generatePackageJSON(proj.Name, packages)
+		if err != nil {
+			return nil, err
+		}
+		files["package.json"] = packageJSON
+		files["tsconfig.json"] = []byte(nodejsTSConfig)
+	case "python":
+		files["requirements.txt"] = generateRequirements(packages)
+	}
+	return files, nil
+}
+
+// packageMajorVersion returns the major version of the given package, or 0 if the package has no version.
+func packageMajorVersion(pkg *schema.Package) uint64 {
+	if pkg.Version == nil {
+		return 0
+	}
+	return pkg.Version.Major
+}
+
+func generateCSProj(packages []*schema.Package) []byte {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "<Project Sdk=\"Microsoft.NET.Sdk\">\n\n")
+	fmt.Fprintf(&buf, "  <PropertyGroup>\n")
+	fmt.Fprintf(&buf, "    <OutputType>Exe</OutputType>\n")
+	fmt.Fprintf(&buf, "    <TargetFramework>netcoreapp3.1</TargetFramework>\n")
+	fmt.Fprintf(&buf, "    <Nullable>enable</Nullable>\n")
+	fmt.Fprintf(&buf, "  </PropertyGroup>\n\n")
+	fmt.Fprintf(&buf, "  <ItemGroup>\n")
+	fmt.Fprintf(&buf, "    <PackageReference Include=\"Pulumi\" Version=\"2.*\" />\n")
+	for _, pkg := range packages {
+		version := "*"
+		if major := packageMajorVersion(pkg); major != 0 {
+			version = fmt.Sprintf("%d.*", major)
+		}
+		fmt.Fprintf(&buf, "    <PackageReference Include=\"Pulumi.%s\" Version=\"%s\" />\n", strings.Title(pkg.Name), version)
+	}
+	fmt.Fprintf(&buf, "  </ItemGroup>\n\n")
+	fmt.Fprintf(&buf, "</Project>\n")
+	return buf.Bytes()
+}
+
+const dotnetProgram = `using System.Threading.Tasks;
+using Pulumi;
+
+class Program
+{
+    static Task<int> Main() => Deployment.RunAsync<MyStack>();
+}
+`
+
+func generateGoMod(name tokens.PackageName, packages []*schema.Package) []byte {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "module %s\n\n", name)
+	fmt.Fprintf(&buf, "go 1.14\n\n")
+	fmt.Fprintf(&buf, "require (\n")
+	fmt.Fprintf(&buf, "\tgithub.com/pulumi/pulumi/sdk/v2 v2.0.0\n")
+	for _, pkg := range packages {
+		// Packages without a version are resolved by the Go toolchain when the program is built.
+		if pkg.Version == nil {
+			continue
+		}
+		var vPath string
+		if pkg.Version.Major > 1 {
+			vPath = fmt.Sprintf("/v%d", pkg.Version.Major)
+		}
+		fmt.Fprintf(&buf, "\tgithub.com/pulumi/pulumi-%s/sdk%s v%s\n", pkg.Name, vPath, pkg.Version)
+	}
+	fmt.Fprintf(&buf, ")\n")
+	return buf.Bytes()
+}
+
+func generatePackageJSON(name tokens.PackageName, packages []*schema.Package) ([]byte, error) {
+	dependencies := map[string]string{"@pulumi/pulumi": "^2.0.0"}
+	for _, pkg := range packages {
+		version := "latest"
+		if pkg.Version != nil {
+			version = "^" + pkg.Version.String()
+		}
+		dependencies["@pulumi/"+pkg.Name] = version
+	}
+
+	packageJSON := map[string]interface{}{
+		"name":            name,
+		"devDependencies": map[string]string{"@types/node": "^10.0.0"},
+		"dependencies":    dependencies,
+	}
+	b, err := json.MarshalIndent(packageJSON, "", "    ")
+	if err != nil {
+		return nil, err
+	}
+	return append(b, '\n'), nil
+}
+
+const nodejsTSConfig = `{
+    "compilerOptions": {
+        "strict": true,
+        "outDir": "bin",
+        "target": "es2016",
+        "module": "commonjs",
+        "moduleResolution": "node",
+        "sourceMap": true,
+        "experimentalDecorators": true,
+        "pretty": true,
+        "noFallthroughCasesInSwitch": true,
+        "noImplicitReturns": true,
+        "forceConsistentCasingInFileNames": true
+    },
+    "files": [
+        "index.ts"
+    ]
+}
+`
+
+func generateRequirements(packages []*schema.Package) []byte {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "pulumi>=2.0.0,<3.0.0\n")
+	for _, pkg := range packages {
+		if pkg.Version == nil {
+			fmt.Fprintf(&buf, "pulumi-%s\n", pkg.Name)
+			continue
+		}
+		fmt.Fprintf(&buf, "pulumi-%s>=%s,<%d.0.0\n", pkg.Name, pkg.Version, pkg.Version.Major+1)
+	}
+	return buf.Bytes()
+}
+
+// writeConvertedProject writes the given project files to dir.
+func writeConvertedProject(dir string, files map[string][]byte) error {
+	paths := make([]string, 0, len(files))
+	for path := range files {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	for _, path := range paths {
+		outPath := filepath.Join(dir, path)
+		if err := os.MkdirAll(filepath.Dir(outPath), 0700); err != nil {
+			return err
+		}
+		if err := ioutil.WriteFile(outPath, files[path], 0600); err != nil {
+			return errors.Wrapf(err, "writing %s", outPath)
+		}
+	}
+	return nil
+}
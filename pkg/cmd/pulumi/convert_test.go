@@ -0,0 +1,52 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"testing"
+
+	"github.com/blang/semver"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pulumi/pulumi/pkg/v2/codegen/schema"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/workspace"
+)
+
+func TestGenerateProjectManifests(t *testing.T) {
+	description := "A converted project"
+	proj := &workspace.Project{Name: "proj", Description: &description}
+	version := semver.MustParse("3.1.0")
+	packages := []*schema.Package{{Name: "aws", Version: &version}}
+
+	files, err := generateProjectManifests(proj, "go", packages)
+	assert.NoError(t, err)
+	assert.Contains(t, string(files["Pulumi.yaml"]), "runtime: go")
+	assert.Contains(t, string(files["go.mod"]), "module proj")
+	assert.Contains(t, string(files["go.mod"]), "github.com/pulumi/pulumi-aws/sdk/v3 v3.1.0")
+
+	files, err = generateProjectManifests(proj, "nodejs", packages)
+	assert.NoError(t, err)
+	assert.Contains(t, string(files["package.json"]), `"@pulumi/aws": "^3.1.0"`)
+	assert.Contains(t, files, "tsconfig.json")
+
+	files, err = generateProjectManifests(proj, "python", packages)
+	assert.NoError(t, err)
+	assert.Contains(t, string(files["requirements.txt"]), "pulumi-aws>=3.1.0,<4.0.0")
+
+	files, err = generateProjectManifests(proj, "dotnet", packages)
+	assert.NoError(t, err)
+	assert.Contains(t, string(files["proj.csproj"]), `<PackageReference Include="Pulumi.Aws" Version="3.*" />`)
+	assert.Contains(t, files, "Program.cs")
+}
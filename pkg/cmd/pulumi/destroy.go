@@ -37,6 +37,7 @@ func newDestroyCmd() *cobra.Command {
 
 	// Flags for engine.UpdateOptions.
 	var diffDisplay bool
+	var jsonDisplay bool
 	var eventLogPath string
 	var parallel int
 	var refresh bool
@@ -47,7 +48,9 @@ func newDestroyCmd() *cobra.Command {
 	var suppressOutputs bool
 	var yes bool
 	var targets *[]string
+	var excludes []string
 	var targetDependents bool
+	var continueOnError bool
 
 	var cmd = &cobra.Command{
 		Use:        "destroy",
@@ -67,6 +70,9 @@ func newDestroyCmd() *cobra.Command {
 			if !interactive && !yes {
 				return result.FromError(errors.New("--yes must be passed in to proceed when running in non-interactive mode"))
 			}
+			if jsonDisplay && !yes {
+				return result.FromError(errors.New("--yes must be passed in to proceed when using --json"))
+			}
 
 			opts, err := updateFlagsToOptions(interactive, skipPreview, yes)
 			if err != nil {
@@ -86,6 +92,7 @@ func newDestroyCmd() *cobra.Command {
 				SuppressOutputs:      suppressOutputs,
 				IsInteractive:        interactive,
 				Type:                 displayType,
+				JSONDisplay:          jsonDisplay,
 				EventLogPath:         eventLogPath,
 				Debug:                debug,
 			}
@@ -124,7 +131,9 @@ func newDestroyCmd() *cobra.Command {
 				Debug:            debug,
 				Refresh:          refresh,
 				DestroyTargets:   targetUrns,
+				Excludes:         excludeURNs(excludes),
 				TargetDependents: targetDependents,
+				ContinueOnError:  continueOnError,
 				UseLegacyDiff:    useLegacyDiff(),
 			}
 
@@ -138,7 +147,7 @@ func newDestroyCmd() *cobra.Command {
 				Scopes:             cancellationScopes,
 			})
 
-			if res == nil && len(*targets) == 0 {
+			if res == nil && len(*targets) == 0 && len(excludes) == 0 && !jsonDisplay {
 				fmt.Printf("The resources in the stack have been deleted, but the history and configuration "+
 					"associated with the stack are still maintained. \nIf you want to remove the stack "+
 					"completely, run 'pulumi stack rm %s'.\n", s.Ref())
@@ -164,16 +173,27 @@ func newDestroyCmd() *cobra.Command {
 
 	targets = cmd.PersistentFlags().StringArrayP(
 		"target", "t", []string{},
-		"Specify a single resource URN to destroy. All resources necessary to destroy this target will also be destroyed."+
-			" Multiple resources can be specified using: --target urn1 --target urn2")
+		"Specify a single resource URN or URN pattern to destroy. All resources necessary to destroy this target will"+
+			" also be destroyed. Multiple resources can be specified using: --target urn1 --target urn2")
+	cmd.PersistentFlags().StringArrayVar(
+		&excludes, "exclude", []string{},
+		"Specify a resource URN or URN pattern to exclude from the destroy. Excluded resources will not be destroyed."+
+			" Multiple resources can be specified using --exclude urn1 --exclude urn2")
 	cmd.PersistentFlags().BoolVar(
 		&targetDependents, "target-dependents", false,
 		"Allows destroying of dependent targets discovered but not specified in --target list")
 
 	// Flags for engine.UpdateOptions.
+	cmd.PersistentFlags().BoolVar(
+		&continueOnError, "continue-on-error", false,
+		"Continue the destroy past failed resource operations, skipping only the resources that depend on them,"+
+			" and report all of the failures at the end")
 	cmd.PersistentFlags().BoolVar(
 		&diffDisplay, "diff", false,
 		"Display operation as a rich diff showing the overall change")
+	cmd.PersistentFlags().BoolVarP(
+		&jsonDisplay, "json", "j", false,
+		"Stream the destroy's events to stdout as JSON, one object per line")
 	cmd.PersistentFlags().IntVarP(
 		&parallel, "parallel", "p", defaultParallel,
 		"Allow P resource operations to run in parallel at once (1 for no parallelism). Defaults to unbounded.")
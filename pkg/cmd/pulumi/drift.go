@@ -0,0 +1,251 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/pulumi/pulumi/pkg/v2/backend"
+	"github.com/pulumi/pulumi/pkg/v2/backend/display"
+	"github.com/pulumi/pulumi/pkg/v2/engine"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/resource"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/util/cmdutil"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/util/result"
+)
+
+// driftExitCode is the exit code of `pulumi drift` when drift is detected. It differs from the exit code used for
+// failures so that scheduled jobs can tell the two apart.
+const driftExitCode = 2
+
+func newDriftCmd() *cobra.Command {
+	var debug bool
+	var stack string
+	var jsonOut bool
+	var parallel int
+	var targets []string
+
+	var cmd = &cobra.Command{
+		Use:   "drift",
+		Short: "Detect resources that have changed outside of Pulumi",
+		Long: "Detect resources that have changed outside of Pulumi.\n" +
+			"\n" +
+			"This command reads the current state of each of the stack's resources from its provider and\n" +
+			"compares it with the state recorded in the stack. Unlike `pulumi refresh`, the stack's state\n" +
+			"is never modified. The command exits with exit code 2 if any resource has drifted, which makes\n" +
+			"it suitable for running on a schedule: any other non-zero exit code means the command itself\n" +
+			"failed. Pass `--json` to emit a machine-readable report of the drift.",
+		Args: cmdutil.NoArgs,
+		Run: cmdutil.RunResultFunc(func(cmd *cobra.Command, args []string) result.Result {
+			displayType := display.DisplayProgress
+			if jsonOut {
+				displayType = display.DisplayNone
+			}
+
+			opts := backend.UpdateOptions{
+				Display: display.Options{
+					Color:         cmdutil.GetGlobalColorization(),
+					IsInteractive: cmdutil.Interactive(),
+					Type:          displayType,
+					Debug:         debug,
+				},
+			}
+
+			s, err := requireStack(stack, false, opts.Display, true /*setCurrent*/)
+			if err != nil {
+				return result.FromError(err)
+			}
+
+			proj, root, err := readProject()
+			if err != nil {
+				return result.FromError(err)
+			}
+
+			m, err := getUpdateMetadata("", root)
+			if err != nil {
+				return result.FromError(errors.Wrap(err, "gathering environment metadata"))
+			}
+
+			sm, err := getStackSecretsManager(s)
+			if err != nil {
+				return result.FromError(errors.Wrap(err, "getting secrets manager"))
+			}
+
+			cfg, err := getStackConfiguration(s, sm)
+			if err != nil {
+				return result.FromError(errors.Wrap(err, "getting stack configuration"))
+			}
+
+			targetURNs := []resource.URN{}
+			for _, t := range targets {
+				targetURNs = append(targetURNs, resource.URN(t))
+			}
+
+			opts.Engine = engine.UpdateOptions{
+				Parallel:       parallel,
+				Debug:          debug,
+				UseLegacyDiff:  useLegacyDiff(),
+				RefreshTargets: targetURNs,
+			}
+
+			drift, res := s.DetectDrift(commandContext(), backend.UpdateOperation{
+				Proj:               proj,
+				Root:               root,
+				M:                  m,
+				Opts:               opts,
+				StackConfiguration: cfg,
+				SecretsManager:     sm,
+				Scopes:             cancellationScopes,
+			})
+			switch {
+			case res != nil && res.Error() == context.Canceled:
+				return result.FromError(errors.New("drift detection cancelled"))
+			case res != nil:
+				return PrintEngineResult(res)
+			}
+
+			if jsonOut {
+				if err = printJSON(makeDriftReportJSON(drift)); err != nil {
+					return result.FromError(err)
+				}
+			} else {
+				printDriftReport(drift)
+			}
+
+			return driftResult(drift)
+		}),
+	}
+
+	cmd.PersistentFlags().BoolVarP(
+		&debug, "debug", "d", false,
+		"Print detailed debugging output during resource operations")
+	cmd.PersistentFlags().StringVarP(
+		&stack, "stack", "s", "",
+		"The name of the stack to operate on. Defaults to the current stack")
+	cmd.PersistentFlags().StringVar(
+		&stackConfigFile, "config-file", "",
+		"Use the configuration values in the specified file rather than detecting the file name")
+	cmd.PersistentFlags().BoolVarP(
+		&jsonOut, "json", "j", false,
+		"Emit a report of the drift as JSON")
+	cmd.PersistentFlags().IntVarP(
+		&parallel, "parallel", "p", defaultParallel,
+		"Allow P resource operations to run in parallel at once (1 for no parallelism). Defaults to unbounded.")
+	cmd.PersistentFlags().StringArrayVarP(
+		&targets, "target", "t", []string{},
+		"Specify a single resource URN to check. Multiple resources can be specified using: --target urn1 --target urn2")
+
+	return cmd
+}
+
+// driftResult returns the result of a successful drift detection: nil if nothing drifted, or a bail that exits with
+// driftExitCode otherwise.
+func driftResult(drift []backend.ResourceDrift) result.Result {
+	if len(drift) != 0 {
+		return cmdutil.BailWithExitCode(driftExitCode)
+	}
+	return nil
+}
+
+// driftReportJSON is the shape of the --json output of this command.
+type driftReportJSON struct {
+	Drifted   bool                `json:"drifted"`
+	Resources []resourceDriftJSON `json:"resources"`
+}
+
+// resourceDriftJSON describes a single drifted resource. Secret values are replaced with "[secret]".
+type resourceDriftJSON struct {
+	URN        resource.URN                 `json:"urn"`
+	Type       string                       `json:"type"`
+	ID         resource.ID                  `json:"id,omitempty"`
+	Status     string                       `json:"status"`
+	Properties map[string]propertyDriftJSON `json:"properties,omitempty"`
+}
+
+// propertyDriftJSON describes a single drifted output property.
+type propertyDriftJSON struct {
+	Kind string      `json:"kind"`
+	Old  interface{} `json:"old,omitempty"`
+	New  interface{} `json:"new,omitempty"`
+}
+
+func makeDriftReportJSON(drift []backend.ResourceDrift) driftReportJSON {
+	resources := make([]resourceDriftJSON, len(drift))
+	for i, d := range drift {
+		r := resourceDriftJSON{
+			URN:    d.URN,
+			Type:   string(d.Type),
+			ID:     d.ID,
+			Status: "changed",
+		}
+		if d.Deleted {
+			r.Status = "deleted"
+		}
+
+		if d.Diff != nil {
+			old, new := display.MassageSecrets(d.Old, false), display.MassageSecrets(d.New, false)
+
+			r.Properties = make(map[string]propertyDriftJSON)
+			for k := range d.Diff.Adds {
+				r.Properties[string(k)] = propertyDriftJSON{Kind: "add", New: new[k].Mappable()}
+			}
+			for k := range d.Diff.Deletes {
+				r.Properties[string(k)] = propertyDriftJSON{Kind: "delete", Old: old[k].Mappable()}
+			}
+			for k := range d.Diff.Updates {
+				r.Properties[string(k)] = propertyDriftJSON{
+					Kind: "update",
+					Old:  old[k].Mappable(),
+					New:  new[k].Mappable(),
+				}
+			}
+		}
+
+		resources[i] = r
+	}
+
+	return driftReportJSON{
+		Drifted:   len(drift) != 0,
+		Resources: resources,
+	}
+}
+
+func printDriftReport(drift []backend.ResourceDrift) {
+	fmt.Println()
+	if len(drift) == 0 {
+		fmt.Println("No drift detected.")
+		return
+	}
+
+	fmt.Printf("Drift detected in %d resource(s):\n", len(drift))
+	for _, d := range drift {
+		if d.Deleted {
+			fmt.Printf("    %s: deleted\n", d.URN)
+			continue
+		}
+
+		var keys []string
+		for _, k := range d.Diff.Keys() {
+			keys = append(keys, string(k))
+		}
+		sort.Strings(keys)
+		fmt.Printf("    %s: changed %s\n", d.URN, strings.Join(keys, ", "))
+	}
+}
@@ -0,0 +1,43 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pulumi/pulumi/pkg/v2/backend"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/resource"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/util/cmdutil"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/util/result"
+)
+
+func TestDriftResult(t *testing.T) {
+	assert.Nil(t, driftResult(nil))
+
+	res := driftResult([]backend.ResourceDrift{{
+		URN:     resource.URN("urn:pulumi:test::test::pkgA:m:typA::resA"),
+		Type:    "pkgA:m:typA",
+		ID:      "id",
+		Deleted: true,
+	}})
+	assert.NotNil(t, res)
+	assert.True(t, res.IsBail())
+	assert.Equal(t, driftExitCode, cmdutil.ExitCode(res))
+
+	// Failures must keep exiting with a different code so that callers can tell them apart from drift.
+	assert.NotEqual(t, driftExitCode, cmdutil.ExitCode(result.Bail()))
+}
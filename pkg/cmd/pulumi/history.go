@@ -48,7 +48,7 @@ func newHistoryCmd() *cobra.Command {
 				return err
 			}
 			b := s.Backend()
-			updates, err := b.GetHistory(commandContext(), s.Ref())
+			updates, err := b.GetHistory(commandContext(), s.Ref(), jsonOut /*includeResources*/)
 			if err != nil {
 				return errors.Wrap(err, "getting history")
 			}
@@ -65,7 +65,7 @@ func newHistoryCmd() *cobra.Command {
 				return displayUpdatesJSON(updates, decrypter)
 			}
 
-			return displayUpdatesConsole(updates, opts)
+			return displayUpdatesConsole(updates, false /*showResources*/, opts)
 		}),
 	}
 	cmd.PersistentFlags().StringVarP(
@@ -0,0 +1,474 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+
+	"github.com/blang/semver"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/pulumi/pulumi/pkg/v2/backend"
+	"github.com/pulumi/pulumi/pkg/v2/backend/display"
+	"github.com/pulumi/pulumi/pkg/v2/codegen/dotnet"
+	gogen "github.com/pulumi/pulumi/pkg/v2/codegen/go"
+	"github.com/pulumi/pulumi/pkg/v2/codegen/hcl2"
+	"github.com/pulumi/pulumi/pkg/v2/codegen/importer"
+	"github.com/pulumi/pulumi/pkg/v2/codegen/nodejs"
+	"github.com/pulumi/pulumi/pkg/v2/codegen/python"
+	"github.com/pulumi/pulumi/pkg/v2/codegen/schema"
+	"github.com/pulumi/pulumi/pkg/v2/engine"
+	"github.com/pulumi/pulumi/pkg/v2/resource/deploy"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/diag"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/resource"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/resource/plugin"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/tokens"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/util/cmdutil"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/util/contract"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/util/result"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/workspace"
+)
+
+// importSpec is a single resource to import, as described in an import file.
+type importSpec struct {
+	Type     tokens.Type  `json:"type"`
+	Name     tokens.QName `json:"name"`
+	ID       resource.ID  `json:"id"`
+	Parent   string       `json:"parent,omitempty"`
+	Provider string       `json:"provider,omitempty"`
+	Version  string       `json:"version,omitempty"`
+}
+
+// importFile is the format of the file passed to `pulumi import --file`.
+type importFile struct {
+	// NameTable maps variable names used in the generated code to the URNs of existing parents and providers.
+	NameTable map[string]resource.URN `json:"nameTable,omitempty"`
+	Resources []importSpec            `json:"resources"`
+}
+
+func readImportFile(p string) (importFile, error) {
+	f, err := os.Open(p)
+	if err != nil {
+		return importFile{}, err
+	}
+	defer contract.IgnoreClose(f)
+
+	var result importFile
+	if err = json.NewDecoder(f).Decode(&result); err != nil {
+		return importFile{}, err
+	}
+	return result, nil
+}
+
+// parseImportSpecs resolves the parent and provider references in the given specs to URNs and returns the
+// corresponding engine imports. Parents and providers may be given either as URNs or as keys in the name table.
+func parseImportSpecs(f importFile, protect bool) ([]deploy.Import, error) {
+	resolve := func(ref string) resource.URN {
+		if urn, ok := f.NameTable[ref]; ok {
+			return urn
+		}
+		return resource.URN(ref)
+	}
+
+	imports := make([]deploy.Import, len(f.Resources))
+	for i, spec := range f.Resources {
+		if spec.Type == "" {
+			return nil, errors.Errorf("resource %v has no type", i)
+		}
+		if spec.Name == "" {
+			return nil, errors.Errorf("resource %v has no name", i)
+		}
+		if spec.ID == "" {
+			return nil, errors.Errorf("resource %v has no ID", i)
+		}
+
+		imp := deploy.Import{
+			Type:    spec.Type,
+			Name:    spec.Name,
+			ID:      spec.ID,
+			Protect: protect,
+		}
+		if spec.Parent != "" {
+			imp.Parent = resolve(spec.Parent)
+			if !imp.Parent.IsValid() {
+				return nil, errors.Errorf("resource %v has an invalid parent '%v'", i, spec.Parent)
+			}
+		}
+		if spec.Provider != "" {
+			imp.Provider = resolve(spec.Provider)
+			if !imp.Provider.IsValid() {
+				return nil, errors.Errorf("resource %v has an invalid provider '%v'", i, spec.Provider)
+			}
+		}
+		if spec.Version != "" {
+			v, err := semver.ParseTolerant(spec.Version)
+			if err != nil {
+				return nil, errors.Wrapf(err, "resource %v has an invalid version", i)
+			}
+			imp.Version = &v
+		}
+		imports[i] = imp
+	}
+	return imports, nil
+}
+
+// programGenerator generates the source files of a program in a particular language from a bound PCL program.
+type programGenerator func(*hcl2.Program) (map[string][]byte, hcl.Diagnostics, error)
+
+// getGenerateProgram returns the program generator for the given project runtime.
+func getGenerateProgram(runtime string) (programGenerator, error) {
+	switch runtime {
+	case "dotnet":
+		return dotnet.GenerateProgram, nil
+	case "go":
+		return gogen.GenerateProgram, nil
+	case "nodejs":
+		return nodejs.GenerateProgram, nil
+	case "python":
+		return python.GenerateProgram, nil
+	default:
+		return nil, errors.Errorf("code generation is not supported for the %v runtime", runtime)
+	}
+}
+
+// getProgramGenerator returns a language generator for the given project runtime.
+func getProgramGenerator(runtime string) (importer.LanguageGenerator, error) {
+	generateProgram, err := getGenerateProgram(runtime)
+	if err != nil {
+		return nil, err
+	}
+
+	return func(w io.Writer, p *hcl2.Program) error {
+		files, diags, err := generateProgram(p)
+		if err != nil {
+			return err
+		}
+		if diags.HasErrors() {
+			return errors.New(diags.Error())
+		}
+		for _, contents := range files {
+			if _, err := w.Write(contents); err != nil {
+				return err
+			}
+		}
+		return nil
+	}, nil
+}
+
+// generateImportedDefinitions writes code for the imported resources in the given snapshot to w.
+func generateImportedDefinitions(w io.Writer, proj *workspace.Project, root string, snap *deploy.Snapshot,
+	urns []resource.URN, nameTable map[string]resource.URN) error {
+
+	gen, err := getProgramGenerator(proj.Runtime.Name())
+	if err != nil {
+		return err
+	}
+
+	// Existing resources are referred to by their names unless the user has told us otherwise.
+	names, resources := importer.NameTable{}, map[resource.URN]*resource.State{}
+	if snap != nil {
+		for _, res := range snap.Resources {
+			names[res.URN], resources[res.URN] = string(res.URN.Name()), res
+		}
+	}
+	for name, urn := range nameTable {
+		names[urn] = name
+	}
+
+	var states []*resource.State
+	for _, urn := range urns {
+		state, ok := resources[urn]
+		if !ok {
+			return errors.Errorf("resource '%v' was not imported", urn)
+		}
+		states = append(states, state)
+	}
+
+	projinfo := &engine.Projinfo{Proj: proj, Root: root}
+	pwd, _, err := projinfo.GetPwdMain()
+	if err != nil {
+		return err
+	}
+	ctx, err := plugin.NewContext(cmdutil.Diag(), cmdutil.Diag(), nil, nil, pwd, proj.Runtime.Options(), nil)
+	if err != nil {
+		return err
+	}
+	defer contract.IgnoreClose(ctx)
+
+	return importer.GenerateLanguageDefinitions(w, schema.NewPluginLoader(ctx.Host), gen, states, names)
+}
+
+func newImportCmd() *cobra.Command {
+	var parentSpec string
+	var providerSpec string
+	var importFilePath string
+	var outputFilePath string
+	var protect bool
+
+	var debug bool
+	var message string
+	var stack string
+
+	// Flags for engine.UpdateOptions.
+	var diffDisplay bool
+	var eventLogPath string
+	var parallel int
+	var showConfig bool
+	var skipPreview bool
+	var suppressOutputs bool
+	var yes bool
+
+	cmd := &cobra.Command{
+		Use:   "import [type] [name] [id]",
+		Args:  cmdutil.MaximumNArgs(3),
+		Short: "Import resources into an existing stack",
+		Long: "Import resources into an existing stack.\n" +
+			"\n" +
+			"Resources that are not managed by Pulumi can be imported into a Pulumi stack\n" +
+			"using this command. A definition for each resource will be printed to stdout\n" +
+			"in the language used by the project associated with the stack; these definitions\n" +
+			"should be added to the Pulumi program. The resources are protected from deletion\n" +
+			"by default.\n" +
+			"\n" +
+			"Should you want to import your resource(s) without protection, you can pass\n" +
+			"`--protect=false` as an argument to the command. This will leave all resources unprotected.\n" +
+			"\n" +
+			"A single resource may be specified in the command line arguments or a set of\n" +
+			"resources may be specified by a JSON file. The resource type token, name, and ID\n" +
+			"are required; the parent and provider are optional and default to the stack and\n" +
+			"the default provider, respectively. The JSON file must conform to this format:\n" +
+			"\n" +
+			"    {\n" +
+			"        \"nameTable\": {\n" +
+			"            \"provider-or-parent-name-0\": \"provider-or-parent-urn-0\",\n" +
+			"            ...\n" +
+			"        },\n" +
+			"        \"resources\": [\n" +
+			"            {\n" +
+			"                \"type\": \"type-token\",\n" +
+			"                \"name\": \"name\",\n" +
+			"                \"id\": \"resource-id\",\n" +
+			"                \"parent\": \"optional-parent-name-or-urn\",\n" +
+			"                \"provider\": \"optional-provider-name-or-urn\",\n" +
+			"                \"version\": \"optional-provider-version\"\n" +
+			"            },\n" +
+			"            ...\n" +
+			"        ]\n" +
+			"    }\n" +
+			"\n" +
+			"The name table maps variable names to the URNs of existing resources that are\n" +
+			"referenced as parents or providers. These names are used in the generated code.\n",
+		Run: cmdutil.RunResultFunc(func(cmd *cobra.Command, args []string) result.Result {
+			var f importFile
+			switch {
+			case len(args) > 0:
+				if importFilePath != "" {
+					return result.Error("an inline resource may not be specified in conjunction with an import file")
+				}
+				if len(args) != 3 {
+					return result.Error("an inline resource must be specified as a type, a name, and an ID")
+				}
+				f = importFile{Resources: []importSpec{{
+					Type:     tokens.Type(args[0]),
+					Name:     tokens.QName(args[1]),
+					ID:       resource.ID(args[2]),
+					Parent:   parentSpec,
+					Provider: providerSpec,
+				}}}
+			case importFilePath != "":
+				if parentSpec != "" || providerSpec != "" {
+					return result.Error("--parent and --provider may not be used with an import file")
+				}
+				file, err := readImportFile(importFilePath)
+				if err != nil {
+					return result.FromError(errors.Wrap(err, "could not read import file"))
+				}
+				f = file
+			default:
+				return result.Error("a resource or an import file must be specified")
+			}
+
+			imports, err := parseImportSpecs(f, protect)
+			if err != nil {
+				return result.FromError(err)
+			}
+			if len(imports) == 0 {
+				return result.Error("no resources to import")
+			}
+
+			yes = yes || skipConfirmations()
+			interactive := cmdutil.Interactive()
+			if !interactive && !yes {
+				return result.Error("--yes must be passed in to proceed when running in non-interactive mode")
+			}
+
+			opts, err := updateFlagsToOptions(interactive, skipPreview, yes)
+			if err != nil {
+				return result.FromError(err)
+			}
+
+			var displayType = display.DisplayProgress
+			if diffDisplay {
+				displayType = display.DisplayDiff
+			}
+
+			opts.Display = display.Options{
+				Color:           cmdutil.GetGlobalColorization(),
+				ShowConfig:      showConfig,
+				SuppressOutputs: suppressOutputs,
+				IsInteractive:   interactive,
+				Type:            displayType,
+				EventLogPath:    eventLogPath,
+				Debug:           debug,
+			}
+
+			s, err := requireStack(stack, false, opts.Display, true /*setCurrent*/)
+			if err != nil {
+				return result.FromError(err)
+			}
+			proj, root, err := readProject()
+			if err != nil {
+				return result.FromError(err)
+			}
+
+			m, err := getUpdateMetadata(message, root)
+			if err != nil {
+				return result.FromError(errors.Wrap(err, "gathering environment metadata"))
+			}
+
+			sm, err := getStackSecretsManager(s)
+			if err != nil {
+				return result.FromError(errors.Wrap(err, "getting secrets manager"))
+			}
+
+			cfg, err := getStackConfiguration(s, sm)
+			if err != nil {
+				return result.FromError(errors.Wrap(err, "getting stack configuration"))
+			}
+
+			opts.Engine = engine.UpdateOptions{
+				Parallel:      parallel,
+				Debug:         debug,
+				UseLegacyDiff: useLegacyDiff(),
+			}
+
+			_, res := s.Import(commandContext(), backend.UpdateOperation{
+				Proj:               proj,
+				Root:               root,
+				M:                  m,
+				Opts:               opts,
+				StackConfiguration: cfg,
+				SecretsManager:     sm,
+				Scopes:             cancellationScopes,
+				Imports:            imports,
+			})
+			switch {
+			case res != nil && res.Error() == context.Canceled:
+				return result.FromError(errors.New("import cancelled"))
+			case res != nil:
+				return PrintEngineResult(res)
+			}
+
+			// Now that the resources are in the stack's state, generate code that matches them.
+			snap, err := s.Snapshot(commandContext())
+			if err != nil {
+				return result.FromError(err)
+			}
+			urns := deploy.ImportURNs(&deploy.Target{Name: s.Ref().Name()}, proj.Name, imports)
+
+			var code bytes.Buffer
+			if err = generateImportedDefinitions(&code, proj, root, snap, urns, f.NameTable); err != nil {
+				cmdutil.Diag().Warningf(diag.Message("", "the resources were imported, but code could not be "+
+					"generated for them: %v"), err)
+				return nil
+			}
+
+			if outputFilePath == "" {
+				fmt.Printf("Please copy the following code into your Pulumi application. Not doing so\n" +
+					"will cause Pulumi to delete the imported resources on your next update:\n\n")
+				fmt.Print(code.String())
+				return nil
+			}
+			if err = ioutil.WriteFile(outputFilePath, code.Bytes(), 0600); err != nil {
+				return result.FromError(errors.Wrap(err, "writing generated code"))
+			}
+			return nil
+		}),
+	}
+
+	cmd.PersistentFlags().StringVar(
+		&parentSpec, "parent", "",
+		"The URN of the parent resource for the inline resource. Defaults to the stack")
+	cmd.PersistentFlags().StringVar(
+		&providerSpec, "provider", "",
+		"The URN of the provider for the inline resource. Defaults to the default provider")
+	cmd.PersistentFlags().StringVarP(
+		&importFilePath, "file", "f", "",
+		"The path to a JSON-encoded file containing a list of resources to import")
+	cmd.PersistentFlags().StringVarP(
+		&outputFilePath, "out", "o", "",
+		"The path to the file that will contain the generated resource declarations. Defaults to stdout")
+	cmd.PersistentFlags().BoolVar(
+		&protect, "protect", true,
+		"Allow resources to be imported with protection from deletion enabled")
+
+	cmd.PersistentFlags().BoolVarP(
+		&debug, "debug", "d", false,
+		"Print detailed debugging output during resource operations")
+	cmd.PersistentFlags().StringVarP(
+		&stack, "stack", "s", "",
+		"The name of the stack to operate on. Defaults to the current stack")
+	cmd.PersistentFlags().StringVar(
+		&stackConfigFile, "config-file", "",
+		"Use the configuration values in the specified file rather than detecting the file name")
+	cmd.PersistentFlags().StringVarP(
+		&message, "message", "m", "",
+		"Optional message to associate with the update operation")
+
+	// Flags for engine.UpdateOptions.
+	cmd.PersistentFlags().BoolVar(
+		&diffDisplay, "diff", false,
+		"Display operation as a rich diff showing the overall change")
+	cmd.PersistentFlags().IntVarP(
+		&parallel, "parallel", "p", defaultParallel,
+		"Allow P resource operations to run in parallel at once (1 for no parallelism). Defaults to unbounded.")
+	cmd.PersistentFlags().BoolVar(
+		&showConfig, "show-config", false,
+		"Show configuration keys and variables")
+	cmd.PersistentFlags().BoolVar(
+		&skipPreview, "skip-preview", false,
+		"Do not perform a preview before performing the import")
+	cmd.PersistentFlags().BoolVar(
+		&suppressOutputs, "suppress-outputs", false,
+		"Suppress display of stack outputs (in case they contain sensitive values)")
+	cmd.PersistentFlags().BoolVarP(
+		&yes, "yes", "y", false,
+		"Automatically approve and perform the import after previewing it")
+
+	if hasDebugCommands() {
+		cmd.PersistentFlags().StringVar(
+			&eventLogPath, "event-log", "",
+			"Log events to a file at this path")
+	}
+	return cmd
+}
@@ -0,0 +1,50 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pulumi/pulumi/sdk/v2/go/common/resource"
+)
+
+func TestParseImportSpecs(t *testing.T) {
+	provURN := resource.URN("urn:pulumi:dev::proj::pulumi:providers:aws::prov")
+
+	imports, err := parseImportSpecs(importFile{
+		NameTable: map[string]resource.URN{"prov": provURN},
+		Resources: []importSpec{
+			{Type: "aws:s3/bucket:Bucket", Name: "a", ID: "bucket-a", Provider: "prov"},
+			{Type: "aws:s3/bucket:Bucket", Name: "b", ID: "bucket-b", Provider: string(provURN), Version: "2.0.0"},
+		},
+	}, true)
+	assert.NoError(t, err)
+	assert.Len(t, imports, 2)
+	assert.Equal(t, provURN, imports[0].Provider)
+	assert.Equal(t, provURN, imports[1].Provider)
+	assert.True(t, imports[0].Protect)
+	assert.Nil(t, imports[0].Version)
+	assert.Equal(t, "2.0.0", imports[1].Version.String())
+
+	// Missing IDs and unresolvable references are rejected.
+	_, err = parseImportSpecs(importFile{Resources: []importSpec{{Type: "aws:s3/bucket:Bucket", Name: "a"}}}, true)
+	assert.Error(t, err)
+	_, err = parseImportSpecs(importFile{Resources: []importSpec{
+		{Type: "aws:s3/bucket:Bucket", Name: "a", ID: "bucket-a", Parent: "unknown"},
+	}}, true)
+	assert.Error(t, err)
+}
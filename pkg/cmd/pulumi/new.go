@@ -38,6 +38,7 @@ import (
 	"github.com/pulumi/pulumi/pkg/v2/engine"
 	"github.com/pulumi/pulumi/pkg/v2/npm"
 	"github.com/pulumi/pulumi/sdk/v2/go/common/apitype"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/diag"
 	"github.com/pulumi/pulumi/sdk/v2/go/common/diag/colors"
 	"github.com/pulumi/pulumi/sdk/v2/go/common/resource/config"
 	"github.com/pulumi/pulumi/sdk/v2/go/common/tokens"
@@ -62,6 +63,7 @@ type newArgs struct {
 	interactive       bool
 	name              string
 	offline           bool
+	parameterArray    []string
 	prompt            promptForValueFunc
 	secretsProvider   string
 	stack             string
@@ -143,6 +145,11 @@ func runNew(args newArgs) error {
 		return err
 	}
 
+	// If no template was named, also offer the templates of each registered template source.
+	if args.templateNameOrURL == "" {
+		templates = append(templates, registeredSourceTemplates(args.offline)...)
+	}
+
 	var template workspace.Template
 	if len(templates) == 0 {
 		return errors.New("no templates")
@@ -224,8 +231,15 @@ func runNew(args newArgs) error {
 		}
 	}
 
+	// Prompt for the values of the template's parameters, if any weren't already specified.
+	parameters, err := promptForTemplateParameters(template, args.parameterArray, args.yes, args.prompt, opts)
+	if err != nil {
+		return err
+	}
+
 	// Actually copy the files.
-	if err = workspace.CopyTemplateFiles(template.Dir, cwd, args.force, args.name, args.description); err != nil {
+	if err = workspace.CopyTemplateFilesWithParameters(
+		template.Dir, cwd, args.force, args.name, args.description, parameters); err != nil {
 		if os.IsNotExist(err) {
 			return errors.Wrapf(err, "template '%s' not found", args.templateNameOrURL)
 		}
@@ -334,6 +348,14 @@ func newNewCmd() *cobra.Command {
 			"or `azure-python`).  If no template name is provided, a list of suggested templates will be presented\n" +
 			"which can be selected interactively.\n" +
 			"\n" +
+			"Additional template sources may be registered in ~/.pulumi/template-sources.json, e.g.\n" +
+			"`{\"sources\": [{\"name\": \"acme\", \"url\": \"https://github.com/acme/templates.git\"}]}`.\n" +
+			"A source has either a git `url` (with an optional `branch`) or a local `path`, and an optional\n" +
+			"`subDirectory`.  Its templates are listed alongside the built-in ones and are named SOURCE/TEMPLATE.\n" +
+			"\n" +
+			"A template may declare parameters that are substituted for ${NAME} in its files.  Values are\n" +
+			"prompted for unless passed with `--parameter NAME=VALUE`.\n" +
+			"\n" +
 			"By default, a stack created using the pulumi.com backend will use the pulumi.com secrets\n" +
 			"provider and a stack created using the local or cloud object storage backend will use the\n" +
 			"`passphrase` secrets provider.  A different secrets provider can be selected by passing the\n" +
@@ -378,6 +400,8 @@ func newNewCmd() *cobra.Command {
 			return
 		}
 
+		templates = append(templates, registeredSourceTemplates(false /*offline*/)...)
+
 		// If we have any templates, show them.
 		if len(templates) > 0 {
 			available, _ := templatesToOptionArrayAndMap(templates, true)
@@ -413,6 +437,9 @@ func newNewCmd() *cobra.Command {
 	cmd.PersistentFlags().BoolVarP(
 		&args.offline, "offline", "o", false,
 		"Use locally cached templates without making any network requests")
+	cmd.PersistentFlags().StringArrayVar(
+		&args.parameterArray, "parameter", []string{},
+		"Template parameter values, as NAME=VALUE; parameters that are not specified are prompted for")
 	cmd.PersistentFlags().StringVarP(
 		&args.stack, "stack", "s", "",
 		"The stack name; either an existing stack or stack to create; if not specified, a prompt will request it")
@@ -811,6 +838,82 @@ func chooseTemplate(templates []workspace.Template, opts display.Options) (works
 	return selectedOption, nil
 }
 
+// registeredSourceTemplates returns the templates of each template source registered on this machine. Their templates
+// are always listed, rather than only when they are marked important. Sources that can't be read are reported and
+// skipped, so that they don't prevent the use of other templates.
+func registeredSourceTemplates(offline bool) []workspace.Template {
+	settings, err := workspace.GetTemplateSettings()
+	if err != nil {
+		cmdutil.Diag().Warningf(diag.Message("", "could not read template sources: %v"), err)
+		return nil
+	}
+
+	var templates []workspace.Template
+	for _, source := range settings.Sources {
+		repo, err := source.Retrieve(offline)
+		if err == nil {
+			var sourceTemplates []workspace.Template
+			if sourceTemplates, err = repo.Templates(); err == nil {
+				for _, template := range sourceTemplates {
+					template.Important = true
+					templates = append(templates, template)
+				}
+			}
+		}
+		if err != nil {
+			cmdutil.Diag().Warningf(
+				diag.Message("", "could not list the templates of template source '%s': %v"), source.Name, err)
+		}
+	}
+	return templates
+}
+
+// promptForTemplateParameters returns the value of each of the template's parameters. Values passed as NAME=VALUE
+// in parameterArray are validated and used as-is; the remaining parameters are prompted for.
+func promptForTemplateParameters(template workspace.Template, parameterArray []string, yes bool,
+	prompt promptForValueFunc, opts display.Options) (map[string]string, error) {
+
+	given := make(map[string]string)
+	for _, p := range parameterArray {
+		kvp := strings.SplitN(p, "=", 2)
+		if len(kvp) != 2 {
+			return nil, errors.Errorf("template parameter '%s' must be of the form NAME=VALUE", p)
+		}
+		given[kvp[0]] = kvp[1]
+	}
+
+	values := make(map[string]string)
+	for _, param := range template.Parameters {
+		value, ok := given[param.Name]
+		delete(given, param.Name)
+		if !ok {
+			valueType := param.Description
+			if valueType == "" {
+				valueType = param.Name
+			}
+			var err error
+			if value, err = prompt(yes, valueType, param.Default, false, param.ValidateValue, opts); err != nil {
+				return nil, err
+			}
+		}
+		if err := param.ValidateValue(value); err != nil {
+			return nil, errors.Wrapf(err, "invalid value for template parameter '%s'", param.Name)
+		}
+		values[param.Name] = value
+	}
+
+	if len(given) > 0 {
+		var unknown []string
+		for name := range given {
+			unknown = append(unknown, name)
+		}
+		sort.Strings(unknown)
+		return nil, errors.Errorf("template '%s' has no parameter named %s", template.Name,
+			strings.Join(unknown, ", "))
+	}
+	return values, nil
+}
+
 // parseConfig parses the config values passed via command line flags.
 // These are passed as `-c aws:region=us-east-1 -c foo:bar=blah` and end up
 // in configArray as ["aws:region=us-east-1", "foo:bar=blah"].
@@ -758,6 +758,38 @@ func TestSetFail(t *testing.T) {
 const projectName = "test_project"
 const stackName = "test_stack"
 
+func TestPromptForTemplateParameters(t *testing.T) {
+	template := workspace.Template{
+		Name: "acme/webapp",
+		Parameters: []workspace.ProjectTemplateParameter{
+			{Name: "TEAM", Description: "owning team", Default: "platform", Pattern: "[a-z]+"},
+			{Name: "REGION", Default: "us-west-2"},
+		},
+	}
+	var prompted []string
+	prompt := func(yes bool, valueType string, defaultValue string, secret bool,
+		isValidFn func(value string) error, opts display.Options) (string, error) {
+		prompted = append(prompted, valueType)
+		return defaultValue, nil
+	}
+
+	// Parameters that are not passed are prompted for, using their descriptions.
+	values, err := promptForTemplateParameters(template, []string{"REGION=eu-west-1"}, false, prompt, display.Options{})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"TEAM": "platform", "REGION": "eu-west-1"}, values)
+	assert.Equal(t, []string{"owning team"}, prompted)
+
+	_, err = promptForTemplateParameters(template, []string{"TEAM=Data Team"}, false, prompt, display.Options{})
+	assert.EqualError(t, err,
+		"invalid value for template parameter 'TEAM': 'Data Team' does not match the pattern [a-z]+")
+
+	_, err = promptForTemplateParameters(template, []string{"OWNER=me"}, false, prompt, display.Options{})
+	assert.EqualError(t, err, "template 'acme/webapp' has no parameter named OWNER")
+
+	_, err = promptForTemplateParameters(template, []string{"TEAM"}, false, prompt, display.Options{})
+	assert.EqualError(t, err, "template parameter 'TEAM' must be of the form NAME=VALUE")
+}
+
 func promptMock(name string, stackName string) promptForValueFunc {
 	return func(yes bool, valueType string, defaultValue string, secret bool,
 		isValidFn func(value string) error, opts display.Options) (string, error) {
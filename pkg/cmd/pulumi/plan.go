@@ -0,0 +1,71 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"encoding/json"
+	"io/ioutil"
+
+	"github.com/pkg/errors"
+
+	"github.com/pulumi/pulumi/pkg/v2/resource/deploy"
+	"github.com/pulumi/pulumi/pkg/v2/resource/stack"
+	"github.com/pulumi/pulumi/pkg/v2/secrets"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/apitype"
+)
+
+// writePlan serializes the given update plan to the file at the given path, encrypting any secret inputs with the
+// stack's secrets manager.
+func writePlan(path string, plan *deploy.UpdatePlan, sm secrets.Manager) error {
+	enc, err := sm.Encrypter()
+	if err != nil {
+		return errors.Wrap(err, "getting encrypter")
+	}
+
+	serialized, err := stack.SerializeUpdatePlan(plan, enc)
+	if err != nil {
+		return errors.Wrap(err, "serializing update plan")
+	}
+
+	b, err := json.MarshalIndent(serialized, "", "    ")
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(path, b, 0600)
+}
+
+// readPlan reads an update plan from the file at the given path, decrypting any secret inputs with the stack's
+// secrets manager.
+func readPlan(path string, sm secrets.Manager) (*deploy.UpdatePlan, error) {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not read update plan")
+	}
+
+	var serialized apitype.UpdatePlanV1
+	if err = json.Unmarshal(b, &serialized); err != nil {
+		return nil, errors.Wrapf(err, "could not parse update plan %s", path)
+	}
+
+	dec, err := sm.Decrypter()
+	if err != nil {
+		return nil, errors.Wrap(err, "getting decrypter")
+	}
+	enc, err := sm.Encrypter()
+	if err != nil {
+		return nil, errors.Wrap(err, "getting encrypter")
+	}
+	return stack.DeserializeUpdatePlan(serialized, dec, enc)
+}
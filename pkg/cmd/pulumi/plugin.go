@@ -15,6 +15,8 @@
 package main
 
 import (
+	"os"
+
 	"github.com/spf13/cobra"
 
 	"github.com/pulumi/pulumi/pkg/v2/engine"
@@ -43,8 +45,11 @@ func newPluginCmd() *cobra.Command {
 		Args: cmdutil.NoArgs,
 	}
 
+	cmd.AddCommand(newPluginBundleCmd())
 	cmd.AddCommand(newPluginInstallCmd())
+	cmd.AddCommand(newPluginLockCmd())
 	cmd.AddCommand(newPluginLsCmd())
+	cmd.AddCommand(newPluginPruneCmd())
 	cmd.AddCommand(newPluginRmCmd())
 
 	return cmd
@@ -87,3 +92,21 @@ func getProjectPlugins() ([]workspace.PluginInfo, error) {
 	}
 	return results, nil
 }
+
+// loadProjectLockFile loads the lock file of the project in the current directory, returning the lock file and its
+// path. If there is no current project, it returns a nil lock file.
+func loadProjectLockFile() (*workspace.LockFile, string, error) {
+	cwd, err := os.Getwd()
+	if err != nil {
+		return nil, "", err
+	}
+	path, err := workspace.DetectLockFilePathFrom(cwd)
+	if err != nil || path == "" {
+		return nil, "", err
+	}
+	lock, err := workspace.LoadLockFile(path)
+	if err != nil {
+		return nil, "", err
+	}
+	return lock, path, nil
+}
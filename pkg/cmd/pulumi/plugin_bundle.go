@@ -0,0 +1,108 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/pulumi/pulumi/sdk/v2/go/common/util/cmdutil"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/util/contract"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/workspace"
+)
+
+func newPluginBundleCmd() *cobra.Command {
+	var out string
+
+	var cmd = &cobra.Command{
+		Use:   "bundle",
+		Args:  cmdutil.NoArgs,
+		Short: "Pack the current project's plugins into a single archive",
+		Long: "Pack the current project's plugins into a single archive.\n" +
+			"\n" +
+			"This command downloads each plugin required by the current project for this\n" +
+			"platform and writes them all to one bundle file.  The bundle can be copied to a\n" +
+			"machine without internet access and installed there with\n" +
+			"`pulumi plugin install --from-bundle`.\n" +
+			"\n" +
+			"Plugins are downloaded from the sources listed in PULUMI_PLUGIN_SOURCES, if set,\n" +
+			"falling back to get.pulumi.com unless PULUMI_PLUGIN_SOURCES_ONLY is true, and must\n" +
+			"match any checksums pinned by the project's Pulumi.lock file.",
+		Run: cmdutil.RunFunc(func(cmd *cobra.Command, args []string) error {
+			platform, err := workspace.PluginPlatform()
+			if err != nil {
+				return err
+			}
+			if out == "" {
+				out = fmt.Sprintf("pulumi-plugins-%s.tar.gz", platform)
+			}
+
+			plugins, err := getProjectPlugins()
+			if err != nil {
+				return err
+			}
+			lock, _, err := loadProjectLockFile()
+			if err != nil {
+				return err
+			}
+
+			f, err := os.Create(out)
+			if err != nil {
+				return errors.Wrapf(err, "creating %s", out)
+			}
+			defer contract.IgnoreClose(f)
+
+			bw := workspace.NewPluginBundleWriter(f)
+			count := 0
+			for _, plugin := range plugins {
+				// Language plugins are not downloaded, and plugins without a version can't be located.
+				if plugin.Kind == workspace.LanguagePlugin || plugin.Version == nil {
+					continue
+				}
+
+				tarball, err := downloadPluginTarball(plugin)
+				if err != nil {
+					return errors.Wrapf(err, "downloading %s plugin %s", plugin.Kind, plugin)
+				}
+				if lock != nil {
+					if expected := lock.Checksum(plugin); expected != "" {
+						if actual := workspace.PluginChecksum(tarball); actual != expected {
+							plugin.Checksum = expected
+							return &workspace.ChecksumMismatchError{Info: plugin, Actual: actual}
+						}
+					}
+				}
+				if err = bw.Add(plugin, platform, tarball); err != nil {
+					return errors.Wrapf(err, "writing %s", out)
+				}
+				count++
+			}
+			if err = bw.Close(); err != nil {
+				return errors.Wrapf(err, "writing %s", out)
+			}
+
+			fmt.Printf("Bundled %d plugin(s) in %s\n", count, out)
+			return nil
+		}),
+	}
+
+	cmd.PersistentFlags().StringVarP(&out,
+		"out", "o", "", "The file to write the bundle to (default \"pulumi-plugins-PLATFORM.tar.gz\")")
+
+	return cmd
+}
@@ -15,9 +15,12 @@
 package main
 
 import (
+	"bytes"
 	"fmt"
 	"io"
+	"io/ioutil"
 	"os"
+	"strings"
 
 	"github.com/blang/semver"
 	"github.com/pkg/errors"
@@ -26,6 +29,7 @@ import (
 	"github.com/pulumi/pulumi/pkg/v2/backend/display"
 	"github.com/pulumi/pulumi/sdk/v2/go/common/diag"
 	"github.com/pulumi/pulumi/sdk/v2/go/common/util/cmdutil"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/util/contract"
 	"github.com/pulumi/pulumi/sdk/v2/go/common/workspace"
 )
 
@@ -33,6 +37,7 @@ func newPluginInstallCmd() *cobra.Command {
 	var serverURL string
 	var exact bool
 	var file string
+	var fromBundle string
 	var reinstall bool
 	var verbose bool
 
@@ -48,12 +53,29 @@ func newPluginInstallCmd() *cobra.Command {
 			"project.  VERSION cannot be a range: it must be a specific number.\n" +
 			"\n" +
 			"If you let Pulumi compute the set to download, it is conservative and may end up\n" +
-			"downloading more plugins than is strictly necessary.",
+			"downloading more plugins than is strictly necessary.\n" +
+			"\n" +
+			"If the current project's Pulumi.lock file pins the checksum of a plugin, the\n" +
+			"plugin's tarball must match it.\n" +
+			"\n" +
+			"Plugins are downloaded from the server given by --server, followed by the\n" +
+			"comma-separated list of servers, file:// URLs, and local directories in the\n" +
+			"PULUMI_PLUGIN_SOURCES environment variable, and finally from get.pulumi.com.  Set\n" +
+			"PULUMI_PLUGIN_SOURCES_ONLY=true to skip get.pulumi.com.  On a machine without\n" +
+			"internet access, use --from-bundle to install the plugins in a bundle created by\n" +
+			"`pulumi plugin bundle`.",
 		Run: cmdutil.RunFunc(func(cmd *cobra.Command, args []string) error {
 			displayOpts := display.Options{
 				Color: cmdutil.GetGlobalColorization(),
 			}
 
+			if fromBundle != "" {
+				if len(args) > 0 || file != "" {
+					return errors.New("--from-bundle cannot be combined with a specific plugin or --file (-f)")
+				}
+				return installPluginBundle(fromBundle, reinstall, verbose)
+			}
+
 			// Parse the kind, name, and version, if specified.
 			var installs []workspace.PluginInfo
 			if len(args) > 0 {
@@ -93,6 +115,17 @@ func newPluginInstallCmd() *cobra.Command {
 				}
 			}
 
+			// Verify each plugin against the checksum pinned by the current project's lock file, if any.
+			lock, _, err := loadProjectLockFile()
+			if err != nil {
+				return err
+			}
+			if lock != nil {
+				for i := range installs {
+					installs[i].Checksum = lock.Checksum(installs[i])
+				}
+			}
+
 			// Now for each kind, name, version pair, download it from the release website, and install it.
 			for _, install := range installs {
 				label := fmt.Sprintf("[%s plugin %s]", install.Kind, install)
@@ -126,13 +159,14 @@ func newPluginInstallCmd() *cobra.Command {
 				var tarball io.ReadCloser
 				var err error
 				if file == "" {
+					source = strings.Join(install.PluginSources(), ", ")
 					if verbose {
 						cmdutil.Diag().Infoerrf(
-							diag.Message("", "%s downloading from %s"), label, install.ServerURL)
+							diag.Message("", "%s downloading from %s"), label, source)
 					}
 					var size int64
 					if tarball, size, err = install.Download(); err != nil {
-						return errors.Wrapf(err, "%s downloading from %s", label, install.ServerURL)
+						return errors.Wrapf(err, "%s downloading from %s", label, source)
 					}
 					tarball = workspace.ReadCloserProgressBar(tarball, size, "Downloading plugin", displayOpts.Color)
 				} else {
@@ -164,6 +198,8 @@ func newPluginInstallCmd() *cobra.Command {
 		"exact", false, "Force installation of an exact version match (usually >= is accepted)")
 	cmd.PersistentFlags().StringVarP(&file,
 		"file", "f", "", "Install a plugin from a tarball file, instead of downloading it")
+	cmd.PersistentFlags().StringVar(&fromBundle,
+		"from-bundle", "", "Install all of the plugins in a bundle created by `pulumi plugin bundle`")
 	cmd.PersistentFlags().BoolVar(&reinstall,
 		"reinstall", false, "Reinstall a plugin even if it already exists")
 	cmd.PersistentFlags().BoolVar(&verbose,
@@ -171,3 +207,49 @@ func newPluginInstallCmd() *cobra.Command {
 
 	return cmd
 }
+
+// installPluginBundle installs the plugins for the current platform from the given bundle, verifying each against the
+// checksum pinned by the current project's lock file, if any.
+func installPluginBundle(bundle string, reinstall, verbose bool) error {
+	platform, err := workspace.PluginPlatform()
+	if err != nil {
+		return err
+	}
+	lock, _, err := loadProjectLockFile()
+	if err != nil {
+		return err
+	}
+
+	f, err := os.Open(bundle)
+	if err != nil {
+		return errors.Wrapf(err, "opening bundle %s", bundle)
+	}
+	defer contract.IgnoreClose(f)
+
+	return workspace.ReadPluginBundle(f, func(install workspace.PluginInfo, bundled string, tarball []byte) error {
+		label := fmt.Sprintf("[%s plugin %s]", install.Kind, install)
+		if bundled != platform {
+			cmdutil.Diag().Warningf(
+				diag.Message("", "%s skipping install (bundled for %s, not %s)"), label, bundled, platform)
+			return nil
+		}
+
+		cmdutil.Diag().Infoerrf(
+			diag.Message("", "%s installing"), label)
+		if !reinstall && workspace.HasPlugin(install) {
+			if verbose {
+				cmdutil.Diag().Infoerrf(
+					diag.Message("", "%s skipping install (existing == match)"), label)
+			}
+			return nil
+		}
+
+		if lock != nil {
+			install.Checksum = lock.Checksum(install)
+		}
+		if err := install.Install(ioutil.NopCloser(bytes.NewReader(tarball))); err != nil {
+			return errors.Wrapf(err, "installing %s from %s", label, bundle)
+		}
+		return nil
+	})
+}
@@ -0,0 +1,96 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"fmt"
+	"io/ioutil"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/pulumi/pulumi/sdk/v2/go/common/util/cmdutil"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/util/contract"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/workspace"
+)
+
+func newPluginLockCmd() *cobra.Command {
+	var cmd = &cobra.Command{
+		Use:   "lock",
+		Args:  cmdutil.NoArgs,
+		Short: "Pin the checksums of the current project's plugins",
+		Long: "Pin the checksums of the current project's plugins.\n" +
+			"\n" +
+			"This command downloads each plugin required by the current project and records\n" +
+			"the SHA-256 checksum of its tarball for this platform in the project's\n" +
+			"Pulumi.lock file.  Once pinned, a plugin is only installed if its tarball matches\n" +
+			"the recorded checksum.  Plugins that the project no longer requires are removed\n" +
+			"from the lock file, and checksums recorded for other platforms are kept.\n" +
+			"\n" +
+			"Run this command after intentionally changing the version of a plugin.",
+		Run: cmdutil.RunFunc(func(cmd *cobra.Command, args []string) error {
+			lock, lockPath, err := loadProjectLockFile()
+			if err != nil {
+				return err
+			} else if lock == nil {
+				return errors.New("no Pulumi.yaml project file found in this directory or any of its parents")
+			}
+
+			plugins, err := getProjectPlugins()
+			if err != nil {
+				return err
+			}
+
+			// Language plugins are not downloaded, and plugins without a version can't be pinned.
+			var locked []workspace.PluginInfo
+			for _, plugin := range plugins {
+				if plugin.Kind != workspace.LanguagePlugin && plugin.Version != nil {
+					locked = append(locked, plugin)
+				}
+			}
+			lock.Retain(locked)
+
+			for _, plugin := range locked {
+				tarball, err := downloadPluginTarball(plugin)
+				if err != nil {
+					return errors.Wrapf(err, "downloading %s plugin %s", plugin.Kind, plugin)
+				}
+				checksum := workspace.PluginChecksum(tarball)
+				lock.SetChecksum(plugin, checksum)
+				fmt.Printf("%s plugin %s: %s\n", plugin.Kind, plugin, checksum)
+			}
+
+			if err = lock.Save(lockPath); err != nil {
+				return errors.Wrapf(err, "saving %s", lockPath)
+			}
+			fmt.Printf("Pinned %d plugin(s) in %s\n", len(locked), lockPath)
+			return nil
+		}),
+	}
+
+	return cmd
+}
+
+// downloadPluginTarball downloads the tarball of the given plugin for the current platform.
+func downloadPluginTarball(plugin workspace.PluginInfo) ([]byte, error) {
+	tarball, size, err := plugin.Download()
+	if err != nil {
+		return nil, err
+	}
+	defer contract.IgnoreClose(tarball)
+
+	tarball = workspace.ReadCloserProgressBar(tarball, size, "Downloading plugin", cmdutil.GetGlobalColorization())
+	return ioutil.ReadAll(tarball)
+}
@@ -0,0 +1,148 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/pulumi/pulumi/pkg/v2/backend/display"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/util/cmdutil"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/workspace"
+)
+
+func newPluginPruneCmd() *cobra.Command {
+	var days int
+	var lockFileDirs []string
+	var yes bool
+	var cmd = &cobra.Command{
+		Use:   "prune",
+		Args:  cmdutil.NoArgs,
+		Short: "Remove unused plugins from the download cache",
+		Long: "Remove unused plugins from the download cache.\n" +
+			"\n" +
+			"Pass --days N to remove the plugins that have not been used in the last N days,\n" +
+			"and --lock-files-in DIR to remove the plugins that are not pinned by any\n" +
+			"Pulumi.lock file in DIR or its subdirectories.  --lock-files-in may be passed\n" +
+			"more than once, and never removes plugins that lock files cannot pin: language\n" +
+			"plugins, plugins without a version, and plugins installed without a recorded\n" +
+			"checksum.  If both are passed, only plugins that meet both conditions are\n" +
+			"removed.\n" +
+			"\n" +
+			"A removed plugin is downloaded again the next time a program requires it.",
+		Run: cmdutil.RunFunc(func(cmd *cobra.Command, args []string) error {
+			yes = yes || skipConfirmations()
+			opts := display.Options{
+				Color: cmdutil.GetGlobalColorization(),
+			}
+
+			if days < 0 {
+				return errors.New("--days must not be negative")
+			} else if days == 0 && len(lockFileDirs) == 0 {
+				return errors.New("please pass --days and/or --lock-files-in to choose which plugins to remove")
+			}
+
+			var locks []*workspace.LockFile
+			for _, dir := range lockFileDirs {
+				paths, err := workspace.FindLockFiles(dir)
+				if err != nil {
+					return errors.Wrapf(err, "searching %s for lock files", dir)
+				}
+				for _, path := range paths {
+					lock, err := workspace.LoadLockFile(path)
+					if err != nil {
+						return err
+					}
+					locks = append(locks, lock)
+				}
+			}
+
+			var cutoff time.Time
+			if days > 0 {
+				cutoff = time.Now().AddDate(0, 0, -days)
+			}
+
+			plugins, err := workspace.GetPlugins()
+			if err != nil {
+				return errors.Wrap(err, "loading plugins")
+			}
+			deletes := prunablePlugins(plugins, locks, len(lockFileDirs) > 0, cutoff)
+			if len(deletes) == 0 {
+				fmt.Println("No plugins to remove")
+				return nil
+			}
+			return deletePlugins(deletes, yes, opts)
+		}),
+	}
+
+	cmd.PersistentFlags().IntVar(
+		&days, "days", 0,
+		"Remove plugins that have not been used in this many days")
+	cmd.PersistentFlags().StringArrayVar(
+		&lockFileDirs, "lock-files-in", nil,
+		"Remove plugins that are not pinned by a Pulumi.lock file in this directory or its subdirectories")
+	cmd.PersistentFlags().BoolVarP(
+		&yes, "yes", "y", false,
+		"Skip confirmation prompts, and proceed with removal anyway")
+
+	return cmd
+}
+
+// prunablePlugins returns the plugins that meet every pruning condition: if checkLocks is set, the plugin must be
+// pinnable but not pinned by any of the given lock files, and if the cutoff is set, the plugin must not have been used
+// since then.
+func prunablePlugins(plugins []workspace.PluginInfo, locks []*workspace.LockFile, checkLocks bool,
+	cutoff time.Time) []workspace.PluginInfo {
+
+	var prunable []workspace.PluginInfo
+	for _, plugin := range plugins {
+		if !cutoff.IsZero() && plugin.LastUsedTime.After(cutoff) {
+			continue
+		}
+		if checkLocks && (!isPluginPinnable(plugin) || isPluginLocked(plugin, locks)) {
+			continue
+		}
+		prunable = append(prunable, plugin)
+	}
+	return prunable
+}
+
+// isPluginPinnable returns true if lock files can pin the plugin, i.e. if the absence of a lock file entry means that
+// no project uses it. Language plugins and unversioned plugins are never pinned, and neither are cached plugins whose
+// checksum was not recorded when they were installed.
+func isPluginPinnable(plugin workspace.PluginInfo) bool {
+	if plugin.Kind == workspace.LanguagePlugin || plugin.Version == nil {
+		return false
+	}
+	dir, err := plugin.DirPath()
+	if err != nil {
+		return false
+	}
+	checksum, err := workspace.InstalledPluginChecksum(dir)
+	return err == nil && checksum != ""
+}
+
+// isPluginLocked returns true if any of the given lock files pins the plugin.
+func isPluginLocked(plugin workspace.PluginInfo, locks []*workspace.LockFile) bool {
+	for _, lock := range locks {
+		if lock.References(plugin) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,109 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/blang/semver"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pulumi/pulumi/sdk/v2/go/common/workspace"
+)
+
+func TestPrunablePlugins(t *testing.T) {
+	home, err := ioutil.TempDir("", "pulumi-home")
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	defer os.RemoveAll(home)
+	oldHome := os.Getenv(workspace.PulumiHomeEnvVar)
+	assert.NoError(t, os.Setenv(workspace.PulumiHomeEnvVar, home))
+	defer func() { assert.NoError(t, os.Setenv(workspace.PulumiHomeEnvVar, oldHome)) }()
+
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	assert.NoError(t, tar.NewWriter(gz).Close())
+	assert.NoError(t, gz.Close())
+	tarball := buf.Bytes()
+
+	// Installing a plugin records its checksum, which makes it pinnable.
+	now := time.Now()
+	plugin := func(name, version string, lastUsed time.Time) workspace.PluginInfo {
+		v := semver.MustParse(version)
+		info := workspace.PluginInfo{Kind: workspace.ResourcePlugin, Name: name, Version: &v, LastUsedTime: lastUsed}
+		assert.NoError(t, info.Install(ioutil.NopCloser(bytes.NewReader(tarball))))
+		return info
+	}
+	names := func(plugins []workspace.PluginInfo) []string {
+		var result []string
+		for _, p := range plugins {
+			result = append(result, p.String())
+		}
+		return result
+	}
+
+	lockedStale := plugin("aws", "1.0.0", now.AddDate(0, 0, -30))
+	unlockedStale := plugin("aws", "2.0.0", now.AddDate(0, 0, -30))
+	unlockedFresh := plugin("gcp", "1.0.0", now)
+	unlockedNeverUsed := plugin("azure", "1.0.0", time.Time{})
+	plugins := []workspace.PluginInfo{lockedStale, unlockedStale, unlockedFresh, unlockedNeverUsed}
+
+	lock := &workspace.LockFile{}
+	lock.Plugins = append(lock.Plugins, workspace.PluginLock{
+		Kind:    workspace.ResourcePlugin,
+		Name:    "aws",
+		Version: "1.0.0",
+	})
+	locks := []*workspace.LockFile{lock}
+	cutoff := now.AddDate(0, 0, -7)
+
+	assert.Equal(t, names([]workspace.PluginInfo{unlockedStale, unlockedFresh, unlockedNeverUsed}),
+		names(prunablePlugins(plugins, locks, true, time.Time{})))
+	assert.Equal(t, names([]workspace.PluginInfo{lockedStale, unlockedStale, unlockedNeverUsed}),
+		names(prunablePlugins(plugins, nil, false, cutoff)))
+	assert.Equal(t, names([]workspace.PluginInfo{unlockedStale, unlockedNeverUsed}),
+		names(prunablePlugins(plugins, locks, true, cutoff)))
+}
+
+func TestPrunablePluginsSkipsUnpinnable(t *testing.T) {
+	home, err := ioutil.TempDir("", "pulumi-home")
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	defer os.RemoveAll(home)
+	oldHome := os.Getenv(workspace.PulumiHomeEnvVar)
+	assert.NoError(t, os.Setenv(workspace.PulumiHomeEnvVar, home))
+	defer func() { assert.NoError(t, os.Setenv(workspace.PulumiHomeEnvVar, oldHome)) }()
+
+	// None of these plugins can be pinned by a lock file, so lock files never prune them: the language plugin and
+	// the unversioned plugin have no lock entries, and the other plugin was installed without a recorded checksum.
+	v := semver.MustParse("1.0.0")
+	plugins := []workspace.PluginInfo{
+		{Kind: workspace.LanguagePlugin, Name: "nodejs", Version: &v},
+		{Kind: workspace.ResourcePlugin, Name: "unversioned"},
+		{Kind: workspace.ResourcePlugin, Name: "aws", Version: &v},
+	}
+	assert.Empty(t, prunablePlugins(plugins, []*workspace.LockFile{{}}, true, time.Time{}))
+
+	// They can still be pruned by age.
+	assert.Len(t, prunablePlugins(plugins, nil, false, time.Now()), 3)
+}
@@ -92,32 +92,7 @@ func newPluginRmCmd() *cobra.Command {
 				return errors.New("no plugins found")
 			}
 
-			// Confirm that the user wants to do this (unless --yes was passed), and do the deletes.
-			var suffix string
-			if len(deletes) != 1 {
-				suffix = "s"
-			}
-			fmt.Print(
-				opts.Color.Colorize(
-					fmt.Sprintf("%sThis will remove %d plugin%s from the cache:%s\n",
-						colors.SpecAttention, len(deletes), suffix, colors.Reset)))
-			for _, del := range deletes {
-				fmt.Printf("    %s %s\n", del.Kind, del.String())
-			}
-			if yes || confirmPrompt("", "yes", opts) {
-				var result error
-				for _, plugin := range deletes {
-					if err := plugin.Delete(); err != nil {
-						result = multierror.Append(
-							result, errors.Wrapf(err, "failed to delete %s plugin %s", plugin.Kind, plugin))
-					}
-				}
-				if result != nil {
-					return result
-				}
-			}
-
-			return nil
+			return deletePlugins(deletes, yes, opts)
 		}),
 	}
 
@@ -130,3 +105,32 @@ func newPluginRmCmd() *cobra.Command {
 
 	return cmd
 }
+
+// deletePlugins removes the given plugins from the cache, after confirming with the user unless yes is set.
+func deletePlugins(deletes []workspace.PluginInfo, yes bool, opts display.Options) error {
+	var suffix string
+	if len(deletes) != 1 {
+		suffix = "s"
+	}
+	fmt.Print(
+		opts.Color.Colorize(
+			fmt.Sprintf("%sThis will remove %d plugin%s from the cache:%s\n",
+				colors.SpecAttention, len(deletes), suffix, colors.Reset)))
+	for _, del := range deletes {
+		fmt.Printf("    %s %s\n", del.Kind, del.String())
+	}
+	if yes || confirmPrompt("", "yes", opts) {
+		var result error
+		for _, plugin := range deletes {
+			if err := plugin.Delete(); err != nil {
+				result = multierror.Append(
+					result, errors.Wrapf(err, "failed to delete %s plugin %s", plugin.Kind, plugin))
+			}
+		}
+		if result != nil {
+			return result
+		}
+	}
+
+	return nil
+}
@@ -203,6 +203,10 @@ func installPolicyPackDependencies(proj *workspace.PolicyPackProject, projPath,
 		}); err != nil {
 			return err
 		}
+	} else if strings.EqualFold(proj.Runtime.Name(), "go") {
+		if err := goInstallDependencies(); err != nil {
+			return errors.Wrap(err, "`go mod download` failed; rerun manually to try again.")
+		}
 	}
 	return nil
 }
@@ -215,6 +219,8 @@ func printPolicyPackNextSteps(proj *workspace.PolicyPackProject, root string, ge
 			commands = append(commands, "npm install")
 		} else if strings.EqualFold(proj.Runtime.Name(), "python") {
 			commands = append(commands, pythonCommands()...)
+		} else if strings.EqualFold(proj.Runtime.Name(), "go") {
+			commands = append(commands, "go mod download")
 		}
 	}
 
@@ -239,7 +245,8 @@ func printPolicyPackNextSteps(proj *workspace.PolicyPackProject, root string, ge
 		[]string{"run the Policy Pack against a Pulumi program, in the directory of the Pulumi program run"}
 	usageCommands := []string{fmt.Sprintf("pulumi up --policy-pack %s", root)}
 
-	if strings.EqualFold(proj.Runtime.Name(), "nodejs") || strings.EqualFold(proj.Runtime.Name(), "python") {
+	if strings.EqualFold(proj.Runtime.Name(), "nodejs") || strings.EqualFold(proj.Runtime.Name(), "python") ||
+		strings.EqualFold(proj.Runtime.Name(), "go") {
 		usageCommandPreambles = append(usageCommandPreambles, "publish the Policy Pack, run")
 		usageCommands = append(usageCommands, "pulumi policy publish [org-name]")
 	}
@@ -33,12 +33,15 @@ func newPreviewCmd() *cobra.Command {
 	var stack string
 	var configArray []string
 	var configPath bool
+	var client string
+	var planFilePath string
 
 	// Flags for engine.UpdateOptions.
 	var jsonDisplay bool
 	var policyPackPaths []string
 	var policyPackConfigPaths []string
 	var diffDisplay bool
+	var diffFormat string
 	var eventLogPath string
 	var parallel int
 	var refresh bool
@@ -48,6 +51,7 @@ func newPreviewCmd() *cobra.Command {
 	var showReads bool
 	var suppressOutputs bool
 	var targets []string
+	var excludes []string
 	var replaces []string
 	var targetReplaces []string
 	var targetDependents bool
@@ -75,6 +79,18 @@ func newPreviewCmd() *cobra.Command {
 				displayType = display.DisplayDiff
 			}
 
+			var jsonDiff bool
+			switch diffFormat {
+			case "text":
+			case "json":
+				if jsonDisplay {
+					return result.FromError(errors.New("--diff-format=json cannot be combined with --json"))
+				}
+				jsonDisplay, jsonDiff = true, true
+			default:
+				return result.FromError(errors.Errorf("unknown diff format '%s'; expected 'text' or 'json'", diffFormat))
+			}
+
 			displayOpts := display.Options{
 				Color:                cmdutil.GetGlobalColorization(),
 				ShowConfig:           showConfig,
@@ -85,6 +101,7 @@ func newPreviewCmd() *cobra.Command {
 				IsInteractive:        cmdutil.Interactive(),
 				Type:                 displayType,
 				JSONDisplay:          jsonDisplay,
+				JSONDiff:             jsonDiff,
 				EventLogPath:         eventLogPath,
 				Debug:                debug,
 			}
@@ -103,7 +120,7 @@ func newPreviewCmd() *cobra.Command {
 				return result.FromError(err)
 			}
 
-			proj, root, err := readProject()
+			proj, root, err := readProjectForUpdate(client)
 			if err != nil {
 				return result.FromError(err)
 			}
@@ -147,12 +164,13 @@ func newPreviewCmd() *cobra.Command {
 					ReplaceTargets:   replaceURNs,
 					UseLegacyDiff:    useLegacyDiff(),
 					UpdateTargets:    targetURNs,
+					Excludes:         excludeURNs(excludes),
 					TargetDependents: targetDependents,
 				},
 				Display: displayOpts,
 			}
 
-			changes, res := s.Preview(commandContext(), backend.UpdateOperation{
+			plan, changes, res := s.Preview(commandContext(), backend.UpdateOperation{
 				Proj:               proj,
 				Root:               root,
 				M:                  m,
@@ -167,6 +185,11 @@ func newPreviewCmd() *cobra.Command {
 				return PrintEngineResult(res)
 			case expectNop && changes != nil && changes.HasChanges():
 				return result.FromError(errors.New("error: no changes were expected but changes were proposed"))
+			case planFilePath != "":
+				if err = writePlan(planFilePath, plan, sm); err != nil {
+					return result.FromError(err)
+				}
+				return nil
 			default:
 				return nil
 			}
@@ -185,12 +208,18 @@ func newPreviewCmd() *cobra.Command {
 	cmd.PersistentFlags().StringVar(
 		&stackConfigFile, "config-file", "",
 		"Use the configuration values in the specified file rather than detecting the file name")
+	cmd.PersistentFlags().StringVar(
+		&client, "client", "", "The address of an existing language runtime host to connect to")
+	_ = cmd.PersistentFlags().MarkHidden("client")
 	cmd.PersistentFlags().StringArrayVarP(
 		&configArray, "config", "c", []string{},
 		"Config to use during the preview")
 	cmd.PersistentFlags().BoolVar(
 		&configPath, "config-path", false,
 		"Config keys contain a path to a property in a map or list to set")
+	cmd.PersistentFlags().StringVar(
+		&planFilePath, "save-plan", "",
+		"Save the operations proposed by the preview to a plan file at the given path")
 
 	cmd.PersistentFlags().StringVarP(
 		&message, "message", "m", "",
@@ -198,8 +227,12 @@ func newPreviewCmd() *cobra.Command {
 
 	cmd.PersistentFlags().StringArrayVarP(
 		&targets, "target", "t", []string{},
-		"Specify a single resource URN to update. Other resources will not be updated."+
+		"Specify a single resource URN or URN pattern to update. Other resources will not be updated."+
 			" Multiple resources can be specified using --target urn1 --target urn2")
+	cmd.PersistentFlags().StringArrayVar(
+		&excludes, "exclude", []string{},
+		"Specify a resource URN or URN pattern to exclude from the preview. Excluded resources will not be updated."+
+			" Multiple resources can be specified using --exclude urn1 --exclude urn2")
 	cmd.PersistentFlags().StringArrayVar(
 		&replaces, "replace", []string{},
 		"Specify resources to replace. Multiple resources can be specified using --replace urn1 --replace urn2")
@@ -224,6 +257,10 @@ func newPreviewCmd() *cobra.Command {
 	cmd.Flags().BoolVarP(
 		&jsonDisplay, "json", "j", false,
 		"Serialize the preview diffs, operations, and overall output as JSON")
+	cmd.PersistentFlags().StringVar(
+		&diffFormat, "diff-format", "text",
+		"The format of the preview: 'text', or 'json' to serialize each resource's operation and "+
+			"property-level changes, with secrets masked")
 	cmd.PersistentFlags().IntVarP(
 		&parallel, "parallel", "p", defaultParallel,
 		"Allow P resource operations to run in parallel at once (1 for no parallelism). Defaults to unbounded.")
@@ -201,7 +201,10 @@ func NewPulumiCmd() *cobra.Command {
 	//     - Advanced Commands:
 	cmd.AddCommand(newCancelCmd())
 	cmd.AddCommand(newRefreshCmd())
+	cmd.AddCommand(newDriftCmd())
 	cmd.AddCommand(newStateCmd())
+	cmd.AddCommand(newImportCmd())
+	cmd.AddCommand(newConvertCmd())
 	//     - Other Commands:
 	cmd.AddCommand(newLogsCmd())
 	cmd.AddCommand(newPluginCmd())
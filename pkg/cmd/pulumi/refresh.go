@@ -36,6 +36,7 @@ func newRefreshCmd() *cobra.Command {
 
 	// Flags for engine.UpdateOptions.
 	var diffDisplay bool
+	var jsonDisplay bool
 	var eventLogPath string
 	var parallel int
 	var showConfig bool
@@ -45,6 +46,7 @@ func newRefreshCmd() *cobra.Command {
 	var suppressOutputs bool
 	var yes bool
 	var targets *[]string
+	var excludes []string
 
 	var cmd = &cobra.Command{
 		Use:   "refresh",
@@ -65,6 +67,9 @@ func newRefreshCmd() *cobra.Command {
 			if !interactive && !yes {
 				return result.FromError(errors.New("--yes must be passed in to proceed when running in non-interactive mode"))
 			}
+			if jsonDisplay && !yes {
+				return result.FromError(errors.New("--yes must be passed in to proceed when using --json"))
+			}
 
 			opts, err := updateFlagsToOptions(interactive, skipPreview, yes)
 			if err != nil {
@@ -84,6 +89,7 @@ func newRefreshCmd() *cobra.Command {
 				SuppressOutputs:      suppressOutputs,
 				IsInteractive:        interactive,
 				Type:                 displayType,
+				JSONDisplay:          jsonDisplay,
 				EventLogPath:         eventLogPath,
 				Debug:                debug,
 			}
@@ -123,6 +129,7 @@ func newRefreshCmd() *cobra.Command {
 				Debug:          debug,
 				UseLegacyDiff:  useLegacyDiff(),
 				RefreshTargets: targetUrns,
+				Excludes:       excludeURNs(excludes),
 			}
 
 			changes, res := s.Refresh(commandContext(), backend.UpdateOperation{
@@ -167,12 +174,20 @@ func newRefreshCmd() *cobra.Command {
 
 	targets = cmd.PersistentFlags().StringArrayP(
 		"target", "t", []string{},
-		"Specify a single resource URN to refresh. Multiple resource can be specified using: --target urn1 --target urn2")
+		"Specify a single resource URN or URN pattern to refresh."+
+			" Multiple resource can be specified using: --target urn1 --target urn2")
+	cmd.PersistentFlags().StringArrayVar(
+		&excludes, "exclude", []string{},
+		"Specify a resource URN or URN pattern to exclude from the refresh. Excluded resources will not be refreshed."+
+			" Multiple resources can be specified using --exclude urn1 --exclude urn2")
 
 	// Flags for engine.UpdateOptions.
 	cmd.PersistentFlags().BoolVar(
 		&diffDisplay, "diff", false,
 		"Display operation as a rich diff showing the overall change")
+	cmd.PersistentFlags().BoolVarP(
+		&jsonDisplay, "json", "j", false,
+		"Stream the refresh's events to stdout as JSON, one object per line")
 	cmd.PersistentFlags().IntVarP(
 		&parallel, "parallel", "p", defaultParallel,
 		"Allow P resource operations to run in parallel at once (1 for no parallelism). Defaults to unbounded.")
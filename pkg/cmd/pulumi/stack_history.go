@@ -13,6 +13,7 @@ import (
 
 	"github.com/pulumi/pulumi/pkg/v2/backend"
 	"github.com/pulumi/pulumi/pkg/v2/backend/display"
+	"github.com/pulumi/pulumi/pkg/v2/engine"
 	"github.com/pulumi/pulumi/sdk/v2/go/common/diag/colors"
 	"github.com/pulumi/pulumi/sdk/v2/go/common/resource/config"
 	"github.com/pulumi/pulumi/sdk/v2/go/common/util/cmdutil"
@@ -23,6 +24,7 @@ func newStackHistoryCmd() *cobra.Command {
 	var stack string
 	var jsonOut bool
 	var showSecrets bool
+	var showResources bool
 
 	cmd := &cobra.Command{
 		Use:        "history",
@@ -31,7 +33,10 @@ func newStackHistoryCmd() *cobra.Command {
 		Short:      "[PREVIEW] Display history for a stack",
 		Long: `Display history for a stack
 
-This command displays data about previous updates for a stack.`,
+This command displays data about previous updates for a stack.
+
+Use --show-resources to also display each changed resource's operation, how long it took, and why it failed, if
+it did, with the slowest resources first. The --json output always includes these details when they are known.`,
 		Run: cmdutil.RunFunc(func(cmd *cobra.Command, args []string) error {
 			opts := display.Options{
 				Color: cmdutil.GetGlobalColorization(),
@@ -41,7 +46,7 @@ This command displays data about previous updates for a stack.`,
 				return err
 			}
 			b := s.Backend()
-			updates, err := b.GetHistory(commandContext(), s.Ref())
+			updates, err := b.GetHistory(commandContext(), s.Ref(), showResources || jsonOut /*includeResources*/)
 			if err != nil {
 				return errors.Wrap(err, "getting history")
 			}
@@ -58,7 +63,7 @@ This command displays data about previous updates for a stack.`,
 				return displayUpdatesJSON(updates, decrypter)
 			}
 
-			return displayUpdatesConsole(updates, opts)
+			return displayUpdatesConsole(updates, showResources, opts)
 		}),
 	}
 
@@ -68,6 +73,9 @@ This command displays data about previous updates for a stack.`,
 	cmd.Flags().BoolVar(
 		&showSecrets, "show-secrets", false,
 		"Show secret values when listing config instead of displaying blinded values")
+	cmd.Flags().BoolVar(
+		&showResources, "show-resources", false,
+		"Show the operation, duration, and failure reason of each resource changed by an update")
 	cmd.PersistentFlags().BoolVarP(
 		&jsonOut, "json", "j", false, "Emit output as JSON")
 	return cmd
@@ -76,6 +84,7 @@ This command displays data about previous updates for a stack.`,
 // updateInfoJSON is the shape of the --json output for a configuration value.  While we can add fields to this
 // structure in the future, we should not change existing fields.
 type updateInfoJSON struct {
+	Version     int                        `json:"version,omitempty"`
 	Kind        string                     `json:"kind"`
 	StartTime   string                     `json:"startTime"`
 	Message     string                     `json:"message"`
@@ -84,8 +93,18 @@ type updateInfoJSON struct {
 	Result      string                     `json:"result,omitempty"`
 
 	// These values are only present once the update finishes
-	EndTime         *string         `json:"endTime,omitempty"`
-	ResourceChanges *map[string]int `json:"resourceChanges,omitempty"`
+	EndTime         *string               `json:"endTime,omitempty"`
+	ResourceChanges *map[string]int       `json:"resourceChanges,omitempty"`
+	Resources       []resourceSummaryJSON `json:"resources,omitempty"`
+}
+
+// resourceSummaryJSON is the shape of the --json output for the operation an update performed on a single resource.
+type resourceSummaryJSON struct {
+	URN                  string `json:"urn"`
+	Type                 string `json:"type"`
+	Op                   string `json:"op"`
+	DurationMilliseconds int64  `json:"durationMilliseconds"`
+	Error                string `json:"error,omitempty"`
 }
 
 func displayUpdatesJSON(updates []backend.UpdateInfo, decrypter config.Decrypter) error {
@@ -96,6 +115,7 @@ func displayUpdatesJSON(updates []backend.UpdateInfo, decrypter config.Decrypter
 	updatesJSON := make([]updateInfoJSON, len(updates))
 	for idx, update := range updates {
 		info := updateInfoJSON{
+			Version:     update.Version,
 			Kind:        string(update.Kind),
 			StartTime:   time.Unix(update.StartTime, 0).UTC().Format(timeFormat),
 			Message:     update.Message,
@@ -131,6 +151,15 @@ func displayUpdatesJSON(updates []backend.UpdateInfo, decrypter config.Decrypter
 				resourceChanges[string(k)] = v
 			}
 			info.ResourceChanges = &resourceChanges
+			for _, r := range update.ResourceSummaries {
+				info.Resources = append(info.Resources, resourceSummaryJSON{
+					URN:                  string(r.URN),
+					Type:                 string(r.Type),
+					Op:                   string(r.Op),
+					DurationMilliseconds: r.Duration.Milliseconds(),
+					Error:                r.Error,
+				})
+			}
 		}
 		updatesJSON[idx] = info
 	}
@@ -138,7 +167,7 @@ func displayUpdatesJSON(updates []backend.UpdateInfo, decrypter config.Decrypter
 	return printJSON(updatesJSON)
 }
 
-func displayUpdatesConsole(updates []backend.UpdateInfo, opts display.Options) error {
+func displayUpdatesConsole(updates []backend.UpdateInfo, showResources bool, opts display.Options) error {
 	if len(updates) == 0 {
 		fmt.Println("Stack has never been updated")
 		return nil
@@ -151,6 +180,9 @@ func displayUpdatesConsole(updates []backend.UpdateInfo, opts display.Options) e
 
 	for _, update := range updates {
 
+		if update.Version != 0 {
+			fmt.Printf("Version: %v\n", update.Version)
+		}
 		fmt.Printf("UpdateKind: %v\n", update.Kind)
 		if update.Result == "succeeded" {
 			fmt.Print(opts.Color.Colorize(fmt.Sprintf("%sStatus: %v%s\n", colors.Green, update.Result, colors.Reset)))
@@ -187,8 +219,37 @@ func displayUpdatesConsole(updates []backend.UpdateInfo, opts display.Options) e
 				fmt.Printf("%*s%s: %s\n", indent, "", k, update.Environment[k])
 			}
 		}
+		if showResources {
+			displayResourceSummaries(update.ResourceSummaries, indent, opts)
+		}
 		fmt.Println("")
 	}
 
 	return nil
 }
+
+// displayResourceSummaries prints the operation, duration, and failure reason of each resource changed by an update,
+// slowest first.
+func displayResourceSummaries(summaries []engine.ResourceSummary, indent int, opts display.Options) {
+	if len(summaries) == 0 {
+		return
+	}
+
+	sorted := make([]engine.ResourceSummary, len(summaries))
+	copy(sorted, summaries)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Duration > sorted[j].Duration
+	})
+
+	fmt.Printf("%*sResources:\n", indent, "")
+	for _, r := range sorted {
+		duration := r.Duration.Round(time.Millisecond)
+		if r.Error == "" {
+			fmt.Printf("%*s%-18s %10s  %s\n", indent+4, "", r.Op, duration, r.URN)
+		} else {
+			fmt.Print(opts.Color.Colorize(fmt.Sprintf("%*s%s%-18s %10s  %s%s\n", indent+4, "",
+				colors.Red, r.Op, duration, r.URN, colors.Reset)))
+			fmt.Printf("%*sfailed: %s\n", indent+8, "", r.Error)
+		}
+	}
+}
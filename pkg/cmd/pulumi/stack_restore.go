@@ -0,0 +1,100 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/pulumi/pulumi/pkg/v2/backend/display"
+	"github.com/pulumi/pulumi/pkg/v2/backend/filestate"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/util/cmdutil"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/util/result"
+)
+
+func newStackRestoreCmd() *cobra.Command {
+	var stack string
+	var yes bool
+
+	cmd := &cobra.Command{
+		Use:   "restore <version>",
+		Args:  cmdutil.ExactArgs(1),
+		Short: "Restore a stack's checkpoint from a previous version",
+		Long: "Restore a stack's checkpoint from a previous version.\n" +
+			"\n" +
+			"This command makes the checkpoint recorded for the given version of the stack its\n" +
+			"current checkpoint. The versions of a stack are listed by `pulumi stack history`.\n" +
+			"The checkpoint is verified before it is restored, its secrets are re-encrypted using\n" +
+			"the stack's current secrets provider, and the restore is itself recorded in the\n" +
+			"stack's history. No resources are modified; run `pulumi refresh` afterwards to\n" +
+			"reconcile the restored checkpoint with the actual state of the stack's resources.\n" +
+			"\n" +
+			"This command is only supported by self-managed backends.",
+		Run: cmdutil.RunResultFunc(func(cmd *cobra.Command, args []string) result.Result {
+			yes = yes || skipConfirmations()
+
+			version, err := strconv.Atoi(args[0])
+			if err != nil || version < 1 {
+				return result.Errorf("version must be a positive integer, got '%s'", args[0])
+			}
+
+			opts := display.Options{
+				Color: cmdutil.GetGlobalColorization(),
+			}
+
+			s, err := requireStack(stack, false, opts, false /*setCurrent*/)
+			if err != nil {
+				return result.FromError(err)
+			}
+
+			b, ok := s.Backend().(filestate.Backend)
+			if !ok {
+				return result.Error("restoring a stack's checkpoint is only supported by self-managed backends")
+			}
+
+			sm, err := getStackSecretsManager(s)
+			if err != nil {
+				return result.FromError(errors.Wrap(err, "getting secrets manager"))
+			}
+
+			// Ensure the user really wants to do this.
+			prompt := fmt.Sprintf("This will replace the current checkpoint of the '%s' stack with version %d!",
+				s.Ref(), version)
+			if !yes && !confirmPrompt(prompt, s.Ref().String(), opts) {
+				fmt.Println("confirmation declined")
+				return result.Bail()
+			}
+
+			if err = b.RestoreStack(commandContext(), s, version, sm); err != nil {
+				return result.FromError(err)
+			}
+
+			fmt.Printf("Restored stack '%s' to version %d\n", s.Ref(), version)
+			return nil
+		}),
+	}
+
+	cmd.PersistentFlags().StringVarP(
+		&stack, "stack", "s", "",
+		"The name of the stack to operate on. Defaults to the current stack")
+	cmd.PersistentFlags().BoolVarP(
+		&yes, "yes", "y", false,
+		"Skip confirmation prompts, and proceed with the restore anyway")
+
+	return cmd
+}
@@ -0,0 +1,178 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/pulumi/pulumi/pkg/v2/backend"
+	"github.com/pulumi/pulumi/pkg/v2/backend/display"
+	"github.com/pulumi/pulumi/pkg/v2/resource/deploy"
+	"github.com/pulumi/pulumi/pkg/v2/resource/edit"
+	"github.com/pulumi/pulumi/pkg/v2/version"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/resource"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/tokens"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/util/cmdutil"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/util/contract"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/util/result"
+)
+
+func newStateMoveCommand() *cobra.Command {
+	var source string
+	var dest string
+	var yes bool
+
+	cmd := &cobra.Command{
+		Use:   "move <resource URN>...",
+		Short: "Moves resources from one stack's state to another",
+		Long: `Moves resources from one stack's state to another
+
+This command moves one or more resources from the state of the source stack into the state of the destination
+stack. Any resources that the given resources depend upon, as well as their children, are moved along with them.
+Providers used by the moved resources are copied to the destination stack if they are still in use by resources
+in the source stack. Moved resources whose parents remain in the source stack are parented to the destination stack.
+
+Resources can't be moved if there exist other resources in the source stack that depend on them.
+
+Make sure that URNs are single-quoted to avoid having characters unexpectedly interpreted by the shell.
+
+Example:
+pulumi state move --source dev --dest prod 'urn:pulumi:dev::demo::aws:s3/bucket:Bucket::my-bucket'
+`,
+		Args: cmdutil.MinimumNArgs(1),
+		Run: cmdutil.RunResultFunc(func(cmd *cobra.Command, args []string) result.Result {
+			yes = yes || skipConfirmations()
+			// Show the confirmation prompt if the user didn't pass the --yes parameter to skip it.
+			showPrompt := !yes
+
+			if dest == "" {
+				return result.Error("a destination stack must be specified using --dest")
+			}
+
+			opts := display.Options{
+				Color: cmdutil.GetGlobalColorization(),
+			}
+			sourceStack, err := requireStack(source, false, opts, false /*setCurrent*/)
+			if err != nil {
+				return result.FromError(err)
+			}
+			destStack, err := requireStack(dest, false, opts, false /*setCurrent*/)
+			if err != nil {
+				return result.FromError(err)
+			}
+			if sourceStack.Ref().String() == destStack.Ref().String() {
+				return result.Error("the source and destination stacks must be different")
+			}
+
+			sourceSnap, err := sourceStack.Snapshot(commandContext())
+			if err != nil {
+				return result.FromError(err)
+			}
+			if sourceSnap == nil {
+				return result.Error("the source stack has no resources")
+			}
+			destSnap, err := destStack.Snapshot(commandContext())
+			if err != nil {
+				return result.FromError(err)
+			}
+			if destSnap == nil {
+				sm, err := getStackSecretsManager(destStack)
+				if err != nil {
+					return result.FromError(errors.Wrap(err, "getting secrets manager"))
+				}
+				manifest := deploy.Manifest{
+					Time:    time.Now(),
+					Version: version.Version,
+				}
+				manifest.Magic = manifest.NewMagic()
+				destSnap = deploy.NewSnapshot(manifest, sm, nil, nil)
+			}
+
+			var resources []*resource.State
+			for _, arg := range args {
+				urn := resource.URN(arg)
+				found := edit.LocateResource(sourceSnap, urn)
+				if len(found) == 0 {
+					return result.Errorf("No such resource %q exists in the source stack's state", urn)
+				}
+				resources = append(resources, found...)
+			}
+
+			destProject, err := stackProject(destStack.Ref(), destSnap)
+			if err != nil {
+				return result.FromError(err)
+			}
+
+			if showPrompt && cmdutil.Interactive() && !confirmStateEdit(opts) {
+				fmt.Println("confirmation declined")
+				return result.Bail()
+			}
+
+			// As with other state edits, we only assert the integrity of the results if both snapshots were valid
+			// to begin with.
+			stacksAreAlreadyHosed := sourceSnap.VerifyIntegrity() != nil || destSnap.VerifyIntegrity() != nil
+			err = edit.MoveResources(sourceSnap, destSnap, destStack.Ref().Name(), destProject, resources)
+			if err != nil {
+				return result.FromError(err)
+			}
+			if !stacksAreAlreadyHosed {
+				contract.AssertNoErrorf(sourceSnap.VerifyIntegrity(), "state move produced an invalid source snapshot")
+				contract.AssertNoErrorf(destSnap.VerifyIntegrity(), "state move produced an invalid dest snapshot")
+			}
+
+			// Write the destination first so that a failure leaves the resources duplicated rather than lost.
+			if err = saveSnapshot(destStack, destSnap); err != nil {
+				return result.FromError(errors.Wrap(err, "saving destination stack"))
+			}
+			if err = saveSnapshot(sourceStack, sourceSnap); err != nil {
+				return result.FromError(errors.Wrap(err, "saving source stack"))
+			}
+
+			fmt.Println("Resources moved successfully")
+			return nil
+		}),
+	}
+
+	cmd.PersistentFlags().StringVar(
+		&source, "source", "",
+		"The name of the stack to move resources from. Defaults to the current stack")
+	cmd.PersistentFlags().StringVar(
+		&dest, "dest", "",
+		"The name of the stack to move resources to")
+	cmd.Flags().BoolVarP(&yes, "yes", "y", false, "Skip confirmation prompts")
+	return cmd
+}
+
+// stackProject returns the project to which the given stack belongs. The project is taken from the stack's resources,
+// if it has any; otherwise, it is taken from the stack's reference or, failing that, from the current project.
+func stackProject(ref backend.StackReference, snap *deploy.Snapshot) (tokens.PackageName, error) {
+	if len(snap.Resources) > 0 {
+		return snap.Resources[0].URN.Project(), nil
+	}
+	if projectRef, ok := ref.(backend.ProjectStackReference); ok {
+		if project, ok := projectRef.Project(); ok {
+			return project, nil
+		}
+	}
+	proj, _, err := readProject()
+	if err != nil {
+		return "", errors.Wrapf(err, "determining the project of stack %s", ref)
+	}
+	return proj.Name, nil
+}
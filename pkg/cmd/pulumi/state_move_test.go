@@ -0,0 +1,51 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pulumi/pulumi/pkg/v2/resource/deploy"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/resource"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/tokens"
+)
+
+type projectStackReference struct {
+	name    tokens.QName
+	project tokens.PackageName
+}
+
+func (r projectStackReference) String() string     { return string(r.name) }
+func (r projectStackReference) Name() tokens.QName { return r.name }
+func (r projectStackReference) Project() (tokens.PackageName, bool) {
+	return r.project, r.project != ""
+}
+
+func TestStackProject(t *testing.T) {
+	ref := projectStackReference{name: "prod", project: "dest"}
+
+	// An empty destination stack takes its project from its reference.
+	project, err := stackProject(ref, &deploy.Snapshot{})
+	assert.NoError(t, err)
+	assert.Equal(t, tokens.PackageName("dest"), project)
+
+	// A destination stack with resources takes its project from them.
+	urn := resource.NewURN("prod", "existing", "", "pkgA:m:typA", "resA")
+	project, err = stackProject(ref, &deploy.Snapshot{Resources: []*resource.State{{URN: urn}}})
+	assert.NoError(t, err)
+	assert.Equal(t, tokens.PackageName("existing"), project)
+}
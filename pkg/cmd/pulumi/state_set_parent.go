@@ -0,0 +1,86 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"fmt"
+
+	"github.com/pulumi/pulumi/pkg/v2/backend/display"
+	"github.com/pulumi/pulumi/pkg/v2/resource/deploy"
+	"github.com/pulumi/pulumi/pkg/v2/resource/edit"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/resource"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/util/cmdutil"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/util/result"
+
+	"github.com/spf13/cobra"
+)
+
+func newStateSetParentCommand() *cobra.Command {
+	var stack string
+	var yes bool
+
+	cmd := &cobra.Command{
+		Use:   "set-parent <resource URN> <parent URN>",
+		Short: "Changes the parent of a resource in a stack's state",
+		Long: `Changes the parent of a resource in a stack's state
+
+This command changes the parent of a resource in a stack's state. Because a resource's URN includes the type of its
+parent, the URNs of the resource and all of its descendants are updated, as is every reference to them elsewhere in
+the state. The resource's parent in your program should be changed to match, or the resource will be replaced on the
+next update.
+
+Make sure that URNs are single-quoted to avoid having characters unexpectedly interpreted by the shell.
+
+Example:
+pulumi state set-parent 'urn:pulumi:stage::demo::aws:s3/bucket:Bucket::my-bucket' \
+    'urn:pulumi:stage::demo::my:component:Storage::storage'
+`,
+		Args: cmdutil.ExactArgs(2),
+		Run: cmdutil.RunResultFunc(func(cmd *cobra.Command, args []string) result.Result {
+			yes = yes || skipConfirmations()
+			urn, parentURN := resource.URN(args[0]), resource.URN(args[1])
+			// Show the confirmation prompt if the user didn't pass the --yes parameter to skip it.
+			showPrompt := !yes
+
+			res := runTotalStateEdit(stack, showPrompt, func(opts display.Options, snap *deploy.Snapshot) error {
+				if snap == nil {
+					return fmt.Errorf("no resources found to edit")
+				}
+
+				res, err := locateStackResource(opts, snap, urn)
+				if err != nil {
+					return err
+				}
+				parent, err := locateStackResource(opts, snap, parentURN)
+				if err != nil {
+					return err
+				}
+
+				return edit.SetParent(snap, res, parent)
+			})
+			if res != nil {
+				return res
+			}
+			fmt.Println("Resource parent changed successfully")
+			return nil
+		}),
+	}
+
+	cmd.PersistentFlags().StringVarP(
+		&stack, "stack", "s", "",
+		"The name of the stack to operate on. Defaults to the current stack")
+	cmd.Flags().BoolVarP(&yes, "yes", "y", false, "Skip confirmation prompts")
+	return cmd
+}
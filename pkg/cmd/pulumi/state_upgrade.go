@@ -0,0 +1,93 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	survey "gopkg.in/AlecAivazis/survey.v1"
+	surveycore "gopkg.in/AlecAivazis/survey.v1/core"
+
+	"github.com/pulumi/pulumi/pkg/v2/backend/display"
+	"github.com/pulumi/pulumi/pkg/v2/backend/filestate"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/diag/colors"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/tokens"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/util/cmdutil"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/util/result"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/workspace"
+)
+
+func newStateUpgradeCommand() *cobra.Command {
+	var yes bool
+
+	cmd := &cobra.Command{
+		Use:   "upgrade",
+		Short: "Migrates the current backend to the latest supported version",
+		Long: `Migrates the current backend to the latest supported version
+
+This only has an effect on self-managed backends. Stacks that were created before project-scoped
+stacks were supported are moved into the namespace of the project whose resources they contain.
+Stacks without resources are moved into the current project.`,
+		Args: cmdutil.NoArgs,
+		Run: cmdutil.RunResultFunc(func(cmd *cobra.Command, args []string) result.Result {
+			yes = yes || skipConfirmations()
+
+			opts := display.Options{
+				Color: cmdutil.GetGlobalColorization(),
+			}
+
+			b, err := currentBackend(opts)
+			if err != nil {
+				return result.FromError(err)
+			}
+
+			lb, ok := b.(filestate.Backend)
+			if !ok {
+				// Only the self-managed backend supports upgrades.
+				return nil
+			}
+
+			if !yes {
+				surveycore.DisableColor = true
+				surveycore.QuestionIcon = ""
+				surveycore.SelectFocusIcon = opts.Color.Colorize(colors.BrightGreen + ">" + colors.Reset)
+				prompt := opts.Color.Colorize(colors.Yellow + "warning" + colors.Reset + ": ")
+				prompt += "This will upgrade the current backend to the latest supported version. " +
+					"Older versions of Pulumi will not be able to read the new format. Confirm?"
+				cmdutil.EndKeypadTransmitMode()
+				var response bool
+				if err := survey.AskOne(&survey.Confirm{Message: prompt}, &response, nil); err != nil || !response {
+					fmt.Println("confirmation declined")
+					return result.Bail()
+				}
+			}
+
+			var defaultProject tokens.PackageName
+			if proj, err := workspace.DetectProject(); err == nil {
+				defaultProject = proj.Name
+			}
+
+			if err := lb.Upgrade(commandContext(), defaultProject); err != nil {
+				return result.FromError(err)
+			}
+			return nil
+		}),
+	}
+
+	cmd.Flags().BoolVarP(&yes, "yes", "y", false,
+		"Automatically approve and perform the upgrade")
+	return cmd
+}
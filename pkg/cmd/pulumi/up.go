@@ -50,11 +50,14 @@ func newUpCmd() *cobra.Command {
 	var stack string
 	var configArray []string
 	var path bool
+	var client string
+	var planFilePath string
 
 	// Flags for engine.UpdateOptions.
 	var policyPackPaths []string
 	var policyPackConfigPaths []string
 	var diffDisplay bool
+	var jsonDisplay bool
 	var eventLogPath string
 	var parallel int
 	var refresh bool
@@ -67,9 +70,11 @@ func newUpCmd() *cobra.Command {
 	var yes bool
 	var secretsProvider string
 	var targets []string
+	var excludes []string
 	var replaces []string
 	var targetReplaces []string
 	var targetDependents bool
+	var continueOnError bool
 
 	// up implementation used when the source of the Pulumi program is in the current working directory.
 	upWorkingDirectory := func(opts backend.UpdateOptions) result.Result {
@@ -83,7 +88,7 @@ func newUpCmd() *cobra.Command {
 			return result.FromError(err)
 		}
 
-		proj, root, err := readProject()
+		proj, root, err := readProjectForUpdate(client)
 		if err != nil {
 			return result.FromError(err)
 		}
@@ -127,7 +132,15 @@ func newUpCmd() *cobra.Command {
 			ReplaceTargets:   replaceURNs,
 			UseLegacyDiff:    useLegacyDiff(),
 			UpdateTargets:    targetURNs,
+			Excludes:         excludeURNs(excludes),
 			TargetDependents: targetDependents,
+			ContinueOnError:  continueOnError,
+		}
+
+		if planFilePath != "" {
+			if opts.Engine.Plan, err = readPlan(planFilePath, sm); err != nil {
+				return result.FromError(err)
+			}
 		}
 
 		changes, res := s.Update(commandContext(), backend.UpdateOperation{
@@ -229,8 +242,15 @@ func newUpCmd() *cobra.Command {
 			}
 		}
 
+		// Prompt for the values of the template's parameters.
+		parameters, err := promptForTemplateParameters(template, nil, yes, promptForValue, opts.Display)
+		if err != nil {
+			return result.FromError(err)
+		}
+
 		// Copy the template files from the repo to the temporary "virtual workspace" directory.
-		if err = workspace.CopyTemplateFiles(template.Dir, temp, true, name, description); err != nil {
+		if err = workspace.CopyTemplateFilesWithParameters(
+			template.Dir, temp, true, name, description, parameters); err != nil {
 			return result.FromError(err)
 		}
 
@@ -285,6 +305,7 @@ func newUpCmd() *cobra.Command {
 			Parallel:         parallel,
 			Debug:            debug,
 			Refresh:          refresh,
+			ContinueOnError:  continueOnError,
 		}
 
 		// TODO for the URL case:
@@ -337,6 +358,9 @@ func newUpCmd() *cobra.Command {
 			if !interactive && !yes {
 				return result.FromError(errors.New("--yes must be passed in to proceed when running in non-interactive mode"))
 			}
+			if jsonDisplay && !yes {
+				return result.FromError(errors.New("--yes must be passed in to proceed when using --json"))
+			}
 
 			opts, err := updateFlagsToOptions(interactive, skipPreview, yes)
 			if err != nil {
@@ -361,11 +385,18 @@ func newUpCmd() *cobra.Command {
 				SuppressOutputs:      suppressOutputs,
 				IsInteractive:        interactive,
 				Type:                 displayType,
+				JSONDisplay:          jsonDisplay,
 				EventLogPath:         eventLogPath,
 				Debug:                debug,
 			}
 
 			if len(args) > 0 {
+				if planFilePath != "" {
+					return result.FromError(errors.New("--plan cannot be used when creating a stack from a template"))
+				}
+				if jsonDisplay {
+					return result.FromError(errors.New("--json cannot be used when creating a stack from a template"))
+				}
 				return upTemplateNameOrURL(args[0], opts)
 			}
 
@@ -385,6 +416,12 @@ func newUpCmd() *cobra.Command {
 	cmd.PersistentFlags().StringVar(
 		&stackConfigFile, "config-file", "",
 		"Use the configuration values in the specified file rather than detecting the file name")
+	cmd.PersistentFlags().StringVar(
+		&client, "client", "", "The address of an existing language runtime host to connect to")
+	_ = cmd.PersistentFlags().MarkHidden("client")
+	cmd.PersistentFlags().StringVar(
+		&planFilePath, "plan", "",
+		"Fail the update if it deviates from the operations in the plan file saved by `pulumi preview --save-plan`")
 	cmd.PersistentFlags().StringArrayVarP(
 		&configArray, "config", "c", []string{},
 		"Config to use during the update")
@@ -402,8 +439,12 @@ func newUpCmd() *cobra.Command {
 
 	cmd.PersistentFlags().StringArrayVarP(
 		&targets, "target", "t", []string{},
-		"Specify a single resource URN to update. Other resources will not be updated."+
+		"Specify a single resource URN or URN pattern to update. Other resources will not be updated."+
 			" Multiple resources can be specified using --target urn1 --target urn2")
+	cmd.PersistentFlags().StringArrayVar(
+		&excludes, "exclude", []string{},
+		"Specify a resource URN or URN pattern to exclude from the update. Excluded resources will not be updated."+
+			" Multiple resources can be specified using --exclude urn1 --exclude urn2")
 	cmd.PersistentFlags().StringArrayVar(
 		&replaces, "replace", []string{},
 		"Specify resources to replace. Multiple resources can be specified using --replace urn1 --replace urn2")
@@ -416,6 +457,10 @@ func newUpCmd() *cobra.Command {
 		"Allows updating of dependent targets discovered but not specified in --target list")
 
 	// Flags for engine.UpdateOptions.
+	cmd.PersistentFlags().BoolVar(
+		&continueOnError, "continue-on-error", false,
+		"Continue the update past failed resource operations, skipping only the resources that depend on them,"+
+			" and report all of the failures at the end")
 	cmd.PersistentFlags().StringSliceVar(
 		&policyPackPaths, "policy-pack", []string{},
 		"Run one or more policy packs as part of this update")
@@ -425,6 +470,9 @@ func newUpCmd() *cobra.Command {
 	cmd.PersistentFlags().BoolVar(
 		&diffDisplay, "diff", false,
 		"Display operation as a rich diff showing the overall change")
+	cmd.PersistentFlags().BoolVarP(
+		&jsonDisplay, "json", "j", false,
+		"Stream the update's events to stdout as JSON, one object per line")
 	cmd.PersistentFlags().IntVarP(
 		&parallel, "parallel", "p", defaultParallel,
 		"Allow P resource operations to run in parallel at once (1 for no parallelism). Defaults to unbounded.")
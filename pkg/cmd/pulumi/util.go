@@ -46,6 +46,7 @@ import (
 	"github.com/pulumi/pulumi/pkg/v2/util/cancel"
 	"github.com/pulumi/pulumi/pkg/v2/util/tracing"
 	"github.com/pulumi/pulumi/sdk/v2/go/common/diag/colors"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/resource"
 	"github.com/pulumi/pulumi/sdk/v2/go/common/util/ciutil"
 	"github.com/pulumi/pulumi/sdk/v2/go/common/util/cmdutil"
 	"github.com/pulumi/pulumi/sdk/v2/go/common/util/contract"
@@ -425,6 +426,21 @@ func readProject() (*workspace.Project, string, error) {
 	return proj, filepath.Dir(path), nil
 }
 
+// readProjectForUpdate attempts to detect and read a Pulumi project for the current workspace. If a client address is
+// given, the project's runtime is replaced with one that connects to the language runtime service at that address.
+func readProjectForUpdate(clientAddress string) (*workspace.Project, string, error) {
+	proj, root, err := readProject()
+	if err != nil {
+		return nil, "", err
+	}
+	if clientAddress != "" {
+		proj.Runtime = workspace.NewProjectRuntimeInfo(engine.ClientRuntimeName, map[string]interface{}{
+			"address": clientAddress,
+		})
+	}
+	return proj, root, nil
+}
+
 // readPolicyProject attempts to detect and read a Pulumi PolicyPack project for the current
 // workspace. If the project is successfully detected and read, it is returned along with the path
 // to its containing directory, which will be used as the root of the project's Pulumi program.
@@ -734,6 +750,15 @@ func updateFlagsToOptions(interactive, skipPreview, yes bool) (backend.UpdateOpt
 	}, nil
 }
 
+// excludeURNs converts the values of an `--exclude` flag into URNs or URN patterns.
+func excludeURNs(excludes []string) []resource.URN {
+	var urns []resource.URN
+	for _, e := range excludes {
+		urns = append(urns, resource.URN(e))
+	}
+	return urns
+}
+
 func checkDeploymentVersionError(err error, stackName string) error {
 	switch err {
 	case stack.ErrDeploymentSchemaVersionTooOld:
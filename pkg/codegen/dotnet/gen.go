@@ -635,6 +635,9 @@ func (mod *modContext) genResource(w io.Writer, r *schema.Resource) error {
 	if r.IsProvider {
 		baseType = "Pulumi.ProviderResource"
 	}
+	if r.IsComponent {
+		baseType = "Pulumi.ComponentResource"
+	}
 	if r.DeprecationMessage != "" {
 		fmt.Fprintf(w, "    [Obsolete(@\"%s\")]\n", strings.Replace(r.DeprecationMessage, `"`, `""`, -1))
 	}
@@ -658,7 +661,8 @@ func (mod *modContext) genResource(w io.Writer, r *schema.Resource) error {
 			}
 		}
 
-		if prop.Secret {
+		// Additional secret outputs are only supported for custom resources.
+		if prop.Secret && !r.IsComponent {
 			secretProps = append(secretProps, prop.Name)
 		}
 
@@ -692,6 +696,9 @@ func (mod *modContext) genResource(w io.Writer, r *schema.Resource) error {
 	}
 
 	optionsType := "CustomResourceOptions"
+	if r.IsComponent {
+		optionsType = "ComponentResourceOptions"
+	}
 
 	tok := r.Token
 	if r.IsProvider {
@@ -712,20 +719,26 @@ func (mod *modContext) genResource(w io.Writer, r *schema.Resource) error {
 	fmt.Fprintf(w, "        /// <param name=\"args\">The arguments used to populate this resource's properties</param>\n")
 	fmt.Fprintf(w, "        /// <param name=\"options\">A bag of options that control this resource's behavior</param>\n")
 
+	// Remote components are constructed by their provider.
+	remoteArg := ""
+	if r.IsComponent {
+		remoteArg = ", remote: true"
+	}
+
 	fmt.Fprintf(w, "        public %s(string name, %s args%s, %s? options = null)\n", className, argsType, argsDefault, optionsType)
-	fmt.Fprintf(w, "            : base(\"%s\", name, %s, MakeResourceOptions(options, \"\"))\n", tok, argsOverride)
+	fmt.Fprintf(w, "            : base(\"%s\", name, %s, MakeResourceOptions(options, \"\")%s)\n", tok, argsOverride, remoteArg)
 	fmt.Fprintf(w, "        {\n")
 	fmt.Fprintf(w, "        }\n")
 
 	if mod.dictionaryConstructors {
-		fmt.Fprintf(w, "        internal %s(string name, ImmutableDictionary<string, object?> dictionary, CustomResourceOptions? options = null)\n", className)
-		fmt.Fprintf(w, "            : base(\"%s\", name, new DictionaryResourceArgs(dictionary), MakeResourceOptions(options, \"\"))\n", tok)
+		fmt.Fprintf(w, "        internal %s(string name, ImmutableDictionary<string, object?> dictionary, %s? options = null)\n", className, optionsType)
+		fmt.Fprintf(w, "            : base(\"%s\", name, new DictionaryResourceArgs(dictionary), MakeResourceOptions(options, \"\")%s)\n", tok, remoteArg)
 		fmt.Fprintf(w, "        {\n")
 		fmt.Fprintf(w, "        }\n")
 	}
 
 	// Write a private constructor for the use of `Get`.
-	if !r.IsProvider {
+	if !r.IsProvider && !r.IsComponent {
 		stateParam, stateRef := "", "null"
 		if r.StateInputs != nil {
 			stateParam, stateRef = fmt.Sprintf("%sState? state = null, ", className), "state"
@@ -793,8 +806,9 @@ func (mod *modContext) genResource(w io.Writer, r *schema.Resource) error {
 	fmt.Fprintf(w, "            return merged;\n")
 	fmt.Fprintf(w, "        }\n")
 
-	// Write the `Get` method for reading instances of this resource unless this is a provider resource.
-	if !r.IsProvider {
+	// Write the `Get` method for reading instances of this resource unless this is a provider resource or a
+	// component.
+	if !r.IsProvider && !r.IsComponent {
 		fmt.Fprintf(w, "        /// <summary>\n")
 		fmt.Fprintf(w, "        /// Get an existing %s resource's state with the given name, ID, and optional extra\n", className)
 		fmt.Fprintf(w, "        /// properties used to qualify the lookup.\n")
@@ -844,7 +858,7 @@ func (mod *modContext) genResource(w io.Writer, r *schema.Resource) error {
 	}
 
 	// Generate the `Get` args type, if any.
-	if r.StateInputs != nil {
+	if r.StateInputs != nil && !r.IsComponent {
 		state := &plainType{
 			mod:                   mod,
 			res:                   r,
@@ -0,0 +1,55 @@
+package dotnet
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pulumi/pulumi/pkg/v2/codegen/internal/test"
+	"github.com/pulumi/pulumi/pkg/v2/codegen/schema"
+)
+
+func TestGenerateComponentResource(t *testing.T) {
+	pkg, err := test.LoadPackage(testdataPath, "component")
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	if !assert.NoError(t, pkg.ImportLanguages(map[string]schema.Language{"csharp": Importer})) {
+		t.FailNow()
+	}
+
+	// Generate the resources directly: generating the whole package would download the package's logo.
+	modules, err := generateModuleContextMap("test", pkg, pkg.Language["csharp"].(CSharpPackageInfo))
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	genResource := func(token string) string {
+		for _, r := range pkg.Resources {
+			if r.Token == token {
+				var buf bytes.Buffer
+				assert.NoError(t, modules[""].genResource(&buf, r))
+				return buf.String()
+			}
+		}
+		t.Fatalf("missing resource %v", token)
+		return ""
+	}
+
+	// Components derive from ComponentResource, are constructed by their provider, and cannot be read.
+	component := genResource("example:index:Component")
+	assert.Contains(t, component, "public partial class Component : Pulumi.ComponentResource")
+	assert.Contains(t, component,
+		"public Component(string name, ComponentArgs? args = null, ComponentResourceOptions? options = null)")
+	assert.Contains(t, component,
+		`: base("example:index:Component", name, args ?? new ComponentArgs(), `+
+			`MakeResourceOptions(options, ""), remote: true)`)
+	assert.NotContains(t, component, "public static Component Get(")
+	assert.NotContains(t, component, "AdditionalSecretOutputs")
+
+	// Custom resources are unaffected.
+	widget := genResource("example:index:Widget")
+	assert.Contains(t, widget, "public partial class Widget : Pulumi.CustomResource")
+	assert.Contains(t, widget, "public static Widget Get(")
+	assert.NotContains(t, widget, "remote: true")
+}
@@ -676,9 +676,12 @@ func (pkg *pkgContext) genResource(w io.Writer, r *schema.Resource) error {
 	printCommentWithDeprecationMessage(w, r.Comment, r.DeprecationMessage, false)
 	fmt.Fprintf(w, "type %s struct {\n", name)
 
-	if r.IsProvider {
+	switch {
+	case r.IsProvider:
 		fmt.Fprintf(w, "\tpulumi.ProviderResourceState\n\n")
-	} else {
+	case r.IsComponent:
+		fmt.Fprintf(w, "\tpulumi.ResourceState\n\n")
+	default:
 		fmt.Fprintf(w, "\tpulumi.CustomResourceState\n\n")
 	}
 	var secretProps []string
@@ -686,7 +689,8 @@ func (pkg *pkgContext) genResource(w io.Writer, r *schema.Resource) error {
 		printCommentWithDeprecationMessage(w, p.Comment, p.DeprecationMessage, true)
 		fmt.Fprintf(w, "\t%s %s `pulumi:\"%s\"`\n", Title(p.Name), pkg.outputType(p.Type, !p.IsRequired), p.Name)
 
-		if p.Secret {
+		// Additional secret outputs are only supported for custom resources.
+		if p.Secret && !r.IsComponent {
 			secretProps = append(secretProps, p.Name)
 		}
 	}
@@ -771,17 +775,21 @@ func (pkg *pkgContext) genResource(w io.Writer, r *schema.Resource) error {
 		fmt.Fprintf(w, "\topts = append(opts, secrets)\n")
 	}
 
-	// Finally make the call to registration.
+	// Finally make the call to registration. Components are constructed by their provider.
 	fmt.Fprintf(w, "\tvar resource %s\n", name)
-	fmt.Fprintf(w, "\terr := ctx.RegisterResource(\"%s\", name, args, &resource, opts...)\n", r.Token)
+	if r.IsComponent {
+		fmt.Fprintf(w, "\terr := ctx.RegisterRemoteComponentResource(\"%s\", name, args, &resource, opts...)\n", r.Token)
+	} else {
+		fmt.Fprintf(w, "\terr := ctx.RegisterResource(\"%s\", name, args, &resource, opts...)\n", r.Token)
+	}
 	fmt.Fprintf(w, "\tif err != nil {\n")
 	fmt.Fprintf(w, "\t\treturn nil, err\n")
 	fmt.Fprintf(w, "\t}\n")
 	fmt.Fprintf(w, "\treturn &resource, nil\n")
 	fmt.Fprintf(w, "}\n\n")
 
-	// Emit a factory function that reads existing instances of this resource.
-	if !r.IsProvider {
+	// Emit a factory function that reads existing instances of this resource. Components cannot be read.
+	if !r.IsProvider && !r.IsComponent {
 		fmt.Fprintf(w, "// Get%[1]s gets an existing %[1]s resource's state with the given name, ID, and optional\n", name)
 		fmt.Fprintf(w, "// state properties that are used to uniquely qualify the lookup (nil if not required).\n")
 		fmt.Fprintf(w, "func Get%s(ctx *pulumi.Context,\n", name)
@@ -1161,7 +1169,7 @@ func generatePackageContextMap(tool string, pkg *schema.Package, goInfo GoPackag
 		pkg.names.add(resourceName(r) + "Args")
 		pkg.names.add(camel(resourceName(r)) + "Args")
 		pkg.names.add("New" + resourceName(r))
-		if !r.IsProvider {
+		if !r.IsProvider && !r.IsComponent {
 			pkg.names.add(resourceName(r) + "State")
 			pkg.names.add(camel(resourceName(r)) + "State")
 			pkg.names.add("Get" + resourceName(r))
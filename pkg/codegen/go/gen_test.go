@@ -4,6 +4,8 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+
+	"github.com/pulumi/pulumi/pkg/v2/codegen/internal/test"
 )
 
 func TestInputUsage(t *testing.T) {
@@ -35,3 +37,28 @@ func TestInputUsage(t *testing.T) {
 			" of `FooInput` via:\n\n\t\t FooArgs{...}\n ",
 		usage)
 }
+
+func TestGenerateComponentResource(t *testing.T) {
+	pkg, err := test.LoadPackage(testdataPath, "component")
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	files, err := GeneratePackage("test", pkg)
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	// Components embed ResourceState, are constructed by their provider, and cannot be read.
+	component := string(files["example/component.go"])
+	assert.Contains(t, component, "type Component struct {\n\tpulumi.ResourceState\n")
+	assert.Contains(t, component,
+		`ctx.RegisterRemoteComponentResource("example:index:Component", name, args, &resource, opts...)`)
+	assert.NotContains(t, component, "func GetComponent(")
+	assert.NotContains(t, component, "AdditionalSecretOutputs")
+
+	// Custom resources are unaffected.
+	widget := string(files["example/widget.go"])
+	assert.Contains(t, widget, "type Widget struct {\n\tpulumi.CustomResourceState\n")
+	assert.Contains(t, widget, "func GetWidget(")
+}
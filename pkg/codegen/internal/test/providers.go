@@ -1,9 +1,11 @@
 package test
 
 import (
+	"encoding/json"
 	"io/ioutil"
 	"path/filepath"
 
+	"github.com/pulumi/pulumi/pkg/v2/codegen/schema"
 	"github.com/pulumi/pulumi/pkg/v2/resource/deploy/deploytest"
 	"github.com/pulumi/pulumi/sdk/v2/go/common/resource/plugin"
 )
@@ -12,6 +14,19 @@ func GetSchema(schemaDirectoryPath, providerName string) ([]byte, error) {
 	return ioutil.ReadFile(filepath.Join(schemaDirectoryPath, providerName+".json"))
 }
 
+// LoadPackage reads and imports the schema for the given provider.
+func LoadPackage(schemaDirectoryPath, providerName string) (*schema.Package, error) {
+	bytes, err := GetSchema(schemaDirectoryPath, providerName)
+	if err != nil {
+		return nil, err
+	}
+	var spec schema.PackageSpec
+	if err = json.Unmarshal(bytes, &spec); err != nil {
+		return nil, err
+	}
+	return schema.ImportSpec(spec, nil)
+}
+
 func AWS(schemaDirectoryPath string) (plugin.Provider, error) {
 	schema, err := GetSchema(schemaDirectoryPath, "aws")
 	if err != nil {
@@ -369,16 +369,19 @@ func (mod *modContext) genResource(w io.Writer, r *schema.Resource) error {
 	printComment(w, codegen.FilterExamples(r.Comment, "typescript"), r.DeprecationMessage, "")
 
 	baseType := "CustomResource"
-	if r.IsProvider {
+	switch {
+	case r.IsProvider:
 		baseType = "ProviderResource"
+	case r.IsComponent:
+		baseType = "ComponentResource"
 	}
 
 	// Begin defining the class.
 	fmt.Fprintf(w, "export class %s extends pulumi.%s {\n", name, baseType)
 
-	// Emit a static factory to read instances of this resource unless this is a provider resource.
+	// Emit a static factory to read instances of this resource unless this is a provider or component resource.
 	stateType := name + "State"
-	if !r.IsProvider {
+	if !r.IsProvider && !r.IsComponent {
 		fmt.Fprintf(w, "    /**\n")
 		fmt.Fprintf(w, "     * Get an existing %s resource's state with the given name, ID, and optional extra\n", name)
 		fmt.Fprintf(w, "     * properties used to qualify the lookup.\n")
@@ -475,8 +478,11 @@ func (mod *modContext) genResource(w io.Writer, r *schema.Resource) error {
 	}
 	argsType := name + "Args"
 	trailingBrace, optionsType := "", "CustomResourceOptions"
-	if r.IsProvider {
+	switch {
+	case r.IsProvider:
 		trailingBrace, optionsType = " {", "ResourceOptions"
+	case r.IsComponent:
+		trailingBrace, optionsType = " {", "ComponentResourceOptions"
 	}
 
 	if r.DeprecationMessage != "" {
@@ -485,7 +491,7 @@ func (mod *modContext) genResource(w io.Writer, r *schema.Resource) error {
 	fmt.Fprintf(w, "    constructor(name: string, args%s: %s, opts?: pulumi.%s)%s\n", argsFlags, argsType,
 		optionsType, trailingBrace)
 
-	if !r.IsProvider {
+	if !r.IsProvider && !r.IsComponent {
 		if r.StateInputs != nil {
 			if r.DeprecationMessage != "" {
 				fmt.Fprintf(w, "    /** @deprecated %s */\n", r.DeprecationMessage)
@@ -570,7 +576,8 @@ func (mod *modContext) genResource(w io.Writer, r *schema.Resource) error {
 			fmt.Fprintf(w, "%sinputs[\"%s\"] = undefined /*out*/;\n", prefix, prop.Name)
 		}
 
-		if prop.Secret {
+		// Additional secret outputs are only supported for custom resources.
+		if prop.Secret && !r.IsComponent {
 			secretProps = append(secretProps, prop.Name)
 		}
 	}
@@ -610,14 +617,19 @@ func (mod *modContext) genResource(w io.Writer, r *schema.Resource) error {
 		fmt.Fprintf(w, "        opts = opts ? pulumi.mergeOptions(opts, secretOpts) : secretOpts;\n")
 	}
 
-	fmt.Fprintf(w, "        super(%s.__pulumiType, name, inputs, opts);\n", name)
+	// Components are constructed by their provider.
+	if r.IsComponent {
+		fmt.Fprintf(w, "        super(%s.__pulumiType, name, inputs, opts, true /*remote*/);\n", name)
+	} else {
+		fmt.Fprintf(w, "        super(%s.__pulumiType, name, inputs, opts);\n", name)
+	}
 
 	// Finish the class.
 	fmt.Fprintf(w, "    }\n")
 	fmt.Fprintf(w, "}\n")
 
 	// Emit the state type for get methods.
-	if r.StateInputs != nil {
+	if r.StateInputs != nil && !r.IsComponent {
 		fmt.Fprintf(w, "\n")
 		mod.genPlainType(w, stateType, r.StateInputs.Comment, r.StateInputs.Properties, true, true, true, 0)
 	}
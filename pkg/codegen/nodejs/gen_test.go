@@ -0,0 +1,35 @@
+package nodejs
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pulumi/pulumi/pkg/v2/codegen/internal/test"
+)
+
+func TestGenerateComponentResource(t *testing.T) {
+	pkg, err := test.LoadPackage(testdataPath, "component")
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	files, err := GeneratePackage("test", pkg, nil)
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	// Components derive from ComponentResource, are constructed by their provider, and cannot be read.
+	component := string(files["component.ts"])
+	assert.Contains(t, component, "export class Component extends pulumi.ComponentResource {")
+	assert.Contains(t, component, "opts?: pulumi.ComponentResourceOptions")
+	assert.Contains(t, component, "super(Component.__pulumiType, name, inputs, opts, true /*remote*/);")
+	assert.NotContains(t, component, "public static get(")
+	assert.NotContains(t, component, "additionalSecretOutputs")
+
+	// Custom resources are unaffected.
+	widget := string(files["widget.ts"])
+	assert.Contains(t, widget, "export class Widget extends pulumi.CustomResource {")
+	assert.Contains(t, widget, "public static get(")
+	assert.Contains(t, widget, "super(Widget.__pulumiType, name, inputs, opts);")
+}
@@ -702,9 +702,14 @@ func (mod *modContext) genResource(res *schema.Resource) (string, error) {
 	// Export only the symbols we want exported.
 	fmt.Fprintf(w, "__all__ = ['%s']\n\n", name)
 
-	baseType := "pulumi.CustomResource"
-	if res.IsProvider {
+	var baseType string
+	switch {
+	case res.IsProvider:
 		baseType = "pulumi.ProviderResource"
+	case res.IsComponent:
+		baseType = "pulumi.ComponentResource"
+	default:
+		baseType = "pulumi.CustomResource"
 	}
 
 	if !res.IsProvider && res.DeprecationMessage != "" && mod.compatibility != kubernetes20 {
@@ -820,7 +825,8 @@ func (mod *modContext) genResource(res *schema.Resource) (string, error) {
 			fmt.Fprintf(w, "            __props__['%s'] = None\n", PyName(prop.Name))
 		}
 
-		if prop.Secret {
+		// Additional secret outputs are only supported for custom resources.
+		if prop.Secret && !res.IsComponent {
 			secretProps = append(secretProps, prop.Name)
 		}
 	}
@@ -862,10 +868,15 @@ func (mod *modContext) genResource(res *schema.Resource) (string, error) {
 	fmt.Fprintf(w, "            '%s',\n", tok)
 	fmt.Fprintf(w, "            resource_name,\n")
 	fmt.Fprintf(w, "            __props__,\n")
-	fmt.Fprintf(w, "            opts)\n")
+	if res.IsComponent {
+		fmt.Fprintf(w, "            opts,\n")
+		fmt.Fprintf(w, "            remote=True)\n")
+	} else {
+		fmt.Fprintf(w, "            opts)\n")
+	}
 	fmt.Fprintf(w, "\n")
 
-	if !res.IsProvider {
+	if !res.IsProvider && !res.IsComponent {
 		fmt.Fprintf(w, "    @staticmethod\n")
 		fmt.Fprintf(w, "    def get(resource_name: str,\n")
 		fmt.Fprintf(w, "            id: pulumi.Input[str],\n")
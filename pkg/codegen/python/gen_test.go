@@ -1,6 +1,12 @@
 package python
 
-import "testing"
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pulumi/pulumi/pkg/v2/codegen/internal/test"
+)
 
 var pathTests = []struct {
 	input    string
@@ -26,3 +32,28 @@ func TestRelPathToRelImport(t *testing.T) {
 		})
 	}
 }
+
+func TestGenerateComponentResource(t *testing.T) {
+	pkg, err := test.LoadPackage(testdataPath, "component")
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	files, err := GeneratePackage("test", pkg, nil)
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	// Components derive from ComponentResource, are constructed by their provider, and cannot be read.
+	component := string(files["pulumi_example/component.py"])
+	assert.Contains(t, component, "class Component(pulumi.ComponentResource):")
+	assert.Contains(t, component, "            opts,\n            remote=True)\n")
+	assert.NotContains(t, component, "def get(")
+	assert.NotContains(t, component, "additional_secret_outputs")
+
+	// Custom resources are unaffected.
+	widget := string(files["pulumi_example/widget.py"])
+	assert.Contains(t, widget, "class Widget(pulumi.CustomResource):")
+	assert.Contains(t, widget, "def get(")
+	assert.NotContains(t, widget, "remote=True")
+}
@@ -255,6 +255,8 @@ type Resource struct {
 	Comment string
 	// IsProvider is true if the resource is a provider resource.
 	IsProvider bool
+	// IsComponent is true if the resource is a component resource that is constructed by its provider.
+	IsComponent bool
 	// InputProperties is the list of the resource's input properties.
 	InputProperties []*Property
 	// Properties is the list of the resource's output properties. This should be a superset of the input properties.
@@ -647,6 +649,8 @@ type ResourceSpec struct {
 	Aliases []AliasSpec `json:"aliases,omitempty"`
 	// DeprecationMessage indicates whether or not the resource is deprecated.
 	DeprecationMessage string `json:"deprecationMessage,omitempty"`
+	// IsComponent indicates whether the resource is a component resource that is constructed by its provider.
+	IsComponent bool `json:"isComponent,omitempty"`
 	// Language specifies additional language-specific data about the resource.
 	Language map[string]json.RawMessage `json:"language,omitempty"`
 }
@@ -1206,6 +1210,7 @@ func bindResource(token string, spec ResourceSpec, types *types) (*Resource, err
 		StateInputs:        stateInputs,
 		Aliases:            aliases,
 		DeprecationMessage: spec.DeprecationMessage,
+		IsComponent:        spec.IsComponent,
 		Language:           language,
 	}, nil
 }
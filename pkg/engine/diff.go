@@ -172,9 +172,46 @@ func GetResourcePropertiesSummary(step StepEventMetadata, indent int) string {
 		}
 	}
 
+	// If the resource is being removed from the stack rather than deleted, say so.
+	if (op == deploy.OpDelete || op == deploy.OpDeleteReplaced) && old != nil && old.State != nil &&
+		old.State.RetainOnDelete {
+		writeWithIndentNoPrefix(&b, indent+1, op, "[retainOnDelete]\n")
+	}
+
+	// If the resource is being replaced because of its replaceOnChanges option, say so.
+	if keys := replaceOnChangesKeys(step); len(keys) > 0 {
+		writeWithIndentNoPrefix(&b, indent+1, op, "[replaceOnChanges: %s]\n", strings.Join(keys, ", "))
+	}
+
 	return b.String()
 }
 
+// replaceOnChangesKeys returns the keys causing the given step's replacement that are named by the resource's
+// replaceOnChanges option.
+func replaceOnChangesKeys(step StepEventMetadata) []string {
+	if step.Op != deploy.OpReplace && step.Op != deploy.OpCreateReplacement {
+		return nil
+	}
+	if step.New == nil || step.New.State == nil || len(step.New.State.ReplaceOnChanges) == 0 {
+		return nil
+	}
+
+	var keys []string
+	for _, k := range step.Keys {
+		for _, p := range step.New.State.ReplaceOnChanges {
+			path, err := resource.ParsePropertyPath(p)
+			if err != nil || len(path) == 0 {
+				continue
+			}
+			if path[0] == string(k) || path[0] == "*" {
+				keys = append(keys, string(k))
+				break
+			}
+		}
+	}
+	return keys
+}
+
 func GetResourcePropertiesDetails(
 	step StepEventMetadata, indent int, planning bool, summary bool, debug bool) string {
 	var b bytes.Buffer
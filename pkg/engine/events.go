@@ -60,6 +60,8 @@ func NewEvent(typ EventType, payload interface{}) Event {
 		_, ok = payload.(ResourceOperationFailedPayload)
 	case PolicyViolationEvent:
 		_, ok = payload.(PolicyViolationEventPayload)
+	case PolicyRemediationEvent:
+		_, ok = payload.(PolicyRemediationEventPayload)
 	default:
 		contract.Failf("unknown event type %v", typ)
 	}
@@ -83,6 +85,7 @@ const (
 	ResourceOutputsEvent    EventType = "resource-outputs"
 	ResourceOperationFailed EventType = "resource-operationfailed"
 	PolicyViolationEvent    EventType = "policy-violation"
+	PolicyRemediationEvent  EventType = "policy-remediation"
 )
 
 func (e Event) Payload() interface{} {
@@ -116,6 +119,19 @@ type PolicyViolationEventPayload struct {
 	Prefix            string
 }
 
+// PolicyRemediationEventPayload is the payload for an event with type `policy-remediation`.
+type PolicyRemediationEventPayload struct {
+	ResourceURN       resource.URN
+	Color             colors.Colorization
+	PolicyName        string
+	PolicyPackName    string
+	PolicyPackVersion string
+	Before            resource.PropertyMap // the resource's inputs before the remediation.
+	After             resource.PropertyMap // the resource's inputs after the remediation.
+	Planning          bool
+	Debug             bool
+}
+
 type StdoutEventPayload struct {
 	Message string
 	Color   colors.Colorization
@@ -131,6 +147,7 @@ type SummaryEventPayload struct {
 	MaybeCorrupt    bool              // true if one or more resources may be corrupt
 	Duration        time.Duration     // the duration of the entire update operation (zero values for previews)
 	ResourceChanges ResourceChanges   // count of changed resources, useful for reporting
+	Resources       []ResourceSummary // the operation, duration, and failure of each changed resource
 	PolicyPacks     map[string]string // {policy-pack: version} for each policy pack applied
 }
 
@@ -523,8 +540,8 @@ func (e *eventEmitter) previewSummaryEvent(resourceChanges ResourceChanges, poli
 	})
 }
 
-func (e *eventEmitter) updateSummaryEvent(maybeCorrupt bool,
-	duration time.Duration, resourceChanges ResourceChanges, policyPacks map[string]string) {
+func (e *eventEmitter) updateSummaryEvent(maybeCorrupt bool, duration time.Duration,
+	resourceChanges ResourceChanges, resources []ResourceSummary, policyPacks map[string]string) {
 	contract.Requiref(e != nil, "e", "!= nil")
 
 	e.ch <- NewEvent(SummaryEvent, SummaryEventPayload{
@@ -532,6 +549,7 @@ func (e *eventEmitter) updateSummaryEvent(maybeCorrupt bool,
 		MaybeCorrupt:    maybeCorrupt,
 		Duration:        duration,
 		ResourceChanges: resourceChanges,
+		Resources:       resources,
 		PolicyPacks:     policyPacks,
 	})
 }
@@ -576,6 +594,24 @@ func (e *eventEmitter) policyViolationEvent(urn resource.URN, d plugin.AnalyzeDi
 	})
 }
 
+func (e *eventEmitter) policyRemediationEvent(urn resource.URN, t plugin.Remediation,
+	before resource.PropertyMap, after resource.PropertyMap, planning bool, debug bool) {
+
+	contract.Requiref(e != nil, "e", "!= nil")
+
+	e.ch <- NewEvent(PolicyRemediationEvent, PolicyRemediationEventPayload{
+		ResourceURN:       urn,
+		Color:             colors.Raw,
+		PolicyName:        t.PolicyName,
+		PolicyPackName:    t.PolicyPackName,
+		PolicyPackVersion: t.PolicyPackVersion,
+		Before:            filterPropertyMap(before, debug),
+		After:             filterPropertyMap(after, debug),
+		Planning:          planning,
+		Debug:             debug,
+	})
+}
+
 func diagEvent(e *eventEmitter, d *diag.Diag, prefix, msg string, sev diag.Severity,
 	ephemeral bool) {
 	contract.Requiref(e != nil, "e", "!= nil")
@@ -0,0 +1,116 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package engine
+
+import (
+	"github.com/pulumi/pulumi/pkg/v2/resource/deploy"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/resource"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/resource/plugin"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/util/contract"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/util/logging"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/util/result"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/workspace"
+)
+
+// Import adopts the given existing cloud resources into the stack's checkpoint without running the stack's program.
+func Import(u UpdateInfo, ctx *Context, opts UpdateOptions, imports []deploy.Import,
+	dryRun bool) (ResourceChanges, result.Result) {
+
+	contract.Require(u != nil, "u")
+	contract.Require(ctx != nil, "ctx")
+
+	defer func() { ctx.Events <- cancelEvent() }()
+
+	info, err := newPlanContext(u, "import", ctx.ParentSpan)
+	if err != nil {
+		return nil, result.FromError(err)
+	}
+	defer info.Close()
+
+	emitter, err := makeEventEmitter(ctx.Events, u)
+	if err != nil {
+		return nil, result.FromError(err)
+	}
+	defer emitter.Close()
+
+	// Refuse to import over resources that are already managed by the stack.
+	target, proj := u.GetTarget(), u.GetProject()
+	urns := deploy.ImportURNs(target, proj.Name, imports)
+	existing := make(map[resource.URN]bool)
+	if target.Snapshot != nil {
+		for _, res := range target.Snapshot.Resources {
+			if !res.Delete {
+				existing[res.URN] = true
+			}
+		}
+		for _, urn := range urns {
+			if existing[urn] {
+				return nil, result.Errorf("resource '%v' already exists in the stack", urn)
+			}
+		}
+	}
+
+	// Only the imported resources (and the root stack resource, if it does not yet exist) are targeted. This ensures
+	// that no other resource in the stack is updated or deleted even though the program is not run. An existing root
+	// stack resource is not targeted so that it is same'd rather than updated.
+	opts.UpdateTargets = urns
+	if rootURN := resource.DefaultRootStackURN(target.Name, proj.Name); !existing[rootURN] {
+		opts.UpdateTargets = append(opts.UpdateTargets, rootURN)
+	}
+	opts.DestroyTargets, opts.ReplaceTargets, opts.Excludes, opts.Refresh = nil, nil, nil, false
+
+	_, changes, res := update(ctx, info, planOptions{
+		UpdateOptions: opts,
+		SourceFunc:    newImportSourceFunc(imports),
+		Events:        emitter,
+		Diag:          newEventSink(emitter, false),
+		StatusDiag:    newEventSink(emitter, true),
+	}, dryRun)
+	return changes, res
+}
+
+func newImportSourceFunc(imports []deploy.Import) planSourceFunc {
+	return func(client deploy.BackendClient, opts planOptions, proj *workspace.Project, pwd, main string,
+		target *deploy.Target, plugctx *plugin.Context, dryRun bool) (deploy.Source, error) {
+
+		// Like Refresh, we do not run the program, so we need only the plugins described in the snapshot plus the
+		// providers for the resources we are importing.
+		plugins, err := gatherPluginsFromSnapshot(plugctx, target)
+		if err != nil {
+			return nil, err
+		}
+		for _, imp := range imports {
+			plugins.Add(workspace.PluginInfo{
+				Name:    imp.Type.Package().String(),
+				Kind:    workspace.ResourcePlugin,
+				Version: imp.Version,
+			})
+		}
+
+		if err := ensurePluginsAreInstalled(plugins, pwd); isFatalInstallError(err) {
+			return nil, err
+		} else if err != nil {
+			logging.V(7).Infof("newImportSource(): failed to install missing plugins: %v", err)
+		}
+
+		// We don't need the language plugin, since import doesn't run code, so we will leave that out.
+		if err := ensurePluginsAreLoaded(plugctx, plugins, plugin.AnalyzerPlugins); err != nil {
+			return nil, err
+		}
+
+		defaultProviderVersions := computeDefaultProviderPlugins(newPluginSet(), plugins)
+		return deploy.NewImportSource(proj.Name, target, imports, defaultProviderVersions), nil
+	}
+}
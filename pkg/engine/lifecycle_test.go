@@ -22,7 +22,9 @@ import (
 	"strconv"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/blang/semver"
 	"github.com/mitchellh/copystructure"
@@ -101,7 +103,8 @@ func (j *Journal) End(step deploy.Step, success bool) error {
 	}
 }
 
-func (j *Journal) RegisterResourceOutputs(step deploy.Step) error {
+func (j *Journal) RegisterResourceOutputs(step deploy.Step, outputs resource.PropertyMap) error {
+	step.New().Outputs = outputs
 	select {
 	case j.events <- JournalEntry{Kind: JournalEntryOutputs, Step: step}:
 		return nil
@@ -354,6 +357,7 @@ type TestPlan struct {
 	Decrypter     config.Decrypter
 	BackendClient deploy.BackendClient
 	Options       UpdateOptions
+	Parallelism   *workspace.ProjectParallelism
 	Steps         []TestStep
 }
 
@@ -391,8 +395,9 @@ func (p *TestPlan) GetProject() workspace.Project {
 	_, projectName, runtime := p.getNames()
 
 	return workspace.Project{
-		Name:    projectName,
-		Runtime: workspace.NewProjectRuntimeInfo(runtime, nil),
+		Name:        projectName,
+		Runtime:     workspace.NewProjectRuntimeInfo(runtime, nil),
+		Parallelism: p.Parallelism,
 	}
 }
 
@@ -2328,6 +2333,387 @@ func TestPreviewWithPendingOperations(t *testing.T) {
 	assert.EqualError(t, res.Error(), deploy.PlanPendingOperationsError{}.Error())
 }
 
+// Tests that an update's summary event records the operation, duration, and failure of each changed resource.
+func TestUpdateResourceSummaries(t *testing.T) {
+	loaders := []*deploytest.ProviderLoader{
+		deploytest.NewProviderLoader("pkgA", semver.MustParse("1.0.0"), func() (plugin.Provider, error) {
+			return &deploytest.Provider{
+				CreateF: func(urn resource.URN, news resource.PropertyMap,
+					timeout float64) (resource.ID, resource.PropertyMap, resource.Status, error) {
+
+					if urn.Name() == "resB" {
+						return "", nil, resource.StatusOK, errors.New("create failed")
+					}
+					return "created-id", news, resource.StatusOK, nil
+				},
+			}, nil
+		}),
+	}
+
+	program := deploytest.NewLanguageRuntime(func(_ plugin.RunInfo, mon *deploytest.ResourceMonitor) error {
+		_, _, _, err := mon.RegisterResource("pkgA:m:typA", "resA", true)
+		assert.NoError(t, err)
+		_, _, _, err = mon.RegisterResource("pkgA:m:typA", "resB", true)
+		assert.Error(t, err)
+		return nil
+	})
+
+	host := deploytest.NewPluginHost(nil, nil, program, loaders...)
+	p := &TestPlan{Options: UpdateOptions{host: host}}
+
+	resA, resB := p.NewURN("pkgA:m:typA", "resA", ""), p.NewURN("pkgA:m:typA", "resB", "")
+	p.Steps = []TestStep{{
+		Op:            Update,
+		ExpectFailure: true,
+		SkipPreview:   true,
+		Validate: func(project workspace.Project, target deploy.Target, j *Journal,
+			evts []Event, res result.Result) result.Result {
+
+			var summaries []ResourceSummary
+			for _, e := range evts {
+				if e.Type == SummaryEvent {
+					summaries = e.Payload().(SummaryEventPayload).Resources
+				}
+			}
+
+			if assert.Len(t, summaries, 2) {
+				assert.Equal(t, resA, summaries[0].URN)
+				assert.Equal(t, deploy.OpCreate, summaries[0].Op)
+				assert.Equal(t, "", summaries[0].Error)
+				assert.Equal(t, resB, summaries[1].URN)
+				assert.Equal(t, tokens.Type("pkgA:m:typA"), summaries[1].Type)
+				assert.Equal(t, deploy.OpCreate, summaries[1].Op)
+				assert.Equal(t, "create failed", summaries[1].Error)
+			}
+			return res
+		},
+	}}
+	p.Run(t, nil)
+}
+
+// Tests that an update with ContinueOnError set keeps executing steps that do not depend on a failed step.
+func TestUpdateContinueOnError(t *testing.T) {
+	loaders := []*deploytest.ProviderLoader{
+		deploytest.NewProviderLoader("pkgA", semver.MustParse("1.0.0"), func() (plugin.Provider, error) {
+			return &deploytest.Provider{
+				CreateF: func(urn resource.URN, news resource.PropertyMap,
+					timeout float64) (resource.ID, resource.PropertyMap, resource.Status, error) {
+
+					if urn.Name() == "resA" {
+						return "", nil, resource.StatusOK, errors.New("create failed")
+					}
+					return "created-id", news, resource.StatusOK, nil
+				},
+			}, nil
+		}),
+	}
+
+	program := deploytest.NewLanguageRuntime(func(_ plugin.RunInfo, mon *deploytest.ResourceMonitor) error {
+		// The failed registration is reported to the program, which therefore never registers resA's dependents.
+		_, _, _, errA := mon.RegisterResource("pkgA:m:typA", "resA", true)
+		assert.Error(t, errA)
+
+		_, _, _, err := mon.RegisterResource("pkgA:m:typA", "resB", true)
+		assert.NoError(t, err)
+		return errA
+	})
+
+	host := deploytest.NewPluginHost(nil, nil, program, loaders...)
+	p := &TestPlan{Options: UpdateOptions{host: host, ContinueOnError: true}}
+
+	resB := p.NewURN("pkgA:m:typA", "resB", "")
+	p.Steps = []TestStep{{
+		Op:            Update,
+		ExpectFailure: true,
+		SkipPreview:   true,
+		Validate: func(project workspace.Project, target deploy.Target, j *Journal,
+			evts []Event, res result.Result) result.Result {
+
+			createdB := false
+			for _, entry := range j.Entries {
+				if entry.Kind == JournalEntrySuccess && entry.Step.URN() == resB {
+					createdB = true
+				}
+			}
+			assert.True(t, createdB)
+			return res
+		},
+	}}
+	snap := p.Run(t, nil)
+
+	var names []string
+	for _, r := range snap.Resources {
+		names = append(names, string(r.URN.Name()))
+	}
+	assert.Equal(t, []string{"default", "resB"}, names)
+}
+
+// Tests that an update with ContinueOnError set finishes the rest of the update when the program fails because of a
+// failed registration: steps that are underway run to completion and resources that are no longer registered are
+// deleted.
+func TestUpdateContinueOnErrorProgramFailure(t *testing.T) {
+	startedB, programDone := make(chan bool), make(chan bool)
+	loaders := []*deploytest.ProviderLoader{
+		deploytest.NewProviderLoader("pkgA", semver.MustParse("1.0.0"), func() (plugin.Provider, error) {
+			return &deploytest.Provider{
+				CreateF: func(urn resource.URN, news resource.PropertyMap,
+					timeout float64) (resource.ID, resource.PropertyMap, resource.Status, error) {
+
+					switch urn.Name() {
+					case "resA":
+						return "", nil, resource.StatusOK, errors.New("create failed")
+					case "resB":
+						// Don't finish creating resB until the program has exited.
+						close(startedB)
+						<-programDone
+					}
+					return "created-id", news, resource.StatusOK, nil
+				},
+			}, nil
+		}),
+	}
+
+	failing := false
+	program := deploytest.NewLanguageRuntime(func(_ plugin.RunInfo, mon *deploytest.ResourceMonitor) error {
+		if !failing {
+			_, _, _, err := mon.RegisterResource("pkgA:m:typA", "resC", true)
+			assert.NoError(t, err)
+			return nil
+		}
+
+		// The program exits without waiting for resB, and stops registering resC.
+		go func() {
+			_, _, _, _ = mon.RegisterResource("pkgA:m:typA", "resB", true)
+		}()
+		<-startedB
+
+		_, _, _, errA := mon.RegisterResource("pkgA:m:typA", "resA", true)
+		assert.Error(t, errA)
+		close(programDone)
+		return errA
+	})
+
+	host := deploytest.NewPluginHost(nil, nil, program, loaders...)
+	p := &TestPlan{Options: UpdateOptions{host: host, ContinueOnError: true, Parallel: 10}}
+	resB, resC := p.NewURN("pkgA:m:typA", "resB", ""), p.NewURN("pkgA:m:typA", "resC", "")
+
+	project := p.GetProject()
+	snap, res := TestOp(Update).Run(project, p.GetTarget(nil), p.Options, false, p.BackendClient, nil)
+	assert.Nil(t, res)
+
+	failing = true
+	snap, res = TestOp(Update).Run(project, p.GetTarget(snap), p.Options, false, p.BackendClient,
+		func(_ workspace.Project, _ deploy.Target, j *Journal, _ []Event, res result.Result) result.Result {
+			createdB, deletedC := false, false
+			for _, entry := range j.Entries {
+				if entry.Kind != JournalEntrySuccess {
+					continue
+				}
+				switch {
+				case entry.Step.URN() == resB && entry.Step.Op() == deploy.OpCreate:
+					createdB = true
+				case entry.Step.URN() == resC && entry.Step.Op() == deploy.OpDelete:
+					deletedC = true
+				}
+			}
+			assert.True(t, createdB)
+			assert.True(t, deletedC)
+			return res
+		})
+	assert.NotNil(t, res)
+
+	var names []string
+	for _, r := range snap.Resources {
+		names = append(names, string(r.URN.Name()))
+	}
+	assert.Equal(t, []string{"default", "resB"}, names)
+}
+
+// failingSnapshotManager is a SnapshotManager that fails to record the completion of any step on the given resource.
+type failingSnapshotManager struct {
+	SnapshotManager
+	urn resource.URN
+}
+
+func (m *failingSnapshotManager) BeginMutation(step deploy.Step) (SnapshotMutation, error) {
+	mutation, err := m.SnapshotManager.BeginMutation(step)
+	if err != nil {
+		return nil, err
+	}
+	return &failingSnapshotMutation{SnapshotMutation: mutation, urn: m.urn}, nil
+}
+
+type failingSnapshotMutation struct {
+	SnapshotMutation
+	urn resource.URN
+}
+
+func (m *failingSnapshotMutation) End(step deploy.Step, successful bool) error {
+	if err := m.SnapshotMutation.End(step, successful); err != nil {
+		return err
+	}
+	if step.URN() == m.urn {
+		return errors.New("failed to save snapshot")
+	}
+	return nil
+}
+
+// Tests that an update with ContinueOnError set still stops when the snapshot cannot be saved, as only failures to
+// apply a step may be continued past.
+func TestUpdateContinueOnErrorSnapshotFailure(t *testing.T) {
+	loaders := []*deploytest.ProviderLoader{
+		deploytest.NewProviderLoader("pkgA", semver.MustParse("1.0.0"), func() (plugin.Provider, error) {
+			return &deploytest.Provider{}, nil
+		}),
+	}
+
+	program := deploytest.NewLanguageRuntime(func(_ plugin.RunInfo, mon *deploytest.ResourceMonitor) error {
+		_, _, _, errA := mon.RegisterResource("pkgA:m:typA", "resA", true)
+		assert.Error(t, errA)
+
+		// The update has been cancelled, so this registration must not be carried out.
+		_, _, _, _ = mon.RegisterResource("pkgA:m:typA", "resB", true)
+		return errA
+	})
+
+	host := deploytest.NewPluginHost(nil, nil, program, loaders...)
+	p := &TestPlan{Options: UpdateOptions{host: host, ContinueOnError: true}}
+
+	resA, resB := p.NewURN("pkgA:m:typA", "resA", ""), p.NewURN("pkgA:m:typA", "resB", "")
+	p.Steps = []TestStep{{
+		Op: TestOp(func(info UpdateInfo, ctx *Context, opts UpdateOptions,
+			dryRun bool) (ResourceChanges, result.Result) {
+
+			ctx.SnapshotManager = &failingSnapshotManager{SnapshotManager: ctx.SnapshotManager, urn: resA}
+			return Update(info, ctx, opts, dryRun)
+		}),
+		ExpectFailure: true,
+		SkipPreview:   true,
+		Validate: func(project workspace.Project, target deploy.Target, j *Journal,
+			evts []Event, res result.Result) result.Result {
+
+			for _, entry := range j.Entries {
+				assert.NotEqual(t, resB, entry.Step.URN())
+			}
+			return res
+		},
+	}}
+	p.Run(t, nil)
+}
+
+// Tests that a project's per-provider parallelism limit bounds the number of concurrent operations on that provider's
+// resources, while the resources of other providers are unaffected.
+func TestProviderParallelismLimit(t *testing.T) {
+	var lock sync.Mutex
+	running, maxRunning := map[tokens.Package]int{}, map[tokens.Package]int{}
+	create := func(urn resource.URN, news resource.PropertyMap,
+		timeout float64) (resource.ID, resource.PropertyMap, resource.Status, error) {
+
+		pkg := urn.Type().Package()
+		lock.Lock()
+		running[pkg]++
+		if running[pkg] > maxRunning[pkg] {
+			maxRunning[pkg] = running[pkg]
+		}
+		lock.Unlock()
+
+		time.Sleep(10 * time.Millisecond)
+
+		lock.Lock()
+		running[pkg]--
+		lock.Unlock()
+		return "created-id", news, resource.StatusOK, nil
+	}
+
+	loaders := []*deploytest.ProviderLoader{
+		deploytest.NewProviderLoader("pkgA", semver.MustParse("1.0.0"), func() (plugin.Provider, error) {
+			return &deploytest.Provider{CreateF: create}, nil
+		}),
+		deploytest.NewProviderLoader("pkgB", semver.MustParse("1.0.0"), func() (plugin.Provider, error) {
+			return &deploytest.Provider{CreateF: create}, nil
+		}),
+	}
+
+	const resourcesPerPackage = 5
+	program := deploytest.NewLanguageRuntime(func(_ plugin.RunInfo, mon *deploytest.ResourceMonitor) error {
+		var wg sync.WaitGroup
+		for _, typ := range []tokens.Type{"pkgA:m:typA", "pkgB:m:typB"} {
+			for i := 0; i < resourcesPerPackage; i++ {
+				wg.Add(1)
+				go func(typ tokens.Type, name string) {
+					defer wg.Done()
+					_, _, _, err := mon.RegisterResource(typ, name, true)
+					assert.NoError(t, err)
+				}(typ, fmt.Sprintf("%s-%d", typ.Name(), i))
+			}
+		}
+		wg.Wait()
+		return nil
+	})
+
+	host := deploytest.NewPluginHost(nil, nil, program, loaders...)
+	p := &TestPlan{
+		Options:     UpdateOptions{host: host, Parallel: 10},
+		Parallelism: &workspace.ProjectParallelism{Providers: map[string]int{"pkgA": 1}},
+		Steps:       []TestStep{{Op: Update, SkipPreview: true}},
+	}
+	snap := p.Run(t, nil)
+
+	// Each package has a default provider in addition to its resources.
+	assert.Len(t, snap.Resources, 2*(resourcesPerPackage+1))
+	assert.Equal(t, 1, maxRunning["pkgA"])
+	assert.Equal(t, 0, running["pkgA"]+running["pkgB"])
+}
+
+// Tests that a destroy with ContinueOnError set skips only the deletes of resources on which a failed delete depends
+// and of the ancestors of a failed delete.
+func TestDestroyContinueOnError(t *testing.T) {
+	loaders := []*deploytest.ProviderLoader{
+		deploytest.NewProviderLoader("pkgA", semver.MustParse("1.0.0"), func() (plugin.Provider, error) {
+			return &deploytest.Provider{
+				DeleteF: func(urn resource.URN, id resource.ID, olds resource.PropertyMap,
+					timeout float64) (resource.Status, error) {
+
+					if urn.Name() == "resB" || urn.Name() == "resE" {
+						return resource.StatusOK, errors.New("delete failed")
+					}
+					return resource.StatusOK, nil
+				},
+			}, nil
+		}),
+	}
+
+	host := deploytest.NewPluginHost(nil, nil, nil, loaders...)
+	p := &TestPlan{Options: UpdateOptions{host: host, ContinueOnError: true}}
+
+	resA, resB, resC := p.NewURN("pkgA:m:typA", "resA", ""), p.NewURN("pkgA:m:typA", "resB", ""),
+		p.NewURN("pkgA:m:typA", "resC", "")
+
+	// resE fails to delete, so its parent resD must not be deleted either, nor may resF, on which resD depends.
+	resD, resF := p.NewURN("pkgA:m:typA", "resD", ""), p.NewURN("pkgA:m:typA", "resF", "")
+	resE := p.NewURN("pkgA:m:typA", "resE", resD)
+	old := &deploy.Snapshot{
+		Resources: []*resource.State{
+			{Type: resA.Type(), URN: resA, Custom: true, ID: "a"},
+			{Type: resB.Type(), URN: resB, Custom: true, ID: "b", Dependencies: []resource.URN{resA}},
+			{Type: resC.Type(), URN: resC, Custom: true, ID: "c"},
+			{Type: resF.Type(), URN: resF, Custom: true, ID: "f"},
+			{Type: resD.Type(), URN: resD, Custom: true, ID: "d", Dependencies: []resource.URN{resF}},
+			{Type: resE.Type(), URN: resE, Custom: true, ID: "e", Parent: resD},
+		},
+	}
+
+	p.Steps = []TestStep{{Op: Destroy, ExpectFailure: true, SkipPreview: true}}
+	snap := p.Run(t, old)
+	assert.NoError(t, snap.VerifyIntegrity())
+
+	var names []string
+	for _, r := range snap.Resources {
+		names = append(names, string(r.URN.Name()))
+	}
+	assert.Equal(t, []string{"default", "resA", "resB", "resF", "resD", "resE"}, names)
+}
+
 // Tests that a failed partial update causes the engine to persist the resource's old inputs and new outputs.
 func TestUpdatePartialFailure(t *testing.T) {
 	loaders := []*deploytest.ProviderLoader{
@@ -4501,6 +4887,82 @@ func TestImportUpdatedID(t *testing.T) {
 	}
 }
 
+func TestImportIntoExistingStack(t *testing.T) {
+	p := &TestPlan{}
+
+	loaders := []*deploytest.ProviderLoader{
+		deploytest.NewProviderLoader("pkgA", semver.MustParse("1.0.0"), func() (plugin.Provider, error) {
+			return &deploytest.Provider{
+				CreateF: func(urn resource.URN, news resource.PropertyMap,
+					timeout float64) (resource.ID, resource.PropertyMap, resource.Status, error) {
+					return "created-id", news, resource.StatusOK, nil
+				},
+				ReadF: func(urn resource.URN, id resource.ID,
+					inputs, state resource.PropertyMap) (plugin.ReadResult, resource.Status, error) {
+					return plugin.ReadResult{
+						Inputs:  resource.PropertyMap{"foo": resource.NewStringProperty("bar")},
+						Outputs: resource.PropertyMap{"foo": resource.NewStringProperty("bar")},
+					}, resource.StatusOK, nil
+				},
+			}, nil
+		}),
+	}
+
+	stackName, projectName, _ := p.getNames()
+	rootURN := resource.DefaultRootStackURN(stackName, projectName)
+	program := deploytest.NewLanguageRuntime(func(_ plugin.RunInfo, monitor *deploytest.ResourceMonitor) error {
+		root, _, _, err := monitor.RegisterResource(resource.RootStackType, rootURN.Name().String(), false)
+		assert.NoError(t, err)
+		_, _, _, err = monitor.RegisterResource("pkgA:m:typA", "resA", true, deploytest.ResourceOptions{
+			Parent: root,
+		})
+		assert.NoError(t, err)
+		return nil
+	})
+	p.Options.host = deploytest.NewPluginHost(nil, nil, program, loaders...)
+
+	// Create a stack that already has a root stack resource, a default provider, and a custom resource.
+	p.Steps = []TestStep{{Op: Update}}
+	snap := p.Run(t, nil)
+	assert.Len(t, snap.Resources, 3)
+
+	provURN := p.NewProviderURN("pkgA", "default", "")
+	resAURN := p.NewURN("pkgA:m:typA", "resA", "")
+	resBURN := p.NewURN("pkgA:m:typA", "resB", "")
+
+	// Import a new resource into the stack. Nothing that already exists should be touched.
+	imports := []deploy.Import{{Type: "pkgA:m:typA", Name: "resB", ID: "imported-id"}}
+	importOp := TestOp(func(info UpdateInfo, ctx *Context, opts UpdateOptions,
+		dryRun bool) (ResourceChanges, result.Result) {
+		return Import(info, ctx, opts, imports, dryRun)
+	})
+	p.Steps = []TestStep{{
+		Op: importOp,
+		Validate: func(project workspace.Project, target deploy.Target, j *Journal,
+			_ []Event, res result.Result) result.Result {
+
+			for _, entry := range j.Entries {
+				switch urn := entry.Step.URN(); urn {
+				case resBURN:
+					assert.Equal(t, deploy.OpImport, entry.Step.Op())
+				case rootURN, provURN:
+					assert.Equal(t, deploy.OpSame, entry.Step.Op())
+				default:
+					t.Fatalf("unexpected resource %v", urn)
+				}
+			}
+			return res
+		},
+	}}
+	snap = p.Run(t, snap)
+
+	urns := make(map[resource.URN]bool)
+	for _, res := range snap.Resources {
+		urns[res.URN] = true
+	}
+	assert.Equal(t, map[resource.URN]bool{rootURN: true, provURN: true, resAURN: true, resBURN: true}, urns)
+}
+
 func TestDestroyTarget(t *testing.T) {
 	// Try refreshing a stack with combinations of the above resources as target to destroy.
 	subsets := combinations.All(complexTestDependencyGraphNames)
@@ -4756,6 +5218,122 @@ func updateInvalidTarget(t *testing.T) {
 	p.Run(t, old)
 }
 
+func TestUpdateTargetPatternsAndExcludes(t *testing.T) {
+	loaders := []*deploytest.ProviderLoader{
+		deploytest.NewProviderLoader("pkgA", semver.MustParse("1.0.0"), func() (plugin.Provider, error) {
+			return &deploytest.Provider{
+				DiffF: func(urn resource.URN, id resource.ID, olds, news resource.PropertyMap,
+					ignoreChanges []string) (plugin.DiffResult, error) {
+
+					// all resources will change.
+					return plugin.DiffResult{Changes: plugin.DiffSome}, nil
+				},
+			}, nil
+		}),
+	}
+
+	program := deploytest.NewLanguageRuntime(func(_ plugin.RunInfo, monitor *deploytest.ResourceMonitor) error {
+		for _, name := range []string{"web-a", "web-b", "db"} {
+			_, _, _, err := monitor.RegisterResource("pkgA:m:typA", name, true)
+			assert.NoError(t, err)
+		}
+		return nil
+	})
+	host := deploytest.NewPluginHost(nil, nil, program, loaders...)
+
+	p := &TestPlan{
+		Options: UpdateOptions{host: host},
+	}
+	p.Steps = []TestStep{{Op: Update}}
+	snap := p.Run(t, nil)
+
+	webA := p.NewURN("pkgA:m:typA", "web-a", "")
+	webB := p.NewURN("pkgA:m:typA", "web-b", "")
+
+	// Target every web resource except web-b.
+	p.Options.UpdateTargets = []resource.URN{p.NewURN("pkgA:m:typA", "web-*", "")}
+	p.Options.Excludes = []resource.URN{webB}
+	p.Steps = []TestStep{{
+		Op: Update,
+		Validate: func(project workspace.Project, target deploy.Target, j *Journal,
+			evts []Event, res result.Result) result.Result {
+
+			assert.Nil(t, res)
+
+			updated := make(map[resource.URN]bool)
+			for _, entry := range j.Entries {
+				if entry.Step.Op() == deploy.OpUpdate {
+					updated[entry.Step.URN()] = true
+				}
+			}
+			assert.Equal(t, map[resource.URN]bool{webA: true}, updated)
+			return res
+		},
+	}}
+	snap = p.Run(t, snap)
+
+	// A pattern that matches nothing is an error.
+	p.Options.UpdateTargets = []resource.URN{p.NewURN("pkgA:m:typA", "api-*", "")}
+	p.Options.Excludes = nil
+	p.Steps = []TestStep{{Op: Update, ExpectFailure: true}}
+	p.Run(t, snap)
+}
+
+// Tests that a destroy with exclusions but no targets destroys every resource that is neither excluded nor needed by an
+// excluded resource.
+func TestDestroyExcludes(t *testing.T) {
+	loaders := []*deploytest.ProviderLoader{
+		deploytest.NewProviderLoader("pkgA", semver.MustParse("1.0.0"), func() (plugin.Provider, error) {
+			return &deploytest.Provider{}, nil
+		}),
+	}
+
+	host := deploytest.NewPluginHost(nil, nil, nil, loaders...)
+	p := &TestPlan{Options: UpdateOptions{host: host}}
+
+	// resB depends on resA and resC is parented to resP, so excluding resB and resC keeps resA and resP as well. resE
+	// depends on resB, which does not prevent it from being destroyed.
+	resA, resB := p.NewURN("pkgA:m:typA", "resA", ""), p.NewURN("pkgA:m:typA", "resB", "")
+	resP, resD := p.NewURN("pkgA:m:typA", "resP", ""), p.NewURN("pkgA:m:typA", "resD", "")
+	resC, resE := p.NewURN("pkgA:m:typA", "resC", resP), p.NewURN("pkgA:m:typA", "resE", "")
+	old := &deploy.Snapshot{
+		Resources: []*resource.State{
+			{Type: resA.Type(), URN: resA, Custom: true, ID: "a"},
+			{Type: resB.Type(), URN: resB, Custom: true, ID: "b", Dependencies: []resource.URN{resA}},
+			{Type: resP.Type(), URN: resP, Custom: true, ID: "p"},
+			{Type: resC.Type(), URN: resC, Custom: true, ID: "c", Parent: resP},
+			{Type: resD.Type(), URN: resD, Custom: true, ID: "d"},
+			{Type: resE.Type(), URN: resE, Custom: true, ID: "e", Dependencies: []resource.URN{resB}},
+		},
+	}
+
+	p.Options.Excludes = []resource.URN{resB, resC}
+	p.Steps = []TestStep{{
+		Op: Destroy,
+		Validate: func(project workspace.Project, target deploy.Target, j *Journal,
+			evts []Event, res result.Result) result.Result {
+
+			assert.Nil(t, res)
+
+			deleted := make(map[resource.URN]bool)
+			for _, entry := range j.Entries {
+				if entry.Kind == JournalEntrySuccess && entry.Step.Op() == deploy.OpDelete {
+					deleted[entry.Step.URN()] = true
+				}
+			}
+			assert.Equal(t, map[resource.URN]bool{resD: true, resE: true}, deleted)
+			return res
+		},
+	}}
+	snap := p.Run(t, old)
+
+	var names []string
+	for _, r := range snap.Resources {
+		names = append(names, string(r.URN.Name()))
+	}
+	assert.Equal(t, []string{"default", "resA", "resB", "resP", "resC"}, names)
+}
+
 func TestCreateDuringTargetedUpdate_CreateMentionedAsTarget(t *testing.T) {
 	loaders := []*deploytest.ProviderLoader{
 		deploytest.NewProviderLoader("pkgA", semver.MustParse("1.0.0"), func() (plugin.Provider, error) {
@@ -5936,3 +6514,393 @@ func TestProviderInheritanceGolangLifecycle(t *testing.T) {
 	}
 	p.Run(t, nil)
 }
+
+func TestRetainOnDelete(t *testing.T) {
+	p := &TestPlan{}
+
+	loaders := []*deploytest.ProviderLoader{
+		deploytest.NewProviderLoader("pkgA", semver.MustParse("1.0.0"), func() (plugin.Provider, error) {
+			return &deploytest.Provider{
+				DiffF: func(urn resource.URN, id resource.ID,
+					olds, news resource.PropertyMap, ignoreChanges []string) (plugin.DiffResult, error) {
+
+					if !olds["foo"].DeepEquals(news["foo"]) {
+						return plugin.DiffResult{ReplaceKeys: []resource.PropertyKey{"foo"}}, nil
+					}
+					return plugin.DiffResult{}, nil
+				},
+				DeleteF: func(urn resource.URN, id resource.ID, olds resource.PropertyMap,
+					timeout float64) (resource.Status, error) {
+
+					assert.Fail(t, "Delete was called")
+					return resource.StatusOK, nil
+				},
+			}, nil
+		}),
+	}
+
+	inputs := resource.PropertyMap{"foo": resource.NewStringProperty("bar")}
+	createResource := true
+	program := deploytest.NewLanguageRuntime(func(_ plugin.RunInfo, monitor *deploytest.ResourceMonitor) error {
+		if createResource {
+			_, _, _, err := monitor.RegisterResource("pkgA:m:typA", "resA", true, deploytest.ResourceOptions{
+				Inputs:         inputs,
+				RetainOnDelete: true,
+			})
+			assert.NoError(t, err)
+		}
+		return nil
+	})
+
+	p.Options.host = deploytest.NewPluginHost(nil, nil, program, loaders...)
+	p.Steps = []TestStep{{Op: Update}}
+	snap := p.Run(t, nil)
+	assert.Len(t, snap.Resources, 2)
+	assert.True(t, snap.Resources[1].RetainOnDelete)
+
+	// Replace the resource. The original should be dropped from the stack without being deleted.
+	inputs = resource.PropertyMap{"foo": resource.NewStringProperty("baz")}
+	snap = p.Run(t, snap)
+	assert.Len(t, snap.Resources, 2)
+
+	// Remove the resource from the program. It should be dropped from the stack without being deleted. The default
+	// provider is no longer referenced, so it is dropped as well.
+	createResource = false
+	p.Steps = []TestStep{{
+		Op: Update,
+		Validate: func(project workspace.Project, target deploy.Target, j *Journal,
+			_ []Event, res result.Result) result.Result {
+
+			retained := false
+			for _, entry := range j.Entries {
+				if entry.Step.Op() == deploy.OpDelete && entry.Step.Type() == "pkgA:m:typA" {
+					assert.True(t, entry.Step.Old().RetainOnDelete)
+					retained = true
+				}
+			}
+			assert.True(t, retained)
+			return res
+		},
+	}}
+	snap = p.Run(t, snap)
+	assert.Len(t, snap.Resources, 0)
+}
+
+func TestConstruct(t *testing.T) {
+	p := &TestPlan{}
+
+	loaders := []*deploytest.ProviderLoader{
+		deploytest.NewProviderLoader("pkgA", semver.MustParse("1.0.0"), func() (plugin.Provider, error) {
+			return &deploytest.Provider{
+				ConstructF: func(monitor *deploytest.ResourceMonitor, typ, name string, parent resource.URN,
+					inputs resource.PropertyMap, options plugin.ConstructOptions) (plugin.ConstructResult, error) {
+
+					assert.Equal(t, "pkgA:m:typComponent", typ)
+					assert.Equal(t, "resA", name)
+					assert.True(t, inputs["foo"].DeepEquals(resource.NewStringProperty("bar")))
+
+					// Register the component and a child against the caller's resource monitor.
+					urn, _, _, err := monitor.RegisterResource(tokens.Type(typ), name, false, deploytest.ResourceOptions{
+						Parent:       parent,
+						Aliases:      options.Aliases,
+						Dependencies: options.Dependencies,
+						Protect:      options.Protect,
+					})
+					assert.NoError(t, err)
+
+					_, _, _, err = monitor.RegisterResource("pkgA:m:typA", name+"-child", true, deploytest.ResourceOptions{
+						Parent: urn,
+						Inputs: inputs,
+					})
+					assert.NoError(t, err)
+
+					return plugin.ConstructResult{
+						URN:     urn,
+						Outputs: resource.PropertyMap{"baz": resource.NewStringProperty("qux")},
+					}, nil
+				},
+			}, nil
+		}),
+	}
+
+	program := deploytest.NewLanguageRuntime(func(_ plugin.RunInfo, monitor *deploytest.ResourceMonitor) error {
+		urn, _, outputs, err := monitor.RegisterResource("pkgA:m:typComponent", "resA", false, deploytest.ResourceOptions{
+			Inputs: resource.PropertyMap{"foo": resource.NewStringProperty("bar")},
+			Remote: true,
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, resource.URN("urn:pulumi:test::test::pkgA:m:typComponent::resA"), urn)
+		assert.True(t, outputs["baz"].DeepEquals(resource.NewStringProperty("qux")))
+		return nil
+	})
+
+	p.Options.host = deploytest.NewPluginHost(nil, nil, program, loaders...)
+	p.Steps = []TestStep{{Op: Update}}
+	snap := p.Run(t, nil)
+
+	// The snapshot should contain the default provider, the component, and its child.
+	assert.Len(t, snap.Resources, 3)
+	assert.Equal(t, tokens.Type("pkgA:m:typComponent"), snap.Resources[1].Type)
+	assert.Equal(t, tokens.Type("pkgA:m:typA"), snap.Resources[2].Type)
+	assert.Equal(t, snap.Resources[1].URN, snap.Resources[2].Parent)
+}
+
+type countingDecrypter struct {
+	count int32
+}
+
+func (c *countingDecrypter) DecryptValue(ciphertext string) (string, error) {
+	atomic.AddInt32(&c.count, 1)
+	return ciphertext, nil
+}
+
+// Tests that the configuration passed to providers that construct components is only decrypted when the program
+// registers a remote component.
+func TestConstructDecryptsConfigLazily(t *testing.T) {
+	loaders := []*deploytest.ProviderLoader{
+		deploytest.NewProviderLoader("pkgA", semver.MustParse("1.0.0"), func() (plugin.Provider, error) {
+			return &deploytest.Provider{
+				ConstructF: func(monitor *deploytest.ResourceMonitor, typ, name string, parent resource.URN,
+					inputs resource.PropertyMap, options plugin.ConstructOptions) (plugin.ConstructResult, error) {
+
+					urn, _, _, err := monitor.RegisterResource(tokens.Type(typ), name, false)
+					return plugin.ConstructResult{URN: urn}, err
+				},
+			}, nil
+		}),
+	}
+
+	remote := false
+	program := deploytest.NewLanguageRuntime(func(_ plugin.RunInfo, monitor *deploytest.ResourceMonitor) error {
+		for _, name := range []string{"resA", "resB"} {
+			_, _, _, err := monitor.RegisterResource("pkgA:m:typComponent", name, false, deploytest.ResourceOptions{
+				Remote: remote,
+			})
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+
+	decrypter := &countingDecrypter{}
+	p := &TestPlan{
+		Options:   UpdateOptions{host: deploytest.NewPluginHost(nil, nil, program, loaders...)},
+		Decrypter: decrypter,
+		Config:    config.Map{config.MustMakeKey("foo", "bar"): config.NewSecureValue("hunter2")},
+		Steps:     []TestStep{{Op: Update, SkipPreview: true}},
+	}
+
+	// Create the components locally, then count the decryptions performed by an update without remote components.
+	snap := p.Run(t, nil)
+	atomic.StoreInt32(&decrypter.count, 0)
+	snap = p.Run(t, snap)
+	local := atomic.LoadInt32(&decrypter.count)
+
+	// With remote components, the configuration is decrypted once more, no matter how many are constructed.
+	remote = true
+	atomic.StoreInt32(&decrypter.count, 0)
+	p.Run(t, snap)
+	assert.Equal(t, local+1, atomic.LoadInt32(&decrypter.count))
+}
+
+// Tests that an update held to the plan recorded by a preview fails before performing any step that deviates from
+// the plan, whether the program registers an extra resource, changes a resource's inputs, or keeps a resource that the
+// plan deletes.
+func TestUpdateWithPlanDeviations(t *testing.T) {
+	var m sync.Mutex
+	var performed []string
+	record := func(op string, urn resource.URN) {
+		m.Lock()
+		defer m.Unlock()
+		performed = append(performed, op+" "+string(urn.Name()))
+	}
+
+	loaders := []*deploytest.ProviderLoader{
+		deploytest.NewProviderLoader("pkgA", semver.MustParse("1.0.0"), func() (plugin.Provider, error) {
+			return &deploytest.Provider{
+				CreateF: func(urn resource.URN, news resource.PropertyMap,
+					timeout float64) (resource.ID, resource.PropertyMap, resource.Status, error) {
+
+					record("create", urn)
+					return "created-id", news, resource.StatusOK, nil
+				},
+				UpdateF: func(urn resource.URN, id resource.ID, olds, news resource.PropertyMap,
+					timeout float64, ignoreChanges []string) (resource.PropertyMap, resource.Status, error) {
+
+					record("update", urn)
+					return news, resource.StatusOK, nil
+				},
+				DeleteF: func(urn resource.URN, id resource.ID, olds resource.PropertyMap,
+					timeout float64) (resource.Status, error) {
+
+					record("delete", urn)
+					return resource.StatusOK, nil
+				},
+			}, nil
+		}),
+	}
+
+	// The program registers each of the given resources and then resA with the given value for its "foo" input. As
+	// the deviations below are the first steps that would change anything, none of the update's steps may run.
+	var foo string
+	var others []string
+	program := deploytest.NewLanguageRuntime(func(_ plugin.RunInfo, mon *deploytest.ResourceMonitor) error {
+		for _, name := range others {
+			if _, _, _, err := mon.RegisterResource("pkgA:m:typA", name, true); err != nil {
+				return err
+			}
+		}
+		_, _, _, err := mon.RegisterResource("pkgA:m:typA", "resA", true, deploytest.ResourceOptions{
+			Inputs: resource.PropertyMap{"foo": resource.NewStringProperty(foo)},
+		})
+		return err
+	})
+	host := deploytest.NewPluginHost(nil, nil, program, loaders...)
+	p := &TestPlan{Options: UpdateOptions{host: host}}
+	project := p.GetProject()
+
+	// Create resA, resB, and resC.
+	foo, others = "bar", []string{"resB", "resC"}
+	snap, res := TestOp(Update).Run(project, p.GetTarget(nil), p.Options, false, nil, nil)
+	assert.Nil(t, res)
+
+	// Preview an update that changes resA's inputs and deletes resC, recording its plan.
+	foo, others = "baz", []string{"resB"}
+	var plan *deploy.UpdatePlan
+	_, res = TestOp(func(info UpdateInfo, ctx *Context, opts UpdateOptions,
+		dryRun bool) (ResourceChanges, result.Result) {
+
+		updatePlan, changes, res := Preview(info, ctx, opts)
+		plan = updatePlan
+		return changes, res
+	}).Run(project, p.GetTarget(CloneSnapshot(t, snap)), p.Options, true, nil, nil)
+	assert.Nil(t, res)
+	if !assert.NotNil(t, plan) {
+		t.FailNow()
+	}
+
+	cases := []struct {
+		name   string
+		foo    string
+		others []string
+	}{
+		{name: "extra resource", foo: "baz", others: []string{"resB", "resD"}},
+		{name: "changed input", foo: "qux", others: []string{"resB"}},
+		{name: "missing delete", foo: "baz", others: []string{"resB", "resC"}},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			performed = nil
+			foo, others = c.foo, c.others
+
+			opts := p.Options
+			opts.Plan = plan
+			_, res := TestOp(Update).Run(project, p.GetTarget(CloneSnapshot(t, snap)), opts, false, nil,
+				func(_ workspace.Project, _ deploy.Target, _ *Journal, events []Event, res result.Result) result.Result {
+					found := false
+					for _, e := range events {
+						if e.Type == DiagEvent {
+							p := e.Payload().(DiagEventPayload)
+							if p.Severity == diag.Error && strings.Contains(p.Message, "plan") {
+								found = true
+								break
+							}
+						}
+					}
+					assert.True(t, found)
+					return res
+				})
+			assert.NotNil(t, res)
+			assert.Empty(t, performed)
+		})
+	}
+
+	// The program the plan was recorded for is carried out exactly as planned.
+	performed = nil
+	foo, others = "baz", []string{"resB"}
+	opts := p.Options
+	opts.Plan = plan
+	_, res = TestOp(Update).Run(project, p.GetTarget(CloneSnapshot(t, snap)), opts, false, nil, nil)
+	assert.Nil(t, res)
+	assert.Equal(t, []string{"update resA", "delete resC"}, performed)
+}
+
+func TestPolicyRemediation(t *testing.T) {
+	var checked resource.PropertyMap
+	loaders := []*deploytest.ProviderLoader{
+		deploytest.NewProviderLoader("pkgA", semver.MustParse("1.0.0"), func() (plugin.Provider, error) {
+			return &deploytest.Provider{
+				CheckF: func(urn resource.URN,
+					olds, news resource.PropertyMap) (resource.PropertyMap, []plugin.CheckFailure, error) {
+
+					checked = news
+					return news, nil, nil
+				},
+			}, nil
+		}),
+	}
+
+	program := deploytest.NewLanguageRuntime(func(_ plugin.RunInfo, monitor *deploytest.ResourceMonitor) error {
+		_, _, _, err := monitor.RegisterResource("pkgA:m:typA", "resA", true, deploytest.ResourceOptions{
+			Inputs: resource.PropertyMap{"foo": resource.NewStringProperty("bar")},
+		})
+		assert.NoError(t, err)
+		return nil
+	})
+
+	remediated := resource.PropertyMap{"foo": resource.NewStringProperty("baz")}
+	analyzers := []plugin.Analyzer{
+		&deploytest.Analyzer{
+			Info: plugin.AnalyzerInfo{Name: "remediator"},
+			RemediateF: func(r plugin.AnalyzerResource) ([]plugin.Remediation, error) {
+				if r.Type != "pkgA:m:typA" {
+					return nil, nil
+				}
+				return []plugin.Remediation{{
+					PolicyName:     "fix-foo",
+					PolicyPackName: "remediator",
+					Properties:     remediated,
+				}}, nil
+			},
+		},
+		// Analyzers that do not implement Remediate leave the inputs alone.
+		&deploytest.Analyzer{
+			Info: plugin.AnalyzerInfo{Name: "legacy"},
+			RemediateF: func(r plugin.AnalyzerResource) ([]plugin.Remediation, error) {
+				return nil, rpcerror.New(codes.Unimplemented, "Remediate is not yet implemented")
+			},
+		},
+	}
+	host := deploytest.NewPluginHostWithAnalyzers(nil, nil, program, analyzers, loaders...)
+
+	p := &TestPlan{
+		Options: UpdateOptions{host: host},
+	}
+	resURN := p.NewURN("pkgA:m:typA", "resA", "")
+
+	snap, res := TestOp(Update).Run(p.GetProject(), p.GetTarget(nil), p.Options, false, p.BackendClient,
+		func(_ workspace.Project, _ deploy.Target, _ *Journal, events []Event, res result.Result) result.Result {
+			var remediations []PolicyRemediationEventPayload
+			for _, e := range events {
+				if e.Type == PolicyRemediationEvent {
+					remediations = append(remediations, e.Payload().(PolicyRemediationEventPayload))
+				}
+			}
+			if assert.Len(t, remediations, 1) {
+				assert.Equal(t, resURN, remediations[0].ResourceURN)
+				assert.Equal(t, "fix-foo", remediations[0].PolicyName)
+				assert.Equal(t, "bar", remediations[0].Before["foo"].StringValue())
+				assert.Equal(t, "baz", remediations[0].After["foo"].StringValue())
+			}
+			return res
+		})
+	assert.Nil(t, res)
+
+	// The provider checks, and the snapshot records, the remediated inputs.
+	assert.Equal(t, remediated, checked)
+	if assert.Len(t, snap.Resources, 2) {
+		assert.Equal(t, resURN, snap.Resources[1].URN)
+		assert.Equal(t, remediated, snap.Resources[1].Inputs)
+	}
+}
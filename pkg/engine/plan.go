@@ -19,11 +19,13 @@ import (
 	"sync"
 
 	"github.com/opentracing/opentracing-go"
+	"github.com/pkg/errors"
 	"github.com/pulumi/pulumi/pkg/v2/resource/deploy"
 	"github.com/pulumi/pulumi/pkg/v2/resource/deploy/providers"
 	"github.com/pulumi/pulumi/sdk/v2/go/common/diag"
 	"github.com/pulumi/pulumi/sdk/v2/go/common/resource"
 	"github.com/pulumi/pulumi/sdk/v2/go/common/resource/plugin"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/tokens"
 	"github.com/pulumi/pulumi/sdk/v2/go/common/util/contract"
 	"github.com/pulumi/pulumi/sdk/v2/go/common/util/fsutil"
 	"github.com/pulumi/pulumi/sdk/v2/go/common/util/result"
@@ -48,6 +50,21 @@ func ProjectInfoContext(projinfo *Projinfo, host plugin.Host, config plugin.Conf
 		return "", "", nil, err
 	}
 
+	// If the project wants to connect to an existing language runtime, do so now.
+	if projinfo.Proj.Runtime.Name() == ClientRuntimeName {
+		address, ok := projinfo.Proj.Runtime.Options()["address"].(string)
+		if !ok || address == "" {
+			contract.IgnoreClose(ctx)
+			return "", "", nil, errors.New("missing address of language runtime service")
+		}
+		clientHost, err := connectToLanguageRuntime(ctx, address)
+		if err != nil {
+			contract.IgnoreClose(ctx)
+			return "", "", nil, err
+		}
+		ctx.Host = clientHost
+	}
+
 	return pwd, main, ctx, nil
 }
 
@@ -100,6 +117,33 @@ type planOptions struct {
 	// true if we should trust the dependency graph reported by the language host. Not all Pulumi-supported languages
 	// correctly report their dependencies, in which case this will be false.
 	trustDependencies bool
+
+	// the parallelism limits for particular provider packages and resource types configured by the project.
+	providerParallelism map[tokens.Package]int
+	typeParallelism     map[tokens.Type]int
+}
+
+// projectParallelism returns the per-provider and per-type parallelism limits configured by the given project.
+func projectParallelism(proj *workspace.Project) (map[tokens.Package]int, map[tokens.Type]int) {
+	if proj.Parallelism == nil {
+		return nil, nil
+	}
+
+	var providers map[tokens.Package]int
+	if len(proj.Parallelism.Providers) > 0 {
+		providers = make(map[tokens.Package]int)
+		for pkg, limit := range proj.Parallelism.Providers {
+			providers[tokens.Package(pkg)] = limit
+		}
+	}
+	var types map[tokens.Type]int
+	if len(proj.Parallelism.Types) > 0 {
+		types = make(map[tokens.Type]int)
+		for typ, limit := range proj.Parallelism.Types {
+			types[tokens.Type(typ)] = limit
+		}
+	}
+	return providers, types
 }
 
 // planSourceFunc is a callback that will be used to prepare for, and evaluate, the "new" state for a stack.
@@ -124,8 +168,10 @@ func plan(ctx *Context, info *planContext, opts planOptions, dryRun bool) (*plan
 	if err != nil {
 		return nil, err
 	}
+	plugctx.Env = opts.Env
 
 	opts.trustDependencies = proj.TrustResourceDependencies()
+	opts.providerParallelism, opts.typeParallelism = projectParallelism(proj)
 	// Now create the state source.  This may issue an error if it can't create the source.  This entails,
 	// for example, loading any plugins which will be required to execute a program, among other things.
 	source, err := opts.SourceFunc(ctx.BackendClient, opts, proj, pwd, main, target, plugctx, dryRun)
@@ -178,17 +224,22 @@ func (planResult *planResult) Walk(cancelCtx *Context, events deploy.Events, pre
 	var walkResult result.Result
 	go func() {
 		opts := deploy.Options{
-			Events:            events,
-			Parallel:          planResult.Options.Parallel,
-			Refresh:           planResult.Options.Refresh,
-			RefreshOnly:       planResult.Options.isRefresh,
-			RefreshTargets:    planResult.Options.RefreshTargets,
-			ReplaceTargets:    planResult.Options.ReplaceTargets,
-			DestroyTargets:    planResult.Options.DestroyTargets,
-			UpdateTargets:     planResult.Options.UpdateTargets,
-			TargetDependents:  planResult.Options.TargetDependents,
-			TrustDependencies: planResult.Options.trustDependencies,
-			UseLegacyDiff:     planResult.Options.UseLegacyDiff,
+			Events:              events,
+			Parallel:            planResult.Options.Parallel,
+			Refresh:             planResult.Options.Refresh,
+			RefreshOnly:         planResult.Options.isRefresh,
+			RefreshTargets:      planResult.Options.RefreshTargets,
+			ReplaceTargets:      planResult.Options.ReplaceTargets,
+			DestroyTargets:      planResult.Options.DestroyTargets,
+			UpdateTargets:       planResult.Options.UpdateTargets,
+			Excludes:            planResult.Options.Excludes,
+			ProviderParallelism: planResult.Options.providerParallelism,
+			TypeParallelism:     planResult.Options.typeParallelism,
+			ContinueOnError:     planResult.Options.ContinueOnError,
+			TargetDependents:    planResult.Options.TargetDependents,
+			TrustDependencies:   planResult.Options.trustDependencies,
+			UseLegacyDiff:       planResult.Options.UseLegacyDiff,
+			Plan:                planResult.Options.Plan,
 		}
 		walkResult = planResult.Plan.Execute(ctx, opts, preview)
 		close(done)
@@ -333,11 +384,13 @@ func ShouldRecordReadStep(step deploy.Step) bool {
 		step.Old().Outputs.Diff(step.New().Outputs) != nil
 }
 
-func (acts *planActions) OnResourceOutputs(step deploy.Step) error {
+func (acts *planActions) OnResourceOutputs(step deploy.Step, outputs resource.PropertyMap) error {
 	acts.MapLock.Lock()
 	assertSeen(acts.Seen, step)
 	acts.MapLock.Unlock()
 
+	step.New().Outputs = outputs
+
 	// Skip reporting if necessary.
 	if !shouldReportStep(step, acts.Opts) {
 		return nil
@@ -353,6 +406,11 @@ func (acts *planActions) OnPolicyViolation(urn resource.URN, d plugin.AnalyzeDia
 	acts.Opts.Events.policyViolationEvent(urn, d)
 }
 
+func (acts *planActions) OnPolicyRemediation(urn resource.URN, t plugin.Remediation,
+	before resource.PropertyMap, after resource.PropertyMap) {
+	acts.Opts.Events.policyRemediationEvent(urn, t, before, after, true /*planning*/, acts.Opts.Debug)
+}
+
 func assertSeen(seen map[resource.URN]deploy.Step, step deploy.Step) {
 	_, has := seen[step.URN()]
 	contract.Assertf(has, "URN '%v' had not been marked as seen", step.URN())
@@ -0,0 +1,111 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package engine
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/pulumi/pulumi/sdk/v2/go/common/resource/plugin"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/util/rpcutil"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/workspace"
+	pulumirpc "github.com/pulumi/pulumi/sdk/v2/proto/go"
+)
+
+const (
+	// ClientRuntimeName is the name of the runtime used by projects whose program is hosted by an existing language
+	// runtime service rather than by a language plugin launched by the engine.
+	ClientRuntimeName = "client"
+
+	// EngineAddressMetadataKey is the gRPC metadata key under which the engine's RPC address is sent to a client
+	// language runtime. Client runtimes are started before the engine, so they cannot be told its address up front.
+	EngineAddressMetadataKey = "pulumi-engine-address"
+)
+
+// clientLanguageRuntimeHost is a plugin host that uses an existing language runtime service in place of launching a
+// language plugin.
+type clientLanguageRuntimeHost struct {
+	plugin.Host
+
+	conn            *grpc.ClientConn
+	languageRuntime plugin.LanguageRuntime
+}
+
+// connectToLanguageRuntime dials the language runtime service at the given address and returns a plugin host that
+// routes all language runtime requests to it.
+func connectToLanguageRuntime(ctx *plugin.Context, address string) (plugin.Host, error) {
+	engineAddress := ctx.Host.ServerAddr()
+	addEngineAddress := func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn,
+		invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+
+		ctx = metadata.AppendToOutgoingContext(ctx, EngineAddressMetadataKey, engineAddress)
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+
+	conn, err := grpc.Dial(address, grpc.WithInsecure(),
+		grpc.WithChainUnaryInterceptor(rpcutil.OpenTracingClientInterceptor(), addEngineAddress),
+		rpcutil.GrpcChannelOptions())
+	if err != nil {
+		return nil, errors.Wrap(err, "could not connect to language runtime service")
+	}
+
+	runtime := plugin.NewLanguageRuntimeClient(ctx, ClientRuntimeName, pulumirpc.NewLanguageRuntimeClient(conn))
+	return &clientLanguageRuntimeHost{
+		Host:            ctx.Host,
+		conn:            conn,
+		languageRuntime: runtime,
+	}, nil
+}
+
+func (host *clientLanguageRuntimeHost) LanguageRuntime(runtime string) (plugin.LanguageRuntime, error) {
+	return host.languageRuntime, nil
+}
+
+// EnsurePlugins ensures that all non-language plugins are loaded; the language runtime is always available.
+func (host *clientLanguageRuntimeHost) EnsurePlugins(plugins []workspace.PluginInfo, kinds plugin.Flags) error {
+	var others []workspace.PluginInfo
+	for _, p := range plugins {
+		if p.Kind != workspace.LanguagePlugin {
+			others = append(others, p)
+		}
+	}
+	return host.Host.EnsurePlugins(others, kinds&^plugin.LanguagePlugins)
+}
+
+// GetRequiredPlugins returns the resource plugins required by the program. The language runtime itself is not a
+// plugin that needs to be installed, so it is omitted.
+func (host *clientLanguageRuntimeHost) GetRequiredPlugins(info plugin.ProgInfo,
+	kinds plugin.Flags) ([]workspace.PluginInfo, error) {
+
+	if kinds&plugin.LanguagePlugins == 0 || kinds&plugin.ResourcePlugins == 0 {
+		return nil, nil
+	}
+	deps, err := host.languageRuntime.GetRequiredPlugins(info)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to discover plugin requirements")
+	}
+	return deps, nil
+}
+
+func (host *clientLanguageRuntimeHost) Close() error {
+	err := host.Host.Close()
+	if closeErr := host.conn.Close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
@@ -15,10 +15,14 @@
 package engine
 
 import (
+	"bytes"
 	"fmt"
+	"io/ioutil"
 	"sort"
+	"sync"
 
 	"github.com/blang/semver"
+	"github.com/pkg/errors"
 	"golang.org/x/sync/errgroup"
 
 	"github.com/pulumi/pulumi/pkg/v2/resource/deploy"
@@ -123,64 +127,160 @@ func gatherPluginsFromSnapshot(plugctx *plugin.Context, target *deploy.Target) (
 // ensurePluginsAreInstalled inspects all plugins in the plugin set and, if any plugins are not currently installed,
 // uses the given backend client to install them. Installations are processed in parallel, though
 // ensurePluginsAreInstalled does not return until all installations are completed.
-func ensurePluginsAreInstalled(plugins pluginSet) error {
+//
+// If pwd is within a project, the tarballs of installed plugins are verified against the checksums pinned in the
+// project's lock file, and the checksums of plugins that are not yet pinned are recorded there.
+func ensurePluginsAreInstalled(plugins pluginSet, pwd string) error {
 	logging.V(preparePluginLog).Infof("ensurePluginsAreInstalled(): beginning")
+
+	var lockPath string
+	var lock *workspace.LockFile
+	if pwd != "" {
+		path, err := workspace.DetectLockFilePathFrom(pwd)
+		if err != nil {
+			return err
+		}
+		if path != "" {
+			if lock, err = workspace.LoadLockFile(path); err != nil {
+				return err
+			}
+			lockPath = path
+		}
+	}
+
+	var lockMutex sync.Mutex
+	var lockChanged bool
+	var mismatch error
 	var installTasks errgroup.Group
 	for _, plug := range plugins.Values() {
-		_, path, err := workspace.GetPluginPath(plug.Kind, plug.Name, plug.Version)
+		dir, path, err := workspace.GetPluginPath(plug.Kind, plug.Name, plug.Version)
 		if err == nil && path != "" {
 			logging.V(preparePluginLog).Infof(
 				"ensurePluginsAreInstalled(): plugin %s %s already installed", plug.Name, plug.Version)
+
+			// Cached plugins are pinned and verified just like freshly installed ones.
+			if lock != nil {
+				lockMutex.Lock()
+				changed, err := lockInstalledPlugin(lock, plug, dir)
+				if err != nil {
+					mismatch = err
+				}
+				lockChanged = changed || lockChanged
+				lockMutex.Unlock()
+			}
 			continue
 		}
 
 		// Launch an install task asynchronously and add it to the current error group.
 		info := plug // don't close over the loop induction variable
+		if lock != nil {
+			info.Checksum = lock.Checksum(info)
+		}
 		installTasks.Go(func() error {
 			logging.V(preparePluginLog).Infof(
 				"ensurePluginsAreInstalled(): plugin %s %s not installed, doing install", info.Name, info.Version)
-			return installPlugin(info)
+			checksum, err := installPlugin(info)
+
+			lockMutex.Lock()
+			defer lockMutex.Unlock()
+			if _, isMismatch := err.(*workspace.ChecksumMismatchError); isMismatch {
+				mismatch = err
+			} else if err == nil && lock != nil && checksum != "" {
+				lockChanged = lock.SetChecksum(info, checksum) || lockChanged
+			}
+			return err
 		})
 	}
 
 	err := installTasks.Wait()
+	if lockChanged {
+		if saveErr := lock.Save(lockPath); saveErr != nil && err == nil {
+			err = errors.Wrapf(saveErr, "saving %s", lockPath)
+		}
+	}
+	if mismatch != nil {
+		// Prefer reporting a checksum mismatch over any other failure, as callers must not ignore it.
+		err = mismatch
+	}
 	logging.V(preparePluginLog).Infof("ensurePluginsAreInstalled(): completed")
 	return err
 }
 
+// lockInstalledPlugin pins the checksum of a plugin that is already installed in the given directory of the plugin
+// cache in the given lock file or, if the lock file already pins the plugin, verifies the plugin against it. It returns
+// true if the lock file changed. Plugins found outside of the cache, plugins resolved to a different version than the
+// one requested, and plugins installed without a recorded checksum cannot be pinned and are left alone.
+func lockInstalledPlugin(lock *workspace.LockFile, plug workspace.PluginInfo, dir string) (bool, error) {
+	if dir == "" || plug.Kind == workspace.LanguagePlugin || plug.Version == nil {
+		return false, nil
+	}
+	if pluginDir, err := plug.DirPath(); err != nil || pluginDir != dir {
+		return false, nil
+	}
+
+	checksum, err := workspace.InstalledPluginChecksum(dir)
+	if err != nil || checksum == "" {
+		logging.V(preparePluginLog).Infof(
+			"ensurePluginsAreInstalled(): checksum of plugin %s %s unknown, not pinning", plug.Name, plug.Version)
+		return false, nil
+	}
+
+	if pinned := lock.Checksum(plug); pinned != "" {
+		if pinned != checksum {
+			plug.Checksum = pinned
+			return false, &workspace.ChecksumMismatchError{Info: plug, Actual: checksum}
+		}
+		return false, nil
+	}
+	return lock.SetChecksum(plug, checksum), nil
+}
+
+// isFatalInstallError returns true if the given error from ensurePluginsAreInstalled must stop the operation. Most
+// installation failures are not fatal, as the operation fails later with a more precise error if a plugin is actually
+// missing, but a plugin that does not match its pinned checksum must never be used.
+func isFatalInstallError(err error) bool {
+	_, isMismatch := err.(*workspace.ChecksumMismatchError)
+	return isMismatch
+}
+
 // ensurePluginsAreLoaded ensures that all of the plugins in the given plugin set that match the given plugin flags are
 // loaded.
 func ensurePluginsAreLoaded(plugctx *plugin.Context, plugins pluginSet, kinds plugin.Flags) error {
 	return plugctx.Host.EnsurePlugins(plugins.Values(), kinds)
 }
 
-// installPlugin installs a plugin from the given backend client.
-func installPlugin(plugin workspace.PluginInfo) error {
+// installPlugin installs a plugin from the given backend client, returning the checksum of the plugin's tarball.
+func installPlugin(plugin workspace.PluginInfo) (string, error) {
 	logging.V(preparePluginLog).Infof("installPlugin(%s, %s): beginning install", plugin.Name, plugin.Version)
 	if plugin.Kind == workspace.LanguagePlugin {
 		logging.V(preparePluginLog).Infof(
 			"installPlugin(%s, %s): is a language plugin, skipping install", plugin.Name, plugin.Version)
-		return nil
+		return "", nil
 	}
 
 	logging.V(preparePluginVerboseLog).Infof(
 		"installPlugin(%s, %s): initiating download", plugin.Name, plugin.Version)
 	stream, size, err := plugin.Download()
 	if err != nil {
-		return err
+		return "", err
 	}
 
 	fmt.Printf("[%s plugin %s-%s] installing\n", plugin.Kind, plugin.Name, plugin.Version)
 	stream = workspace.ReadCloserProgressBar(stream, size, "Downloading plugin", cmdutil.GetGlobalColorization())
+	tarball, err := ioutil.ReadAll(stream)
+	contract.IgnoreClose(stream)
+	if err != nil {
+		return "", err
+	}
 
 	logging.V(preparePluginVerboseLog).Infof(
 		"installPlugin(%s, %s): extracting tarball to installation directory", plugin.Name, plugin.Version)
-	if err := plugin.Install(stream); err != nil {
-		return err
+	if err := plugin.Install(ioutil.NopCloser(bytes.NewReader(tarball))); err != nil {
+		return "", err
 	}
 
 	logging.V(7).Infof("installPlugin(%s, %s): successfully installed", plugin.Name, plugin.Version)
-	return nil
+	return workspace.PluginChecksum(tarball), nil
 }
 
 // computeDefaultProviderPlugins computes, for every resource plugin, a mapping from packages to semver versions
@@ -15,6 +15,12 @@
 package engine
 
 import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"io/ioutil"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/blang/semver"
@@ -124,3 +130,59 @@ func TestDefaultProvidersSnapshotOverrides(t *testing.T) {
 	assert.NotNil(t, awsVer)
 	assert.Equal(t, "0.17.0", awsVer.String())
 }
+
+func TestEnsurePluginsAreInstalledLocksCachedPlugins(t *testing.T) {
+	home, err := ioutil.TempDir("", "pulumi-home")
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	defer os.RemoveAll(home)
+	oldHome := os.Getenv(workspace.PulumiHomeEnvVar)
+	assert.NoError(t, os.Setenv(workspace.PulumiHomeEnvVar, home))
+	defer func() { assert.NoError(t, os.Setenv(workspace.PulumiHomeEnvVar, oldHome)) }()
+
+	project, err := ioutil.TempDir("", "project")
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	defer os.RemoveAll(project)
+	err = ioutil.WriteFile(filepath.Join(project, "Pulumi.yaml"), []byte("name: test\nruntime: go\n"), 0600)
+	assert.NoError(t, err)
+	lockPath := workspace.LockFilePath(filepath.Join(project, "Pulumi.yaml"))
+
+	// Install the plugin into the cache, so that ensurePluginsAreInstalled finds it there.
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+	contents := []byte("#!/bin/sh\n")
+	assert.NoError(t, tw.WriteHeader(&tar.Header{
+		Name:     "pulumi-resource-pkgA",
+		Mode:     0700,
+		Size:     int64(len(contents)),
+		Typeflag: tar.TypeReg,
+	}))
+	_, err = tw.Write(contents)
+	assert.NoError(t, err)
+	assert.NoError(t, tw.Close())
+	assert.NoError(t, gz.Close())
+	tarball := buf.Bytes()
+
+	info := workspace.PluginInfo{Kind: workspace.ResourcePlugin, Name: "pkgA", Version: mustMakeVersion("1.0.0")}
+	assert.NoError(t, info.Install(ioutil.NopCloser(bytes.NewReader(tarball))))
+	plugins := newPluginSet()
+	plugins.Add(info)
+
+	// The first resolve pins the cached plugin.
+	assert.NoError(t, ensurePluginsAreInstalled(plugins, project))
+	lock, err := workspace.LoadLockFile(lockPath)
+	assert.NoError(t, err)
+	assert.Equal(t, workspace.PluginChecksum(tarball), lock.Checksum(info))
+
+	// Later resolves verify it.
+	assert.NoError(t, ensurePluginsAreInstalled(plugins, project))
+	lock.SetChecksum(info, workspace.PluginChecksum([]byte("other")))
+	assert.NoError(t, lock.Save(lockPath))
+	err = ensurePluginsAreInstalled(plugins, project)
+	assert.IsType(t, &workspace.ChecksumMismatchError{}, err)
+	assert.True(t, isFatalInstallError(err))
+}
@@ -44,7 +44,7 @@ func Refresh(u UpdateInfo, ctx *Context, opts UpdateOptions, dryRun bool) (Resou
 	// Force opts.Refresh to true.
 	opts.Refresh = true
 
-	return update(ctx, info, planOptions{
+	_, changes, res := update(ctx, info, planOptions{
 		UpdateOptions: opts,
 		SourceFunc:    newRefreshSource,
 		Events:        emitter,
@@ -52,6 +52,7 @@ func Refresh(u UpdateInfo, ctx *Context, opts UpdateOptions, dryRun bool) (Resou
 		StatusDiag:    newEventSink(emitter, true),
 		isRefresh:     true,
 	}, dryRun)
+	return changes, res
 }
 
 func newRefreshSource(client deploy.BackendClient, opts planOptions, proj *workspace.Project, pwd, main string,
@@ -66,7 +67,9 @@ func newRefreshSource(client deploy.BackendClient, opts planOptions, proj *works
 	}
 
 	// Like Update, if we're missing plugins, attempt to download the missing plugins.
-	if err := ensurePluginsAreInstalled(plugins); err != nil {
+	if err := ensurePluginsAreInstalled(plugins, pwd); isFatalInstallError(err) {
+		return nil, err
+	} else if err != nil {
 		logging.V(7).Infof("newRefreshSource(): failed to install missing plugins: %v", err)
 	}
 
@@ -18,6 +18,7 @@ import (
 	"io"
 
 	"github.com/pulumi/pulumi/pkg/v2/resource/deploy"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/resource"
 )
 
 // SnapshotManager is responsible for maintaining the in-memory representation
@@ -32,8 +33,8 @@ type SnapshotManager interface {
 	BeginMutation(step deploy.Step) (SnapshotMutation, error)
 
 	// RegisterResourceOutputs registers the set of resource outputs generated by performing the
-	// given step. These outputs are persisted in the snapshot.
-	RegisterResourceOutputs(step deploy.Step) error
+	// given step by setting them on the step's new state. These outputs are persisted in the snapshot.
+	RegisterResourceOutputs(step deploy.Step, outputs resource.PropertyMap) error
 }
 
 // SnapshotMutation represents an outstanding mutation that is yet to be completed. When the engine completes
@@ -125,13 +125,27 @@ type UpdateOptions struct {
 	// Specific resources to update during an update operation.
 	UpdateTargets []resource.URN
 
+	// Specific resources to exclude from an update, refresh, or destroy operation. Like targets, these may be URNs
+	// or glob patterns.
+	Excludes []resource.URN
+
 	// true if we're allowing dependent targets to change, even if not specified in one of the above
 	// XXXTargets lists.
 	TargetDependents bool
 
+	// true if the engine should keep executing steps that do not depend on a failed step, reporting all of the
+	// failures at the end, rather than stopping at the first failure.
+	ContinueOnError bool
+
 	// true if the engine should use legacy diffing behavior during an update.
 	UseLegacyDiff bool
 
+	// an optional update plan, recorded by a previous preview, that the update must adhere to.
+	Plan *deploy.UpdatePlan
+
+	// additional environment variables for the plugins launched by the update.
+	Env map[string]string
+
 	// true if we should report events for steps that involve default providers.
 	reportDefaultProviderSteps bool
 
@@ -142,6 +156,16 @@ type UpdateOptions struct {
 // ResourceChanges contains the aggregate resource changes by operation type.
 type ResourceChanges map[deploy.StepOp]int
 
+// ResourceSummary records the operation that an update performed on a single resource, how long the operation took,
+// and why it failed, if it did.
+type ResourceSummary struct {
+	URN      resource.URN  `json:"urn"`
+	Type     tokens.Type   `json:"type"`
+	Op       deploy.StepOp `json:"op"`
+	Duration time.Duration `json:"duration"`
+	Error    string        `json:"error,omitempty"`
+}
+
 // HasChanges returns true if there are any non-same changes in the resulting summary.
 func (changes ResourceChanges) HasChanges() bool {
 	var c int
@@ -157,6 +181,19 @@ func (changes ResourceChanges) HasChanges() bool {
 }
 
 func Update(u UpdateInfo, ctx *Context, opts UpdateOptions, dryRun bool) (ResourceChanges, result.Result) {
+	_, changes, res := updateWithPlan(u, ctx, opts, dryRun)
+	return changes, res
+}
+
+// Preview computes the operations that an update would perform without performing them. These operations are also
+// returned as an update plan, which a later update can be held to using UpdateOptions.Plan.
+func Preview(u UpdateInfo, ctx *Context, opts UpdateOptions) (*deploy.UpdatePlan, ResourceChanges, result.Result) {
+	return updateWithPlan(u, ctx, opts, true)
+}
+
+func updateWithPlan(u UpdateInfo, ctx *Context, opts UpdateOptions,
+	dryRun bool) (*deploy.UpdatePlan, ResourceChanges, result.Result) {
+
 	contract.Require(u != nil, "update")
 	contract.Require(ctx != nil, "ctx")
 
@@ -164,13 +201,13 @@ func Update(u UpdateInfo, ctx *Context, opts UpdateOptions, dryRun bool) (Resour
 
 	info, err := newPlanContext(u, "update", ctx.ParentSpan)
 	if err != nil {
-		return nil, result.FromError(err)
+		return nil, nil, result.FromError(err)
 	}
 	defer info.Close()
 
 	emitter, err := makeEventEmitter(ctx.Events, u)
 	if err != nil {
-		return nil, result.FromError(err)
+		return nil, nil, result.FromError(err)
 	}
 	defer emitter.Close()
 
@@ -224,8 +261,11 @@ func installPlugins(
 	// If there are any plugins that are not available, we can attempt to install them here.
 	//
 	// Note that this is purely a best-effort thing. If we can't install missing plugins, just proceed; we'll fail later
-	// with an error message indicating exactly what plugins are missing.
-	if err := ensurePluginsAreInstalled(allPlugins); err != nil {
+	// with an error message indicating exactly what plugins are missing. The exception is a plugin that does not match
+	// the checksum pinned in the project's lock file, which must never be used.
+	if err := ensurePluginsAreInstalled(allPlugins, pwd); isFatalInstallError(err) {
+		return nil, nil, err
+	} else if err != nil {
 		logging.V(7).Infof("newUpdateSource(): failed to install missing plugins: %v", err)
 	}
 
@@ -396,10 +436,14 @@ func newUpdateSource(
 	}, defaultProviderVersions, dryRun), nil
 }
 
-func update(ctx *Context, info *planContext, opts planOptions, dryRun bool) (ResourceChanges, result.Result) {
+// update runs the planned operations, or, if dryRun is true, prints them. Along with the resulting changes, it
+// returns the update plan recorded for a dry run.
+func update(ctx *Context, info *planContext, opts planOptions,
+	dryRun bool) (*deploy.UpdatePlan, ResourceChanges, result.Result) {
+
 	planResult, err := plan(ctx, info, opts, dryRun)
 	if err != nil {
-		return nil, result.FromError(err)
+		return nil, nil, result.FromError(err)
 	}
 
 	policies := map[string]string{}
@@ -416,6 +460,7 @@ func update(ctx *Context, info *planContext, opts planOptions, dryRun bool) (Res
 		}
 	}
 
+	var updatePlan *deploy.UpdatePlan
 	var resourceChanges ResourceChanges
 	var res result.Result
 	if planResult != nil {
@@ -424,13 +469,16 @@ func update(ctx *Context, info *planContext, opts planOptions, dryRun bool) (Res
 		// Make the current working directory the same as the program's, and restore it upon exit.
 		done, chErr := planResult.Chdir()
 		if chErr != nil {
-			return nil, result.FromError(chErr)
+			return nil, nil, result.FromError(chErr)
 		}
 		defer done()
 
 		if dryRun {
 			// If a dry run, just print the plan, don't actually carry out the deployment.
 			resourceChanges, res = printPlan(ctx, planResult, dryRun, policies)
+			if res == nil {
+				updatePlan = planResult.Plan.UpdatePlan()
+			}
 		} else {
 			// Otherwise, we will actually deploy the latest bits.
 			opts.Events.preludeEvent(dryRun, planResult.Ctx.Update.GetTarget().Config)
@@ -442,15 +490,15 @@ func update(ctx *Context, info *planContext, opts planOptions, dryRun bool) (Res
 			res = planResult.Walk(ctx, actions, false)
 			resourceChanges = ResourceChanges(actions.Ops)
 
-			if len(resourceChanges) != 0 {
+			if len(resourceChanges) != 0 || len(actions.Resources) != 0 {
 
 				// Print out the total number of steps performed (and their kinds), the duration, and any summary info.
 				opts.Events.updateSummaryEvent(actions.MaybeCorrupt, time.Since(start),
-					resourceChanges, policies)
+					resourceChanges, actions.Resources, policies)
 			}
 		}
 	}
-	return resourceChanges, res
+	return updatePlan, resourceChanges, res
 }
 
 // abbreviateFilePath is a helper function that cleans up and shortens a provided file path.
@@ -485,6 +533,8 @@ type updateActions struct {
 	Steps        int
 	Ops          map[deploy.StepOp]int
 	Seen         map[resource.URN]deploy.Step
+	Starts       map[deploy.Step]time.Time
+	Resources    []ResourceSummary
 	MapLock      sync.Mutex
 	MaybeCorrupt bool
 	Update       UpdateInfo
@@ -496,15 +546,34 @@ func newUpdateActions(context *Context, u UpdateInfo, opts planOptions) *updateA
 		Context: context,
 		Ops:     make(map[deploy.StepOp]int),
 		Seen:    make(map[resource.URN]deploy.Step),
+		Starts:  make(map[deploy.Step]time.Time),
 		Update:  u,
 		Opts:    opts,
 	}
 }
 
+// recordResource records the outcome of a step in the update's per-resource summary.
+func (acts *updateActions) recordResource(step deploy.Step, op deploy.StepOp, start time.Time, err error) {
+	summary := ResourceSummary{
+		URN:      step.URN(),
+		Type:     step.Type(),
+		Op:       op,
+		Duration: time.Since(start),
+	}
+	if err != nil {
+		summary.Error = err.Error()
+	}
+
+	acts.MapLock.Lock()
+	acts.Resources = append(acts.Resources, summary)
+	acts.MapLock.Unlock()
+}
+
 func (acts *updateActions) OnResourceStepPre(step deploy.Step) (interface{}, error) {
 	// Ensure we've marked this step as observed.
 	acts.MapLock.Lock()
 	acts.Seen[step.URN()] = step
+	acts.Starts[step] = time.Now()
 	acts.MapLock.Unlock()
 
 	// Skip reporting if necessary.
@@ -522,6 +591,8 @@ func (acts *updateActions) OnResourceStepPost(
 
 	acts.MapLock.Lock()
 	assertSeen(acts.Seen, step)
+	start := acts.Starts[step]
+	delete(acts.Starts, step)
 	acts.MapLock.Unlock()
 
 	// If we've already been terminated, exit without writing the checkpoint. We explicitly want to leave the
@@ -547,6 +618,7 @@ func (acts *updateActions) OnResourceStepPost(
 		acts.Opts.Diag.Errorf(diag.GetResourceOperationFailedError(errorURN), err)
 		if reportStep {
 			acts.Opts.Events.resourceOperationFailedEvent(step, status, acts.Steps, acts.Opts.Debug)
+			acts.recordResource(step, step.Op(), start, err)
 		}
 	} else if reportStep {
 		op, record := step.Op(), step.Logical()
@@ -565,6 +637,10 @@ func (acts *updateActions) OnResourceStepPost(
 			acts.Steps++
 			acts.Ops[op]++
 			acts.MapLock.Unlock()
+
+			if op != deploy.OpSame {
+				acts.recordResource(step, op, start, nil)
+			}
 		}
 
 		// Also show outputs here for custom resources, since there might be some from the initial registration. We do
@@ -606,21 +682,29 @@ func (acts *updateActions) OnResourceStepPost(
 	return ctx.(SnapshotMutation).End(step, err == nil || status == resource.StatusPartialFailure)
 }
 
-func (acts *updateActions) OnResourceOutputs(step deploy.Step) error {
+func (acts *updateActions) OnResourceOutputs(step deploy.Step, outputs resource.PropertyMap) error {
 	acts.MapLock.Lock()
 	assertSeen(acts.Seen, step)
 	acts.MapLock.Unlock()
 
+	// The snapshot manager sets the new outputs, as it may be reading the step's state concurrently, and performs
+	// another snapshot write to ensure they get written out.
+	if err := acts.Context.SnapshotManager.RegisterResourceOutputs(step, outputs); err != nil {
+		return err
+	}
+
 	// Skip reporting if necessary.
 	if shouldReportStep(step, acts.Opts) {
 		acts.Opts.Events.resourceOutputsEvent(step.Op(), step, false /*planning*/, acts.Opts.Debug)
 	}
-
-	// There's a chance there are new outputs that weren't written out last time.
-	// We need to perform another snapshot write to ensure they get written out.
-	return acts.Context.SnapshotManager.RegisterResourceOutputs(step)
+	return nil
 }
 
 func (acts *updateActions) OnPolicyViolation(urn resource.URN, d plugin.AnalyzeDiagnostic) {
 	acts.Opts.Events.policyViolationEvent(urn, d)
 }
+
+func (acts *updateActions) OnPolicyRemediation(urn resource.URN, t plugin.Remediation,
+	before resource.PropertyMap, after resource.PropertyMap) {
+	acts.Opts.Events.policyRemediationEvent(urn, t, before, after, false /*planning*/, acts.Opts.Debug)
+}
@@ -57,6 +57,7 @@ func NewProviderLoaderWithHost(pkg tokens.Package, version semver.Version,
 
 type pluginHost struct {
 	providerLoaders []*ProviderLoader
+	analyzers       []plugin.Analyzer
 	languageRuntime plugin.LanguageRuntime
 	sink            diag.Sink
 	statusSink      diag.Sink
@@ -78,6 +79,16 @@ func NewPluginHost(sink, statusSink diag.Sink, languageRuntime plugin.LanguageRu
 	}
 }
 
+// NewPluginHostWithAnalyzers creates a plugin host that also runs the given analyzers, as if they had been loaded from
+// policy packs.
+func NewPluginHostWithAnalyzers(sink, statusSink diag.Sink, languageRuntime plugin.LanguageRuntime,
+	analyzers []plugin.Analyzer, providerLoaders ...*ProviderLoader) plugin.Host {
+
+	host := NewPluginHost(sink, statusSink, languageRuntime, providerLoaders...).(*pluginHost)
+	host.analyzers = analyzers
+	return host
+}
+
 func (host *pluginHost) isClosed() bool {
 	host.m.Lock()
 	defer host.m.Unlock()
@@ -186,5 +197,5 @@ func (host *pluginHost) PolicyAnalyzer(name tokens.QName, path string,
 }
 
 func (host *pluginHost) ListAnalyzers() []plugin.Analyzer {
-	return nil
+	return host.analyzers
 }
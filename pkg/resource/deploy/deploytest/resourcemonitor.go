@@ -18,16 +18,43 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+
 	"github.com/pulumi/pulumi/sdk/v2/go/common/resource"
 	"github.com/pulumi/pulumi/sdk/v2/go/common/resource/plugin"
 	"github.com/pulumi/pulumi/sdk/v2/go/common/tokens"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/util/rpcutil"
 	pulumirpc "github.com/pulumi/pulumi/sdk/v2/proto/go"
 )
 
 type ResourceMonitor struct {
+	conn   *grpc.ClientConn
 	resmon pulumirpc.ResourceMonitorClient
 }
 
+func dialMonitor(endpoint string) (*ResourceMonitor, error) {
+	// Connect to the resource monitor and create an appropriate client.
+	conn, err := grpc.Dial(
+		endpoint,
+		grpc.WithInsecure(),
+		rpcutil.GrpcChannelOptions(),
+	)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not connect to resource monitor")
+	}
+
+	// Fire up a resource monitor client
+	return &ResourceMonitor{
+		conn:   conn,
+		resmon: pulumirpc.NewResourceMonitorClient(conn),
+	}, nil
+}
+
+func (rm *ResourceMonitor) Close() error {
+	return rm.conn.Close()
+}
+
 type ResourceOptions struct {
 	Parent                resource.URN
 	Protect               bool
@@ -38,6 +65,10 @@ type ResourceOptions struct {
 	DeleteBeforeReplace   *bool
 	Version               string
 	IgnoreChanges         []string
+	ReplaceOnChanges      []string
+	RetainOnDelete        bool
+	Remote                bool
+	Providers             map[string]string
 	Aliases               []resource.URN
 	ImportID              resource.ID
 	CustomTimeouts        *resource.CustomTimeouts
@@ -112,6 +143,10 @@ func (rm *ResourceMonitor) RegisterResource(t tokens.Type, name string, custom b
 		DeleteBeforeReplace:        deleteBeforeReplace,
 		DeleteBeforeReplaceDefined: opts.DeleteBeforeReplace != nil,
 		IgnoreChanges:              opts.IgnoreChanges,
+		ReplaceOnChanges:           opts.ReplaceOnChanges,
+		RetainOnDelete:             opts.RetainOnDelete,
+		Remote:                     opts.Remote,
+		Providers:                  opts.Providers,
 		Version:                    opts.Version,
 		Aliases:                    aliasStrings,
 		ImportId:                   string(opts.ImportID),
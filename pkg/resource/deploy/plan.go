@@ -47,17 +47,22 @@ type BackendClient interface {
 
 // Options controls the planning and deployment process.
 type Options struct {
-	Events            Events         // an optional events callback interface.
-	Parallel          int            // the degree of parallelism for resource operations (<=1 for serial).
-	Refresh           bool           // whether or not to refresh before executing the plan.
-	RefreshOnly       bool           // whether or not to exit after refreshing.
-	RefreshTargets    []resource.URN // The specific resources to refresh during a refresh op.
-	ReplaceTargets    []resource.URN // Specific resources to replace.
-	DestroyTargets    []resource.URN // Specific resources to destroy.
-	UpdateTargets     []resource.URN // Specific resources to update.
-	TargetDependents  bool           // true if we're allowing things to proceed, even with unspecified targets
-	TrustDependencies bool           // whether or not to trust the resource dependency graph.
-	UseLegacyDiff     bool           // whether or not to use legacy diffing behavior.
+	Events              Events                 // an optional events callback interface.
+	Parallel            int                    // the degree of parallelism for resource operations (<=1 for serial).
+	Refresh             bool                   // whether or not to refresh before executing the plan.
+	RefreshOnly         bool                   // whether or not to exit after refreshing.
+	RefreshTargets      []resource.URN         // The specific resources to refresh during a refresh op.
+	ReplaceTargets      []resource.URN         // Specific resources to replace.
+	DestroyTargets      []resource.URN         // Specific resources to destroy.
+	UpdateTargets       []resource.URN         // Specific resources to update.
+	Excludes            []resource.URN         // Specific resources to exclude from the operation.
+	ProviderParallelism map[tokens.Package]int // the maximum concurrency for each provider package's resources.
+	TypeParallelism     map[tokens.Type]int    // the maximum concurrency for each resource type.
+	ContinueOnError     bool                   // whether or not to keep executing steps independent of failed ones.
+	TargetDependents    bool                   // true if we're allowing things to proceed, even with unspecified targets
+	TrustDependencies   bool                   // whether or not to trust the resource dependency graph.
+	UseLegacyDiff       bool                   // whether or not to use legacy diffing behavior.
+	Plan                *UpdatePlan            // an optional update plan that the generated steps must conform to.
 }
 
 // DegreeOfParallelism returns the degree of parallelism that should be used during the
@@ -78,12 +83,15 @@ func (o Options) InfiniteParallelism() bool {
 type StepExecutorEvents interface {
 	OnResourceStepPre(step Step) (interface{}, error)
 	OnResourceStepPost(ctx interface{}, step Step, status resource.Status, err error) error
-	OnResourceOutputs(step Step) error
+	// OnResourceOutputs is called when the outputs of a step's new state are registered. It is responsible for setting
+	// the outputs of the state, so that it can do so without racing with any concurrent readers of the state.
+	OnResourceOutputs(step Step, outputs resource.PropertyMap) error
 }
 
-// PolicyEvents is an interface that can be used to hook policy violation events.
+// PolicyEvents is an interface that can be used to hook policy violation and remediation events.
 type PolicyEvents interface {
 	OnPolicyViolation(resource.URN, plugin.AnalyzeDiagnostic)
+	OnPolicyRemediation(resource.URN, plugin.Remediation, resource.PropertyMap, resource.PropertyMap)
 }
 
 // Events is an interface that can be used to hook interesting engine/planning events.
@@ -117,6 +125,7 @@ type Plan struct {
 	preview              bool                             // true if this plan is to be previewed rather than applied.
 	depGraph             *graph.DependencyGraph           // the dependency graph of the old snapshot
 	providers            *providers.Registry              // the provider registry for this plan.
+	updatePlan           *UpdatePlan                      // the update plan recorded while generating steps.
 }
 
 // addDefaultProviders adds any necessary default provider definitions and references to the given snapshot. Version
@@ -279,6 +288,7 @@ func NewPlan(ctx *plugin.Context, target *Target, prev *Snapshot, source Source,
 		preview:              preview,
 		depGraph:             depGraph,
 		providers:            reg,
+		updatePlan:           NewUpdatePlan(),
 	}, nil
 }
 
@@ -289,6 +299,16 @@ func (p *Plan) Prev() *Snapshot                        { return p.prev }
 func (p *Plan) Olds() map[resource.URN]*resource.State { return p.olds }
 func (p *Plan) Source() Source                         { return p.source }
 
+// UpdatePlan returns the operations that this plan generated. After a preview, this can be used to hold a later
+// update to the operations that were previewed.
+func (p *Plan) UpdatePlan() *UpdatePlan { return p.updatePlan }
+
+// isImport returns true if this plan imports a fixed set of resources rather than evaluating a program.
+func (p *Plan) isImport() bool {
+	_, ok := p.source.(*importSource)
+	return ok
+}
+
 func (p *Plan) GetProvider(ref providers.Reference) (plugin.Provider, bool) {
 	return p.providers.GetProvider(ref)
 }
@@ -16,6 +16,7 @@ package deploy
 
 import (
 	"context"
+	"fmt"
 	"strings"
 
 	"github.com/pkg/errors"
@@ -38,26 +39,10 @@ type planExecutor struct {
 	stepExec *stepExecutor  // step executor owned by this plan
 }
 
-// A set is returned of all the target URNs to facilitate later callers.  The set can be 'nil'
-// indicating no targets, or will be non-nil and non-empty if there are targets.  Only URNs in the
-// original array are in the set.  i.e. it's only checked for containment.  The value of the map is
-// unused.
-func createTargetMap(targets []resource.URN) map[resource.URN]bool {
-	if len(targets) == 0 {
-		return nil
-	}
-
-	targetMap := make(map[resource.URN]bool)
-	for _, target := range targets {
-		targetMap[target] = true
-	}
-
-	return targetMap
-}
-
 // checkTargets validates that all the targets passed in refer to existing resources.  Diagnostics
 // are generated for any target that cannot be found.  The target must either have existed in the stack
-// prior to running the operation, or it must be the urn for a resource that was created.
+// prior to running the operation, or it must be the urn for a resource that was created. A target that
+// is a pattern must match at least one such resource.
 func (pe *planExecutor) checkTargets(targets []resource.URN, op StepOp) result.Result {
 	if len(targets) == 0 {
 		return nil
@@ -71,6 +56,16 @@ func (pe *planExecutor) checkTargets(targets []resource.URN, op StepOp) result.R
 
 	hasUnknownTarget := false
 	for _, target := range targets {
+		if IsTargetPattern(target) {
+			if !pe.patternMatchesAny(target, olds, news) {
+				hasUnknownTarget = true
+
+				logging.V(7).Infof("Resource pattern to %v (%v) did not match any resources.", op, target)
+				pe.plan.Diag().Errorf(diag.GetTargetPatternMatchedNothingError(), target)
+			}
+			continue
+		}
+
 		hasOld := false
 		if _, has := olds[target]; has {
 			hasOld = true
@@ -96,6 +91,23 @@ func (pe *planExecutor) checkTargets(targets []resource.URN, op StepOp) result.R
 	return nil
 }
 
+// patternMatchesAny returns true if the given target pattern matches any of the given old or new resources.
+func (pe *planExecutor) patternMatchesAny(target resource.URN, olds map[resource.URN]*resource.State,
+	news map[resource.URN]bool) bool {
+
+	for urn := range olds {
+		if targetMatches(target, urn) {
+			return true
+		}
+	}
+	for urn := range news {
+		if targetMatches(target, urn) {
+			return true
+		}
+	}
+	return false
+}
+
 // reportExecResult issues an appropriate diagnostic depending on went wrong.
 func (pe *planExecutor) reportExecResult(message string, preview bool) {
 	kind := "update"
@@ -106,6 +118,20 @@ func (pe *planExecutor) reportExecResult(message string, preview bool) {
 	pe.reportError("", errors.New(kind+" "+message))
 }
 
+// reportFailedSteps issues a single diagnostic that lists each of the given failed steps.
+func (pe *planExecutor) reportFailedSteps(failed []Step) {
+	if len(failed) == 0 {
+		return
+	}
+
+	var message strings.Builder
+	fmt.Fprintf(&message, "%d resource operations failed:", len(failed))
+	for _, step := range failed {
+		fmt.Fprintf(&message, "\n    %s %s", step.Op(), step.URN())
+	}
+	pe.reportError("", errors.New(message.String()))
+}
+
 // reportError reports a single error to the executor's diag stream with the indicated URN for context.
 func (pe *planExecutor) reportError(urn resource.URN, err error) {
 	pe.plan.Diag().Errorf(diag.RawMessage(urn, err.Error()))
@@ -146,9 +172,17 @@ func (pe *planExecutor) Execute(callerCtx context.Context, opts Options, preview
 	// Non-nill means 'update only in this set'.  We don't error if the user specifies an target
 	// during `update` that we don't know about because it might be the urn for a resource they
 	// want to create.
-	updateTargetsOpt := createTargetMap(opts.UpdateTargets)
-	replaceTargetsOpt := createTargetMap(opts.ReplaceTargets)
-	destroyTargetsOpt := createTargetMap(opts.DestroyTargets)
+	//
+	// Exclusions apply to the destroy targets if there are any, and to the update targets otherwise. Excluding resources
+	// from a destroy without targets restricts the deletes to the resources that are neither excluded nor needed by an
+	// excluded resource.
+	var updateTargetsOpt, destroyTargetsOpt *targetSet
+	if len(opts.DestroyTargets) > 0 {
+		destroyTargetsOpt = newTargetSet(opts.DestroyTargets, opts.Excludes)
+	} else {
+		updateTargetsOpt = newTargetSet(opts.UpdateTargets, opts.Excludes)
+	}
+	replaceTargetsOpt := newTargetSet(opts.ReplaceTargets, nil)
 	if res := pe.checkTargets(opts.ReplaceTargets, OpReplace); res != nil {
 		return res
 	}
@@ -179,7 +213,7 @@ func (pe *planExecutor) Execute(callerCtx context.Context, opts Options, preview
 	ctx, cancel := context.WithCancel(callerCtx)
 
 	// Set up a step generator and executor for this plan.
-	pe.stepExec = newStepExecutor(ctx, cancel, pe.plan, opts, preview, false)
+	pe.stepExec = newStepExecutor(ctx, cancel, pe.plan, opts, preview, opts.ContinueOnError)
 
 	// We iterate the source in its own goroutine because iteration is blocking and we want the main loop to be able to
 	// respond to cancellation requests promptly.
@@ -208,7 +242,8 @@ func (pe *planExecutor) Execute(callerCtx context.Context, opts Options, preview
 	//  1. The SourceIterator sends us a `nil` event. This means that we're done processing source events and
 	//     we should begin processing deletes.
 	//  2. The SourceIterator sends us an error. This means some error occurred in the source program and we
-	//     should bail.
+	//     should bail. If the error may be due to failed registrations that we continued past, we finish the
+	//     rest of the plan first.
 	//  3. The stepExecCancel cancel context gets canceled. This means some error occurred in the step executor
 	//     and we need to bail. This can also happen if the user hits Ctrl-C.
 	canceled, res := func() (bool, result.Result) {
@@ -219,6 +254,17 @@ func (pe *planExecutor) Execute(callerCtx context.Context, opts Options, preview
 				logging.V(4).Infof("planExecutor.Execute(...): incoming event (nil? %v, %v)", event.Event == nil, event.Result)
 
 				if event.Result != nil {
+					// If we are continuing past errors and the program was told that some of its registrations
+					// failed, the program's failure is most likely due to them. In that case, let everything that
+					// is already underway run to completion and perform the deletes before reporting the failure.
+					if opts.ContinueOnError && pe.stepExec.FailedRegistrations() {
+						logging.V(4).Infof("planExecutor.Execute(...): program failed after failed registrations")
+						pe.stepExec.WaitForPendingChains()
+						if res := pe.performDeletes(ctx, updateTargetsOpt, destroyTargetsOpt); res != nil {
+							return false, res
+						}
+					}
+
 					if !event.Result.IsBail() {
 						pe.reportError("", event.Result.Error())
 					}
@@ -253,12 +299,29 @@ func (pe *planExecutor) Execute(callerCtx context.Context, opts Options, preview
 	pe.stepExec.WaitForCompletion()
 	logging.V(4).Infof("planExecutor.Execute(...): step executor has completed")
 
+	// If we continued past failed steps, summarize all of the failures now that nothing else is going to run.
+	if opts.ContinueOnError {
+		pe.reportFailedSteps(pe.stepExec.Failed())
+	}
+
 	// Now that we've performed all steps in the plan, ensure that the list of targets to update was
 	// valid.  We have to do this *after* performing the steps as the target list may have referred
 	// to a resource that was created in one of hte steps.
 	if res == nil {
 		res = pe.checkTargets(opts.UpdateTargets, OpUpdate)
 	}
+	if res == nil {
+		res = pe.checkTargets(opts.Excludes, OpSame)
+	}
+
+	// Likewise, ensure that every operation in the update plan was performed. This is only meaningful if execution ran
+	// to completion.
+	if res == nil && !canceled && !pe.stepExec.Errored() {
+		if err := pe.stepGen.CheckPlanCompleted(); err != nil {
+			pe.reportError("", err)
+			res = result.Bail()
+		}
+	}
 
 	if res != nil && res.IsBail() {
 		return res
@@ -292,7 +355,7 @@ func (pe *planExecutor) Execute(callerCtx context.Context, opts Options, preview
 }
 
 func (pe *planExecutor) performDeletes(
-	ctx context.Context, updateTargetsOpt, destroyTargetsOpt map[resource.URN]bool) result.Result {
+	ctx context.Context, updateTargetsOpt, destroyTargetsOpt *targetSet) result.Result {
 
 	defer func() {
 		// We're done here - signal completion so that the step executor knows to terminate.
@@ -309,7 +372,7 @@ func (pe *planExecutor) performDeletes(
 	// At this point we have generated the set of resources above that we would normally want to
 	// delete.  However, if the user provided -target's we will only actually delete the specific
 	// resources that are in the set explicitly asked for.
-	var targetsOpt map[resource.URN]bool
+	var targetsOpt *targetSet
 	if updateTargetsOpt != nil {
 		targetsOpt = updateTargetsOpt
 	} else if destroyTargetsOpt != nil {
@@ -331,7 +394,12 @@ func (pe *planExecutor) performDeletes(
 	// This is not "true" delete parallelism, since there may be resources that could safely begin
 	// deleting but we won't until the previous set of deletes fully completes. This approximation
 	// is conservative, but correct.
+	skipped := make(map[resource.URN]bool)
 	for _, antichain := range deletes {
+		if pe.stepExec.continueOnError {
+			antichain = pe.skipDeletesOfFailedDependencies(antichain, skipped)
+		}
+
 		logging.V(4).Infof("planExecutor.Execute(...): beginning delete antichain")
 		tok := pe.stepExec.ExecuteParallel(antichain)
 		tok.Wait(ctx)
@@ -352,6 +420,55 @@ func (pe *planExecutor) performDeletes(
 	return nil
 }
 
+// skipDeletesOfFailedDependencies removes any steps from the given antichain of deletes that would delete a resource
+// that must outlive a resource that failed to delete or whose delete was skipped: a resource on which such a resource
+// depends, or one of its ancestors. The skipped deletes are recorded in the given set. Deletes are scheduled
+// dependents- and children-first, so the failures from earlier antichains are known by the time a later antichain is
+// filtered.
+func (pe *planExecutor) skipDeletesOfFailedDependencies(deletes antichain, skipped map[resource.URN]bool) antichain {
+	remaining := make(map[resource.URN]bool)
+	for urn := range skipped {
+		remaining[urn] = true
+	}
+	for _, step := range pe.stepExec.Failed() {
+		remaining[step.URN()] = true
+	}
+	if len(remaining) == 0 {
+		return deletes
+	}
+
+	// A resource may not be deleted while any of its descendants remain.
+	ancestors := make(map[resource.URN]bool)
+	for urn := range remaining {
+		for res := pe.plan.olds[urn]; res != nil && res.Parent != ""; res = pe.plan.olds[res.Parent] {
+			ancestors[res.Parent] = true
+		}
+	}
+
+	var steps antichain
+	for _, step := range deletes {
+		skip := ancestors[step.URN()]
+		if old := step.Old(); !skip && old != nil {
+			for _, dependent := range pe.plan.depGraph.DependingOn(old, nil) {
+				if remaining[dependent.URN] {
+					skip = true
+					break
+				}
+			}
+		}
+		if skip {
+			logging.V(7).Infof("performDeletes(...): skipping %v on %v: a dependent or child was not deleted",
+				step.Op(), step.URN())
+			pe.plan.Ctx().StatusDiag.Infof(diag.RawMessage(step.URN(),
+				"skipping deletion because a resource that depends on it or one of its children was not deleted"))
+			skipped[step.URN()] = true
+			continue
+		}
+		steps = append(steps, step)
+	}
+	return steps
+}
+
 // handleSingleEvent handles a single source event. For all incoming events, it produces a chain that needs
 // to be executed and schedules the chain for execution.
 func (pe *planExecutor) handleSingleEvent(event SourceEvent) result.Result {
@@ -431,10 +548,13 @@ func (pe *planExecutor) refresh(callerCtx context.Context, opts Options, preview
 	}
 
 	// Make sure if there were any targets specified, that they all refer to existing resources.
-	targetMapOpt := createTargetMap(opts.RefreshTargets)
+	targetsOpt := newTargetSet(opts.RefreshTargets, opts.Excludes)
 	if res := pe.checkTargets(opts.RefreshTargets, OpRefresh); res != nil {
 		return res
 	}
+	if res := pe.checkTargets(opts.Excludes, OpRefresh); res != nil {
+		return res
+	}
 
 	// If the user did not provide any --target's, create a refresh step for each resource in the
 	// old snapshot.  If they did provider --target's then only create refresh steps for those
@@ -442,7 +562,7 @@ func (pe *planExecutor) refresh(callerCtx context.Context, opts Options, preview
 	steps := []Step{}
 	resourceToStep := map[*resource.State]Step{}
 	for _, res := range prev.Resources {
-		if targetMapOpt == nil || targetMapOpt[res.URN] {
+		if targetsOpt.Contains(res.URN) {
 			step := NewRefreshStep(pe.plan, res, nil)
 			steps = append(steps, step)
 			resourceToStep[res] = step
@@ -89,6 +89,7 @@ type RegisterResourceEvent interface {
 // RegisterResult is the state of the resource after it has been registered.
 type RegisterResult struct {
 	State *resource.State // the resource state.
+	Err   error           // the error that prevented the resource from being registered, if any.
 }
 
 // RegisterResourceOutputsEvent is an event that asks the engine to complete the provisioning of a resource.
@@ -128,4 +129,5 @@ type ReadResourceEvent interface {
 
 type ReadResult struct {
 	State *resource.State
+	Err   error // the error that prevented the resource from being read, if any.
 }
@@ -17,6 +17,7 @@ package deploy
 import (
 	"context"
 	"fmt"
+	"sync"
 	"time"
 
 	"github.com/blang/semver"
@@ -95,7 +96,7 @@ func (src *evalSource) Iterate(
 	regChan := make(chan *registerResourceEvent)
 	regOutChan := make(chan *registerResourceOutputsEvent)
 	regReadChan := make(chan *readResourceEvent)
-	mon, err := newResourceMonitor(src, providers, regChan, regOutChan, regReadChan, tracingSpan)
+	mon, err := newResourceMonitor(src, opts, providers, regChan, regOutChan, regReadChan, tracingSpan)
 	if err != nil {
 		return nil, result.FromError(errors.Wrap(err, "failed to start resource monitor"))
 	}
@@ -236,7 +237,10 @@ type defaultProviders struct {
 	// A map of ProviderRequest strings to provider references, used to keep track of the set of default providers that
 	// have already been loaded.
 	providers map[string]providers.Reference
-	config    plugin.ConfigSource
+	// A map of ProviderRequest strings to the errors that prevented default providers from being registered. This is
+	// only populated when the engine continues past failed steps.
+	failures map[string]error
+	config   plugin.ConfigSource
 
 	requests        chan defaultProviderRequest
 	providerRegChan chan<- *registerResourceEvent
@@ -300,7 +304,7 @@ func (d *defaultProviders) newRegisterDefaultProviderEvent(
 	event := &registerResourceEvent{
 		goal: resource.NewGoal(
 			providers.MakeProviderType(req.Package()),
-			req.Name(), true, inputs, "", false, nil, "", nil, nil, nil, nil, nil, nil, "", nil),
+			req.Name(), true, inputs, "", false, nil, "", nil, nil, nil, nil, nil, nil, "", nil, nil, false),
 		done: done,
 	}
 	return event, done, nil
@@ -326,6 +330,9 @@ func (d *defaultProviders) handleRequest(req providers.ProviderRequest) (provide
 	if ok {
 		return ref, nil
 	}
+	if err, failed := d.failures[req.String()]; failed {
+		return providers.Reference{}, err
+	}
 
 	event, done, err := d.newRegisterDefaultProviderEvent(req)
 	if err != nil {
@@ -347,6 +354,15 @@ func (d *defaultProviders) handleRequest(req providers.ProviderRequest) (provide
 		return providers.Reference{}, context.Canceled
 	}
 
+	if result.Err != nil {
+		logging.V(5).Infof("failed to register default provider for package %s: %v", req, result.Err)
+		if d.failures == nil {
+			d.failures = make(map[string]error)
+		}
+		d.failures[req.String()] = result.Err
+		return providers.Reference{}, result.Err
+	}
+
 	logging.V(5).Infof("registered default provider for package %s: %s", req, result.State.URN)
 
 	id := result.State.ID
@@ -391,20 +407,24 @@ func (d *defaultProviders) getDefaultProviderRef(req providers.ProviderRequest)
 // resmon implements the pulumirpc.ResourceMonitor interface and acts as the gateway between a language runtime's
 // evaluation of a program and the internal resource planning and deployment logic.
 type resmon struct {
-	providers        ProviderSource                     // the provider source itself.
-	defaultProviders *defaultProviders                  // the default provider manager.
-	regChan          chan *registerResourceEvent        // the channel to send resource registrations to.
-	regOutChan       chan *registerResourceOutputsEvent // the channel to send resource output registrations to.
-	regReadChan      chan *readResourceEvent            // the channel to send resource reads to.
-	addr             string                             // the address the host is listening on.
-	cancel           chan bool                          // a channel that can cancel the server.
-	done             chan error                         // a channel that resolves when the server completes.
+	providers         ProviderSource                     // the provider source itself.
+	defaultProviders  *defaultProviders                  // the default provider manager.
+	target            *Target                            // the target being deployed into.
+	constructInfo     plugin.ConstructInfo               // information for construct calls.
+	constructInfoOnce sync.Once                          // guards the decryption of the construct config.
+	constructInfoErr  error                              // the error, if any, from decrypting the construct config.
+	regChan           chan *registerResourceEvent        // the channel to send resource registrations to.
+	regOutChan        chan *registerResourceOutputsEvent // the channel to send resource output registrations to.
+	regReadChan       chan *readResourceEvent            // the channel to send resource reads to.
+	addr              string                             // the address the host is listening on.
+	cancel            chan bool                          // a channel that can cancel the server.
+	done              chan error                         // a channel that resolves when the server completes.
 }
 
 var _ SourceResourceMonitor = (*resmon)(nil)
 
 // newResourceMonitor creates a new resource monitor RPC server.
-func newResourceMonitor(src *evalSource, provs ProviderSource, regChan chan *registerResourceEvent,
+func newResourceMonitor(src *evalSource, opts Options, provs ProviderSource, regChan chan *registerResourceEvent,
 	regOutChan chan *registerResourceOutputsEvent, regReadChan chan *readResourceEvent,
 	tracingSpan opentracing.Span) (*resmon, error) {
 
@@ -425,6 +445,7 @@ func newResourceMonitor(src *evalSource, provs ProviderSource, regChan chan *reg
 	resmon := &resmon{
 		providers:        provs,
 		defaultProviders: d,
+		target:           src.runinfo.Target,
 		regChan:          regChan,
 		regOutChan:       regOutChan,
 		regReadChan:      regReadChan,
@@ -445,11 +466,33 @@ func newResourceMonitor(src *evalSource, provs ProviderSource, regChan chan *reg
 	resmon.addr = fmt.Sprintf("127.0.0.1:%d", port)
 	resmon.done = done
 
+	resmon.constructInfo = plugin.ConstructInfo{
+		Project:        string(src.runinfo.Proj.Name),
+		Stack:          string(src.runinfo.Target.Name),
+		DryRun:         src.dryRun,
+		Parallel:       opts.Parallel,
+		MonitorAddress: resmon.addr,
+	}
+
 	go d.serve()
 
 	return resmon, nil
 }
 
+// getConstructInfo returns the information passed to providers that construct components. The target's configuration
+// is decrypted on the first call so that programs without remote components never need a decrypter.
+func (rm *resmon) getConstructInfo() (plugin.ConstructInfo, error) {
+	rm.constructInfoOnce.Do(func() {
+		config, err := rm.target.Config.Decrypt(rm.target.Decrypter)
+		if err != nil {
+			rm.constructInfoErr = err
+			return
+		}
+		rm.constructInfo.Config = config
+	})
+	return rm.constructInfo, rm.constructInfoErr
+}
+
 // Address returns the address at which the monitor's RPC server may be reached.
 func (rm *resmon) Address() string {
 	return rm.addr
@@ -520,7 +563,7 @@ func (rm *resmon) SupportsFeature(ctx context.Context,
 	hasSupport := false
 
 	switch req.Id {
-	case "secrets":
+	case "secrets", "remote":
 		hasSupport = true
 	}
 
@@ -703,6 +746,9 @@ func (rm *resmon) ReadResource(ctx context.Context,
 	}
 
 	contract.Assert(result != nil)
+	if result.Err != nil {
+		return nil, result.Err
+	}
 	marshaled, err := plugin.MarshalProperties(result.State.Outputs, plugin.MarshalOptions{
 		Label:        label,
 		KeepUnknowns: true,
@@ -729,13 +775,17 @@ func (rm *resmon) RegisterResource(ctx context.Context,
 	protect := req.GetProtect()
 	deleteBeforeReplaceValue := req.GetDeleteBeforeReplace()
 	ignoreChanges := req.GetIgnoreChanges()
+	replaceOnChanges := req.GetReplaceOnChanges()
+	retainOnDelete := req.GetRetainOnDelete()
+	remote := req.GetRemote()
 	id := resource.ID(req.GetImportId())
 	customTimeouts := req.GetCustomTimeouts()
 	var t tokens.Type
 
 	// Custom resources must have a three-part type so that we can 1) identify if they are providers and 2) retrieve the
-	// provider responsible for managing a particular resource (based on the type's Package).
-	if custom {
+	// provider responsible for managing a particular resource (based on the type's Package). The same goes for remote
+	// components, which are constructed by the provider for their type's package.
+	if custom || remote {
 		var err error
 		t, err = tokens.ParseTypeToken(req.GetType())
 		if err != nil {
@@ -760,6 +810,18 @@ func (rm *resmon) RegisterResource(ctx context.Context,
 		provider = ref.String()
 	}
 
+	var providerRef providers.Reference
+	if remote {
+		providerReq, err := parseProviderRequest(t.Package(), req.GetVersion())
+		if err != nil {
+			return nil, err
+		}
+		providerRef, err = getProviderReference(rm.defaultProviders, providerReq, provider)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	aliases := []resource.URN{}
 	for _, aliasURN := range req.GetAliases() {
 		aliases = append(aliases, resource.URN(aliasURN))
@@ -836,31 +898,76 @@ func (rm *resmon) RegisterResource(ctx context.Context,
 
 	logging.V(5).Infof(
 		"ResourceMonitor.RegisterResource received: t=%v, name=%v, custom=%v, #props=%v, parent=%v, protect=%v, "+
-			"provider=%v, deps=%v, deleteBeforeReplace=%v, ignoreChanges=%v, aliases=%v, customTimeouts=%v",
+			"provider=%v, deps=%v, deleteBeforeReplace=%v, ignoreChanges=%v, replaceOnChanges=%v, "+
+			"retainOnDelete=%v, aliases=%v, customTimeouts=%v, remote=%v",
 		t, name, custom, len(props), parent, protect, provider, dependencies, deleteBeforeReplace, ignoreChanges,
-		aliases, timeouts)
+		replaceOnChanges, retainOnDelete, aliases, timeouts, remote)
 
-	// Send the goal state to the engine.
-	step := &registerResourceEvent{
-		goal: resource.NewGoal(t, name, custom, props, parent, protect, dependencies, provider, nil,
-			propertyDependencies, deleteBeforeReplace, ignoreChanges, additionalSecretOutputs, aliases, id, &timeouts),
-		done: make(chan *RegisterResult),
-	}
+	var result *RegisterResult
+	var outputDeps map[string]*pulumirpc.RegisterResourceResponse_PropertyDependencies
+	if remote {
+		// Remote components are constructed by their provider, which registers the component and its children with
+		// this monitor.
+		prov, ok := rm.providers.GetProvider(providerRef)
+		if !ok {
+			return nil, errors.Errorf("unknown provider '%v'", providerRef)
+		}
 
-	select {
-	case rm.regChan <- step:
-	case <-rm.cancel:
-		logging.V(5).Infof("ResourceMonitor.RegisterResource operation canceled, name=%s", name)
-		return nil, rpcerror.New(codes.Unavailable, "resource monitor shut down while sending resource registration")
-	}
+		constructInfo, err := rm.getConstructInfo()
+		if err != nil {
+			return nil, err
+		}
 
-	// Now block waiting for the operation to finish.
-	var result *RegisterResult
-	select {
-	case result = <-step.done:
-	case <-rm.cancel:
-		logging.V(5).Infof("ResourceMonitor.RegisterResource operation canceled, name=%s", name)
-		return nil, rpcerror.New(codes.Unavailable, "resource monitor shut down while waiting on step's done channel")
+		constructResult, err := prov.Construct(constructInfo, t, name, parent, props, plugin.ConstructOptions{
+			Aliases:              aliases,
+			Dependencies:         dependencies,
+			Protect:              protect,
+			Providers:            req.GetProviders(),
+			PropertyDependencies: propertyDependencies,
+		})
+		if err != nil {
+			return nil, err
+		}
+		result = &RegisterResult{State: &resource.State{
+			Type:    t,
+			URN:     constructResult.URN,
+			Outputs: constructResult.Outputs,
+		}}
+
+		outputDeps = make(map[string]*pulumirpc.RegisterResourceResponse_PropertyDependencies)
+		for k, urns := range constructResult.OutputDependencies {
+			deps := make([]string, len(urns))
+			for i, urn := range urns {
+				deps[i] = string(urn)
+			}
+			outputDeps[string(k)] = &pulumirpc.RegisterResourceResponse_PropertyDependencies{Urns: deps}
+		}
+	} else {
+		// Send the goal state to the engine.
+		step := &registerResourceEvent{
+			goal: resource.NewGoal(t, name, custom, props, parent, protect, dependencies, provider, nil,
+				propertyDependencies, deleteBeforeReplace, ignoreChanges, additionalSecretOutputs, aliases, id,
+				&timeouts, replaceOnChanges, retainOnDelete),
+			done: make(chan *RegisterResult),
+		}
+
+		select {
+		case rm.regChan <- step:
+		case <-rm.cancel:
+			logging.V(5).Infof("ResourceMonitor.RegisterResource operation canceled, name=%s", name)
+			return nil, rpcerror.New(codes.Unavailable, "resource monitor shut down while sending resource registration")
+		}
+
+		// Now block waiting for the operation to finish.
+		select {
+		case result = <-step.done:
+		case <-rm.cancel:
+			logging.V(5).Infof("ResourceMonitor.RegisterResource operation canceled, name=%s", name)
+			return nil, rpcerror.New(codes.Unavailable, "resource monitor shut down while waiting on step's done channel")
+		}
+		if result.Err != nil {
+			return nil, result.Err
+		}
 	}
 
 	// Filter out partially-known values if the requestor does not support them.
@@ -891,9 +998,10 @@ func (rm *resmon) RegisterResource(ctx context.Context,
 		return nil, err
 	}
 	return &pulumirpc.RegisterResourceResponse{
-		Urn:    string(state.URN),
-		Id:     string(state.ID),
-		Object: obj,
+		Urn:                  string(state.URN),
+		Id:                   string(state.ID),
+		Object:               obj,
+		PropertyDependencies: outputDeps,
 	}, nil
 }
 
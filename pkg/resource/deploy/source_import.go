@@ -0,0 +1,242 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deploy
+
+import (
+	"context"
+	"sync"
+
+	"github.com/blang/semver"
+	"github.com/pkg/errors"
+
+	"github.com/pulumi/pulumi/pkg/v2/resource/deploy/providers"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/resource"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/tokens"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/util/contract"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/util/logging"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/util/result"
+)
+
+// Import specifies a resource to import.
+type Import struct {
+	Type     tokens.Type     // The type token for the resource. Required.
+	Name     tokens.QName    // The name of the resource. Required.
+	ID       resource.ID     // The ID of the resource. Required.
+	Parent   resource.URN    // The parent of the resource, if any. Defaults to the root stack resource.
+	Provider resource.URN    // The specific provider to use for the resource, if any.
+	Version  *semver.Version // The provider version to use for the resource, if any.
+	Protect  bool            // Whether to mark the resource as protected after import.
+}
+
+// NewImportSource returns a planning source that registers a fixed list of resources to import, along with any root
+// stack resource and default providers they require, without evaluating a program.
+func NewImportSource(project tokens.PackageName, target *Target, imports []Import,
+	defaultProviderVersions map[tokens.Package]*semver.Version) Source {
+
+	return &importSource{
+		project:                 project,
+		target:                  target,
+		imports:                 imports,
+		defaultProviderVersions: defaultProviderVersions,
+	}
+}
+
+type importSource struct {
+	project                 tokens.PackageName                 // the project the resources are imported into.
+	target                  *Target                            // the stack the resources are imported into.
+	imports                 []Import                           // the resources to import.
+	defaultProviderVersions map[tokens.Package]*semver.Version // the default provider versions for this source.
+}
+
+func (src *importSource) Close() error                { return nil }
+func (src *importSource) Project() tokens.PackageName { return src.project }
+func (src *importSource) Info() interface{}           { return nil }
+
+// RootStackURN returns the URN of the root stack resource that imported resources are parented to by default.
+func (src *importSource) RootStackURN() resource.URN {
+	return resource.DefaultRootStackURN(src.target.Name, src.project)
+}
+
+// ImportURNs returns the URNs that the resources to import will be assigned, in order.
+func ImportURNs(target *Target, project tokens.PackageName, imports []Import) []resource.URN {
+	root := resource.DefaultRootStackURN(target.Name, project)
+
+	urns := make([]resource.URN, len(imports))
+	for i, imp := range imports {
+		parent := imp.Parent
+		if parent == "" {
+			parent = root
+		}
+		parentType := tokens.Type("")
+		if parent.Type() != resource.RootStackType {
+			parentType = parent.QualifiedType()
+		}
+		urns[i] = resource.NewURN(target.Name, project, parentType, imp.Type, imp.Name)
+	}
+	return urns
+}
+
+func (src *importSource) Iterate(
+	ctx context.Context, opts Options, providerSource ProviderSource) (SourceIterator, result.Result) {
+
+	regChan := make(chan *registerResourceEvent)
+	cancel := make(chan bool)
+
+	iter := &importSourceIterator{
+		src:     src,
+		regChan: regChan,
+		finChan: make(chan result.Result),
+		cancel:  cancel,
+		defaultProviders: &defaultProviders{
+			defaultVersions: src.defaultProviderVersions,
+			providers:       make(map[string]providers.Reference),
+			config:          src.target,
+			requests:        make(chan defaultProviderRequest),
+			providerRegChan: regChan,
+			cancel:          cancel,
+		},
+	}
+
+	go iter.defaultProviders.serve()
+	go func() {
+		select {
+		case <-ctx.Done():
+			iter.Close()
+		case <-cancel:
+		}
+	}()
+	go func() {
+		res := iter.run()
+		select {
+		case iter.finChan <- res:
+		case <-cancel:
+		}
+	}()
+
+	return iter, nil
+}
+
+type importSourceIterator struct {
+	src              *importSource
+	regChan          chan *registerResourceEvent // the channel that contains resource registrations.
+	finChan          chan result.Result          // the channel that communicates completion.
+	cancel           chan bool                   // the channel that is closed when the iterator is closed.
+	cancelOnce       sync.Once                   // ensures that the cancellation channel is closed exactly once.
+	defaultProviders *defaultProviders           // the default provider manager.
+	done             bool                        // set to true when the iteration is done.
+}
+
+func (iter *importSourceIterator) Close() error {
+	iter.cancelOnce.Do(func() { close(iter.cancel) })
+	return nil
+}
+
+func (iter *importSourceIterator) Next() (SourceEvent, result.Result) {
+	if iter.done {
+		return nil, nil
+	}
+
+	select {
+	case reg := <-iter.regChan:
+		contract.Assert(reg != nil)
+		goal := reg.Goal()
+		logging.V(5).Infof("ImportSourceIterator produced a registration: t=%v,name=%v", goal.Type, goal.Name)
+		return reg, nil
+	case res := <-iter.finChan:
+		iter.done = true
+		contract.IgnoreError(iter.Close())
+		return nil, res
+	}
+}
+
+// run registers the root stack resource followed by each resource to import.
+func (iter *importSourceIterator) run() result.Result {
+	src := iter.src
+
+	olds := make(map[resource.URN]*resource.State)
+	if snap := src.target.Snapshot; snap != nil {
+		for _, res := range snap.Resources {
+			if !res.Delete {
+				olds[res.URN] = res
+			}
+		}
+	}
+
+	// The root stack resource is always registered. If it already exists it is not targeted and will be same'd,
+	// which keeps it ahead of the resources parented to it in the resulting snapshot.
+	rootURN := src.RootStackURN()
+	goal := resource.NewGoal(resource.RootStackType, rootURN.Name(), false, resource.PropertyMap{}, "", false,
+		nil, "", nil, nil, nil, nil, nil, nil, "", nil, nil, false)
+	if _, err := iter.register(goal); err != nil {
+		return result.FromError(err)
+	}
+
+	for _, imp := range src.imports {
+		parent := imp.Parent
+		if parent == "" {
+			parent = rootURN
+		}
+
+		var ref providers.Reference
+		if imp.Provider != "" {
+			prov, ok := olds[imp.Provider]
+			if !ok || !providers.IsProviderType(prov.Type) {
+				return result.Errorf("unknown provider '%v' for resource '%v'", imp.Provider, imp.Name)
+			}
+			r, err := providers.NewReference(prov.URN, prov.ID)
+			if err != nil {
+				return result.FromError(err)
+			}
+			ref = r
+		} else {
+			req := providers.NewProviderRequest(imp.Version, imp.Type.Package())
+			r, err := iter.defaultProviders.getDefaultProviderRef(req)
+			if err != nil {
+				return result.FromError(err)
+			}
+			ref = r
+		}
+
+		goal := resource.NewGoal(imp.Type, imp.Name, true, resource.PropertyMap{}, parent, imp.Protect, nil,
+			ref.String(), nil, nil, nil, nil, nil, nil, imp.ID, nil, nil, false)
+		if _, err := iter.register(goal); err != nil {
+			return result.FromError(err)
+		}
+	}
+
+	return nil
+}
+
+// register sends a registration for the given goal to the engine and awaits its result.
+func (iter *importSourceIterator) register(goal *resource.Goal) (*RegisterResult, error) {
+	done := make(chan *RegisterResult)
+	event := &registerResourceEvent{goal: goal, done: done}
+
+	select {
+	case iter.regChan <- event:
+	case <-iter.cancel:
+		return nil, context.Canceled
+	}
+
+	select {
+	case res := <-done:
+		if res == nil || res.State == nil {
+			return nil, errors.Errorf("failed to register resource '%v'", goal.Name)
+		}
+		return res, nil
+	case <-iter.cancel:
+		return nil, context.Canceled
+	}
+}
@@ -255,7 +255,8 @@ func (s *CreateStep) Apply(preview bool) (resource.Status, StepCompleteFunc, err
 }
 
 // DeleteStep is a mutating step that deletes an existing resource. If `old` is marked "External",
-// DeleteStep is a no-op.
+// DeleteStep is a no-op. If `old` is marked "RetainOnDelete", DeleteStep only removes the resource from the
+// checkpoint.
 type DeleteStep struct {
 	plan      *Plan           // the current plan.
 	old       *resource.State // the state of the existing resource.
@@ -331,8 +332,9 @@ func (s *DeleteStep) Apply(preview bool) (resource.Status, StepCompleteFunc, err
 			errors.Errorf("refusing to delete protected resource '%s'", s.old.URN)
 	}
 
-	// Deleting an External resource is a no-op, since Pulumi does not own the lifecycle.
-	if !preview && !s.old.External {
+	// Deleting an External resource is a no-op, since Pulumi does not own the lifecycle. Deleting a resource that
+	// is marked RetainOnDelete only removes it from the checkpoint.
+	if !preview && !s.old.External && !s.old.RetainOnDelete {
 		if s.old.Custom {
 			// Invoke the Delete RPC function for this provider:
 			prov, err := getProvider(s)
@@ -761,7 +763,8 @@ func (s *RefreshStep) Apply(preview bool) (resource.Status, StepCompleteFunc, er
 		s.new = resource.NewState(s.old.Type, s.old.URN, s.old.Custom, s.old.Delete, resourceID, inputs, outputs,
 			s.old.Parent, s.old.Protect, s.old.External, s.old.Dependencies, initErrors, s.old.Provider,
 			s.old.PropertyDependencies, s.old.PendingReplacement, s.old.AdditionalSecretOutputs, s.old.Aliases,
-			&s.old.CustomTimeouts, s.old.ImportID)
+			&s.old.CustomTimeouts, s.old.ImportID, s.old.ReplaceOnChanges,
+			s.old.RetainOnDelete)
 	} else {
 		s.new = nil
 	}
@@ -779,6 +782,7 @@ type ImportStep struct {
 	diffs         []resource.PropertyKey         // any keys that differed between the user's program and the actual state.
 	detailedDiff  map[string]plugin.PropertyDiff // the structured property diff.
 	ignoreChanges []string                       // a list of property paths to ignore when updating.
+	planned       bool                           // true if this import came from an import source, not a program.
 }
 
 func NewImportStep(plan *Plan, reg RegisterResourceEvent, new *resource.State, ignoreChanges []string) Step {
@@ -794,6 +798,7 @@ func NewImportStep(plan *Plan, reg RegisterResourceEvent, new *resource.State, i
 		reg:           reg,
 		new:           new,
 		ignoreChanges: ignoreChanges,
+		planned:       plan.isImport(),
 	}
 }
 
@@ -866,12 +871,24 @@ func (s *ImportStep) Apply(preview bool) (resource.Status, StepCompleteFunc, err
 	}
 	s.new.Outputs = read.Outputs
 
+	// If this resource is being imported without a program, adopt the inputs reported by the provider as-is: there
+	// are no user inputs to check or to compare against the existing resource.
+	if s.planned {
+		s.new.Inputs = read.Inputs
+		s.old = resource.NewState(s.new.Type, s.new.URN, s.new.Custom, false, s.new.ID, read.Inputs, read.Outputs,
+			s.new.Parent, s.new.Protect, false, s.new.Dependencies, s.new.InitErrors, s.new.Provider,
+			s.new.PropertyDependencies, false, nil, nil, &s.new.CustomTimeouts, s.new.ImportID, s.new.ReplaceOnChanges,
+			s.new.RetainOnDelete)
+		return rst, complete, nil
+	}
+
 	// Magic up an old state so the frontend can display a proper diff. This state is the output of the just-executed
 	// `Read` combined with the resource identity and metadata from the desired state. This ensures that the only
 	// differences between the old and new states are between the inputs and outputs.
 	s.old = resource.NewState(s.new.Type, s.new.URN, s.new.Custom, false, s.new.ID, read.Inputs, read.Outputs,
 		s.new.Parent, s.new.Protect, false, s.new.Dependencies, s.new.InitErrors, s.new.Provider,
-		s.new.PropertyDependencies, false, nil, nil, &s.new.CustomTimeouts, s.new.ImportID)
+		s.new.PropertyDependencies, false, nil, nil, &s.new.CustomTimeouts, s.new.ImportID, s.new.ReplaceOnChanges,
+		s.new.RetainOnDelete)
 
 	// Check the user inputs using the provider inputs for defaults.
 	inputs, failures, err := prov.Check(s.new.URN, s.old.Inputs, s.new.Inputs, preview)
@@ -93,10 +93,18 @@ type stepExecutor struct {
 
 	workers        sync.WaitGroup     // WaitGroup tracking the worker goroutines that are owned by this step executor.
 	incomingChains chan incomingChain // Incoming chains that we are to execute
+	pendingChains  sync.WaitGroup     // WaitGroup tracking the chains that have been submitted but not yet completed.
+	limiter        *stepLimiter       // Per-provider and per-type parallelism limits, or nil if there are none.
 
 	ctx      context.Context    // cancellation context for the current plan.
 	cancel   context.CancelFunc // CancelFunc that cancels the above context.
 	sawError atomic.Value       // atomic boolean indicating whether or not the step excecutor saw that there was an error.
+
+	failedLock sync.Mutex // lock protecting failed.
+	failed     []Step     // the steps whose execution ended in failure.
+
+	// atomic boolean indicating whether or not a failed registration was reported to the program.
+	failedRegistrations atomic.Value
 }
 
 //
@@ -112,10 +120,12 @@ func (se *stepExecutor) ExecuteSerial(chain chain) completionToken {
 	// If one is pending, we should exit early - we will shortly be tearing down the engine and exiting.
 
 	completion := make(chan bool)
+	se.pendingChains.Add(1)
 	select {
 	case se.incomingChains <- incomingChain{Chain: chain, CompletionChan: completion}:
 	case <-se.ctx.Done():
 		close(completion)
+		se.pendingChains.Done()
 	}
 
 	return completionToken{channel: completion}
@@ -160,24 +170,26 @@ func (se *stepExecutor) ExecuteRegisterResourceOutputs(e RegisterResourceOutputs
 	outs := e.Outputs()
 	se.log(synchronousWorkerID,
 		"registered resource outputs %s: old=#%d, new=#%d", urn, len(reg.New().Outputs), len(outs))
-	reg.New().Outputs = e.Outputs()
-	// If there is an event subscription for finishing the resource, execute them.
-	if e := se.opts.Events; e != nil {
-		if eventerr := e.OnResourceOutputs(reg); eventerr != nil {
-			se.log(synchronousWorkerID, "register resource outputs failed: %s", eventerr.Error())
-
-			// This is a bit of a kludge, but ExecuteRegisterResourceOutputs is an odd duck
-			// in that it doesn't execute on worker goroutines. Arguably, it should, but today it's
-			// not possible to express RegisterResourceOutputs as a step. We could 1) more generally allow
-			// clients of stepExecutor to do work on worker threads by e.g. scheduling arbitrary callbacks
-			// or 2) promote RRE to be step-like so that it can be scheduled as if it were a step. Neither
-			// of these are particularly appealing right now.
-			outErr := errors.Wrap(eventerr, "resource complete event returned an error")
-			diagMsg := diag.RawMessage(reg.URN(), outErr.Error())
-			se.plan.Diag().Errorf(diagMsg)
-			se.cancelDueToError()
-			return
-		}
+	// If there is an event subscription for finishing the resource, execute them. The subscription sets the outputs.
+	if e := se.opts.Events; e == nil {
+		reg.New().Outputs = outs
+	} else if eventerr := e.OnResourceOutputs(reg, outs); eventerr != nil {
+		se.log(synchronousWorkerID, "register resource outputs failed: %s", eventerr.Error())
+
+		// This is a bit of a kludge, but ExecuteRegisterResourceOutputs is an odd duck
+		// in that it doesn't execute on worker goroutines. Arguably, it should, but today it's
+		// not possible to express RegisterResourceOutputs as a step. We could 1) more generally allow
+		// clients of stepExecutor to do work on worker threads by e.g. scheduling arbitrary callbacks
+		// or 2) promote RRE to be step-like so that it can be scheduled as if it were a step. Neither
+		// of these are particularly appealing right now.
+		outErr := errors.Wrap(eventerr, "resource complete event returned an error")
+		diagMsg := diag.RawMessage(reg.URN(), outErr.Error())
+		se.plan.Diag().Errorf(diagMsg)
+
+		// The program is waiting for this event to complete, so we cannot continue past this error.
+		se.sawError.Store(true)
+		se.cancel()
+		return
 	}
 	e.Done()
 }
@@ -193,6 +205,14 @@ func (se *stepExecutor) SignalCompletion() {
 	close(se.incomingChains)
 }
 
+// WaitForPendingChains blocks the calling goroutine until every chain that has been submitted so far has completed
+// execution. Unlike WaitForCompletion, it does not require that no more chains will be submitted.
+func (se *stepExecutor) WaitForPendingChains() {
+	se.log(synchronousWorkerID, "StepExecutor.WaitForPendingChains(): waiting for pending chains to complete")
+	se.pendingChains.Wait()
+	se.log(synchronousWorkerID, "StepExecutor.WaitForPendingChains(): pending chains all completed")
+}
+
 // WaitForCompletion blocks the calling goroutine until the step executor completes execution of all in-flight
 // chains.
 func (se *stepExecutor) WaitForCompletion() {
@@ -210,7 +230,7 @@ func (se *stepExecutor) WaitForCompletion() {
 // executeChain executes a chain, one step at a time. If any step in the chain fails to execute, or if the
 // context is canceled, the chain stops execution.
 func (se *stepExecutor) executeChain(workerID int, chain chain) {
-	for _, step := range chain {
+	for i, step := range chain {
 		select {
 		case <-se.ctx.Done():
 			se.log(workerID, "step %v on %v canceled", step.Op(), step.URN())
@@ -218,9 +238,24 @@ func (se *stepExecutor) executeChain(workerID int, chain chain) {
 		default:
 		}
 
-		if err := se.executeStep(workerID, step); err != nil {
+		completed, err := se.executeStep(workerID, step)
+		if err != nil {
 			se.log(workerID, "step %v on %v failed, signalling cancellation", step.Op(), step.URN())
-			se.cancelDueToError()
+			continuing := se.cancelDueToError(step, err)
+
+			// If we are continuing past errors, the program must learn that the registration that this chain would
+			// have completed failed, or it would wait for it forever. This also keeps the program from registering
+			// any resources that depend on the failed one.
+			if continuing {
+				remaining := chain[i:]
+				if completed {
+					remaining = chain[i+1:]
+				}
+				if failRegistration(remaining, errors.Errorf("resource '%s' failed to %s", step.URN(), step.Op())) {
+					se.failedRegistrations.Store(true)
+				}
+			}
+
 			if err != errStepApplyFailed {
 				// Step application errors are recorded by the OnResourceStepPost callback. This is confusing,
 				// but it means that at this level we shouldn't be logging any errors that came from there.
@@ -235,11 +270,60 @@ func (se *stepExecutor) executeChain(workerID int, chain chain) {
 	}
 }
 
-func (se *stepExecutor) cancelDueToError() {
+// cancelDueToError records the failure of the given step and cancels the plan unless execution may continue past the
+// error. Only a failure to apply the step itself may be continued past: an error from a pre- or post-step event, such
+// as a failure to save the snapshot, always cancels the plan. It returns true if execution continues.
+func (se *stepExecutor) cancelDueToError(step Step, err error) bool {
 	se.sawError.Store(true)
-	if !se.continueOnError {
+
+	se.failedLock.Lock()
+	se.failed = append(se.failed, step)
+	se.failedLock.Unlock()
+
+	if !se.continueOnError || err != errStepApplyFailed {
 		se.cancel()
+		return false
 	}
+	return true
+}
+
+// Failed returns the steps whose execution ended in failure, in the order in which they failed.
+func (se *stepExecutor) Failed() []Step {
+	se.failedLock.Lock()
+	defer se.failedLock.Unlock()
+	return append([]Step(nil), se.failed...)
+}
+
+// FailedRegistrations returns true if the failure of a registration or read was reported to the program.
+func (se *stepExecutor) FailedRegistrations() bool {
+	return se.failedRegistrations.Load().(bool)
+}
+
+// failRegistration completes the first registration or read in the given steps with the given error. It returns true
+// if there was such a registration or read.
+func failRegistration(steps []Step, err error) bool {
+	for _, step := range steps {
+		switch s := step.(type) {
+		case *SameStep:
+			if s.reg != nil {
+				s.reg.Done(&RegisterResult{Err: err})
+				return true
+			}
+		case *CreateStep:
+			s.reg.Done(&RegisterResult{Err: err})
+			return true
+		case *UpdateStep:
+			s.reg.Done(&RegisterResult{Err: err})
+			return true
+		case *ImportStep:
+			s.reg.Done(&RegisterResult{Err: err})
+			return true
+		case *ReadStep:
+			s.event.Done(&ReadResult{Err: err})
+			return true
+		}
+	}
+	return false
 }
 
 //
@@ -253,9 +337,9 @@ func (se *stepExecutor) cancelDueToError() {
 // verbatim to the post-step event.
 //
 
-// executeStep executes a single step, returning true if the step execution was successful and
-// false if it was not.
-func (se *stepExecutor) executeStep(workerID int, step Step) error {
+// executeStep executes a single step, returning an error if the step execution was not successful. It also returns
+// true if the step completed its registration, which a partially-failed step may do despite its error.
+func (se *stepExecutor) executeStep(workerID int, step Step) (bool, error) {
 	var payload interface{}
 	events := se.opts.Events
 	if events != nil {
@@ -263,7 +347,7 @@ func (se *stepExecutor) executeStep(workerID int, step Step) error {
 		payload, err = events.OnResourceStepPre(step)
 		if err != nil {
 			se.log(workerID, "step %v on %v failed pre-resource step: %v", step.Op(), step.URN(), err)
-			return errors.Wrap(err, "pre-step event returned an error")
+			return false, errors.Wrap(err, "pre-step event returned an error")
 		}
 	}
 
@@ -274,7 +358,7 @@ func (se *stepExecutor) executeStep(workerID int, step Step) error {
 		// If we have a state object, and this is a create or update, remember it, as we may need to update it later.
 		if step.Logical() && step.New() != nil {
 			if prior, has := se.pendingNews.Load(step.URN()); has {
-				return errors.Errorf(
+				return false, errors.Errorf(
 					"resource '%s' registered twice (%s and %s)", step.URN(), prior.(Step).Op(), step.Op())
 			}
 
@@ -295,7 +379,7 @@ func (se *stepExecutor) executeStep(workerID int, step Step) error {
 	if events != nil {
 		if postErr := events.OnResourceStepPost(payload, step, status, err); postErr != nil {
 			se.log(workerID, "step %v on %v failed post-resource step: %v", step.Op(), step.URN(), postErr)
-			return errors.Wrap(postErr, "post-step event returned an error")
+			return false, errors.Wrap(postErr, "post-step event returned an error")
 		}
 	}
 
@@ -308,10 +392,10 @@ func (se *stepExecutor) executeStep(workerID int, step Step) error {
 
 	if err != nil {
 		se.log(workerID, "step %v on %v failed with an error: %v", step.Op(), step.URN(), err)
-		return errStepApplyFailed
+		return stepComplete != nil, errStepApplyFailed
 	}
 
-	return nil
+	return true, nil
 }
 
 // log is a simple logging helper for the step executor.
@@ -356,8 +440,7 @@ func (se *stepExecutor) worker(workerID int, launchAsync bool) {
 
 			se.log(workerID, "worker received chain for execution")
 			if !launchAsync {
-				se.executeChain(workerID, request.Chain)
-				close(request.CompletionChan)
+				se.runChain(workerID, request)
 				continue
 			}
 
@@ -368,8 +451,7 @@ func (se *stepExecutor) worker(workerID int, launchAsync bool) {
 			go func() {
 				defer se.workers.Done()
 				se.log(newWorkerID, "launching oneshot worker")
-				se.executeChain(newWorkerID, request.Chain)
-				close(request.CompletionChan)
+				se.runChain(newWorkerID, request)
 			}()
 
 			oneshotWorkerID++
@@ -380,6 +462,26 @@ func (se *stepExecutor) worker(workerID int, launchAsync bool) {
 	}
 }
 
+// runChain executes the given request once its parallelism limits allow. If a limit is exhausted, the request is
+// parked and the worker returns immediately; the request is later run by the worker that frees up capacity for it.
+func (se *stepExecutor) runChain(workerID int, request incomingChain) {
+	if se.limiter != nil && !se.limiter.Acquire(request) {
+		se.log(workerID, "worker parked chain due to parallelism limits")
+		return
+	}
+
+	queue := []incomingChain{request}
+	for len(queue) > 0 {
+		request, queue = queue[0], queue[1:]
+		se.executeChain(workerID, request.Chain)
+		close(request.CompletionChan)
+		se.pendingChains.Done()
+		if se.limiter != nil {
+			queue = append(queue, se.limiter.Release(request.Chain)...)
+		}
+	}
+}
+
 func newStepExecutor(ctx context.Context, cancel context.CancelFunc, plan *Plan, opts Options,
 	preview, continueOnError bool) *stepExecutor {
 	exec := &stepExecutor{
@@ -388,11 +490,13 @@ func newStepExecutor(ctx context.Context, cancel context.CancelFunc, plan *Plan,
 		preview:         preview,
 		continueOnError: continueOnError,
 		incomingChains:  make(chan incomingChain),
+		limiter:         newStepLimiter(opts),
 		ctx:             ctx,
 		cancel:          cancel,
 	}
 
 	exec.sawError.Store(false)
+	exec.failedRegistrations.Store(false)
 
 	// If we're being asked to run as parallel as possible, spawn a single worker that launches chain executions
 	// asynchronously.
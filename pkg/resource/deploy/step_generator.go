@@ -18,6 +18,8 @@ import (
 	"strings"
 
 	"github.com/pkg/errors"
+	"google.golang.org/grpc/codes"
+
 	"github.com/pulumi/pulumi/pkg/v2/resource/deploy/providers"
 	"github.com/pulumi/pulumi/pkg/v2/resource/graph"
 	"github.com/pulumi/pulumi/sdk/v2/go/common/apitype"
@@ -28,6 +30,7 @@ import (
 	"github.com/pulumi/pulumi/sdk/v2/go/common/util/contract"
 	"github.com/pulumi/pulumi/sdk/v2/go/common/util/logging"
 	"github.com/pulumi/pulumi/sdk/v2/go/common/util/result"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/util/rpcutil/rpcerror"
 )
 
 // stepGenerator is responsible for turning resource events into steps that
@@ -38,8 +41,8 @@ type stepGenerator struct {
 	plan *Plan   // the plan to which this step generator belongs
 	opts Options // options for this step generator
 
-	updateTargetsOpt  map[resource.URN]bool // the set of resources to update; resources not in this set will be same'd
-	replaceTargetsOpt map[resource.URN]bool // the set of resoures to replace
+	updateTargetsOpt  *targetSet // the set of resources to update; resources not in this set will be same'd
+	replaceTargetsOpt *targetSet // the set of resoures to replace
 
 	// signals that one or more errors have been reported to the user, and the plan should terminate
 	// in error. This primarily allows `preview` to aggregate many policy violation events and
@@ -69,6 +72,9 @@ type stepGenerator struct {
 
 	// a map from old names (aliased URNs) to the new URN that aliased to them.
 	aliased map[resource.URN]resource.URN
+
+	// checks generated steps against the update plan given in the options, if any.
+	planChecker *planChecker
 }
 
 func (sg *stepGenerator) isTargetedUpdate() bool {
@@ -76,11 +82,11 @@ func (sg *stepGenerator) isTargetedUpdate() bool {
 }
 
 func (sg *stepGenerator) isTargetedForUpdate(urn resource.URN) bool {
-	return sg.updateTargetsOpt == nil || sg.updateTargetsOpt[urn]
+	return sg.updateTargetsOpt.Contains(urn)
 }
 
 func (sg *stepGenerator) isTargetedReplace(urn resource.URN) bool {
-	return sg.replaceTargetsOpt != nil && sg.replaceTargetsOpt[urn]
+	return sg.replaceTargetsOpt != nil && sg.replaceTargetsOpt.Contains(urn)
 }
 
 func (sg *stepGenerator) Errored() bool {
@@ -90,6 +96,17 @@ func (sg *stepGenerator) Errored() bool {
 // GenerateReadSteps is responsible for producing one or more steps required to service
 // a ReadResourceEvent coming from the language host.
 func (sg *stepGenerator) GenerateReadSteps(event ReadResourceEvent) ([]Step, result.Result) {
+	steps, res := sg.generateReadSteps(event)
+	if res != nil {
+		return nil, res
+	}
+	if res := sg.checkAndRecordSteps(steps); res != nil {
+		return nil, res
+	}
+	return steps, nil
+}
+
+func (sg *stepGenerator) generateReadSteps(event ReadResourceEvent) ([]Step, result.Result) {
 	urn := sg.plan.generateURN(event.Parent(), event.Type(), event.Name())
 	newState := resource.NewState(event.Type(),
 		urn,
@@ -107,9 +124,11 @@ func (sg *stepGenerator) GenerateReadSteps(event ReadResourceEvent) ([]Step, res
 		nil,   /* propertyDependencies */
 		false, /* deleteBeforeCreate */
 		event.AdditionalSecretOutputs(),
-		nil, /* aliases */
-		nil, /* customTimeouts */
-		"",  /* importID */
+		nil,   /* aliases */
+		nil,   /* customTimeouts */
+		"",    /* importID */
+		nil,   /* replaceOnChanges */
+		false, /* retainOnDelete */
 	)
 	old, hasOld := sg.plan.Olds()[urn]
 
@@ -157,6 +176,20 @@ func (sg *stepGenerator) GenerateSteps(event RegisterResourceEvent) ([]Step, res
 		contract.Assert(len(steps) == 0)
 		return nil, res
 	}
+
+	// Hold the resource's inputs and steps to the update plan, if any, and record them in this plan's own.
+	goal := event.Goal()
+	urn := sg.plan.generateURN(goal.Parent, goal.Type, goal.Name)
+	if sg.planChecker != nil {
+		if err := sg.planChecker.checkInputs(urn, goal.Properties); err != nil {
+			return nil, result.FromError(err)
+		}
+	}
+	sg.plan.updatePlan.recordInputs(urn, goal.Properties)
+	if res := sg.checkAndRecordSteps(steps); res != nil {
+		return nil, res
+	}
+
 	if !sg.isTargetedUpdate() {
 		return steps, nil
 	}
@@ -236,8 +269,20 @@ func (sg *stepGenerator) generateSteps(event RegisterResourceEvent) ([]Step, res
 		}
 	}
 
+	// Fetch the provider for this resource.
+	prov, res := sg.loadResourceProvider(urn, goal.Custom, goal.Provider, goal.Type)
+	if res != nil {
+		return nil, res
+	}
+
+	// Give any analyzers the opportunity to remediate the goal's inputs before they are checked by the provider.
+	goalInputs, res := sg.remediate(urn, goal)
+	if res != nil {
+		return nil, res
+	}
+
 	// Create the desired inputs from the goal state
-	inputs := goal.Properties
+	inputs := goalInputs
 	if hasOld {
 		// Set inputs back to their old values (if any) for any "ignored" properties
 		processedInputs, res := processIgnoreChanges(inputs, oldInputs, goal.IgnoreChanges)
@@ -251,7 +296,8 @@ func (sg *stepGenerator) generateSteps(event RegisterResourceEvent) ([]Step, res
 	// get serialized into the checkpoint file.
 	new := resource.NewState(goal.Type, urn, goal.Custom, false, "", inputs, nil, goal.Parent, goal.Protect, false,
 		goal.Dependencies, goal.InitErrors, goal.Provider, goal.PropertyDependencies, false,
-		goal.AdditionalSecretOutputs, goal.Aliases, &goal.CustomTimeouts, "")
+		goal.AdditionalSecretOutputs, goal.Aliases, &goal.CustomTimeouts, "", goal.ReplaceOnChanges,
+		goal.RetainOnDelete)
 
 	// Mark the URN/resource as having been seen. So we can run analyzers on all resources seen, as well as
 	// lookup providers for calculating replacement of resources that use the provider.
@@ -261,12 +307,6 @@ func (sg *stepGenerator) generateSteps(event RegisterResourceEvent) ([]Step, res
 		sg.providers[urn] = new
 	}
 
-	// Fetch the provider for this resource.
-	prov, res := sg.loadResourceProvider(urn, goal.Custom, goal.Provider, goal.Type)
-	if res != nil {
-		return nil, res
-	}
-
 	// We only allow unknown property values to be exposed to the provider if we are performing an update preview.
 	allowUnknowns := sg.plan.preview
 
@@ -312,7 +352,7 @@ func (sg *stepGenerator) generateSteps(event RegisterResourceEvent) ([]Step, res
 		// don't consider those inputs since Pulumi does not own them. Finally, if the resource has been
 		// targeted for replacement, ignore its old state.
 		if recreating || wasExternal || sg.isTargetedReplace(urn) {
-			inputs, failures, err = prov.Check(urn, nil, goal.Properties, allowUnknowns)
+			inputs, failures, err = prov.Check(urn, nil, goalInputs, allowUnknowns)
 		} else {
 			inputs, failures, err = prov.Check(urn, oldInputs, inputs, allowUnknowns)
 		}
@@ -328,30 +368,7 @@ func (sg *stepGenerator) generateSteps(event RegisterResourceEvent) ([]Step, res
 	// Send the resource off to any Analyzers before being operated on.
 	analyzers := sg.plan.ctx.Host.ListAnalyzers()
 	for _, analyzer := range analyzers {
-		r := plugin.AnalyzerResource{
-			URN:        new.URN,
-			Type:       new.Type,
-			Name:       new.URN.Name(),
-			Properties: inputs,
-			Options: plugin.AnalyzerResourceOptions{
-				Protect:                 new.Protect,
-				IgnoreChanges:           goal.IgnoreChanges,
-				DeleteBeforeReplace:     goal.DeleteBeforeReplace,
-				AdditionalSecretOutputs: new.AdditionalSecretOutputs,
-				Aliases:                 new.Aliases,
-				CustomTimeouts:          new.CustomTimeouts,
-			},
-		}
-		providerResource := sg.getProviderResource(new.URN, new.Provider)
-		if providerResource != nil {
-			r.Provider = &plugin.AnalyzerProviderResource{
-				URN:        providerResource.URN,
-				Type:       providerResource.Type,
-				Name:       providerResource.URN.Name(),
-				Properties: providerResource.Inputs,
-			}
-		}
-
+		r := sg.analyzerResource(urn, goal, inputs)
 		diagnostics, err := analyzer.Analyze(r)
 		if err != nil {
 			return nil, result.FromError(err)
@@ -446,7 +463,7 @@ func (sg *stepGenerator) generateSteps(event RegisterResourceEvent) ([]Step, res
 				"Planner decided not to update '%v' due to not being in target group (same) (inputs=%v)", urn, new.Inputs)
 		} else {
 			updateSteps, res := sg.generateStepsFromDiff(
-				event, urn, old, new, oldInputs, oldOutputs, inputs, prov, goal)
+				event, urn, old, new, oldInputs, oldOutputs, inputs, goalInputs, prov, goal)
 
 			if res != nil {
 				return nil, res
@@ -506,7 +523,7 @@ func (sg *stepGenerator) generateSteps(event RegisterResourceEvent) ([]Step, res
 
 func (sg *stepGenerator) generateStepsFromDiff(
 	event RegisterResourceEvent, urn resource.URN, old, new *resource.State,
-	oldInputs, oldOutputs, inputs resource.PropertyMap,
+	oldInputs, oldOutputs, inputs, goalInputs resource.PropertyMap,
 	prov plugin.Provider, goal *resource.Goal) ([]Step, result.Result) {
 
 	// We only allow unknown property values to be exposed to the provider if we are performing an update preview.
@@ -528,6 +545,12 @@ func (sg *stepGenerator) generateStepsFromDiff(
 			"unrecognized diff state for %s: %d", urn, diff.Changes)
 	}
 
+	// Upgrade any changes to properties named by the resource's replaceOnChanges option to replacements.
+	diff, err = applyReplaceOnChanges(diff, goal.ReplaceOnChanges)
+	if err != nil {
+		return nil, result.FromError(errors.Wrapf(err, "applying replaceOnChanges for %s", urn))
+	}
+
 	// If there were changes, check for a replacement vs. an in-place update.
 	if diff.Changes == plugin.DiffSome {
 		if diff.Replace() {
@@ -551,7 +574,7 @@ func (sg *stepGenerator) generateStepsFromDiff(
 			// Note that if we're performing a targeted replace, we already have the correct inputs.
 			if prov != nil && !sg.isTargetedReplace(urn) {
 				var failures []plugin.CheckFailure
-				inputs, failures, err = prov.Check(urn, nil, goal.Properties, allowUnknowns)
+				inputs, failures, err = prov.Check(urn, nil, goalInputs, allowUnknowns)
 				if err != nil {
 					return nil, result.FromError(err)
 				} else if issueCheckErrors(sg.plan, new, urn, failures) {
@@ -660,7 +683,18 @@ func (sg *stepGenerator) generateStepsFromDiff(
 	return nil, nil
 }
 
-func (sg *stepGenerator) GenerateDeletes(targetsOpt map[resource.URN]bool) ([]Step, result.Result) {
+func (sg *stepGenerator) GenerateDeletes(targetsOpt *targetSet) ([]Step, result.Result) {
+	steps, res := sg.generateDeletes(targetsOpt)
+	if res != nil {
+		return nil, res
+	}
+	if res := sg.checkAndRecordSteps(steps); res != nil {
+		return nil, res
+	}
+	return steps, nil
+}
+
+func (sg *stepGenerator) generateDeletes(targetsOpt *targetSet) ([]Step, result.Result) {
 	// To compute the deletion list, we must walk the list of old resources *backwards*.  This is because the list is
 	// stored in dependency order, and earlier elements are possibly leaf nodes for later elements.  We must not delete
 	// dependencies prior to their dependent nodes.
@@ -715,6 +749,19 @@ func (sg *stepGenerator) GenerateDeletes(targetsOpt map[resource.URN]bool) ([]St
 		}
 	}
 
+	// If only --exclude was provided, delete everything save for the excluded resources and the resources that they
+	// need in order to remain in the stack.
+	if targetsOpt.excludesOnly() {
+		kept := sg.determineResourcesToKeepForExcludes(targetsOpt)
+		filtered := []Step{}
+		for _, step := range dels {
+			if !kept[step.URN()] {
+				filtered = append(filtered, step)
+			}
+		}
+		return filtered, nil
+	}
+
 	// If -target was provided to either `pulumi update` or `pulumi destroy` then only delete
 	// resources that were specified.
 	allowedResourcesToDelete, res := sg.determineAllowedResourcesToDeleteFromTargets(targetsOpt)
@@ -736,7 +783,7 @@ func (sg *stepGenerator) GenerateDeletes(targetsOpt map[resource.URN]bool) ([]St
 	deletingUnspecifiedTarget := false
 	for _, step := range dels {
 		urn := step.URN()
-		if targetsOpt != nil && !targetsOpt[urn] && !sg.opts.TargetDependents {
+		if targetsOpt != nil && !targetsOpt.Contains(urn) && !sg.opts.TargetDependents {
 			d := diag.GetResourceWillBeDestroyedButWasNotSpecifiedInTargetList(urn)
 
 			// Targets were specified, but didn't include this resource to create.  Report all the
@@ -764,25 +811,42 @@ func (sg *stepGenerator) GenerateDeletes(targetsOpt map[resource.URN]bool) ([]St
 	return dels, nil
 }
 
+// checkAndRecordSteps ensures that the given steps conform to the update plan, if any, and records them in this plan's
+// own update plan.
+func (sg *stepGenerator) checkAndRecordSteps(steps []Step) result.Result {
+	if sg.planChecker != nil {
+		if err := sg.planChecker.checkSteps(steps); err != nil {
+			return result.FromError(err)
+		}
+	}
+	sg.plan.updatePlan.recordSteps(steps)
+	return nil
+}
+
+// CheckPlanCompleted ensures that every operation in the update plan, if any, was generated.
+func (sg *stepGenerator) CheckPlanCompleted() error {
+	if sg.planChecker == nil {
+		return nil
+	}
+	return sg.planChecker.checkCompleted()
+}
+
 func (sg *stepGenerator) determineAllowedResourcesToDeleteFromTargets(
-	targetsOpt map[resource.URN]bool) (map[resource.URN]bool, result.Result) {
+	targetsOpt *targetSet) (map[resource.URN]bool, result.Result) {
 
 	if targetsOpt == nil {
 		// no specific targets, so we won't filter down anything
 		return nil, nil
 	}
 
-	logging.V(7).Infof("Planner was asked to only delete/update '%v'", targetsOpt)
+	logging.V(7).Infof("Planner was asked to only delete/update a subset of resources")
 	resourcesToDelete := make(map[resource.URN]bool)
 
-	// Now actually use all the requested targets to figure out the exact set to delete.
-	for target := range targetsOpt {
-		current := sg.plan.olds[target]
-		if current == nil {
-			// user specified a target that didn't exist.  they will have already gotten a warning
-			// about this when we called checkTargets.  explicitly ignore this target since it won't
-			// be something we could possibly be trying to delete, nor could have dependents we
-			// might need to replace either.
+	// Now actually use all the requested targets to figure out the exact set to delete. Targets that did not exist
+	// will have already been reported when we called checkTargets, and can't be something we could possibly be
+	// trying to delete, nor could have dependents we might need to replace either.
+	for target, current := range sg.plan.olds {
+		if !targetsOpt.Contains(target) {
 			continue
 		}
 
@@ -833,6 +897,38 @@ func (sg *stepGenerator) determineAllowedResourcesToDeleteFromTargets(
 	return resourcesToDelete, nil
 }
 
+// determineResourcesToKeepForExcludes returns the set of resources that may not be deleted because they are excluded by
+// the given target set, or because an excluded resource depends on them, is parented to them, or is managed by them.
+func (sg *stepGenerator) determineResourcesToKeepForExcludes(targetsOpt *targetSet) map[resource.URN]bool {
+	var worklist []*resource.State
+	for _, res := range sg.plan.prev.Resources {
+		if !targetsOpt.Contains(res.URN) {
+			worklist = append(worklist, res)
+		}
+	}
+
+	// The dependencies of a resource include its parent and its provider, so walking them transitively reaches every
+	// resource that an excluded resource needs.
+	visited := make(graph.ResourceSet)
+	kept := make(map[resource.URN]bool)
+	for len(worklist) > 0 {
+		res := worklist[len(worklist)-1]
+		worklist = worklist[:len(worklist)-1]
+		if visited[res] {
+			continue
+		}
+		visited[res] = true
+		kept[res.URN] = true
+
+		for dep := range sg.plan.depGraph.DependenciesOf(res) {
+			worklist = append(worklist, dep)
+		}
+	}
+
+	logging.V(7).Infof("Planner will not delete excluded resources or their dependencies: %v", kept)
+	return kept
+}
+
 // GeneratePendingDeletes generates delete steps for all resources that are pending deletion. This function should be
 // called at the start of a plan in order to find all resources that are pending deletion from the prevous plan.
 func (sg *stepGenerator) GeneratePendingDeletes() []Step {
@@ -1161,6 +1257,62 @@ func (sg *stepGenerator) loadResourceProvider(
 	return p, nil
 }
 
+// analyzerResource returns the view of the resource with the given goal and inputs that is sent to analyzers.
+func (sg *stepGenerator) analyzerResource(urn resource.URN, goal *resource.Goal,
+	inputs resource.PropertyMap) plugin.AnalyzerResource {
+
+	r := plugin.AnalyzerResource{
+		URN:        urn,
+		Type:       goal.Type,
+		Name:       urn.Name(),
+		Properties: inputs,
+		Options: plugin.AnalyzerResourceOptions{
+			Protect:                 goal.Protect,
+			IgnoreChanges:           goal.IgnoreChanges,
+			DeleteBeforeReplace:     goal.DeleteBeforeReplace,
+			AdditionalSecretOutputs: goal.AdditionalSecretOutputs,
+			Aliases:                 goal.Aliases,
+			CustomTimeouts:          goal.CustomTimeouts,
+		},
+	}
+	providerResource := sg.getProviderResource(urn, goal.Provider)
+	if providerResource != nil {
+		r.Provider = &plugin.AnalyzerProviderResource{
+			URN:        providerResource.URN,
+			Type:       providerResource.Type,
+			Name:       providerResource.URN.Name(),
+			Properties: providerResource.Inputs,
+		}
+	}
+	return r
+}
+
+// remediate gives each analyzer the opportunity to transform the goal's inputs, and returns the resulting inputs.
+// Each analyzer sees the inputs as remediated by the analyzers before it.
+func (sg *stepGenerator) remediate(urn resource.URN, goal *resource.Goal) (resource.PropertyMap, result.Result) {
+	inputs := goal.Properties
+	for _, analyzer := range sg.plan.ctx.Host.ListAnalyzers() {
+		remediations, err := analyzer.Remediate(sg.analyzerResource(urn, goal, inputs))
+		if err != nil {
+			// Policy packs built against older SDKs do not implement Remediate, which just means that they have no
+			// remediations to apply.
+			if rpcErr, ok := rpcerror.FromError(err); ok && rpcErr.Code() == codes.Unimplemented {
+				logging.V(7).Infof("analyzer %v does not implement Remediate, skipping", analyzer.Name())
+				continue
+			}
+			return nil, result.FromError(err)
+		}
+		for _, t := range remediations {
+			if t.Properties == nil || t.Properties.DeepEquals(inputs) {
+				continue
+			}
+			sg.opts.Events.OnPolicyRemediation(urn, t, inputs, t.Properties)
+			inputs = t.Properties
+		}
+	}
+	return inputs, nil
+}
+
 func (sg *stepGenerator) getProviderResource(urn resource.URN, provider string) *resource.State {
 	if provider == "" {
 		return nil
@@ -1351,7 +1503,12 @@ func (sg *stepGenerator) AnalyzeResources() result.Result {
 
 // newStepGenerator creates a new step generator that operates on the given plan.
 func newStepGenerator(
-	plan *Plan, opts Options, updateTargetsOpt, replaceTargetsOpt map[resource.URN]bool) *stepGenerator {
+	plan *Plan, opts Options, updateTargetsOpt, replaceTargetsOpt *targetSet) *stepGenerator {
+
+	var planChecker *planChecker
+	if opts.Plan != nil {
+		planChecker = newPlanChecker(opts.Plan)
+	}
 
 	return &stepGenerator{
 		plan:                 plan,
@@ -1372,5 +1529,67 @@ func newStepGenerator(
 		resourceStates:       make(map[resource.URN]*resource.State),
 		dependentReplaceKeys: make(map[resource.URN][]resource.PropertyKey),
 		aliased:              make(map[resource.URN]resource.URN),
+		planChecker:          planChecker,
 	}
 }
+
+// applyReplaceOnChanges upgrades the changes in the given diff that affect any of the given property paths to
+// replacements. A changed path is affected if it is a prefix of or is prefixed by one of the replaceOnChanges paths. If
+// the diff carries a detailed diff, each affected path is marked as requiring replacement. Otherwise, every affected
+// changed key is added to the diff's replace keys.
+func applyReplaceOnChanges(diff plugin.DiffResult, replaceOnChanges []string) (plugin.DiffResult, error) {
+	if diff.Changes != plugin.DiffSome || len(replaceOnChanges) == 0 {
+		return diff, nil
+	}
+
+	paths := make([]resource.PropertyPath, len(replaceOnChanges))
+	for i, p := range replaceOnChanges {
+		path, err := resource.ParsePropertyPath(p)
+		if err != nil {
+			return diff, errors.Wrapf(err, "invalid property path %q", p)
+		}
+		paths[i] = path
+	}
+
+	replaceKeys := make(map[resource.PropertyKey]bool)
+	for _, k := range diff.ReplaceKeys {
+		replaceKeys[k] = true
+	}
+	addReplaceKey := func(k resource.PropertyKey) {
+		if !replaceKeys[k] {
+			replaceKeys[k] = true
+			diff.ReplaceKeys = append(diff.ReplaceKeys, k)
+		}
+	}
+
+	if diff.DetailedDiff != nil {
+		detailedDiff := make(map[string]plugin.PropertyDiff, len(diff.DetailedDiff))
+		for k, v := range diff.DetailedDiff {
+			if path, err := resource.ParsePropertyPath(k); err == nil && len(path) > 0 {
+				for _, p := range paths {
+					if p.Contains(path) || path.Contains(p) {
+						v.Kind = v.Kind.AsReplace()
+						if key, ok := path[0].(string); ok {
+							addReplaceKey(resource.PropertyKey(key))
+						}
+						break
+					}
+				}
+			}
+			detailedDiff[k] = v
+		}
+		diff.DetailedDiff = detailedDiff
+		return diff, nil
+	}
+
+	for _, k := range diff.ChangedKeys {
+		path := resource.PropertyPath{string(k)}
+		for _, p := range paths {
+			if p.Contains(path) || path.Contains(p) {
+				addReplaceKey(k)
+				break
+			}
+		}
+	}
+	return diff, nil
+}
@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/pulumi/pulumi/sdk/v2/go/common/resource"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/resource/plugin"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -113,3 +114,70 @@ func TestIgnoreChanges(t *testing.T) {
 		})
 	}
 }
+
+func TestApplyReplaceOnChanges(t *testing.T) {
+	// Detailed diffs upgrade the matching property diffs.
+	diff, err := applyReplaceOnChanges(plugin.DiffResult{
+		Changes:     plugin.DiffSome,
+		ChangedKeys: []resource.PropertyKey{"a", "b"},
+		DetailedDiff: map[string]plugin.PropertyDiff{
+			"a.b":  {Kind: plugin.DiffUpdate},
+			"a.c":  {Kind: plugin.DiffAdd},
+			"b[0]": {Kind: plugin.DiffDelete},
+		},
+	}, []string{"a.b", "b[*]"})
+	assert.NoError(t, err)
+	assert.True(t, diff.Replace())
+	assert.Equal(t, plugin.DiffUpdateReplace, diff.DetailedDiff["a.b"].Kind)
+	assert.Equal(t, plugin.DiffAdd, diff.DetailedDiff["a.c"].Kind)
+	assert.Equal(t, plugin.DiffDeleteReplace, diff.DetailedDiff["b[0]"].Kind)
+	assert.ElementsMatch(t, []resource.PropertyKey{"a", "b"}, diff.ReplaceKeys)
+
+	// A detailed diff entry for a parent property matches a path nested within it.
+	diff, err = applyReplaceOnChanges(plugin.DiffResult{
+		Changes:     plugin.DiffSome,
+		ChangedKeys: []resource.PropertyKey{"a", "b"},
+		DetailedDiff: map[string]plugin.PropertyDiff{
+			"a": {Kind: plugin.DiffUpdate},
+			"b": {Kind: plugin.DiffUpdate},
+		},
+	}, []string{"a.b.c"})
+	assert.NoError(t, err)
+	assert.Equal(t, plugin.DiffUpdateReplace, diff.DetailedDiff["a"].Kind)
+	assert.Equal(t, plugin.DiffUpdate, diff.DetailedDiff["b"].Kind)
+	assert.Equal(t, []resource.PropertyKey{"a"}, diff.ReplaceKeys)
+
+	// A path matches the detailed diff entries for properties nested within it.
+	diff, err = applyReplaceOnChanges(plugin.DiffResult{
+		Changes:     plugin.DiffSome,
+		ChangedKeys: []resource.PropertyKey{"a", "b"},
+		DetailedDiff: map[string]plugin.PropertyDiff{
+			"a.b.c": {Kind: plugin.DiffAdd},
+			"b.c":   {Kind: plugin.DiffUpdate},
+		},
+	}, []string{"a"})
+	assert.NoError(t, err)
+	assert.Equal(t, plugin.DiffAddReplace, diff.DetailedDiff["a.b.c"].Kind)
+	assert.Equal(t, plugin.DiffUpdate, diff.DetailedDiff["b.c"].Kind)
+	assert.Equal(t, []resource.PropertyKey{"a"}, diff.ReplaceKeys)
+
+	// Without a detailed diff, changed keys are matched against the paths.
+	diff, err = applyReplaceOnChanges(plugin.DiffResult{
+		Changes:     plugin.DiffSome,
+		ChangedKeys: []resource.PropertyKey{"a", "b"},
+	}, []string{"b.c"})
+	assert.NoError(t, err)
+	assert.Equal(t, []resource.PropertyKey{"b"}, diff.ReplaceKeys)
+
+	// Unrelated changes are left alone.
+	diff, err = applyReplaceOnChanges(plugin.DiffResult{
+		Changes:     plugin.DiffSome,
+		ChangedKeys: []resource.PropertyKey{"a"},
+	}, []string{"b"})
+	assert.NoError(t, err)
+	assert.False(t, diff.Replace())
+
+	// Invalid paths are reported.
+	_, err = applyReplaceOnChanges(plugin.DiffResult{Changes: plugin.DiffSome}, []string{"a["})
+	assert.Error(t, err)
+}
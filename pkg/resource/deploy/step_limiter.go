@@ -0,0 +1,151 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deploy
+
+import (
+	"sync"
+
+	"github.com/pulumi/pulumi/pkg/v2/resource/deploy/providers"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/tokens"
+)
+
+// stepLimit is a single concurrency limit that applies to a chain.
+type stepLimit struct {
+	key string // the key under which running chains are counted, e.g. "provider:aws".
+	max int    // the maximum number of chains that may run concurrently under this key.
+}
+
+// parkedChain is a chain that could not run because one of its limits was exhausted.
+type parkedChain struct {
+	request incomingChain
+	limits  []stepLimit
+}
+
+// stepLimiter enforces the per-provider and per-type parallelism limits of a plan. Rather than blocking a worker, a
+// chain whose limits are exhausted is parked; it is handed to the worker that releases the capacity it is waiting
+// for. This keeps chains that are not subject to an exhausted limit flowing through the remaining workers.
+type stepLimiter struct {
+	providers map[tokens.Package]int // the maximum concurrency for each provider package.
+	types     map[tokens.Type]int    // the maximum concurrency for each resource type.
+
+	lock    sync.Mutex     // lock protecting running and parked.
+	running map[string]int // the number of running chains under each limit key.
+	parked  []parkedChain  // chains waiting for capacity, in the order in which they arrived.
+}
+
+// newStepLimiter returns a limiter for the given options, or nil if the options do not configure any limits.
+func newStepLimiter(opts Options) *stepLimiter {
+	if len(opts.ProviderParallelism) == 0 && len(opts.TypeParallelism) == 0 {
+		return nil
+	}
+	return &stepLimiter{
+		providers: opts.ProviderParallelism,
+		types:     opts.TypeParallelism,
+		running:   make(map[string]int),
+	}
+}
+
+// limitsFor returns the limits that apply to any of the steps in the given chain.
+func (l *stepLimiter) limitsFor(c chain) []stepLimit {
+	var limits []stepLimit
+	seen := make(map[string]bool)
+	add := func(key string, max int) {
+		if !seen[key] {
+			seen[key] = true
+			limits = append(limits, stepLimit{key: key, max: max})
+		}
+	}
+
+	for _, step := range c {
+		typ := step.Type()
+		if res := step.Res(); res != nil && res.Custom {
+			pkg := typ.Package()
+			if providers.IsProviderType(typ) {
+				pkg = providers.GetProviderPackage(typ)
+			}
+			if max, ok := l.providers[pkg]; ok {
+				add("provider:"+string(pkg), max)
+			}
+		}
+		if max, ok := l.types[typ]; ok {
+			add("type:"+string(typ), max)
+		}
+	}
+	return limits
+}
+
+// fits returns true if there is capacity under each of the given limits. The lock must be held.
+func (l *stepLimiter) fits(limits []stepLimit) bool {
+	for _, limit := range limits {
+		if l.running[limit.key] >= limit.max {
+			return false
+		}
+	}
+	return true
+}
+
+// Acquire claims capacity for the given request. If it returns false, the request has been parked and will be
+// returned by a later call to Release.
+func (l *stepLimiter) Acquire(request incomingChain) bool {
+	limits := l.limitsFor(request.Chain)
+	if len(limits) == 0 {
+		return true
+	}
+
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	if l.fits(limits) {
+		for _, limit := range limits {
+			l.running[limit.key]++
+		}
+		return true
+	}
+	l.parked = append(l.parked, parkedChain{request: request, limits: limits})
+	return false
+}
+
+// Release returns the capacity claimed for the given chain, and claims capacity for and returns any parked requests
+// that are now able to run. The caller is responsible for executing the returned requests.
+func (l *stepLimiter) Release(c chain) []incomingChain {
+	limits := l.limitsFor(c)
+	if len(limits) == 0 {
+		return nil
+	}
+
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	for _, limit := range limits {
+		l.running[limit.key]--
+	}
+
+	// Parked chains are resumed in the order in which they arrived, before any new chain can claim the capacity that
+	// was just released.
+	var ready []incomingChain
+	remaining := l.parked[:0]
+	for _, p := range l.parked {
+		if l.fits(p.limits) {
+			for _, limit := range p.limits {
+				l.running[limit.key]++
+			}
+			ready = append(ready, p.request)
+		} else {
+			remaining = append(remaining, p)
+		}
+	}
+	l.parked = remaining
+	return ready
+}
@@ -0,0 +1,61 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deploy
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pulumi/pulumi/sdk/v2/go/common/resource"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/tokens"
+)
+
+func limiterRequest(typ tokens.Type) incomingChain {
+	step := &CreateStep{new: &resource.State{Type: typ, Custom: true}}
+	return incomingChain{Chain: chain{step}, CompletionChan: make(chan bool)}
+}
+
+func TestStepLimiter(t *testing.T) {
+	assert.Nil(t, newStepLimiter(Options{}))
+
+	limiter := newStepLimiter(Options{
+		ProviderParallelism: map[tokens.Package]int{"pkgA": 2},
+		TypeParallelism:     map[tokens.Type]int{"pkgA:m:typA": 1},
+	})
+
+	a1, a2 := limiterRequest("pkgA:m:typA"), limiterRequest("pkgA:m:typA")
+	b1, b2 := limiterRequest("pkgA:m:typB"), limiterRequest("pkgA:m:typB")
+	other := limiterRequest("pkgB:m:typA")
+
+	// The type limit admits only one typA resource at a time.
+	assert.True(t, limiter.Acquire(a1))
+	assert.False(t, limiter.Acquire(a2))
+
+	// The provider limit admits a second resource from pkgA, but not a third.
+	assert.True(t, limiter.Acquire(b1))
+	assert.False(t, limiter.Acquire(b2))
+
+	// Resources from other packages are unaffected.
+	assert.True(t, limiter.Acquire(other))
+	assert.Empty(t, limiter.Release(other.Chain))
+
+	// Finishing a resource hands its capacity to the parked resources that it was holding up.
+	assert.Equal(t, []incomingChain{a2}, limiter.Release(a1.Chain))
+	assert.Equal(t, []incomingChain{b2}, limiter.Release(b1.Chain))
+	assert.Empty(t, limiter.Release(a2.Chain))
+	assert.Empty(t, limiter.Release(b2.Chain))
+	assert.Empty(t, limiter.parked)
+}
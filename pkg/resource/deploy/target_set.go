@@ -0,0 +1,118 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deploy
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/pulumi/pulumi/sdk/v2/go/common/resource"
+)
+
+// IsTargetPattern returns true if the given target is a glob pattern rather than a literal URN. In a pattern, `*`
+// matches any sequence of characters, so `urn:pulumi:prod::app::aws:s3/bucket:Bucket::*` selects every bucket in the
+// stack and `urn:pulumi:prod::app::*::web-*` selects every resource whose name begins with `web-`.
+func IsTargetPattern(target resource.URN) bool {
+	return strings.Contains(string(target), "*")
+}
+
+// compileTargetPattern converts a target pattern into an equivalent regular expression.
+func compileTargetPattern(target resource.URN) *regexp.Regexp {
+	parts := strings.Split(string(target), "*")
+	for i, part := range parts {
+		parts[i] = regexp.QuoteMeta(part)
+	}
+	return regexp.MustCompile("^" + strings.Join(parts, ".*") + "$")
+}
+
+// targetSet is the set of resources selected by a list of targets and a list of exclusions. Each target or exclusion
+// is either a literal URN or a glob pattern. A resource is in the set if it is selected by any of the targets, or if
+// there are no targets, and it is not selected by any of the exclusions. Because patterns may select resources that
+// are only registered as the plan executes, membership is tested against patterns rather than expanded up front.
+//
+// A nil *targetSet selects every resource.
+type targetSet struct {
+	includes *urnMatcher
+	excludes *urnMatcher
+}
+
+// newTargetSet creates a target set from the given targets and exclusions. It returns nil if both are empty, which
+// selects every resource.
+func newTargetSet(targets, excludes []resource.URN) *targetSet {
+	if len(targets) == 0 && len(excludes) == 0 {
+		return nil
+	}
+	return &targetSet{includes: newURNMatcher(targets), excludes: newURNMatcher(excludes)}
+}
+
+// Contains returns true if the given URN is selected by this target set.
+func (s *targetSet) Contains(urn resource.URN) bool {
+	if s == nil {
+		return true
+	}
+	if s.includes != nil && !s.includes.matches(urn) {
+		return false
+	}
+	return s.excludes == nil || !s.excludes.matches(urn)
+}
+
+// excludesOnly returns true if this set was created from exclusions alone, and so selects every resource that is not
+// excluded.
+func (s *targetSet) excludesOnly() bool {
+	return s != nil && s.includes == nil && s.excludes != nil
+}
+
+// urnMatcher matches URNs against a list of literal URNs and glob patterns.
+type urnMatcher struct {
+	literals map[resource.URN]bool
+	patterns []*regexp.Regexp
+}
+
+// newURNMatcher creates a matcher for the given targets. It returns nil if there are no targets.
+func newURNMatcher(targets []resource.URN) *urnMatcher {
+	if len(targets) == 0 {
+		return nil
+	}
+
+	m := &urnMatcher{literals: make(map[resource.URN]bool)}
+	for _, target := range targets {
+		if IsTargetPattern(target) {
+			m.patterns = append(m.patterns, compileTargetPattern(target))
+		} else {
+			m.literals[target] = true
+		}
+	}
+	return m
+}
+
+func (m *urnMatcher) matches(urn resource.URN) bool {
+	if m.literals[urn] {
+		return true
+	}
+	for _, p := range m.patterns {
+		if p.MatchString(string(urn)) {
+			return true
+		}
+	}
+	return false
+}
+
+// targetMatches returns true if the given target, which may be a pattern, selects the given URN.
+func targetMatches(target, urn resource.URN) bool {
+	if !IsTargetPattern(target) {
+		return target == urn
+	}
+	return compileTargetPattern(target).MatchString(string(urn))
+}
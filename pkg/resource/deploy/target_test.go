@@ -0,0 +1,64 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deploy
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pulumi/pulumi/sdk/v2/go/common/resource"
+)
+
+func TestTargetSet(t *testing.T) {
+	bucket := resource.URN("urn:pulumi:prod::app::aws:s3/bucket:Bucket::logs")
+	webBucket := resource.URN("urn:pulumi:prod::app::aws:s3/bucket:Bucket::web-assets")
+	webServer := resource.URN("urn:pulumi:prod::app::aws:ec2/instance:Instance::web-server")
+	db := resource.URN("urn:pulumi:prod::app::aws:rds/instance:Instance::db")
+
+	// A nil set selects everything.
+	var all *targetSet
+	assert.Nil(t, newTargetSet(nil, nil))
+	assert.True(t, all.Contains(db))
+
+	// Literal targets select only themselves.
+	literal := newTargetSet([]resource.URN{db}, nil)
+	assert.True(t, literal.Contains(db))
+	assert.False(t, literal.Contains(bucket))
+
+	// Patterns select resources by type or by name.
+	byType := newTargetSet([]resource.URN{"urn:pulumi:prod::app::aws:s3/bucket:Bucket::*"}, nil)
+	assert.True(t, byType.Contains(bucket))
+	assert.True(t, byType.Contains(webBucket))
+	assert.False(t, byType.Contains(webServer))
+
+	byName := newTargetSet([]resource.URN{"urn:pulumi:prod::app::*::web-*"}, nil)
+	assert.True(t, byName.Contains(webBucket))
+	assert.True(t, byName.Contains(webServer))
+	assert.False(t, byName.Contains(bucket))
+
+	// Exclusions win over targets, and without targets select everything else.
+	excluded := newTargetSet([]resource.URN{"urn:pulumi:prod::app::*::web-*"}, []resource.URN{webServer})
+	assert.True(t, excluded.Contains(webBucket))
+	assert.False(t, excluded.Contains(webServer))
+
+	excludeOnly := newTargetSet(nil, []resource.URN{"urn:pulumi:prod::app::aws:s3/bucket:Bucket::*"})
+	assert.True(t, excludeOnly.Contains(db))
+	assert.False(t, excludeOnly.Contains(bucket))
+
+	// Characters other than `*` are matched literally.
+	assert.False(t, targetMatches("urn:pulumi:prod::app::aws:s3/bucket:Bucket::log.", bucket))
+	assert.True(t, targetMatches("urn:pulumi:prod::app::aws:s3/bucket:Bucket::log*", bucket))
+}
@@ -0,0 +1,204 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deploy
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/pulumi/pulumi/sdk/v2/go/common/resource"
+)
+
+// UpdatePlan records the operations that a preview determined an update should perform. An update that is given an
+// update plan fails any step that deviates from it.
+type UpdatePlan struct {
+	ResourcePlans map[resource.URN]*ResourcePlan // the planned operations for each resource, keyed by URN.
+}
+
+// ResourcePlan records the planned operations for a single resource.
+type ResourcePlan struct {
+	Ops    []StepOp             // the steps planned for the resource, in order.
+	Inputs resource.PropertyMap // the inputs the program registered for the resource, if any.
+}
+
+// NewUpdatePlan creates a new, empty update plan.
+func NewUpdatePlan() *UpdatePlan {
+	return &UpdatePlan{ResourcePlans: make(map[resource.URN]*ResourcePlan)}
+}
+
+// resourcePlan returns the plan for the given URN, creating it if necessary.
+func (p *UpdatePlan) resourcePlan(urn resource.URN) *ResourcePlan {
+	rp, ok := p.ResourcePlans[urn]
+	if !ok {
+		rp = &ResourcePlan{}
+		p.ResourcePlans[urn] = rp
+	}
+	return rp
+}
+
+// recordSteps appends the operations of the given steps to the plan.
+func (p *UpdatePlan) recordSteps(steps []Step) {
+	for _, step := range steps {
+		rp := p.resourcePlan(step.URN())
+		rp.Ops = append(rp.Ops, step.Op())
+	}
+}
+
+// recordInputs records the inputs that the program registered for the given resource.
+func (p *UpdatePlan) recordInputs(urn resource.URN, inputs resource.PropertyMap) {
+	p.resourcePlan(urn).Inputs = inputs
+}
+
+// planChecker checks the steps generated during a single execution of a deployment against an update plan.
+type planChecker struct {
+	plan      *UpdatePlan          // the plan to check against.
+	completed map[resource.URN]int // the number of planned steps generated so far for each resource.
+}
+
+func newPlanChecker(plan *UpdatePlan) *planChecker {
+	return &planChecker{plan: plan, completed: make(map[resource.URN]int)}
+}
+
+// checkSteps ensures that each of the given steps is the next step planned for its resource.
+func (c *planChecker) checkSteps(steps []Step) error {
+	for _, step := range steps {
+		urn, op := step.URN(), step.Op()
+
+		rp, ok := c.plan.ResourcePlans[urn]
+		if !ok {
+			// Resources that are not in the plan may only be left unchanged.
+			if op == OpSame {
+				continue
+			}
+			return fmt.Errorf("%v operation for resource '%v' was not planned", op, urn)
+		}
+
+		completed := c.completed[urn]
+		if completed >= len(rp.Ops) {
+			return fmt.Errorf("%v operation for resource '%v' was not planned; planned operations were [%v]",
+				op, urn, formatOps(rp.Ops))
+		}
+		if planned := rp.Ops[completed]; planned != op {
+			return fmt.Errorf("%v operation for resource '%v' does not match the planned %v operation",
+				op, urn, planned)
+		}
+		c.completed[urn] = completed + 1
+	}
+	return nil
+}
+
+// checkInputs ensures that the inputs the program registered for the given resource match those that were planned.
+// Values that were unknown when the plan was created match any value.
+func (c *planChecker) checkInputs(urn resource.URN, inputs resource.PropertyMap) error {
+	rp, ok := c.plan.ResourcePlans[urn]
+	if !ok || rp.Inputs == nil {
+		return nil
+	}
+
+	var keys []string
+	for k := range rp.Inputs {
+		keys = append(keys, string(k))
+	}
+	for k := range inputs {
+		if _, has := rp.Inputs[k]; !has {
+			keys = append(keys, string(k))
+		}
+	}
+	sort.Strings(keys)
+
+	var changed []string
+	for _, k := range keys {
+		planned, hasPlanned := rp.Inputs[resource.PropertyKey(k)]
+		actual, hasActual := inputs[resource.PropertyKey(k)]
+		if hasPlanned != hasActual || !plannedValueMatches(planned, actual) {
+			changed = append(changed, k)
+		}
+	}
+	if len(changed) != 0 {
+		return fmt.Errorf("inputs for resource '%v' do not match the plan: %v changed",
+			urn, strings.Join(changed, ", "))
+	}
+	return nil
+}
+
+// checkCompleted ensures that every planned step has been generated.
+func (c *planChecker) checkCompleted() error {
+	var urns []string
+	for urn, rp := range c.plan.ResourcePlans {
+		for _, op := range rp.Ops[c.completed[urn]:] {
+			if op != OpSame {
+				urns = append(urns, string(urn))
+				break
+			}
+		}
+	}
+	if len(urns) == 0 {
+		return nil
+	}
+
+	sort.Strings(urns)
+	var lines []string
+	for _, urn := range urns {
+		remaining := c.plan.ResourcePlans[resource.URN(urn)].Ops[c.completed[resource.URN(urn)]:]
+		lines = append(lines, fmt.Sprintf("    %v: [%v]", urn, formatOps(remaining)))
+	}
+	return fmt.Errorf("planned operations were not performed:\n%v", strings.Join(lines, "\n"))
+}
+
+// plannedValueMatches returns true if the actual value matches the planned value. Unknown values on either side
+// match anything: the plan may have been created before some values were known, and a preview of the update may not
+// know values that the plan does.
+func plannedValueMatches(planned, actual resource.PropertyValue) bool {
+	switch {
+	case planned.IsComputed() || actual.IsComputed() || planned.IsOutput() || actual.IsOutput():
+		return true
+	case planned.IsSecret() && actual.IsSecret():
+		return plannedValueMatches(planned.SecretValue().Element, actual.SecretValue().Element)
+	case planned.IsArray() && actual.IsArray():
+		pa, aa := planned.ArrayValue(), actual.ArrayValue()
+		if len(pa) != len(aa) {
+			return false
+		}
+		for i := range pa {
+			if !plannedValueMatches(pa[i], aa[i]) {
+				return false
+			}
+		}
+		return true
+	case planned.IsObject() && actual.IsObject():
+		po, ao := planned.ObjectValue(), actual.ObjectValue()
+		if len(po) != len(ao) {
+			return false
+		}
+		for k, pv := range po {
+			av, ok := ao[k]
+			if !ok || !plannedValueMatches(pv, av) {
+				return false
+			}
+		}
+		return true
+	default:
+		return planned.DeepEquals(actual)
+	}
+}
+
+func formatOps(ops []StepOp) string {
+	strs := make([]string, len(ops))
+	for i, op := range ops {
+		strs[i] = string(op)
+	}
+	return strings.Join(strs, ", ")
+}
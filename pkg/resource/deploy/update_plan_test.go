@@ -0,0 +1,80 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deploy
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pulumi/pulumi/sdk/v2/go/common/resource"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/tokens"
+)
+
+func newPlanTestState(name tokens.QName) *resource.State {
+	urn := resource.NewURN("test", "test", "", "pkg:m:typ", name)
+	return &resource.State{Type: "pkg:m:typ", URN: urn}
+}
+
+func TestPlanCheckerSteps(t *testing.T) {
+	planned, unplanned := newPlanTestState("planned"), newPlanTestState("unplanned")
+
+	plan := NewUpdatePlan()
+	plan.recordSteps([]Step{NewDeleteStep(nil, planned)})
+
+	// Unplanned resources may be left alone, but not changed.
+	checker := newPlanChecker(plan)
+	assert.NoError(t, checker.checkSteps([]Step{NewSameStep(nil, nil, unplanned, unplanned)}))
+	assert.Error(t, checker.checkSteps([]Step{NewDeleteStep(nil, unplanned)}))
+
+	// Planned resources must take their planned steps.
+	checker = newPlanChecker(plan)
+	assert.Error(t, checker.checkSteps([]Step{NewSameStep(nil, nil, planned, planned)}))
+	assert.Error(t, checker.checkCompleted())
+	assert.NoError(t, checker.checkSteps([]Step{NewDeleteStep(nil, planned)}))
+	assert.NoError(t, checker.checkCompleted())
+
+	// Planned steps may not be repeated.
+	assert.Error(t, checker.checkSteps([]Step{NewDeleteStep(nil, planned)}))
+}
+
+func TestPlanCheckerInputs(t *testing.T) {
+	urn := newPlanTestState("res").URN
+
+	plan := NewUpdatePlan()
+	plan.recordInputs(urn, resource.PropertyMap{
+		"known":   resource.NewStringProperty("foo"),
+		"unknown": resource.MakeComputed(resource.NewStringProperty("")),
+	})
+	checker := newPlanChecker(plan)
+
+	assert.NoError(t, checker.checkInputs(urn, resource.PropertyMap{
+		"known":   resource.NewStringProperty("foo"),
+		"unknown": resource.NewStringProperty("bar"),
+	}))
+	assert.Error(t, checker.checkInputs(urn, resource.PropertyMap{
+		"known":   resource.NewStringProperty("baz"),
+		"unknown": resource.NewStringProperty("bar"),
+	}))
+	assert.Error(t, checker.checkInputs(urn, resource.PropertyMap{
+		"known":   resource.NewStringProperty("foo"),
+		"unknown": resource.NewStringProperty("bar"),
+		"extra":   resource.NewNumberProperty(42),
+	}))
+
+	// Inputs for resources the plan does not cover are not checked.
+	other := newPlanTestState("other").URN
+	assert.NoError(t, checker.checkInputs(other, resource.PropertyMap{}))
+}
@@ -39,3 +39,12 @@ type ResourceProtectedError struct {
 func (ResourceProtectedError) Error() string {
 	return "Can't delete protected resource"
 }
+
+// ResourceExistsError is returned by edits that would give a resource a URN that is already in use.
+type ResourceExistsError struct {
+	URN resource.URN
+}
+
+func (r ResourceExistsError) Error() string {
+	return fmt.Sprintf("a resource with URN %q already exists", r.URN)
+}
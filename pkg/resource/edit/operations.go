@@ -157,3 +157,415 @@ func RenameStack(snap *deploy.Snapshot, newName tokens.QName, newProject tokens.
 
 	return nil
 }
+
+// RenameResource changes the name of a resource in the snapshot. Because a resource's URN includes its name, every
+// reference to the resource from elsewhere in the snapshot is updated to refer to its new URN. The URNs of the
+// resource's children are unaffected, as they include only the type of their parent.
+func RenameResource(snap *deploy.Snapshot, res *resource.State, newName tokens.QName) error {
+	contract.Require(snap != nil, "snap")
+	contract.Require(res != nil, "res")
+	contract.Require(newName != "", "newName")
+
+	if res.Type == resource.RootStackType {
+		return errors.New("the root stack resource cannot be renamed")
+	}
+
+	newURN := resource.NewURN(res.URN.Stack(), res.URN.Project(), "", res.URN.QualifiedType(), newName)
+	if newURN == res.URN {
+		return nil
+	}
+	if len(LocateResource(snap, newURN)) != 0 {
+		return ResourceExistsError{URN: newURN}
+	}
+
+	rewriteReferences(snap, map[resource.URN]resource.URN{res.URN: newURN})
+	res.URN = newURN
+	return nil
+}
+
+// SetParent changes the parent of a resource in the snapshot. A resource's URN includes the type of its parent, so
+// the URNs of the resource and all of its descendants are recomputed and every reference to them is updated. If
+// necessary, the resources in the snapshot are reordered so that the new parent precedes its children.
+func SetParent(snap *deploy.Snapshot, res *resource.State, parent *resource.State) error {
+	contract.Require(snap != nil, "snap")
+	contract.Require(res != nil, "res")
+	contract.Require(parent != nil, "parent")
+
+	if res.Type == resource.RootStackType {
+		return errors.New("the root stack resource cannot be reparented")
+	}
+
+	// A resource may not be parented to itself or to one of its own descendants.
+	descendants := findDescendants(snap.Resources, res)
+	if parent == res || descendants[parent] {
+		return errors.Errorf("resource %q cannot be parented to itself or one of its descendants", res.URN)
+	}
+
+	// Compute the new URN of the resource and of each of its descendants. Parents always precede their children in
+	// a valid snapshot, so each resource's new parent URN is known by the time the resource itself is visited.
+	renames := map[resource.URN]resource.URN{
+		res.URN: newChildURN(res.URN.Stack(), res.URN.Project(), parent.URN, res.Type, res.URN.Name()),
+	}
+	for _, r := range snap.Resources {
+		if !descendants[r] {
+			continue
+		}
+		newParent, ok := renames[r.Parent]
+		contract.Assertf(ok, "descendant %v visited before its parent", r.URN)
+		renames[r.URN] = newChildURN(r.URN.Stack(), r.URN.Project(), newParent, r.Type, r.URN.Name())
+	}
+
+	for oldURN, newURN := range renames {
+		if oldURN != newURN && len(LocateResource(snap, newURN)) != 0 {
+			return ResourceExistsError{URN: newURN}
+		}
+	}
+
+	rewriteReferences(snap, renames)
+	res.Parent = parent.URN
+
+	sorted, err := sortResources(snap.Resources)
+	if err != nil {
+		return err
+	}
+	snap.Resources = sorted
+	return nil
+}
+
+// MoveResources moves the given resources from the source snapshot into the destination snapshot, which belongs to
+// the stack destStack in the project destProject. Resources that the given resources depend upon and the
+// descendants of the given resources are moved along with them. Providers used by moved resources are copied
+// rather than moved if they are still in use in the source snapshot. Moved resources whose parents remain in the
+// source snapshot are parented to the root stack resource of the destination snapshot, which is created if it does
+// not already exist.
+func MoveResources(source, dest *deploy.Snapshot, destStack tokens.QName, destProject tokens.PackageName,
+	resources []*resource.State) error {
+
+	contract.Require(source != nil, "source")
+	contract.Require(dest != nil, "dest")
+	contract.Require(destStack != "", "destStack")
+	contract.Require(destProject != "", "destProject")
+
+	// Compute the full set of URNs to move: the requested resources, their dependencies, and their descendants.
+	moving := make(map[resource.URN]bool)
+	var worklist []resource.URN
+	addURN := func(urn resource.URN) {
+		if !moving[urn] {
+			moving[urn] = true
+			worklist = append(worklist, urn)
+		}
+	}
+	for _, res := range resources {
+		addURN(res.URN)
+	}
+	for len(worklist) > 0 {
+		urn := worklist[0]
+		worklist = worklist[1:]
+
+		for _, res := range source.Resources {
+			if res.URN == urn {
+				if res.Type == resource.RootStackType {
+					return errors.New("the root stack resource cannot be moved")
+				}
+				for _, dep := range res.Dependencies {
+					addURN(dep)
+				}
+				for _, deps := range res.PropertyDependencies {
+					for _, dep := range deps {
+						addURN(dep)
+					}
+				}
+			}
+			if res.Parent == urn {
+				addURN(res.URN)
+			}
+		}
+	}
+
+	// Determine which providers are needed by the moving resources, and which of those must stay behind because they
+	// are still referenced by resources that are not moving.
+	providerRefs := make(map[resource.URN]bool)
+	for _, res := range source.Resources {
+		if moving[res.URN] && res.Provider != "" {
+			ref, err := providers.ParseReference(res.Provider)
+			if err != nil {
+				return errors.Wrapf(err, "parsing provider reference for resource %q", res.URN)
+			}
+			providerRefs[ref.URN()] = true
+		}
+	}
+	copying := make(map[resource.URN]bool)
+	for _, res := range source.Resources {
+		if moving[res.URN] || res.Provider == "" {
+			continue
+		}
+		ref, err := providers.ParseReference(res.Provider)
+		if err != nil {
+			return errors.Wrapf(err, "parsing provider reference for resource %q", res.URN)
+		}
+		if providerRefs[ref.URN()] {
+			copying[ref.URN()] = true
+			delete(moving, ref.URN())
+		}
+	}
+	for urn := range providerRefs {
+		if !moving[urn] && !copying[urn] {
+			copying[urn] = true
+		}
+	}
+
+	// Resources that are not moving may not depend on resources that are.
+	for _, res := range source.Resources {
+		if moving[res.URN] {
+			continue
+		}
+		var deps []resource.URN
+		deps = append(deps, res.Dependencies...)
+		for _, propDeps := range res.PropertyDependencies {
+			deps = append(deps, propDeps...)
+		}
+		for _, dep := range deps {
+			if moving[dep] {
+				return errors.Errorf("resource %q cannot be moved because %q depends on it", dep, res.URN)
+			}
+		}
+	}
+	for _, op := range source.PendingOperations {
+		if moving[op.Resource.URN] {
+			return errors.Errorf("resource %q cannot be moved because it has a pending operation", op.Resource.URN)
+		}
+	}
+
+	// Compute the new URN of every moving or copied resource. Parents that are not moving are replaced with the
+	// destination's root stack resource.
+	destRoot := resource.DefaultRootStackURN(destStack, destProject)
+	needsRoot := false
+	renames := make(map[resource.URN]resource.URN)
+	for _, res := range source.Resources {
+		if !moving[res.URN] && !copying[res.URN] {
+			continue
+		}
+		if _, ok := renames[res.URN]; ok {
+			continue
+		}
+
+		parent := res.Parent
+		if parent != "" {
+			if newParent, ok := renames[parent]; ok {
+				parent = newParent
+			} else {
+				parent, needsRoot = destRoot, true
+			}
+		}
+		renames[res.URN] = newChildURN(destStack, destProject, parent, res.Type, res.URN.Name())
+	}
+
+	// Make sure that nothing we are moving collides with a resource that already exists in the destination. Copied
+	// providers may be reused if the destination already has the same provider instance.
+	reused := make(map[resource.URN]bool)
+	for _, res := range source.Resources {
+		newURN, ok := renames[res.URN]
+		if !ok {
+			continue
+		}
+		existing := LocateResource(dest, newURN)
+		if len(existing) == 0 {
+			continue
+		}
+		if copying[res.URN] && len(existing) == 1 && existing[0].ID == res.ID {
+			reused[res.URN] = true
+			continue
+		}
+		return ResourceExistsError{URN: newURN}
+	}
+
+	// Split the source resources into those that stay and those that go, copying providers as necessary.
+	var remaining, moved []*resource.State
+	for _, res := range source.Resources {
+		switch {
+		case moving[res.URN]:
+			moved = append(moved, res)
+		case copying[res.URN]:
+			remaining = append(remaining, res)
+			if reused[res.URN] {
+				continue
+			}
+			for _, dep := range res.Dependencies {
+				if !moving[dep] {
+					return errors.Errorf("provider %q cannot be copied because it depends on %q", res.URN, dep)
+				}
+			}
+			copied := *res
+			copied.Dependencies = append([]resource.URN(nil), res.Dependencies...)
+			copied.PropertyDependencies = copyPropertyDependencies(res.PropertyDependencies)
+			moved = append(moved, &copied)
+		default:
+			remaining = append(remaining, res)
+		}
+	}
+
+	// Parents that are staying behind are replaced by the destination's root stack resource before the remaining
+	// references are rewritten to refer to the destination stack.
+	for _, res := range moved {
+		if _, ok := renames[res.Parent]; res.Parent != "" && !ok {
+			res.Parent = destRoot
+		}
+	}
+	rewriteReferences(&deploy.Snapshot{Resources: moved}, renames)
+
+	if needsRoot && len(LocateResource(dest, destRoot)) == 0 {
+		root := resource.NewState(resource.RootStackType, destRoot, false, false, "", resource.PropertyMap{},
+			resource.PropertyMap{}, "", false, false, nil, nil, "", nil, false, nil, nil, nil, "", nil, false)
+		dest.Resources = append([]*resource.State{root}, dest.Resources...)
+	}
+
+	dest.Resources = append(dest.Resources, moved...)
+	source.Resources = remaining
+	return nil
+}
+
+// newChildURN returns the URN of a resource with the given type and name that is parented to the given resource.
+func newChildURN(stack tokens.QName, project tokens.PackageName, parent resource.URN, t tokens.Type,
+	name tokens.QName) resource.URN {
+
+	parentType := tokens.Type("")
+	if parent != "" && parent.Type() != resource.RootStackType {
+		parentType = parent.QualifiedType()
+	}
+	return resource.NewURN(stack, project, parentType, t, name)
+}
+
+// findDescendants returns the set of resources that are transitively parented to the given resource.
+func findDescendants(resources []*resource.State, root *resource.State) map[*resource.State]bool {
+	parents := map[resource.URN]bool{root.URN: true}
+	descendants := make(map[*resource.State]bool)
+	for _, res := range resources {
+		if res != root && res.Parent != "" && parents[res.Parent] {
+			descendants[res] = true
+			parents[res.URN] = true
+		}
+	}
+	return descendants
+}
+
+// rewriteReferences replaces every URN in the snapshot that is a key in renames with the corresponding value. This
+// updates the URNs of the resources themselves as well as their parent, dependency, and provider references.
+func rewriteReferences(snap *deploy.Snapshot, renames map[resource.URN]resource.URN) {
+	rewriteURN := func(urn resource.URN) resource.URN {
+		if newURN, ok := renames[urn]; ok {
+			return newURN
+		}
+		return urn
+	}
+
+	rewriteState := func(res *resource.State) {
+		contract.Assert(res != nil)
+
+		res.URN = rewriteURN(res.URN)
+
+		if res.Parent != "" {
+			res.Parent = rewriteURN(res.Parent)
+		}
+
+		for depIdx, dep := range res.Dependencies {
+			res.Dependencies[depIdx] = rewriteURN(dep)
+		}
+
+		for _, propDeps := range res.PropertyDependencies {
+			for depIdx, dep := range propDeps {
+				propDeps[depIdx] = rewriteURN(dep)
+			}
+		}
+
+		if res.Provider != "" {
+			providerRef, err := providers.ParseReference(res.Provider)
+			contract.AssertNoErrorf(err, "failed to parse provider reference from validated checkpoint")
+
+			providerRef, err = providers.NewReference(rewriteURN(providerRef.URN()), providerRef.ID())
+			contract.AssertNoErrorf(err, "failed to generate provider reference from valid reference")
+
+			res.Provider = providerRef.String()
+		}
+	}
+
+	for _, res := range snap.Resources {
+		rewriteState(res)
+	}
+
+	for _, ops := range snap.PendingOperations {
+		rewriteState(ops.Resource)
+	}
+}
+
+// sortResources returns the given resources ordered such that each resource follows its parent, its dependencies,
+// and its provider. The existing order is preserved wherever possible.
+func sortResources(resources []*resource.State) ([]*resource.State, error) {
+	byURN := make(map[resource.URN][]*resource.State)
+	for _, res := range resources {
+		byURN[res.URN] = append(byURN[res.URN], res)
+	}
+
+	sorted := make([]*resource.State, 0, len(resources))
+	visited, visiting := make(map[*resource.State]bool), make(map[*resource.State]bool)
+
+	var visit func(res *resource.State) error
+	visitURN := func(urn resource.URN) error {
+		for _, dep := range byURN[urn] {
+			if err := visit(dep); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	visit = func(res *resource.State) error {
+		if visited[res] {
+			return nil
+		}
+		if visiting[res] {
+			return errors.Errorf("resource %q is part of a dependency cycle", res.URN)
+		}
+		visiting[res] = true
+
+		if res.Parent != "" {
+			if err := visitURN(res.Parent); err != nil {
+				return err
+			}
+		}
+		for _, dep := range res.Dependencies {
+			if err := visitURN(dep); err != nil {
+				return err
+			}
+		}
+		if res.Provider != "" {
+			ref, err := providers.ParseReference(res.Provider)
+			if err != nil {
+				return err
+			}
+			if err = visitURN(ref.URN()); err != nil {
+				return err
+			}
+		}
+
+		visiting[res], visited[res] = false, true
+		sorted = append(sorted, res)
+		return nil
+	}
+
+	for _, res := range resources {
+		if err := visit(res); err != nil {
+			return nil, err
+		}
+	}
+	return sorted, nil
+}
+
+func copyPropertyDependencies(deps map[resource.PropertyKey][]resource.URN) map[resource.PropertyKey][]resource.URN {
+	if deps == nil {
+		return nil
+	}
+	result := make(map[resource.PropertyKey][]resource.URN, len(deps))
+	for k, v := range deps {
+		result[k] = append([]resource.URN(nil), v...)
+	}
+	return result
+}
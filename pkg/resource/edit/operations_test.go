@@ -321,3 +321,130 @@ func TestRenameStack(t *testing.T) {
 		assert.Len(t, LocateResource(snap, updatedResourceURN), 1)
 	})
 }
+
+func TestRenameResource(t *testing.T) {
+	pA := NewProviderResource("a", "p1", "0")
+	a := NewResource("a", pA)
+	b := NewResource("b", pA, a.URN)
+	c := NewResource("c", pA)
+	c.Parent = a.URN
+	c.URN = resource.NewURN("test", "test", a.URN.QualifiedType(), c.Type, "c")
+	snap := NewSnapshot([]*resource.State{
+		pA,
+		a,
+		b,
+		c,
+	})
+
+	err := RenameResource(snap, a, "a2")
+	assert.NoError(t, err)
+	assert.NoError(t, snap.VerifyIntegrity())
+
+	newURN := resource.NewURN("test", "test", "", a.Type, "a2")
+	assert.Equal(t, newURN, a.URN)
+	assert.Equal(t, []resource.URN{newURN}, b.Dependencies)
+	assert.Equal(t, newURN, c.Parent)
+
+	// Renaming onto an existing URN is an error.
+	err = RenameResource(snap, b, "c")
+	assert.NoError(t, err)
+	err = RenameResource(snap, a, "c")
+	assert.Error(t, err)
+	assert.IsType(t, ResourceExistsError{}, err)
+}
+
+func TestRenameProvider(t *testing.T) {
+	pA := NewProviderResource("a", "p1", "0")
+	a := NewResource("a", pA)
+	snap := NewSnapshot([]*resource.State{
+		pA,
+		a,
+	})
+
+	err := RenameResource(snap, pA, "p2")
+	assert.NoError(t, err)
+	assert.NoError(t, snap.VerifyIntegrity())
+
+	ref, err := providers.ParseReference(a.Provider)
+	assert.NoError(t, err)
+	assert.Equal(t, pA.URN, ref.URN())
+	assert.Equal(t, pA.ID, ref.ID())
+}
+
+func TestSetParent(t *testing.T) {
+	pA := NewProviderResource("a", "p1", "0")
+	a := NewResource("a", pA)
+	b := NewResource("b", pA)
+	c := NewResource("c", pA)
+	c.Parent = b.URN
+	c.URN = resource.NewURN("test", "test", b.URN.QualifiedType(), c.Type, "c")
+	d := NewResource("d", pA, c.URN)
+	e := NewResource("e", pA)
+	snap := NewSnapshot([]*resource.State{
+		pA,
+		a,
+		b,
+		c,
+		d,
+		e,
+	})
+
+	// Parenting b to e moves b and its child c after e, followed by c's dependent d.
+	err := SetParent(snap, b, e)
+	assert.NoError(t, err)
+	assert.NoError(t, snap.VerifyIntegrity())
+	assert.Equal(t, []*resource.State{pA, a, e, b, c, d}, snap.Resources)
+
+	assert.Equal(t, e.URN, b.Parent)
+	assert.Equal(t, resource.NewURN("test", "test", e.URN.QualifiedType(), b.Type, "b"), b.URN)
+	assert.Equal(t, resource.NewURN("test", "test", b.URN.QualifiedType(), c.Type, "c"), c.URN)
+	assert.Equal(t, b.URN, c.Parent)
+	assert.Equal(t, []resource.URN{c.URN}, d.Dependencies)
+
+	// Cycles are rejected.
+	err = SetParent(snap, e, c)
+	assert.Error(t, err)
+	err = SetParent(snap, e, e)
+	assert.Error(t, err)
+}
+
+func TestMoveResources(t *testing.T) {
+	pA := NewProviderResource("a", "p1", "0")
+	a := NewResource("a", pA)
+	b := NewResource("b", pA, a.URN)
+	c := NewResource("c", pA)
+	source := NewSnapshot([]*resource.State{
+		pA,
+		a,
+		b,
+		c,
+	})
+	dest := NewSnapshot(nil)
+
+	// Moving b brings its dependency a along with it. The provider is still in use by c, so it is copied.
+	err := MoveResources(source, dest, "dest", "test", []*resource.State{b})
+	assert.NoError(t, err)
+	assert.NoError(t, source.VerifyIntegrity())
+	assert.NoError(t, dest.VerifyIntegrity())
+
+	assert.Equal(t, []*resource.State{pA, c}, source.Resources)
+	if !assert.Len(t, dest.Resources, 3) {
+		t.FailNow()
+	}
+	assert.Equal(t, resource.NewURN("dest", "test", "", pA.Type, "p1"), dest.Resources[0].URN)
+	assert.Equal(t, a, dest.Resources[1])
+	assert.Equal(t, b, dest.Resources[2])
+	assert.Equal(t, resource.NewURN("dest", "test", "", a.Type, "a"), a.URN)
+	assert.Equal(t, []resource.URN{a.URN}, b.Dependencies)
+
+	ref, err := providers.ParseReference(b.Provider)
+	assert.NoError(t, err)
+	assert.Equal(t, dest.Resources[0].URN, ref.URN())
+
+	// Resources that are depended upon by resources staying behind can't be moved.
+	d := NewResource("d", pA, c.URN)
+	source.Resources = append(source.Resources, d)
+	err = MoveResources(source, dest, "dest", "test", []*resource.State{c})
+	assert.Error(t, err)
+	assert.Equal(t, []*resource.State{pA, c, d}, source.Resources)
+}
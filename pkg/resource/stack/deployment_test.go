@@ -84,7 +84,7 @@ func TestDeploymentSerialization(t *testing.T) {
 		nil,
 		nil,
 		nil,
-		"",
+		"", nil, false,
 	)
 
 	dep, err := SerializeResource(res, config.NopEncrypter, false /* showSecrets */)
@@ -0,0 +1,89 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package stack
+
+import (
+	"time"
+
+	"github.com/pulumi/pulumi/pkg/v2/resource/deploy"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/apitype"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/resource"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/resource/config"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/util/contract"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/version"
+)
+
+// SerializeUpdatePlan serializes an update plan. Secret inputs are encrypted with the given encrypter.
+func SerializeUpdatePlan(plan *deploy.UpdatePlan, enc config.Encrypter) (apitype.UpdatePlanV1, error) {
+	contract.Require(plan != nil, "plan")
+
+	resourcePlans := make(map[resource.URN]apitype.ResourcePlanV1)
+	for urn, rp := range plan.ResourcePlans {
+		steps := make([]apitype.OpType, len(rp.Ops))
+		for i, op := range rp.Ops {
+			steps[i] = apitype.OpType(op)
+		}
+
+		var inputs map[string]interface{}
+		if rp.Inputs != nil {
+			props, err := SerializeProperties(rp.Inputs, enc, false /* showSecrets */)
+			if err != nil {
+				return apitype.UpdatePlanV1{}, err
+			}
+			inputs = props
+		}
+
+		resourcePlans[urn] = apitype.ResourcePlanV1{
+			Steps:  steps,
+			Inputs: inputs,
+		}
+	}
+
+	return apitype.UpdatePlanV1{
+		Manifest: apitype.ManifestV1{
+			Time:    time.Now(),
+			Version: version.Version,
+		},
+		ResourcePlans: resourcePlans,
+	}, nil
+}
+
+// DeserializeUpdatePlan deserializes an update plan. Secret inputs are decrypted with the given decrypter.
+func DeserializeUpdatePlan(plan apitype.UpdatePlanV1, dec config.Decrypter,
+	enc config.Encrypter) (*deploy.UpdatePlan, error) {
+
+	result := deploy.NewUpdatePlan()
+	for urn, rp := range plan.ResourcePlans {
+		ops := make([]deploy.StepOp, len(rp.Steps))
+		for i, step := range rp.Steps {
+			ops[i] = deploy.StepOp(step)
+		}
+
+		var inputs resource.PropertyMap
+		if rp.Inputs != nil {
+			props, err := DeserializeProperties(rp.Inputs, dec, enc)
+			if err != nil {
+				return nil, err
+			}
+			inputs = props
+		}
+
+		result.ResourcePlans[urn] = &deploy.ResourcePlan{
+			Ops:    ops,
+			Inputs: inputs,
+		}
+	}
+	return result, nil
+}
@@ -0,0 +1,41 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package auto contains the Pulumi Automation API, the programmatic interface for driving Pulumi programs
+// without the CLI.
+//
+// Generally this can be thought of as encapsulating the functionality of the CLI (`pulumi up`, `pulumi preview`,
+// `pulumi destroy`, `pulumi stack init`, etc.) but with more flexibility. Operations drive the backend and the engine
+// within the calling process, so the CLI does not need to be installed.
+//
+// The Automation API is built around two concepts: Workspaces and Stacks. A Workspace is the execution context
+// containing a single Pulumi project, a program, and multiple stacks. A Stack is an isolated, independently
+// configurable instance of a Pulumi program.
+//
+// A program may either live on disk, as with `pulumi up`, or be passed inline as a `pulumi.RunFunc`:
+//
+//	ctx := context.Background()
+//	s, err := auto.UpsertStackInlineSource(ctx, "dev", "myproject", func(pCtx *pulumi.Context) error {
+//		pCtx.Export("greeting", pulumi.String("hello"))
+//		return nil
+//	})
+//	if err != nil {
+//		// handle error
+//	}
+//	res, err := s.Up(ctx, optup.Message("deploying from code"))
+//
+// Inline programs are run inside the calling process: the Workspace hosts a language runtime server for the
+// duration of each operation and directs the engine to it. Because an inline program's plugins cannot be discovered
+// ahead of time, any resource plugins it requires must be installed with Workspace.InstallPlugin.
+package auto
@@ -0,0 +1,43 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auto
+
+import (
+	"net/http"
+
+	"github.com/pkg/errors"
+
+	"github.com/pulumi/pulumi/pkg/v2/backend"
+	"github.com/pulumi/pulumi/pkg/v2/backend/filestate"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/apitype"
+)
+
+// IsConcurrentUpdateError returns true if the error was a result of a conflicting update locking the stack.
+func IsConcurrentUpdateError(err error) bool {
+	switch e := errors.Cause(err).(type) {
+	case filestate.StackLockedError:
+		return true
+	case *apitype.ErrorResponse:
+		return e.Code == http.StatusConflict
+	default:
+		return false
+	}
+}
+
+// isAlreadyExistsError returns true if the error was the result of creating a stack that already exists.
+func isAlreadyExistsError(err error) bool {
+	_, ok := errors.Cause(err).(*backend.StackAlreadyExistsError)
+	return ok
+}
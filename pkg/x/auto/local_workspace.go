@@ -0,0 +1,805 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auto
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/blang/semver"
+	"github.com/pkg/errors"
+
+	"github.com/pulumi/pulumi/pkg/v2/backend"
+	"github.com/pulumi/pulumi/pkg/v2/backend/display"
+	"github.com/pulumi/pulumi/pkg/v2/backend/filestate"
+	"github.com/pulumi/pulumi/pkg/v2/backend/httpstate"
+	"github.com/pulumi/pulumi/pkg/v2/resource/stack"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/apitype"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/diag"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/diag/colors"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/encoding"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/resource/config"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/tokens"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/workspace"
+	"github.com/pulumi/pulumi/sdk/v2/go/pulumi"
+)
+
+// LocalWorkspace is a default implementation of the Workspace interface. It stores project and stack settings on
+// the local filesystem in the workspace's working directory and performs all operations in-process against the
+// project's backend. If a program is supplied, it is run in-process in place of the project's own program.
+type LocalWorkspace struct {
+	workDir string
+	program pulumi.RunFunc
+	envvars map[string]string
+
+	m       sync.Mutex      // protects the fields below.
+	backend backend.Backend // the backend that stores the workspace's stacks, once connected.
+	stack   string          // the name of the currently selected stack, if any.
+}
+
+// NewLocalWorkspace creates and configures a LocalWorkspace. LocalWorkspaceOptions can be used to configure things
+// like the working directory, the program to execute, and project settings. If no working directory is given, a
+// new temporary directory is created.
+func NewLocalWorkspace(ctx context.Context, opts ...LocalWorkspaceOption) (Workspace, error) {
+	lwOpts := &localWorkspaceOptions{}
+	for _, o := range opts {
+		o.applyLocalWorkspaceOption(lwOpts)
+	}
+
+	workDir := lwOpts.WorkDir
+	if workDir == "" {
+		dir, err := ioutil.TempDir("", "pulumi_auto")
+		if err != nil {
+			return nil, errors.Wrap(err, "unable to create tmp directory for workspace")
+		}
+		workDir = dir
+	}
+
+	l := &LocalWorkspace{
+		workDir: workDir,
+		program: lwOpts.Program,
+		envvars: lwOpts.EnvVars,
+	}
+
+	if lwOpts.Project != nil {
+		if err := l.SaveProjectSettings(ctx, lwOpts.Project); err != nil {
+			return nil, errors.Wrap(err, "failed to create workspace, unable to save project settings")
+		}
+	}
+
+	return l, nil
+}
+
+// ProjectSettings returns the settings object for the current project if any.
+func (l *LocalWorkspace) ProjectSettings(ctx context.Context) (*workspace.Project, error) {
+	for _, ext := range encoding.Exts {
+		path := filepath.Join(l.WorkDir(), fmt.Sprintf("%s%s", workspace.ProjectFile, ext))
+		if _, err := os.Stat(path); err != nil {
+			continue
+		}
+		return workspace.LoadProject(path)
+	}
+	return nil, errors.Errorf("unable to find project settings in workspace '%s'", l.WorkDir())
+}
+
+// SaveProjectSettings overwrites the settings object in the current project. An existing project file is
+// overwritten in place; otherwise a new Pulumi.yaml is created.
+func (l *LocalWorkspace) SaveProjectSettings(ctx context.Context, settings *workspace.Project) error {
+	path := filepath.Join(l.WorkDir(), fmt.Sprintf("%s%s", workspace.ProjectFile, encoding.YAMLExt))
+	for _, ext := range encoding.Exts {
+		p := filepath.Join(l.WorkDir(), fmt.Sprintf("%s%s", workspace.ProjectFile, ext))
+		if _, err := os.Stat(p); err == nil {
+			path = p
+			break
+		}
+	}
+	return settings.Save(path)
+}
+
+// GetConfig returns the value associated with the specified stack name and key.
+func (l *LocalWorkspace) GetConfig(ctx context.Context, stackName string, key string) (ConfigValue, error) {
+	s, ps, err := l.loadStackSettings(ctx, stackName)
+	if err != nil {
+		return ConfigValue{}, err
+	}
+	k, err := l.parseConfigKey(ctx, key)
+	if err != nil {
+		return ConfigValue{}, err
+	}
+	v, ok := ps.Config[k]
+	if !ok {
+		return ConfigValue{}, errors.Errorf("configuration key '%s' not found for stack '%s'", key, stackName)
+	}
+
+	dec := config.NewBlindingDecrypter()
+	if v.Secure() {
+		if dec, err = stackDecrypter(ctx, l, s); err != nil {
+			return ConfigValue{}, err
+		}
+	}
+	str, err := v.Value(dec)
+	if err != nil {
+		return ConfigValue{}, errors.Wrap(err, "unable to read config")
+	}
+	return ConfigValue{Value: str, Secret: v.Secure()}, nil
+}
+
+// GetAllConfig returns the config map for the specified stack name.
+func (l *LocalWorkspace) GetAllConfig(ctx context.Context, stackName string) (ConfigMap, error) {
+	val := make(ConfigMap)
+	s, ps, err := l.loadStackSettings(ctx, stackName)
+	if err != nil {
+		return nil, err
+	}
+
+	dec := config.NewBlindingDecrypter()
+	if ps.Config.HasSecureValue() {
+		if dec, err = stackDecrypter(ctx, l, s); err != nil {
+			return nil, err
+		}
+	}
+	for k, v := range ps.Config {
+		str, err := v.Value(dec)
+		if err != nil {
+			return nil, errors.Wrap(err, "unable to read config")
+		}
+		val[k.String()] = ConfigValue{Value: str, Secret: v.Secure()}
+	}
+	return val, nil
+}
+
+// SetConfig sets the specified key-value pair on the provided stack name.
+func (l *LocalWorkspace) SetConfig(ctx context.Context, stackName string, key string, val ConfigValue) error {
+	return l.SetAllConfig(ctx, stackName, ConfigMap{key: val})
+}
+
+// SetAllConfig sets all values in the provided config map for the specified stack name.
+func (l *LocalWorkspace) SetAllConfig(ctx context.Context, stackName string, cfg ConfigMap) error {
+	s, ps, err := l.loadStackSettings(ctx, stackName)
+	if err != nil {
+		return err
+	}
+
+	var enc config.Encrypter
+	for key, val := range cfg {
+		k, err := l.parseConfigKey(ctx, key)
+		if err != nil {
+			return err
+		}
+		if !val.Secret {
+			ps.Config[k] = config.NewValue(val.Value)
+			continue
+		}
+
+		if enc == nil {
+			if enc, err = stackEncrypter(ctx, l, s); err != nil {
+				return err
+			}
+		}
+		ciphertext, err := enc.EncryptValue(val.Value)
+		if err != nil {
+			return errors.Wrap(err, "unable to encrypt config value")
+		}
+		ps.Config[k] = config.NewSecureValue(ciphertext)
+	}
+	return saveProjectStack(ctx, l, s, ps)
+}
+
+// RemoveConfig removes the specified key-value pair on the provided stack name.
+func (l *LocalWorkspace) RemoveConfig(ctx context.Context, stackName string, key string) error {
+	s, ps, err := l.loadStackSettings(ctx, stackName)
+	if err != nil {
+		return err
+	}
+	k, err := l.parseConfigKey(ctx, key)
+	if err != nil {
+		return err
+	}
+	delete(ps.Config, k)
+	return saveProjectStack(ctx, l, s, ps)
+}
+
+// WorkDir returns the working directory containing the project and stack settings. It is also the root directory
+// of the program.
+func (l *LocalWorkspace) WorkDir() string {
+	return l.workDir
+}
+
+// EnvVars returns the additional environment variables that apply to every operation.
+func (l *LocalWorkspace) EnvVars() map[string]string {
+	return l.envvars
+}
+
+// Backend returns the backend that stores the workspace's stacks. The backend is the one named by the project
+// settings, if any, or else the backend that the user is currently logged into.
+func (l *LocalWorkspace) Backend(ctx context.Context) (backend.Backend, error) {
+	l.m.Lock()
+	defer l.m.Unlock()
+
+	if l.backend != nil {
+		return l.backend, nil
+	}
+
+	proj, err := l.ProjectSettings(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	url := ""
+	if proj.Backend != nil {
+		url = proj.Backend.URL
+	}
+	if url == "" {
+		creds, err := workspace.GetStoredCredentials()
+		if err != nil {
+			return nil, errors.Wrap(err, "could not get cloud url")
+		}
+		url = creds.Current
+	}
+
+	var b backend.Backend
+	sink := diag.DefaultSink(ioutil.Discard, os.Stderr, diag.FormatOptions{Color: colors.Never})
+	switch token, hasToken := l.envvars[httpstate.AccessTokenEnvVar]; {
+	case filestate.IsFileStateBackendURL(url):
+		b, err = filestate.NewForProject(sink, url, proj)
+	case hasToken:
+		// An access token in the workspace's environment applies to this workspace only, so it is neither validated
+		// nor saved as the user's credentials.
+		b = httpstate.NewWithAccessToken(sink, url, token)
+	default:
+		b, err = httpstate.Login(ctx, sink, url, display.Options{Color: colors.Never})
+	}
+	if err != nil {
+		return nil, err
+	}
+	l.backend = b
+	return b, nil
+}
+
+// WhoAmI returns the currently authenticated user.
+func (l *LocalWorkspace) WhoAmI(ctx context.Context) (string, error) {
+	b, err := l.Backend(ctx)
+	if err != nil {
+		return "", err
+	}
+	user, err := b.CurrentUser()
+	if err != nil {
+		return "", errors.Wrap(err, "could not determine authenticated user")
+	}
+	return user, nil
+}
+
+// Stack returns a summary of the currently selected stack, if any.
+func (l *LocalWorkspace) Stack(ctx context.Context) (*StackSummary, error) {
+	stacks, err := l.ListStacks(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not determine selected stack")
+	}
+	for _, s := range stacks {
+		if s.Current {
+			return &s, nil
+		}
+	}
+	return nil, nil
+}
+
+// CreateStack creates and sets a new stack with the stack name, failing if one already exists.
+func (l *LocalWorkspace) CreateStack(ctx context.Context, stackName string) error {
+	b, ref, err := parseStackReference(ctx, l, stackName)
+	if err != nil {
+		return err
+	}
+	s, err := b.CreateStack(ctx, ref, nil)
+	if err != nil {
+		if isAlreadyExistsError(err) {
+			return err
+		}
+		return errors.Wrap(err, "failed to create stack")
+	}
+
+	// Configure the stack's secrets provider now, as the CLI does, so that its settings file records it.
+	if _, err = stackSecretsManager(ctx, l, s); err != nil {
+		return errors.Wrap(err, "failed to create stack")
+	}
+
+	l.setCurrentStack(stackName)
+	return nil
+}
+
+// SelectStack selects and sets an existing stack matching the stack name, failing if none exists.
+func (l *LocalWorkspace) SelectStack(ctx context.Context, stackName string) error {
+	if _, err := getStack(ctx, l, stackName); err != nil {
+		return errors.Wrap(err, "failed to select stack")
+	}
+	l.setCurrentStack(stackName)
+	return nil
+}
+
+// RemoveStack deletes the stack and all associated configuration and history.
+func (l *LocalWorkspace) RemoveStack(ctx context.Context, stackName string) error {
+	s, err := getStack(ctx, l, stackName)
+	if err != nil {
+		return errors.Wrap(err, "failed to remove stack")
+	}
+	if _, err = backend.RemoveStack(ctx, s, false /*force*/); err != nil {
+		return errors.Wrap(err, "failed to remove stack")
+	}
+
+	path, err := stackSettingsPath(ctx, l, s.Ref().Name())
+	if err != nil {
+		return err
+	}
+	if err = os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return errors.Wrap(err, "failed to remove stack settings")
+	}
+
+	l.m.Lock()
+	defer l.m.Unlock()
+	if l.stack == stackName {
+		l.stack = ""
+	}
+	return nil
+}
+
+// ListStacks returns all Stacks created under the current Project.
+func (l *LocalWorkspace) ListStacks(ctx context.Context) ([]StackSummary, error) {
+	var stacks []StackSummary
+	b, err := l.Backend(ctx)
+	if err != nil {
+		return nil, err
+	}
+	proj, err := l.ProjectSettings(ctx)
+	if err != nil {
+		return nil, err
+	}
+	current, err := l.currentStackReference(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	projName := proj.Name.String()
+	summaries, err := b.ListStacks(ctx, backend.ListStacksFilter{Project: &projName})
+	if err != nil {
+		return nil, errors.Wrap(err, "could not list stacks")
+	}
+	for _, summary := range summaries {
+		s := StackSummary{
+			Name:          summary.Name().String(),
+			Current:       current != nil && summary.Name().String() == current.String(),
+			ResourceCount: summary.ResourceCount(),
+		}
+		if t := summary.LastUpdate(); t != nil {
+			s.LastUpdate = t.Format(time.RFC3339)
+		}
+		stacks = append(stacks, s)
+	}
+	return stacks, nil
+}
+
+// InstallPlugin acquires the resource plugin matching the specified name and version.
+func (l *LocalWorkspace) InstallPlugin(ctx context.Context, name string, version string) error {
+	v, err := semver.ParseTolerant(version)
+	if err != nil {
+		return errors.Wrapf(err, "invalid plugin version %q", version)
+	}
+	info := workspace.PluginInfo{Name: name, Kind: workspace.ResourcePlugin, Version: &v}
+
+	if workspace.HasPlugin(info) {
+		return nil
+	}
+	tarball, _, err := info.Download()
+	if err != nil {
+		return errors.Wrapf(err, "failed to download plugin %s", info)
+	}
+	if err = info.Install(tarball); err != nil {
+		return errors.Wrapf(err, "failed to install plugin %s", info)
+	}
+	return nil
+}
+
+// ExportStack exports the deployment state of the stack matching the given name. Secrets are exported in
+// plaintext.
+func (l *LocalWorkspace) ExportStack(ctx context.Context, stackName string) (apitype.UntypedDeployment, error) {
+	s, err := getStack(ctx, l, stackName)
+	if err != nil {
+		return apitype.UntypedDeployment{}, errors.Wrap(err, "could not export stack")
+	}
+	deployment, err := s.ExportDeployment(ctx)
+	if err != nil {
+		return apitype.UntypedDeployment{}, errors.Wrap(err, "could not export stack")
+	}
+
+	snap, err := stack.DeserializeUntypedDeployment(deployment, stack.DefaultSecretsProvider)
+	if err != nil {
+		return apitype.UntypedDeployment{}, errors.Wrap(err, "could not export stack")
+	}
+	serialized, err := stack.SerializeDeployment(snap, snap.SecretsManager, true /*showSecrets*/)
+	if err != nil {
+		return apitype.UntypedDeployment{}, errors.Wrap(err, "could not export stack")
+	}
+	data, err := json.Marshal(serialized)
+	if err != nil {
+		return apitype.UntypedDeployment{}, errors.Wrap(err, "could not export stack")
+	}
+
+	return apitype.UntypedDeployment{Version: apitype.DeploymentSchemaVersionCurrent, Deployment: data}, nil
+}
+
+// ImportStack imports the specified deployment state into the stack matching the given name. Any pending
+// operations in the deployment are discarded.
+func (l *LocalWorkspace) ImportStack(ctx context.Context, stackName string,
+	state apitype.UntypedDeployment) error {
+
+	s, err := getStack(ctx, l, stackName)
+	if err != nil {
+		return errors.Wrap(err, "could not import stack")
+	}
+
+	snap, err := stack.DeserializeUntypedDeployment(&state, stack.DefaultSecretsProvider)
+	if err != nil {
+		return errors.Wrap(err, "could not import stack")
+	}
+	for _, res := range snap.Resources {
+		if res.URN.Stack() != s.Ref().Name() {
+			return errors.Errorf("could not import stack, resource '%s' is from a different stack (%s != %s)",
+				res.URN, res.URN.Stack(), s.Ref().Name())
+		}
+	}
+	if err = snap.VerifyIntegrity(); err != nil {
+		return errors.Wrap(err, "could not import stack, state contains errors")
+	}
+	snap.PendingOperations = nil
+
+	serialized, err := stack.SerializeDeployment(snap, snap.SecretsManager, false /*showSecrets*/)
+	if err != nil {
+		return errors.Wrap(err, "could not import stack")
+	}
+	data, err := json.Marshal(serialized)
+	if err != nil {
+		return errors.Wrap(err, "could not import stack")
+	}
+
+	deployment := &apitype.UntypedDeployment{Version: apitype.DeploymentSchemaVersionCurrent, Deployment: data}
+	if err = s.ImportDeployment(ctx, deployment); err != nil {
+		return errors.Wrap(err, "could not import stack")
+	}
+	return nil
+}
+
+// Program returns the program `pulumi.RunFunc` to be used for Preview/Update if any.
+// If none is specified, the stack will refer to ProjectSettings for this information.
+func (l *LocalWorkspace) Program() pulumi.RunFunc {
+	return l.program
+}
+
+// SetProgram sets the program associated with the Workspace to the specified `pulumi.RunFunc`.
+func (l *LocalWorkspace) SetProgram(fn pulumi.RunFunc) {
+	l.program = fn
+}
+
+func (l *LocalWorkspace) setCurrentStack(stackName string) {
+	l.m.Lock()
+	defer l.m.Unlock()
+	l.stack = stackName
+}
+
+// currentStackReference returns a reference to the currently selected stack, or nil if no stack is selected.
+func (l *LocalWorkspace) currentStackReference(ctx context.Context) (backend.StackReference, error) {
+	l.m.Lock()
+	stackName := l.stack
+	l.m.Unlock()
+
+	if stackName == "" {
+		return nil, nil
+	}
+	_, ref, err := parseStackReference(ctx, l, stackName)
+	return ref, err
+}
+
+// parseConfigKey parses a configuration key. Keys without a namespace are placed in the project's namespace.
+func (l *LocalWorkspace) parseConfigKey(ctx context.Context, key string) (config.Key, error) {
+	if !strings.Contains(key, tokens.TokenDelimiter) {
+		proj, err := l.ProjectSettings(ctx)
+		if err != nil {
+			return config.Key{}, err
+		}
+		key = fmt.Sprintf("%s:%s", proj.Name, key)
+	}
+	return config.ParseKey(key)
+}
+
+// loadStackSettings returns the named stack along with its settings.
+func (l *LocalWorkspace) loadStackSettings(ctx context.Context,
+	stackName string) (backend.Stack, *workspace.ProjectStack, error) {
+
+	s, err := getStack(ctx, l, stackName)
+	if err != nil {
+		return nil, nil, err
+	}
+	ps, err := loadProjectStack(ctx, l, s)
+	if err != nil {
+		return nil, nil, err
+	}
+	return s, ps, nil
+}
+
+// stackSettingsPath returns the path to the settings file of the given stack in the workspace's working directory.
+func stackSettingsPath(ctx context.Context, ws Workspace, stackName tokens.QName) (string, error) {
+	proj, err := ws.ProjectSettings(ctx)
+	if err != nil {
+		return "", err
+	}
+	fileName := fmt.Sprintf("%s.%s%s",
+		workspace.ProjectFile, strings.Replace(string(stackName), tokens.QNameDelimiter, "-", -1), encoding.YAMLExt)
+	return filepath.Join(ws.WorkDir(), proj.Config, fileName), nil
+}
+
+// loadProjectStack loads the settings of the given stack from the workspace's working directory.
+func loadProjectStack(ctx context.Context, ws Workspace, s backend.Stack) (*workspace.ProjectStack, error) {
+	path, err := stackSettingsPath(ctx, ws, s.Ref().Name())
+	if err != nil {
+		return nil, err
+	}
+	return workspace.LoadProjectStack(path)
+}
+
+// saveProjectStack saves the settings of the given stack to the workspace's working directory.
+func saveProjectStack(ctx context.Context, ws Workspace, s backend.Stack, ps *workspace.ProjectStack) error {
+	path, err := stackSettingsPath(ctx, ws, s.Ref().Name())
+	if err != nil {
+		return err
+	}
+	return ps.Save(path)
+}
+
+// parseStackReference parses the given stack name using the workspace's backend. The Pulumi Service requires stack
+// references to name their owner and project, which are otherwise inferred from the working directory, so names
+// for that backend are qualified with the current user and the workspace's project as necessary.
+func parseStackReference(ctx context.Context, ws Workspace,
+	stackName string) (backend.Backend, backend.StackReference, error) {
+
+	b, err := ws.Backend(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if _, ok := b.(httpstate.Backend); ok {
+		proj, err := ws.ProjectSettings(ctx)
+		if err != nil {
+			return nil, nil, err
+		}
+		switch parts := strings.Split(stackName, "/"); len(parts) {
+		case 1:
+			owner, err := b.CurrentUser()
+			if err != nil {
+				return nil, nil, err
+			}
+			stackName = FullyQualifiedStackName(owner, proj.Name.String(), stackName)
+		case 2:
+			stackName = FullyQualifiedStackName(parts[0], proj.Name.String(), parts[1])
+		}
+	}
+
+	ref, err := b.ParseStackReference(stackName)
+	if err != nil {
+		return nil, nil, err
+	}
+	return b, ref, nil
+}
+
+// getStack returns the named stack, failing if it does not exist.
+func getStack(ctx context.Context, ws Workspace, stackName string) (backend.Stack, error) {
+	b, ref, err := parseStackReference(ctx, ws, stackName)
+	if err != nil {
+		return nil, err
+	}
+	s, err := b.GetStack(ctx, ref)
+	if err != nil {
+		return nil, err
+	}
+	if s == nil {
+		return nil, errors.Errorf("no stack named '%s' found", stackName)
+	}
+	return s, nil
+}
+
+// lookupEnv returns the value of the given environment variable for operations in the given workspace. Variables in
+// the workspace's EnvVars take precedence over those of the current process.
+func lookupEnv(ws Workspace, key string) (string, bool) {
+	if v, ok := ws.EnvVars()[key]; ok {
+		return v, true
+	}
+	return os.LookupEnv(key)
+}
+
+// LocalWorkspaceOption is used to customize and configure a LocalWorkspace at initialization time.
+// See Workdir, Program, Project, and EnvVars for concrete options.
+type LocalWorkspaceOption interface {
+	applyLocalWorkspaceOption(*localWorkspaceOptions)
+}
+
+type localWorkspaceOptions struct {
+	// WorkDir is the directory to execute commands from and store state.
+	WorkDir string
+	// Program is the Pulumi Program to execute. If none is supplied, the program identified in
+	// $WORKDIR/Pulumi.yaml will be used instead.
+	Program pulumi.RunFunc
+	// Project is the project settings for the workspace.
+	Project *workspace.Project
+	// EnvVars is a map of environment values scoped to the workspace.
+	// These values are passed to the plugins launched by Stack operations
+	// and are consulted for the variables that Pulumi itself reads, such as PULUMI_CONFIG_PASSPHRASE and
+	// PULUMI_ACCESS_TOKEN. They are never set in the current process's environment, so libraries used in-process, such
+	// as cloud SDKs that read credentials for a state bucket or a secrets provider, do not observe them.
+	EnvVars map[string]string
+}
+
+type localWorkspaceOption func(*localWorkspaceOptions)
+
+func (o localWorkspaceOption) applyLocalWorkspaceOption(lo *localWorkspaceOptions) {
+	o(lo)
+}
+
+// WorkDir is the directory to execute commands from and store state.
+func WorkDir(workDir string) LocalWorkspaceOption {
+	return localWorkspaceOption(func(lo *localWorkspaceOptions) {
+		lo.WorkDir = workDir
+	})
+}
+
+// Program is the Pulumi Program to execute. If none is supplied, the program identified in $WORKDIR/Pulumi.yaml
+// will be used instead.
+func Program(program pulumi.RunFunc) LocalWorkspaceOption {
+	return localWorkspaceOption(func(lo *localWorkspaceOptions) {
+		lo.Program = program
+	})
+}
+
+// Project sets project settings for the workspace, overwriting any existing project file.
+func Project(settings workspace.Project) LocalWorkspaceOption {
+	return localWorkspaceOption(func(lo *localWorkspaceOptions) {
+		lo.Project = &settings
+	})
+}
+
+// EnvVars is a map of environment values scoped to the workspace.
+// These values are passed to the plugins launched by Stack operations
+// and are consulted for the variables that Pulumi itself reads, such as PULUMI_CONFIG_PASSPHRASE and
+// PULUMI_ACCESS_TOKEN. They are never set in the current process's environment, so libraries used in-process, such
+// as cloud SDKs that read credentials for a state bucket or a secrets provider, do not observe them.
+func EnvVars(envvars map[string]string) LocalWorkspaceOption {
+	return localWorkspaceOption(func(lo *localWorkspaceOptions) {
+		lo.EnvVars = envvars
+	})
+}
+
+// NewStackLocalSource creates a Stack backed by a LocalWorkspace created on behalf of the user,
+// from the specified WorkDir. This Workspace will pick up
+// any available Settings files (Pulumi.yaml, Pulumi.<stack>.yaml).
+func NewStackLocalSource(ctx context.Context, stackName, workDir string, opts ...LocalWorkspaceOption) (Stack, error) {
+	opts = append(opts, WorkDir(workDir))
+	w, err := NewLocalWorkspace(ctx, opts...)
+	var stack Stack
+	if err != nil {
+		return stack, errors.Wrap(err, "failed to create stack")
+	}
+	return NewStack(ctx, stackName, w)
+}
+
+// UpsertStackLocalSource creates a Stack backed by a LocalWorkspace created on behalf of the user,
+// from the specified WorkDir. If the Stack already exists, it will not error
+// and proceed to selecting the Stack. This Workspace will pick up any available
+// Settings files (Pulumi.yaml, Pulumi.<stack>.yaml).
+func UpsertStackLocalSource(ctx context.Context, stackName, workDir string,
+	opts ...LocalWorkspaceOption) (Stack, error) {
+
+	opts = append(opts, WorkDir(workDir))
+	w, err := NewLocalWorkspace(ctx, opts...)
+	var stack Stack
+	if err != nil {
+		return stack, errors.Wrap(err, "failed to create stack")
+	}
+	return UpsertStack(ctx, stackName, w)
+}
+
+// SelectStackLocalSource selects an existing Stack backed by a LocalWorkspace created on behalf of the user,
+// from the specified WorkDir. This Workspace will pick up
+// any available Settings files (Pulumi.yaml, Pulumi.<stack>.yaml).
+func SelectStackLocalSource(ctx context.Context, stackName, workDir string,
+	opts ...LocalWorkspaceOption) (Stack, error) {
+
+	opts = append(opts, WorkDir(workDir))
+	w, err := NewLocalWorkspace(ctx, opts...)
+	var stack Stack
+	if err != nil {
+		return stack, errors.Wrap(err, "failed to select stack")
+	}
+	return SelectStack(ctx, stackName, w)
+}
+
+// NewStackInlineSource creates a Stack backed by a LocalWorkspace created on behalf of the user,
+// with the specified program. If no Project option is specified, default project settings will be created
+// on behalf of the user. Similarly, unless a WorkDir option is specified, the working directory will default
+// to a new temporary directory provided by the OS.
+func NewStackInlineSource(ctx context.Context, stackName, projectName string, program pulumi.RunFunc,
+	opts ...LocalWorkspaceOption) (Stack, error) {
+
+	w, err := newInlineWorkspace(ctx, projectName, program, opts...)
+	var stack Stack
+	if err != nil {
+		return stack, errors.Wrap(err, "failed to create stack")
+	}
+	return NewStack(ctx, stackName, w)
+}
+
+// UpsertStackInlineSource creates a Stack backed by a LocalWorkspace created on behalf of the user,
+// with the specified program. If the Stack already exists, it will not error and
+// proceed to selecting the Stack. If no Project option is specified, default project settings will be created
+// on behalf of the user. Similarly, unless a WorkDir option is specified, the working directory will default
+// to a new temporary directory provided by the OS.
+func UpsertStackInlineSource(ctx context.Context, stackName, projectName string, program pulumi.RunFunc,
+	opts ...LocalWorkspaceOption) (Stack, error) {
+
+	w, err := newInlineWorkspace(ctx, projectName, program, opts...)
+	var stack Stack
+	if err != nil {
+		return stack, errors.Wrap(err, "failed to create stack")
+	}
+	return UpsertStack(ctx, stackName, w)
+}
+
+// SelectStackInlineSource selects an existing Stack backed by a new LocalWorkspace created on behalf of the user,
+// with the specified program. If no Project option is specified, default project settings will be created
+// on behalf of the user. Similarly, unless a WorkDir option is specified, the working directory will default
+// to a new temporary directory provided by the OS.
+func SelectStackInlineSource(ctx context.Context, stackName, projectName string, program pulumi.RunFunc,
+	opts ...LocalWorkspaceOption) (Stack, error) {
+
+	w, err := newInlineWorkspace(ctx, projectName, program, opts...)
+	var stack Stack
+	if err != nil {
+		return stack, errors.Wrap(err, "failed to select stack")
+	}
+	return SelectStack(ctx, stackName, w)
+}
+
+// newInlineWorkspace creates a LocalWorkspace for an inline program. Unless the working directory already contains
+// a project file, default project settings are written for the given project name.
+func newInlineWorkspace(ctx context.Context, projectName string, program pulumi.RunFunc,
+	opts ...LocalWorkspaceOption) (Workspace, error) {
+
+	opts = append(opts, Program(program))
+	w, err := NewLocalWorkspace(ctx, opts...)
+	if err != nil {
+		return nil, err
+	}
+	if _, err = w.ProjectSettings(ctx); err != nil {
+		proj := &workspace.Project{
+			Name:    tokens.PackageName(projectName),
+			Runtime: workspace.NewProjectRuntimeInfo("go", nil),
+		}
+		if err = w.SaveProjectSettings(ctx, proj); err != nil {
+			return nil, errors.Wrap(err, "unable to save project settings")
+		}
+	}
+	return w, nil
+}
@@ -0,0 +1,80 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package optup contains functional options to be used with stack updates,
+// e.g. `stack.Up(ctx, optup.Message("deploying"), optup.Parallel(10))`.
+package optup
+
+// Parallel is the number of resource operations to run in parallel at once (1 for no parallelism).
+func Parallel(n int) Option {
+	return optionFunc(func(opts *Options) {
+		opts.Parallel = n
+	})
+}
+
+// Message (optional) to associate with the update operation.
+func Message(message string) Option {
+	return optionFunc(func(opts *Options) {
+		opts.Message = message
+	})
+}
+
+// ExpectNoChanges will cause the update to return an error if any changes occur.
+func ExpectNoChanges() Option {
+	return optionFunc(func(opts *Options) {
+		opts.ExpectNoChanges = true
+	})
+}
+
+// Target specifies an exclusive list of resource URNs to update.
+func Target(urns []string) Option {
+	return optionFunc(func(opts *Options) {
+		opts.Target = urns
+	})
+}
+
+// TargetDependents allows updating of dependent targets discovered but not specified in the Target list.
+func TargetDependents() Option {
+	return optionFunc(func(opts *Options) {
+		opts.TargetDependents = true
+	})
+}
+
+// Option is a parameter to be applied to a Stack.Up() operation.
+type Option interface {
+	ApplyOption(*Options)
+}
+
+// ---------------------------------- implementation details ----------------------------------
+
+// Options is an implementation detail.
+type Options struct {
+	// Parallel is the number of resource operations to run in parallel at once (1 for no parallelism).
+	Parallel int
+	// Message (optional) to associate with the update operation.
+	Message string
+	// ExpectNoChanges will return an error if any changes occur during this update.
+	ExpectNoChanges bool
+	// Target specifies an exclusive list of resource URNs to update.
+	Target []string
+	// TargetDependents allows updating of dependent targets discovered but not specified in the Target list.
+	TargetDependents bool
+}
+
+type optionFunc func(*Options)
+
+// ApplyOption is an implementation detail.
+func (o optionFunc) ApplyOption(opts *Options) {
+	o(opts)
+}
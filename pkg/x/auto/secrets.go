@@ -0,0 +1,142 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auto
+
+import (
+	"context"
+	cryptorand "crypto/rand"
+	"encoding/base64"
+	"fmt"
+
+	"github.com/pkg/errors"
+
+	"github.com/pulumi/pulumi/pkg/v2/backend"
+	"github.com/pulumi/pulumi/pkg/v2/backend/filestate"
+	"github.com/pulumi/pulumi/pkg/v2/backend/httpstate"
+	"github.com/pulumi/pulumi/pkg/v2/resource/stack"
+	"github.com/pulumi/pulumi/pkg/v2/secrets"
+	"github.com/pulumi/pulumi/pkg/v2/secrets/cloud"
+	"github.com/pulumi/pulumi/pkg/v2/secrets/passphrase"
+	"github.com/pulumi/pulumi/pkg/v2/secrets/service"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/resource/config"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/workspace"
+)
+
+// passphraseEnvVar is the environment variable that supplies the passphrase for stacks that use the passphrase
+// secrets provider. There is no one to prompt, so it must be set, either in the environment or in the workspace's
+// EnvVars.
+const passphraseEnvVar = "PULUMI_CONFIG_PASSPHRASE"
+
+// stackSecretsManager returns the secrets manager for the given stack, configuring the stack's secrets provider in its
+// settings file if it has not been configured yet. This mirrors how the CLI chooses a stack's secrets manager.
+func stackSecretsManager(ctx context.Context, ws Workspace, s backend.Stack) (secrets.Manager, error) {
+	ps, err := loadProjectStack(ctx, ws, s)
+	if err != nil {
+		return nil, errors.Wrap(err, "loading stack settings")
+	}
+
+	var sm secrets.Manager
+	switch {
+	case ps.SecretsProvider != passphrase.Type && ps.SecretsProvider != "default" && ps.SecretsProvider != "":
+		sm, err = cloudSecretsManager(ctx, ws, s, ps)
+	case ps.EncryptionSalt != "":
+		sm, err = passphraseSecretsManager(ctx, ws, s, ps)
+	default:
+		switch st := s.(type) {
+		case filestate.Stack:
+			sm, err = passphraseSecretsManager(ctx, ws, s, ps)
+		case httpstate.Stack:
+			sm, err = service.NewServiceSecretsManager(st.Backend().(httpstate.Backend).Client(), st.StackIdentifier())
+		default:
+			err = errors.Errorf("unknown stack type %T", s)
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	return stack.NewCachingSecretsManager(sm), nil
+}
+
+// stackEncrypter returns an encrypter for the given stack's secrets.
+func stackEncrypter(ctx context.Context, ws Workspace, s backend.Stack) (config.Encrypter, error) {
+	sm, err := stackSecretsManager(ctx, ws, s)
+	if err != nil {
+		return nil, err
+	}
+	return sm.Encrypter()
+}
+
+// stackDecrypter returns a decrypter for the given stack's secrets.
+func stackDecrypter(ctx context.Context, ws Workspace, s backend.Stack) (config.Decrypter, error) {
+	sm, err := stackSecretsManager(ctx, ws, s)
+	if err != nil {
+		return nil, err
+	}
+	return sm.Decrypter()
+}
+
+// cloudSecretsManager returns a secrets manager for a stack that uses a cloud secrets provider, generating and
+// recording a data key if the stack does not yet have one.
+func cloudSecretsManager(ctx context.Context, ws Workspace, s backend.Stack,
+	ps *workspace.ProjectStack) (secrets.Manager, error) {
+
+	if ps.EncryptedKey == "" {
+		dataKey, err := cloud.GenerateNewDataKey(ps.SecretsProvider)
+		if err != nil {
+			return nil, err
+		}
+		ps.EncryptedKey = base64.StdEncoding.EncodeToString(dataKey)
+		if err = saveProjectStack(ctx, ws, s, ps); err != nil {
+			return nil, err
+		}
+	}
+
+	dataKey, err := base64.StdEncoding.DecodeString(ps.EncryptedKey)
+	if err != nil {
+		return nil, err
+	}
+	return cloud.NewCloudSecretsManager(ps.SecretsProvider, dataKey)
+}
+
+// passphraseSecretsManager returns a secrets manager for a stack that uses the passphrase secrets provider,
+// generating and recording an encryption salt if the stack does not yet have one.
+func passphraseSecretsManager(ctx context.Context, ws Workspace, s backend.Stack,
+	ps *workspace.ProjectStack) (secrets.Manager, error) {
+
+	phrase, ok := lookupEnv(ws, passphraseEnvVar)
+	if !ok {
+		return nil, errors.Errorf("passphrase must be set with the %s environment variable", passphraseEnvVar)
+	}
+
+	if ps.EncryptionSalt == "" {
+		salt := make([]byte, 8)
+		if _, err := cryptorand.Read(salt); err != nil {
+			return nil, errors.Wrap(err, "generating encryption salt")
+		}
+
+		// Encrypt a message and store it with the salt so that the passphrase can be checked later.
+		crypter := config.NewSymmetricCrypterFromPassphrase(phrase, salt)
+		msg, err := crypter.EncryptValue("pulumi")
+		if err != nil {
+			return nil, err
+		}
+		ps.EncryptionSalt = fmt.Sprintf("v1:%s:%s", base64.StdEncoding.EncodeToString(salt), msg)
+		if err = saveProjectStack(ctx, ws, s, ps); err != nil {
+			return nil, err
+		}
+	}
+
+	return passphrase.NewPassphaseSecretsManager(phrase, ps.EncryptionSalt)
+}
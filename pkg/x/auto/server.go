@@ -0,0 +1,140 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auto
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	pbempty "github.com/golang/protobuf/ptypes/empty"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/pulumi/pulumi/sdk/v2/go/common/util/contract"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/util/rpcutil"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/version"
+	"github.com/pulumi/pulumi/sdk/v2/go/pulumi"
+	pulumirpc "github.com/pulumi/pulumi/sdk/v2/proto/go"
+)
+
+// engineAddressMetadataKey is the gRPC metadata key under which the engine sends its address to a client language
+// runtime. This must match the key used by the engine.
+const engineAddressMetadataKey = "pulumi-engine-address"
+
+// languageRuntimeServer is a language runtime that runs an inline program in the current process. The engine is
+// pointed at this server through the project's `client` runtime in place of launching a language plugin.
+type languageRuntimeServer struct {
+	m sync.Mutex // serializes runs of the program.
+
+	fn      pulumi.RunFunc
+	address string
+	cancel  chan bool
+	done    chan error
+}
+
+// startLanguageRuntimeServer starts a language runtime server for the given program on a free local port.
+func startLanguageRuntimeServer(fn pulumi.RunFunc) (*languageRuntimeServer, error) {
+	s := &languageRuntimeServer{
+		fn:     fn,
+		cancel: make(chan bool),
+	}
+
+	port, done, err := rpcutil.Serve(0, s.cancel, []func(*grpc.Server) error{
+		func(srv *grpc.Server) error {
+			pulumirpc.RegisterLanguageRuntimeServer(srv, s)
+			return nil
+		},
+	}, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not start language runtime server")
+	}
+	s.address, s.done = fmt.Sprintf("127.0.0.1:%d", port), done
+	return s, nil
+}
+
+// Close shuts down the server and waits for it to exit.
+func (s *languageRuntimeServer) Close() error {
+	close(s.cancel)
+	return <-s.done
+}
+
+// GetRequiredPlugins returns no plugins: an inline program's plugins cannot be determined ahead of time, so they
+// must be installed explicitly (see Workspace.InstallPlugin).
+func (s *languageRuntimeServer) GetRequiredPlugins(ctx context.Context,
+	req *pulumirpc.GetRequiredPluginsRequest) (*pulumirpc.GetRequiredPluginsResponse, error) {
+
+	return &pulumirpc.GetRequiredPluginsResponse{}, nil
+}
+
+// Run runs the inline program against the engine and resource monitor named by the request.
+func (s *languageRuntimeServer) Run(ctx context.Context, req *pulumirpc.RunRequest) (*pulumirpc.RunResponse, error) {
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	var engineAddress string
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if addrs := md.Get(engineAddressMetadataKey); len(addrs) > 0 {
+			engineAddress = addrs[0]
+		}
+	}
+	if engineAddress == "" {
+		return nil, errors.New("missing engine address")
+	}
+
+	info := pulumi.RunInfo{
+		Project:     req.GetProject(),
+		Stack:       req.GetStack(),
+		Config:      req.GetConfig(),
+		Parallel:    int(req.GetParallel()),
+		DryRun:      req.GetDryRun(),
+		MonitorAddr: req.GetMonitorAddress(),
+		EngineAddr:  engineAddress,
+	}
+
+	pctx, err := pulumi.NewContext(ctx, info)
+	if err != nil {
+		return nil, err
+	}
+	defer contract.IgnoreClose(pctx)
+
+	if err = runProgram(pctx, s.fn); err != nil {
+		return &pulumirpc.RunResponse{Error: err.Error()}, nil
+	}
+	return &pulumirpc.RunResponse{}, nil
+}
+
+// runProgram runs the given program, converting any panic into an error so that a failing program does not bring
+// down its host.
+func runProgram(ctx *pulumi.Context, fn pulumi.RunFunc) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			if pErr, ok := r.(error); ok {
+				err = errors.Wrap(pErr, "go inline source runtime error, an unhandled error occurred")
+			} else {
+				err = errors.Errorf("go inline source runtime error, an unhandled error occurred: %v", r)
+			}
+		}
+	}()
+	return pulumi.RunWithContext(ctx, fn)
+}
+
+// GetPluginInfo returns the version of the SDK hosting the inline program.
+func (s *languageRuntimeServer) GetPluginInfo(ctx context.Context, req *pbempty.Empty) (*pulumirpc.PluginInfo, error) {
+	return &pulumirpc.PluginInfo{
+		Version: version.Version,
+	}, nil
+}
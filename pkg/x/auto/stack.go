@@ -0,0 +1,593 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auto
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/pulumi/pulumi/pkg/v2/backend"
+	"github.com/pulumi/pulumi/pkg/v2/backend/display"
+	"github.com/pulumi/pulumi/pkg/v2/engine"
+	"github.com/pulumi/pulumi/pkg/v2/resource/stack"
+	"github.com/pulumi/pulumi/pkg/v2/secrets"
+	"github.com/pulumi/pulumi/pkg/v2/util/cancel"
+	"github.com/pulumi/pulumi/pkg/v2/x/auto/optdestroy"
+	"github.com/pulumi/pulumi/pkg/v2/x/auto/optpreview"
+	"github.com/pulumi/pulumi/pkg/v2/x/auto/optrefresh"
+	"github.com/pulumi/pulumi/pkg/v2/x/auto/optup"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/apitype"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/diag/colors"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/resource"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/resource/config"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/util/contract"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/util/result"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/workspace"
+)
+
+// Stack is an isolated, independently configurable instance of a Pulumi program.
+// Stack exposes methods for the full pulumi lifecycle (Up/Preview/Refresh/Destroy), as well as managing
+// configuration. Multiple Stacks are commonly used to denote different phases of development
+// (such as development, staging and production) or feature branches (such as feature-x-dev, jane-feature-x-dev).
+type Stack struct {
+	workspace Workspace
+	stackName string
+}
+
+// FullyQualifiedStackName returns a stack name formatted with the greatest possible specificity:
+// org/project/stack or user/project/stack
+// Using this format avoids ambiguity in stack identity guards creating or selecting the wrong stack.
+// Note that filestate backends (local file, S3, Azure Blob) do not support stack names in this
+// format, and instead only use the stack name without an org/user or project to qualify it.
+func FullyQualifiedStackName(org, project, stack string) string {
+	return fmt.Sprintf("%s/%s/%s", org, project, stack)
+}
+
+// NewStack creates a new stack using the given workspace, and stack name.
+// It fails if a stack with that name already exists.
+func NewStack(ctx context.Context, stackName string, ws Workspace) (Stack, error) {
+	s := Stack{
+		workspace: ws,
+		stackName: stackName,
+	}
+	if err := ws.CreateStack(ctx, stackName); err != nil {
+		return s, err
+	}
+	return s, nil
+}
+
+// SelectStack selects stack using the given workspace, and stack name.
+// It returns an error if the given Stack does not exist.
+func SelectStack(ctx context.Context, stackName string, ws Workspace) (Stack, error) {
+	s := Stack{
+		workspace: ws,
+		stackName: stackName,
+	}
+	if err := ws.SelectStack(ctx, stackName); err != nil {
+		return s, err
+	}
+	return s, nil
+}
+
+// UpsertStack tries to create a new stack using the given workspace and
+// stack name if the stack does not already exist,
+// or falls back to selecting the existing stack. If the stack does not exist,
+// it will be created and selected.
+func UpsertStack(ctx context.Context, stackName string, ws Workspace) (Stack, error) {
+	s, err := NewStack(ctx, stackName, ws)
+	// error for all failures except if the stack already exists, as we'll
+	// just select the stack if it exists.
+	if err != nil && isAlreadyExistsError(err) {
+		return SelectStack(ctx, stackName, ws)
+	}
+	return s, err
+}
+
+// Name returns the stack name
+func (s *Stack) Name() string {
+	return s.stackName
+}
+
+// Workspace returns the underlying Workspace backing the Stack.
+// This handles state associated with the Project and child Stacks including
+// settings, configuration, and environment.
+func (s *Stack) Workspace() Workspace {
+	return s.workspace
+}
+
+// Preview preforms a dry-run update to a stack, returning pending changes.
+// https://www.pulumi.com/docs/reference/cli/pulumi_preview/
+func (s *Stack) Preview(ctx context.Context, opts ...optpreview.Option) (PreviewResult, error) {
+	var res PreviewResult
+
+	preOpts := &optpreview.Options{}
+	for _, o := range opts {
+		o.ApplyOption(preOpts)
+	}
+
+	bs, err := getStack(ctx, s.Workspace(), s.Name())
+	if err != nil {
+		return res, errors.Wrap(err, "failed to run preview")
+	}
+	engineOpts := engine.UpdateOptions{
+		Parallel:         parallelism(preOpts.Parallel),
+		UpdateTargets:    targetURNs(preOpts.Target),
+		TargetDependents: preOpts.TargetDependents,
+	}
+	op, cleanup, err := s.updateOperation(ctx, bs, preOpts.Message, engineOpts)
+	if err != nil {
+		return res, errors.Wrap(err, "failed to run preview")
+	}
+	defer cleanup()
+
+	_, changes, r := backend.PreviewStack(ctx, bs, op)
+	if err = checkResult("preview", r, preOpts.ExpectNoChanges, changes); err != nil {
+		return res, err
+	}
+	res.ChangeSummary = changeSummary(changes)
+	return res, nil
+}
+
+// Up creates or updates the resources in a stack by executing the program in the Workspace.
+// https://www.pulumi.com/docs/reference/cli/pulumi_up/
+func (s *Stack) Up(ctx context.Context, opts ...optup.Option) (UpResult, error) {
+	var res UpResult
+
+	upOpts := &optup.Options{}
+	for _, o := range opts {
+		o.ApplyOption(upOpts)
+	}
+
+	bs, err := getStack(ctx, s.Workspace(), s.Name())
+	if err != nil {
+		return res, errors.Wrap(err, "failed to run update")
+	}
+	engineOpts := engine.UpdateOptions{
+		Parallel:         parallelism(upOpts.Parallel),
+		UpdateTargets:    targetURNs(upOpts.Target),
+		TargetDependents: upOpts.TargetDependents,
+	}
+	op, cleanup, err := s.updateOperation(ctx, bs, upOpts.Message, engineOpts)
+	if err != nil {
+		return res, errors.Wrap(err, "failed to run update")
+	}
+	defer cleanup()
+
+	changes, r := backend.UpdateStack(ctx, bs, op)
+	if err = checkResult("update", r, upOpts.ExpectNoChanges, changes); err != nil {
+		return res, err
+	}
+
+	if res.Outputs, err = s.Outputs(ctx); err != nil {
+		return res, err
+	}
+	res.Summary, err = s.latestSummary(ctx)
+	return res, err
+}
+
+// Refresh compares the current stack’s resource state with the state known to exist in the actual
+// cloud provider. Any such changes are adopted into the current stack.
+func (s *Stack) Refresh(ctx context.Context, opts ...optrefresh.Option) (RefreshResult, error) {
+	var res RefreshResult
+
+	refreshOpts := &optrefresh.Options{}
+	for _, o := range opts {
+		o.ApplyOption(refreshOpts)
+	}
+
+	bs, err := getStack(ctx, s.Workspace(), s.Name())
+	if err != nil {
+		return res, errors.Wrap(err, "failed to refresh stack")
+	}
+	engineOpts := engine.UpdateOptions{
+		Parallel:       parallelism(refreshOpts.Parallel),
+		RefreshTargets: targetURNs(refreshOpts.Target),
+	}
+	op, cleanup, err := s.updateOperation(ctx, bs, refreshOpts.Message, engineOpts)
+	if err != nil {
+		return res, errors.Wrap(err, "failed to refresh stack")
+	}
+	defer cleanup()
+
+	changes, r := backend.RefreshStack(ctx, bs, op)
+	if err = checkResult("refresh", r, refreshOpts.ExpectNoChanges, changes); err != nil {
+		return res, err
+	}
+
+	res.Summary, err = s.latestSummary(ctx)
+	return res, err
+}
+
+// Destroy deletes all resources in a stack, leaving all history and configuration intact.
+func (s *Stack) Destroy(ctx context.Context, opts ...optdestroy.Option) (DestroyResult, error) {
+	var res DestroyResult
+
+	destroyOpts := &optdestroy.Options{}
+	for _, o := range opts {
+		o.ApplyOption(destroyOpts)
+	}
+
+	bs, err := getStack(ctx, s.Workspace(), s.Name())
+	if err != nil {
+		return res, errors.Wrap(err, "failed to destroy stack")
+	}
+	engineOpts := engine.UpdateOptions{
+		Parallel:         parallelism(destroyOpts.Parallel),
+		DestroyTargets:   targetURNs(destroyOpts.Target),
+		TargetDependents: destroyOpts.TargetDependents,
+	}
+	op, cleanup, err := s.updateOperation(ctx, bs, destroyOpts.Message, engineOpts)
+	if err != nil {
+		return res, errors.Wrap(err, "failed to destroy stack")
+	}
+	defer cleanup()
+
+	changes, r := backend.DestroyStack(ctx, bs, op)
+	if err = checkResult("destroy", r, false, changes); err != nil {
+		return res, err
+	}
+
+	res.Summary, err = s.latestSummary(ctx)
+	return res, err
+}
+
+// Outputs get the current set of Stack outputs from the last Stack.Up().
+func (s *Stack) Outputs(ctx context.Context) (OutputMap, error) {
+	bs, err := getStack(ctx, s.Workspace(), s.Name())
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get outputs")
+	}
+	snap, err := bs.Snapshot(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get outputs")
+	}
+
+	outputs := make(OutputMap)
+	res, _ := stack.GetRootStackResource(snap)
+	if res == nil {
+		return outputs, nil
+	}
+	values, err := stack.SerializeProperties(display.MassageSecrets(res.Outputs, true), config.NewPanicCrypter(),
+		true /* showSecrets */)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get outputs")
+	}
+	for k, v := range res.Outputs {
+		outputs[string(k)] = OutputValue{
+			Value:  values[string(k)],
+			Secret: v.ContainsSecrets(),
+		}
+	}
+	return outputs, nil
+}
+
+// History returns a list summarizing all previous and current results from Stack lifecycle operations
+// (up/preview/refresh/destroy), most recent first.
+func (s *Stack) History(ctx context.Context) ([]UpdateSummary, error) {
+	var history []UpdateSummary
+	bs, err := getStack(ctx, s.Workspace(), s.Name())
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get stack history")
+	}
+	updates, err := bs.Backend().GetHistory(ctx, bs.Ref(), false /*includeResources*/)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get stack history")
+	}
+
+	var decrypter config.Decrypter
+	for _, update := range updates {
+		if update.Config.HasSecureValue() && decrypter == nil {
+			if decrypter, err = stackDecrypter(ctx, s.Workspace(), bs); err != nil {
+				return nil, errors.Wrap(err, "failed to get stack history")
+			}
+		}
+		summary, err := newUpdateSummary(update, decrypter)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to get stack history")
+		}
+		history = append(history, summary)
+	}
+	return history, nil
+}
+
+// GetConfig returns the config value associated with the specified key.
+func (s *Stack) GetConfig(ctx context.Context, key string) (ConfigValue, error) {
+	return s.Workspace().GetConfig(ctx, s.Name(), key)
+}
+
+// GetAllConfig returns the full config map.
+func (s *Stack) GetAllConfig(ctx context.Context) (ConfigMap, error) {
+	return s.Workspace().GetAllConfig(ctx, s.Name())
+}
+
+// SetConfig sets the specified config key-value pair.
+func (s *Stack) SetConfig(ctx context.Context, key string, val ConfigValue) error {
+	return s.Workspace().SetConfig(ctx, s.Name(), key, val)
+}
+
+// SetAllConfig sets all values in the provided config map.
+func (s *Stack) SetAllConfig(ctx context.Context, config ConfigMap) error {
+	return s.Workspace().SetAllConfig(ctx, s.Name(), config)
+}
+
+// RemoveConfig removes the provided config key.
+func (s *Stack) RemoveConfig(ctx context.Context, key string) error {
+	return s.Workspace().RemoveConfig(ctx, s.Name(), key)
+}
+
+// Export exports the deployment state of the stack.
+// This can be combined with Stack.Import to edit a stack's state (such as recovery from failed deployments).
+func (s *Stack) Export(ctx context.Context) (apitype.UntypedDeployment, error) {
+	return s.Workspace().ExportStack(ctx, s.Name())
+}
+
+// Import imports the specified deployment state into the stack.
+// This can be combined with Stack.Export to edit a stack's state (such as recovery from failed deployments).
+func (s *Stack) Import(ctx context.Context, state apitype.UntypedDeployment) error {
+	return s.Workspace().ImportStack(ctx, s.Name(), state)
+}
+
+// UpdateSummary provides a summary of a Stack lifecycle operation (up/preview/refresh/destroy).
+type UpdateSummary struct {
+	Kind        string            `json:"kind"`
+	StartTime   string            `json:"startTime"`
+	Message     string            `json:"message"`
+	Environment map[string]string `json:"environment"`
+	Config      ConfigMap         `json:"config"`
+	Result      string            `json:"result,omitempty"`
+
+	// These values are only present once the update finishes
+	EndTime         *string         `json:"endTime,omitempty"`
+	ResourceChanges *map[string]int `json:"resourceChanges,omitempty"`
+}
+
+// OutputValue models a Pulumi Stack output, providing the plaintext value and a boolean indicating secretness.
+type OutputValue struct {
+	Value  interface{}
+	Secret bool
+}
+
+// OutputMap is the output result of running a Pulumi program
+type OutputMap map[string]OutputValue
+
+// PreviewResult is the output of Stack.Preview() describing the expected set of changes from the next Stack.Up()
+type PreviewResult struct {
+	// ChangeSummary counts the resources that the next Stack.Up() would affect, by operation (create, update, ...).
+	ChangeSummary map[string]int
+}
+
+// UpResult contains information about a Stack.Up operation,
+// including Outputs, and a summary of the deployed changes.
+type UpResult struct {
+	Outputs OutputMap
+	Summary UpdateSummary
+}
+
+// RefreshResult is the output of a successful Stack.Refresh operation
+type RefreshResult struct {
+	Summary UpdateSummary
+}
+
+// DestroyResult is the output of a successful Stack.Destroy operation
+type DestroyResult struct {
+	Summary UpdateSummary
+}
+
+// updateTimeFormat is the format of the times in an UpdateSummary.
+const updateTimeFormat = "2006-01-02T15:04:05.000Z07:00"
+
+// newUpdateSummary converts an update from the stack's history into an UpdateSummary. Secret configuration values
+// are decrypted with the given decrypter, which may be nil if the update's configuration has no secrets.
+func newUpdateSummary(update backend.UpdateInfo, decrypter config.Decrypter) (UpdateSummary, error) {
+	summary := UpdateSummary{
+		Kind:        string(update.Kind),
+		StartTime:   time.Unix(update.StartTime, 0).UTC().Format(updateTimeFormat),
+		Message:     update.Message,
+		Environment: update.Environment,
+		Config:      make(ConfigMap),
+		Result:      string(update.Result),
+	}
+	for k, v := range update.Config {
+		value, err := v.Value(decrypter)
+		if err != nil {
+			return summary, err
+		}
+		summary.Config[k.String()] = ConfigValue{Value: value, Secret: v.Secure()}
+	}
+	if update.Result != backend.InProgressResult {
+		endTime := time.Unix(update.EndTime, 0).UTC().Format(updateTimeFormat)
+		summary.EndTime = &endTime
+		resourceChanges := changeSummary(update.ResourceChanges)
+		summary.ResourceChanges = &resourceChanges
+	}
+	return summary, nil
+}
+
+// changeSummary converts the engine's count of resource changes into a map keyed by operation name.
+func changeSummary(changes engine.ResourceChanges) map[string]int {
+	summary := make(map[string]int)
+	for op, count := range changes {
+		summary[string(op)] = count
+	}
+	return summary
+}
+
+// latestSummary returns the summary of the most recent operation on the stack.
+func (s *Stack) latestSummary(ctx context.Context) (UpdateSummary, error) {
+	var summary UpdateSummary
+	history, err := s.History(ctx)
+	if err != nil {
+		return summary, err
+	}
+	if len(history) == 0 {
+		return summary, errors.New("stack history is empty")
+	}
+	return history[0], nil
+}
+
+// updateOperation prepares an operation on the given stack with the given engine options. If the workspace has an
+// inline program, a language runtime server is started for the program and the operation is directed to it; the
+// returned cleanup function stops the server and must be called once the operation completes.
+func (s *Stack) updateOperation(ctx context.Context, bs backend.Stack, message string,
+	engineOpts engine.UpdateOptions) (backend.UpdateOperation, func(), error) {
+
+	ws := s.Workspace()
+	proj, err := ws.ProjectSettings(ctx)
+	if err != nil {
+		return backend.UpdateOperation{}, nil, err
+	}
+
+	cleanup := func() {}
+	if program := ws.Program(); program != nil {
+		server, err := startLanguageRuntimeServer(program)
+		if err != nil {
+			return backend.UpdateOperation{}, nil, err
+		}
+		cleanup = func() { contract.IgnoreClose(server) }
+
+		// Direct the engine to the server rather than launching a language host for the project's runtime.
+		inline := *proj
+		inline.Runtime = workspace.NewProjectRuntimeInfo(engine.ClientRuntimeName, map[string]interface{}{
+			"address": server.address,
+		})
+		proj = &inline
+	}
+
+	sm, err := stackSecretsManager(ctx, ws, bs)
+	if err != nil {
+		cleanup()
+		return backend.UpdateOperation{}, nil, err
+	}
+	cfg, err := stackConfiguration(ctx, ws, bs, sm)
+	if err != nil {
+		cleanup()
+		return backend.UpdateOperation{}, nil, err
+	}
+
+	// The workspace's environment variables are passed to the plugins that the operation launches.
+	engineOpts.Env = ws.EnvVars()
+
+	return backend.UpdateOperation{
+		Proj: proj,
+		Root: ws.WorkDir(),
+		M: &backend.UpdateMetadata{
+			Message:     message,
+			Environment: make(map[string]string),
+		},
+		Opts: backend.UpdateOptions{
+			Engine: engineOpts,
+			Display: display.Options{
+				Color: colors.Never,
+				Type:  display.DisplayNone,
+			},
+			AutoApprove: true,
+			SkipPreview: true,
+		},
+		StackConfiguration: cfg,
+		SecretsManager:     sm,
+		Scopes:             contextScopeSource{ctx: ctx},
+	}, cleanup, nil
+}
+
+// stackConfiguration returns the configuration of the given stack. As with the CLI, a decrypter is only created if
+// the configuration has secrets.
+func stackConfiguration(ctx context.Context, ws Workspace, bs backend.Stack,
+	sm secrets.Manager) (backend.StackConfiguration, error) {
+
+	ps, err := loadProjectStack(ctx, ws, bs)
+	if err != nil {
+		return backend.StackConfiguration{}, errors.Wrap(err, "loading stack configuration")
+	}
+	if !ps.Config.HasSecureValue() {
+		return backend.StackConfiguration{Config: ps.Config, Decrypter: config.NewPanicCrypter()}, nil
+	}
+	decrypter, err := sm.Decrypter()
+	if err != nil {
+		return backend.StackConfiguration{}, errors.Wrap(err, "getting configuration decrypter")
+	}
+	return backend.StackConfiguration{Config: ps.Config, Decrypter: decrypter}, nil
+}
+
+// targetURNs converts the given targets into URNs.
+func targetURNs(targets []string) []resource.URN {
+	var urns []resource.URN
+	for _, t := range targets {
+		urns = append(urns, resource.URN(t))
+	}
+	return urns
+}
+
+// parallelism returns the degree of parallelism to use for an operation. As with the CLI, an unset degree means that
+// parallelism is unbounded.
+func parallelism(parallel int) int {
+	if parallel <= 0 {
+		return math.MaxInt32
+	}
+	return parallel
+}
+
+// checkResult converts the result of an operation into an error. The diagnostics for a failed operation have already
+// been written to stderr, so a bail is reported without repeating them.
+func checkResult(action string, res result.Result, expectNop bool, changes engine.ResourceChanges) error {
+	switch {
+	case res != nil && res.Error() == context.Canceled:
+		return errors.Errorf("%s cancelled", action)
+	case res != nil && res.IsBail():
+		return errors.Errorf("%s failed", action)
+	case res != nil:
+		return errors.Wrapf(res.Error(), "%s failed", action)
+	case expectNop && changes != nil && changes.HasChanges():
+		return errors.Errorf("%s failed: no changes were expected but changes occurred", action)
+	default:
+		return nil
+	}
+}
+
+// contextScopeSource creates cancellation scopes that cancel an operation when the context of the call that started
+// it is done.
+type contextScopeSource struct {
+	ctx context.Context
+}
+
+func (s contextScopeSource) NewScope(events chan<- engine.Event, isPreview bool) backend.CancellationScope {
+	cancelContext, cancelSource := cancel.NewContext(context.Background())
+
+	c := &contextScope{context: cancelContext, done: make(chan struct{})}
+	go func() {
+		select {
+		case <-s.ctx.Done():
+			cancelSource.Cancel()
+		case <-c.done:
+		}
+	}()
+	return c
+}
+
+type contextScope struct {
+	context *cancel.Context
+	done    chan struct{}
+}
+
+func (s *contextScope) Context() *cancel.Context {
+	return s.context
+}
+
+func (s *contextScope) Close() {
+	close(s.done)
+}
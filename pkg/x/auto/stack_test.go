@@ -0,0 +1,213 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auto
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	pbempty "github.com/golang/protobuf/ptypes/empty"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+
+	"github.com/pulumi/pulumi/pkg/v2/backend"
+	"github.com/pulumi/pulumi/pkg/v2/x/auto/optpreview"
+	"github.com/pulumi/pulumi/pkg/v2/x/auto/optup"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/util/contract"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/workspace"
+	"github.com/pulumi/pulumi/sdk/v2/go/pulumi"
+	"github.com/pulumi/pulumi/sdk/v2/go/pulumi/config"
+	pulumirpc "github.com/pulumi/pulumi/sdk/v2/proto/go"
+)
+
+func TestIsAlreadyExistsError(t *testing.T) {
+	err := errors.Wrap(&backend.StackAlreadyExistsError{StackName: "dev"}, "failed to create stack")
+	assert.True(t, isAlreadyExistsError(err))
+	assert.False(t, IsConcurrentUpdateError(err))
+	assert.False(t, isAlreadyExistsError(errors.New("stack 'dev' already exists")))
+}
+
+func TestLanguageRuntimeServer(t *testing.T) {
+	server, err := startLanguageRuntimeServer(func(ctx *pulumi.Context) error { return nil })
+	assert.NoError(t, err)
+	defer func() { assert.NoError(t, server.Close()) }()
+
+	conn, err := grpc.Dial(server.address, grpc.WithInsecure())
+	assert.NoError(t, err)
+	defer conn.Close()
+	client := pulumirpc.NewLanguageRuntimeClient(conn)
+
+	ctx := context.Background()
+	_, err = client.GetPluginInfo(ctx, &pbempty.Empty{})
+	assert.NoError(t, err)
+
+	plugins, err := client.GetRequiredPlugins(ctx, &pulumirpc.GetRequiredPluginsRequest{})
+	assert.NoError(t, err)
+	assert.Empty(t, plugins.GetPlugins())
+
+	// Runs that do not identify the engine are rejected.
+	_, err = client.Run(ctx, &pulumirpc.RunRequest{Project: "proj", Stack: "dev"})
+	assert.Error(t, err)
+}
+
+// newTestStack creates a stack for the given inline program that is stored in a local backend in a temporary
+// directory.
+func newTestStack(t *testing.T, program pulumi.RunFunc) (Stack, func()) {
+	return newTestStackWithPassphrase(t, program, "test")
+}
+
+// newTestStackWithPassphrase is like newTestStack, but the stack's secrets are encrypted with the given passphrase,
+// which is supplied through the workspace's environment variables.
+func newTestStackWithPassphrase(t *testing.T, program pulumi.RunFunc, passphrase string) (Stack, func()) {
+	dir, err := ioutil.TempDir("", "auto-test")
+	assert.NoError(t, err)
+
+	ctx := context.Background()
+	s, err := NewStackInlineSource(ctx, "dev", "testproj", program,
+		WorkDir(dir),
+		Project(workspace.Project{
+			Name:    "testproj",
+			Runtime: workspace.NewProjectRuntimeInfo("go", nil),
+			Backend: &workspace.ProjectBackend{URL: "file://" + filepath.ToSlash(dir)},
+		}),
+		EnvVars(map[string]string{passphraseEnvVar: passphrase}))
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	return s, func() { contract.IgnoreError(os.RemoveAll(dir)) }
+}
+
+func TestUpPreviewDestroyInline(t *testing.T) {
+	ctx := context.Background()
+	s, cleanup := newTestStack(t, func(pCtx *pulumi.Context) error {
+		c := config.New(pCtx, "")
+		pCtx.Export("greeting", pulumi.String("hello "+c.Require("name")))
+		pCtx.Export("password", pulumi.ToSecret(c.Require("password")))
+		return nil
+	})
+	defer cleanup()
+
+	assert.NoError(t, s.SetAllConfig(ctx, ConfigMap{
+		"name":     {Value: "world"},
+		"password": {Value: "hunter2", Secret: true},
+	}))
+
+	// A preview of the new stack creates only the root stack resource.
+	pre, err := s.Preview(ctx, optpreview.Message("preview"))
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	assert.Equal(t, 1, pre.ChangeSummary["create"])
+
+	// An update creates the stack and records its outputs.
+	up, err := s.Up(ctx, optup.Message("update"))
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	assert.Equal(t, OutputMap{
+		"greeting": {Value: "hello world"},
+		"password": {Value: "hunter2", Secret: true},
+	}, up.Outputs)
+	assert.Equal(t, "update", up.Summary.Kind)
+	assert.Equal(t, "update", up.Summary.Message)
+	assert.Equal(t, "succeeded", up.Summary.Result)
+	assert.Equal(t, ConfigValue{Value: "hunter2", Secret: true}, up.Summary.Config["testproj:password"])
+	if assert.NotNil(t, up.Summary.ResourceChanges) {
+		assert.Equal(t, 1, (*up.Summary.ResourceChanges)["create"])
+	}
+
+	// A second update with the same program and configuration changes nothing.
+	_, err = s.Up(ctx, optup.ExpectNoChanges())
+	assert.NoError(t, err)
+	pre, err = s.Preview(ctx, optpreview.ExpectNoChanges())
+	assert.NoError(t, err)
+	assert.Equal(t, 1, pre.ChangeSummary["same"])
+
+	// A destroy deletes the stack resource and its outputs.
+	destroy, err := s.Destroy(ctx)
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	assert.Equal(t, "destroy", destroy.Summary.Kind)
+	assert.Equal(t, 1, (*destroy.Summary.ResourceChanges)["delete"])
+
+	outs, err := s.Outputs(ctx)
+	assert.NoError(t, err)
+	assert.Empty(t, outs)
+
+	history, err := s.History(ctx)
+	assert.NoError(t, err)
+	assert.Len(t, history, 3)
+}
+
+func TestUpProgramError(t *testing.T) {
+	ctx := context.Background()
+	s, cleanup := newTestStack(t, func(pCtx *pulumi.Context) error {
+		return errors.New("program failed")
+	})
+	defer cleanup()
+
+	_, err := s.Up(ctx)
+	assert.Error(t, err)
+
+	history, err := s.History(ctx)
+	assert.NoError(t, err)
+	if assert.Len(t, history, 1) {
+		assert.Equal(t, "failed", history[0].Result)
+	}
+}
+
+func TestConcurrentWorkspaceEnvVars(t *testing.T) {
+	if _, ok := os.LookupEnv(passphraseEnvVar); ok {
+		t.Skipf("%s is set in the environment", passphraseEnvVar)
+	}
+
+	ctx := context.Background()
+	program := func(pCtx *pulumi.Context) error {
+		pCtx.Export("password", pulumi.ToSecret(config.New(pCtx, "").Require("password")))
+		return nil
+	}
+	s1, cleanup1 := newTestStackWithPassphrase(t, program, "first")
+	defer cleanup1()
+	s2, cleanup2 := newTestStackWithPassphrase(t, program, "second")
+	defer cleanup2()
+
+	// Each workspace's passphrase is used for its own stack only, even when operations run concurrently.
+	var wg sync.WaitGroup
+	for i, s := range []Stack{s1, s2} {
+		wg.Add(1)
+		go func(s Stack, password string) {
+			defer wg.Done()
+			if !assert.NoError(t, s.SetConfig(ctx, "password", ConfigValue{Value: password, Secret: true})) {
+				return
+			}
+			res, err := s.Up(ctx)
+			if assert.NoError(t, err) {
+				assert.Equal(t, OutputValue{Value: password, Secret: true}, res.Outputs["password"])
+			}
+		}(s, fmt.Sprintf("hunter%d", i))
+	}
+	wg.Wait()
+
+	// The workspaces' variables never reach the process's environment.
+	_, ok := os.LookupEnv(passphraseEnvVar)
+	assert.False(t, ok)
+}
@@ -0,0 +1,93 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auto
+
+import (
+	"context"
+
+	"github.com/pulumi/pulumi/pkg/v2/backend"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/apitype"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/workspace"
+	"github.com/pulumi/pulumi/sdk/v2/go/pulumi"
+)
+
+// Workspace is the execution context containing a single Pulumi project, a program, and multiple stacks.
+// Workspaces are used to manage the execution environment, providing various utilities such as plugin
+// installation, environment configuration ($PULUMI_HOME), and creation, deletion, and listing of Stacks.
+type Workspace interface {
+	// ProjectSettings returns the settings object for the current project if any.
+	ProjectSettings(context.Context) (*workspace.Project, error)
+	// SaveProjectSettings overwrites the settings object in the current project.
+	SaveProjectSettings(context.Context, *workspace.Project) error
+	// GetConfig returns the value associated with the specified stack name and key.
+	GetConfig(context.Context, string, string) (ConfigValue, error)
+	// GetAllConfig returns the config map for the specified stack name.
+	GetAllConfig(context.Context, string) (ConfigMap, error)
+	// SetConfig sets the specified key-value pair on the provided stack name.
+	SetConfig(context.Context, string, string, ConfigValue) error
+	// SetAllConfig sets all values in the provided config map for the specified stack name.
+	SetAllConfig(context.Context, string, ConfigMap) error
+	// RemoveConfig removes the specified key-value pair on the provided stack name.
+	RemoveConfig(context.Context, string, string) error
+	// WorkDir returns the working directory containing the project and stack settings. It is also the root
+	// directory of the program.
+	WorkDir() string
+	// EnvVars returns the additional environment variables that apply to every operation.
+	EnvVars() map[string]string
+	// Backend returns the backend that stores the workspace's stacks.
+	Backend(context.Context) (backend.Backend, error)
+	// WhoAmI returns the currently authenticated user.
+	WhoAmI(context.Context) (string, error)
+	// Stack returns a summary of the currently selected stack, if any.
+	Stack(context.Context) (*StackSummary, error)
+	// CreateStack creates and sets a new stack with the stack name, failing if one already exists.
+	CreateStack(context.Context, string) error
+	// SelectStack selects and sets an existing stack matching the stack name, failing if none exists.
+	SelectStack(context.Context, string) error
+	// RemoveStack deletes the stack and all associated configuration and history.
+	RemoveStack(context.Context, string) error
+	// ListStacks returns all Stacks created under the current Project.
+	ListStacks(context.Context) ([]StackSummary, error)
+	// InstallPlugin acquires the plugin matching the specified name and version.
+	InstallPlugin(context.Context, string, string) error
+	// ExportStack exports the deployment state of the stack matching the given name.
+	ExportStack(context.Context, string) (apitype.UntypedDeployment, error)
+	// ImportStack imports the specified deployment state into the stack matching the given name.
+	ImportStack(context.Context, string, apitype.UntypedDeployment) error
+	// Program returns the program `pulumi.RunFunc` to be used for Preview/Update if any.
+	// If none is specified, the stack will refer to ProjectSettings for this information.
+	Program() pulumi.RunFunc
+	// SetProgram sets the program associated with the Workspace to the specified `pulumi.RunFunc`.
+	SetProgram(pulumi.RunFunc)
+}
+
+// ConfigValue is a configuration value used by a Pulumi program.
+// Allows differentiating between secret and plaintext values by setting the `Secret` property.
+type ConfigValue struct {
+	Value  string `json:"value"`
+	Secret bool   `json:"secret"`
+}
+
+// ConfigMap is a map of ConfigValue used by Pulumi programs.
+// Allows differentiating between secret and plaintext values.
+type ConfigMap map[string]ConfigValue
+
+// StackSummary is a description of a stack and its current status.
+type StackSummary struct {
+	Name          string `json:"name"`
+	Current       bool   `json:"current"`
+	LastUpdate    string `json:"lastUpdate,omitempty"`
+	ResourceCount *int   `json:"resourceCount,omitempty"`
+}
@@ -53,6 +53,7 @@ run_go_build "${PULUMI_ROOT}/sdk/nodejs/cmd/pulumi-language-nodejs" "sdk"
 run_go_build "${PULUMI_ROOT}/sdk/python/cmd/pulumi-language-python" "sdk"
 run_go_build "${PULUMI_ROOT}/sdk/dotnet/cmd/pulumi-language-dotnet" "sdk"
 run_go_build "${PULUMI_ROOT}/sdk/go/pulumi-language-go" "sdk"
+run_go_build "${PULUMI_ROOT}/sdk/go/pulumi-analyzer-policy-go" "sdk"
 
 # Copy over the language and dynamic resource providers.
 cp "${ROOT}/sdk/nodejs/dist/pulumi-resource-pulumi-nodejs" "${PUBDIR}/bin/"
@@ -12,7 +12,7 @@ namespace Pulumi
     public partial class Deployment
     {
         private async Task<PrepareResult> PrepareResourceAsync(
-            string label, Resource res, bool custom,
+            string label, Resource res, bool custom, bool remote,
             ResourceArgs args, ResourceOptions options)
         {
             /* IMPORTANT!  We should never await prior to this line, otherwise the Resource will be partly uninitialized. */
@@ -49,6 +49,21 @@ namespace Pulumi
                 providerRef = await ProviderResource.RegisterAsync(customOpts?.Provider).ConfigureAwait(false);
             }
 
+            // For remote resources, resolve a provider reference for each of the providers that
+            // will be used by the resource's children.
+            var providerRefs = new Dictionary<string, string>();
+            if (remote)
+            {
+                foreach (var (pkg, provider) in res._providers)
+                {
+                    var providerRefForPkg = await ProviderResource.RegisterAsync(provider).ConfigureAwait(false);
+                    if (providerRefForPkg != null)
+                    {
+                        providerRefs[pkg] = providerRefForPkg;
+                    }
+                }
+            }
+
             // Collect the URNs for explicit/implicit dependencies for the engine so that it can understand
             // the dependency graph and optimize operations accordingly.
 
@@ -88,7 +103,8 @@ namespace Pulumi
                 providerRef ?? "",
                 allDirectDependencyURNs,
                 propertyToDirectDependencyURNs,
-                aliases);
+                aliases,
+                providerRefs);
 
             void LogExcessive(string message)
             {
@@ -125,7 +141,9 @@ namespace Pulumi
             // [Comp1, Cust1, Comp2, Cust2, Cust3]
             var transitivelyReachableResources = GetTransitivelyReferencedChildResourcesOfComponentResources(resources);
 
-            var transitivelyReachableCustomResources = transitivelyReachableResources.OfType<CustomResource>();
+            // Remote components are treated like custom resources: their children are managed by
+            // their provider, so a dependency on the component is a dependency on its URN.
+            var transitivelyReachableCustomResources = transitivelyReachableResources.Where(r => r is CustomResource || r._remote);
             var tasks = transitivelyReachableCustomResources.Select(r => r.Urn.GetValueAsync());
             var urns = await Task.WhenAll(tasks).ConfigureAwait(false);
             return new HashSet<string>(urns);
@@ -165,8 +183,9 @@ namespace Pulumi
             public readonly HashSet<string> AllDirectDependencyURNs;
             public readonly Dictionary<string, HashSet<string>> PropertyToDirectDependencyURNs;
             public readonly List<string> Aliases;
+            public readonly Dictionary<string, string> ProviderRefs;
 
-            public PrepareResult(Struct serializedProps, string parentUrn, string providerRef, HashSet<string> allDirectDependencyURNs, Dictionary<string, HashSet<string>> propertyToDirectDependencyURNs, List<string> aliases)
+            public PrepareResult(Struct serializedProps, string parentUrn, string providerRef, HashSet<string> allDirectDependencyURNs, Dictionary<string, HashSet<string>> propertyToDirectDependencyURNs, List<string> aliases, Dictionary<string, string> providerRefs)
             {
                 SerializedProps = serializedProps;
                 ParentUrn = parentUrn;
@@ -174,6 +193,7 @@ namespace Pulumi
                 AllDirectDependencyURNs = allDirectDependencyURNs;
                 PropertyToDirectDependencyURNs = propertyToDirectDependencyURNs;
                 Aliases = aliases;
+                ProviderRefs = providerRefs;
             }
         }
     }
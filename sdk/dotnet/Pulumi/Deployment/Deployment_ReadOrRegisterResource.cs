@@ -34,7 +34,7 @@ namespace Pulumi
                 CompleteResourceAsync(resource, args, options, completionSources));
         }
 
-        private async Task<(string urn, string id, Struct data)> ReadOrRegisterResourceAsync(
+        private async Task<(string urn, string id, Struct data, ImmutableDictionary<string, ImmutableHashSet<Resource>> dependencies)> ReadOrRegisterResourceAsync(
             Resource resource, ResourceArgs args, ResourceOptions options)
         {
             if (options.Id != null)
@@ -94,9 +94,15 @@ namespace Pulumi
                     // rest.
                     if (response.data.Fields.TryGetValue(fieldName, out var value))
                     {
+                        if (!response.dependencies.TryGetValue(fieldName, out var dependencies))
+                        {
+                            dependencies = ImmutableHashSet<Resource>.Empty;
+                        }
+
                         var converted = Converter.ConvertValue(
                             $"{resource.GetType().FullName}.{fieldName}", value, completionSource.TargetType);
-                        completionSource.SetValue(converted);
+                        completionSource.SetValue(OutputData.Create(
+                            dependencies, converted.Value, converted.IsKnown, converted.IsSecret));
                     }
                 }
             }
@@ -1,6 +1,8 @@
 ﻿// Copyright 2016-2019, Pulumi Corporation
 
 using System;
+using System.Collections.Immutable;
+using System.Linq;
 using System.Threading.Tasks;
 using Google.Protobuf.WellKnownTypes;
 using Pulumirpc;
@@ -9,20 +11,21 @@ namespace Pulumi
 {
     public partial class Deployment
     {
-        private async Task<(string urn, string id, Struct data)> RegisterResourceAsync(
+        private async Task<(string urn, string id, Struct data, ImmutableDictionary<string, ImmutableHashSet<Resource>> dependencies)> RegisterResourceAsync(
             Resource resource, ResourceArgs args, ResourceOptions options)
         {
             var name = resource.GetResourceName();
             var type = resource.GetResourceType();
             var custom = resource is CustomResource;
+            var remote = resource._remote;
 
             var label = $"resource:{name}[{type}]";
             Log.Debug($"Registering resource start: t={type}, name={name}, custom={custom}");
 
-            var request = CreateRegisterResourceRequest(type, name, custom, options);
+            var request = CreateRegisterResourceRequest(type, name, custom, remote, options);
 
             Log.Debug($"Preparing resource: t={type}, name={name}, custom={custom}");
-            var prepareResult = await PrepareResourceAsync(label, resource, custom, args, options).ConfigureAwait(false);
+            var prepareResult = await PrepareResourceAsync(label, resource, custom, remote, args, options).ConfigureAwait(false);
             Log.Debug($"Prepared resource: t={type}, name={name}, custom={custom}");
 
             PopulateRequest(request, prepareResult);
@@ -30,7 +33,17 @@ namespace Pulumi
             Log.Debug($"Registering resource monitor start: t={type}, name={name}, custom={custom}");
             var result = await this.Monitor.RegisterResourceAsync(resource, request);
             Log.Debug($"Registering resource monitor end: t={type}, name={name}, custom={custom}");
-            return (result.Urn, result.Id, result.Object);
+
+            // Remote components report the resources on which each of their outputs depend.
+            // Represent each of these resources as a DependencyResource so that the outputs carry
+            // the dependencies.
+            var dependencies = ImmutableDictionary.CreateBuilder<string, ImmutableHashSet<Resource>>();
+            foreach (var (key, propertyDependencies) in result.PropertyDependencies)
+            {
+                dependencies[key] = propertyDependencies.Urns.Select(urn => (Resource)new DependencyResource(urn)).ToImmutableHashSet();
+            }
+
+            return (result.Urn, result.Id, result.Object, dependencies.ToImmutable());
         }
 
         private static void PopulateRequest(RegisterResourceRequest request, PrepareResult prepareResult)
@@ -40,6 +53,7 @@ namespace Pulumi
             request.Provider = prepareResult.ProviderRef;
             request.Aliases.AddRange(prepareResult.Aliases);
             request.Dependencies.AddRange(prepareResult.AllDirectDependencyURNs);
+            request.Providers.Add(prepareResult.ProviderRefs);
 
             foreach (var (key, resourceURNs) in prepareResult.PropertyToDirectDependencyURNs)
             {
@@ -49,7 +63,7 @@ namespace Pulumi
             }
         }
 
-        private static RegisterResourceRequest CreateRegisterResourceRequest(string type, string name, bool custom, ResourceOptions options)
+        private static RegisterResourceRequest CreateRegisterResourceRequest(string type, string name, bool custom, bool remote, ResourceOptions options)
         {
             var customOpts = options as CustomResourceOptions;
             var deleteBeforeReplace = customOpts?.DeleteBeforeReplace;
@@ -59,6 +73,7 @@ namespace Pulumi
                 Type = type,
                 Name = name,
                 Custom = custom,
+                Remote = remote,
                 Protect = options.Protect ?? false,
                 Version = options.Version ?? "",
                 ImportId = customOpts?.ImportId ?? "",
@@ -30,6 +30,23 @@ namespace Pulumi
         {
         }
 
+        /// <summary>
+        /// Creates and registers a new component resource.  <paramref name="type"/> is the fully
+        /// qualified type token and <paramref name="name"/> is the "name" part to use in creating a
+        /// stable and globally unique URN for the object. If <paramref name="remote"/> is true, the
+        /// component is constructed by its provider using <paramref name="args"/> as its inputs,
+        /// and its outputs are populated from the component's registered outputs.
+        /// </summary>
+#pragma warning disable RS0022 // Constructor make noninheritable base class inheritable
+        public ComponentResource(string type, string name, ResourceArgs? args, ComponentResourceOptions? options = null, bool remote = false)
+            : base(type, name, custom: false,
+                   args ?? ResourceArgs.Empty,
+                   options ?? new ComponentResourceOptions(),
+                   remote)
+#pragma warning restore RS0022 // Constructor make noninheritable base class inheritable
+        {
+        }
+
         /// <summary>
         /// RegisterOutputs registers synthetic outputs that a component has initialized, usually by
         /// allocating other child sub-resources and propagating their resulting property values.
@@ -35,5 +35,14 @@ namespace Pulumi
 #pragma warning restore RS0022 // Constructor make noninheritable base class inheritable
         {
         }
+
+        /// <summary>
+        /// Creates a synthetic custom resource that is only used internally to track dependencies.
+        /// See <see cref="DependencyResource"/>.
+        /// </summary>
+        private protected CustomResource(string type, string name, ResourceArgs? args, CustomResourceOptions? options = null, bool dependency = false)
+            : base(type, name, custom: true, args ?? ResourceArgs.Empty, options ?? new CustomResourceOptions(), dependency: dependency)
+        {
+        }
     }
 }
@@ -64,7 +64,7 @@ namespace Pulumi
         /// </summary>
         // Set using reflection, so we silence the NRT warnings with `null!`.
         [Output(Constants.UrnPropertyName)]
-        public Output<string> Urn { get; private set; } = null!;
+        public Output<string> Urn { get; private protected set; } = null!;
 
         /// <summary>
         /// When set to true, protect ensures this resource cannot be deleted.
@@ -96,7 +96,12 @@ namespace Pulumi
         /// <summary>
         /// The set of providers to use for child resources. Keyed by package name (e.g. "aws").
         /// </summary>
-        private readonly ImmutableDictionary<string, ProviderResource> _providers;
+        internal readonly ImmutableDictionary<string, ProviderResource> _providers;
+
+        /// <summary>
+        /// True if this is a remote component resource, which is constructed by its provider.
+        /// </summary>
+        internal readonly bool _remote;
 
         /// <summary>
         /// Creates and registers a new resource object.  <paramref name="type"/> is the fully
@@ -110,10 +115,24 @@ namespace Pulumi
         /// <param name="custom">True to indicate that this is a custom resource, managed by a plugin.</param>
         /// <param name="args">The arguments to use to populate the new resource.</param>
         /// <param name="options">A bag of options that control this resource's behavior.</param>
+        /// <param name="remote">True if this is a remote component resource.</param>
+        /// <param name="dependency">True if this is a synthetic resource used internally for dependency tracking.</param>
         private protected Resource(
             string type, string name, bool custom,
-            ResourceArgs args, ResourceOptions options)
+            ResourceArgs args, ResourceOptions options,
+            bool remote = false, bool dependency = false)
         {
+            if (dependency)
+            {
+                _type = "";
+                _name = "";
+                _protect = false;
+                _transformations = ImmutableArray<ResourceTransformation>.Empty;
+                _aliases = ImmutableArray<Input<string>>.Empty;
+                _providers = ImmutableDictionary<string, ProviderResource>.Empty;
+                return;
+            }
+
             if (string.IsNullOrEmpty(type))
                 throw new ArgumentException("'type' cannot be null or empty.", nameof(type));
 
@@ -232,6 +251,7 @@ namespace Pulumi
             }
 
             this._protect = options.Protect == true;
+            this._remote = remote;
 
             // Collapse any 'Alias'es down to URNs. We have to wait until this point to do so
             // because we do not know the default 'name' and 'type' to apply until we are inside the
@@ -121,6 +121,24 @@ type SecretsProvidersV1 struct {
 	State json.RawMessage `json:"state,omitempty"`
 }
 
+// UpdatePlanV1 is the serializable form of an update plan: the operations that a preview determined an update should
+// perform, which a later update can be held to.
+type UpdatePlanV1 struct {
+	// Manifest contains metadata about the preview that produced this plan.
+	Manifest ManifestV1 `json:"manifest" yaml:"manifest"`
+	// ResourcePlans contains the planned operations for each resource, keyed by URN.
+	ResourcePlans map[resource.URN]ResourcePlanV1 `json:"resourcePlans,omitempty" yaml:"resourcePlans,omitempty"`
+}
+
+// ResourcePlanV1 is the serializable form of the planned operations for a single resource.
+type ResourcePlanV1 struct {
+	// Steps contains the operations planned for the resource, in order.
+	Steps []OpType `json:"steps,omitempty" yaml:"steps,omitempty"`
+	// Inputs contains the inputs that the program registered for the resource. This is null for resources that the
+	// program did not register, such as those being deleted.
+	Inputs map[string]interface{} `json:"inputs" yaml:"inputs"`
+}
+
 // OperationType is the type of an operation initiated by the engine. Its value indicates the type of operation
 // that the engine initiated.
 type OperationType string
@@ -289,6 +307,10 @@ type ResourceV3 struct {
 	CustomTimeouts *resource.CustomTimeouts `json:"customTimeouts,omitempty" yaml:"customTimeouts,omitempty"`
 	// ImportID is the import input used for imported resources.
 	ImportID resource.ID `json:"importID,omitempty" yaml:"importID,omitempty"`
+	// ReplaceOnChanges is a list of property paths that force the resource to be replaced when changed.
+	ReplaceOnChanges []string `json:"replaceOnChanges,omitempty" yaml:"replaceOnChanges,omitempty"`
+	// RetainOnDelete is true if the resource should be removed from the stack rather than deleted.
+	RetainOnDelete bool `json:"retainOnDelete,omitempty" yaml:"retainOnDelete,omitempty"`
 }
 
 // ManifestV1 captures meta-information about this checkpoint file, such as versions of binaries, etc.
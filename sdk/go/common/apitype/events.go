@@ -59,6 +59,19 @@ type PolicyEvent struct {
 	EnforcementLevel string `json:"enforcementLevel"`
 }
 
+// PolicyRemediationEvent is emitted whenever a policy remediates a resource's inputs. Any secret values in the
+// inputs are blinded.
+type PolicyRemediationEvent struct {
+	ResourceURN          string                 `json:"resourceUrn,omitempty"`
+	Color                string                 `json:"color"`
+	PolicyName           string                 `json:"policyName"`
+	PolicyPackName       string                 `json:"policyPackName"`
+	PolicyPackVersion    string                 `json:"policyPackVersion"`
+	PolicyPackVersionTag string                 `json:"policyPackVersionTag"`
+	Before               map[string]interface{} `json:"before,omitempty"`
+	After                map[string]interface{} `json:"after,omitempty"`
+}
+
 // PreludeEvent is emitted at the start of an update.
 type PreludeEvent struct {
 	// Config contains the keys and values for the update.
@@ -75,6 +88,9 @@ type SummaryEvent struct {
 	// ResourceChanges contains the count for resource change by type. The keys are deploy.StepOp,
 	// which is not exported in this package.
 	ResourceChanges map[string]int `json:"resourceChanges"`
+	// Resources describes the operation performed on each changed resource, including how long it took and why it
+	// failed, if it did.
+	Resources []ResourceSummary `json:"resources,omitempty"`
 	// PolicyPacks run during update. Maps PolicyPackName -> version.
 	// Note: When this field was initially added, we forgot to add the JSON tag
 	// and are now locked into to using PascalCase for this field to maintain backwards
@@ -207,6 +223,8 @@ type EngineEvent struct {
 	ResOutputsEvent  *ResOutputsEvent   `json:"resOutputsEvent,omitempty"`
 	ResOpFailedEvent *ResOpFailedEvent  `json:"resOpFailedEvent,omitempty"`
 	PolicyEvent      *PolicyEvent       `json:"policyEvent,omitempty"`
+
+	PolicyRemediationEvent *PolicyRemediationEvent `json:"policyRemediationEvent,omitempty"`
 }
 
 // EngineEventBatch is a group of engine events.
@@ -35,6 +35,10 @@ const (
 	DestroyUpdate UpdateKind = "destroy"
 	// ImportUpdate is an update that entails importing a raw checkpoint file.
 	ImportUpdate UpdateKind = "import"
+	// ResourceImportUpdate is an update that adopts existing cloud resources into a stack.
+	ResourceImportUpdate UpdateKind = "resource-import"
+	// RestoreUpdate is an update that restores a stack's checkpoint from a previous version of the stack.
+	RestoreUpdate UpdateKind = "restore"
 )
 
 // UpdateResult is an enum for the result of the update.
@@ -77,6 +81,20 @@ const (
 	OpDeleteReplaced OpType = "delete-replaced"
 )
 
+// ResourceSummary describes the operation that an update performed on a single resource.
+type ResourceSummary struct {
+	// URN is the URN of the resource.
+	URN string `json:"urn"`
+	// Type is the type of the resource.
+	Type string `json:"type"`
+	// Op is the operation performed on the resource.
+	Op OpType `json:"op"`
+	// DurationMilliseconds is the number of milliseconds the operation took.
+	DurationMilliseconds int64 `json:"durationMilliseconds"`
+	// Error is the reason the operation failed, if it did.
+	Error string `json:"error,omitempty"`
+}
+
 // UpdateInfo describes a previous update.
 //
 // Should generally mirror backend.UpdateInfo, but we clone it in this package to add
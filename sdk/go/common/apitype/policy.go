@@ -125,12 +125,16 @@ const (
 
 	// Disabled is an enforcement level that disables the policy from being enforced.
 	Disabled EnforcementLevel = "disabled"
+
+	// Remediate is an enforcement level that fixes policy violations rather than reporting them, by transforming the
+	// resource's inputs before it is created or updated.
+	Remediate EnforcementLevel = "remediate"
 )
 
 // IsValid returns true if the EnforcementLevel is a valid value.
 func (el EnforcementLevel) IsValid() bool {
 	switch el {
-	case Advisory, Mandatory, Disabled:
+	case Advisory, Mandatory, Disabled, Remediate:
 		return true
 	}
 	return false
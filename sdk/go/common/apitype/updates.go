@@ -147,6 +147,15 @@ type UpdateResults struct {
 	ContinuationToken *string `json:"continuationToken,omitempty"`
 }
 
+// GetUpdateEventsResponse contains the engine events recorded by an update. See API call for more details.
+type GetUpdateEventsResponse struct {
+	Events []EngineEvent `json:"events"`
+
+	// ContinuationToken is an opaque value used to indicate the end of the returned events. Pass it in the next
+	// request to obtain subsequent events. A value of nil means that all events have been returned.
+	ContinuationToken *string `json:"continuationToken,omitempty"`
+}
+
 // UpdateProgram describes the metadata associated with an update's Pulumi program. Note that this does not
 // include the contents of the program itself.
 type UpdateProgram struct {
@@ -80,3 +80,7 @@ func GetResourceWillBeDestroyedButWasNotSpecifiedInTargetList(urn resource.URN)
 	return newError(urn, 2014, `Resource '%v' will be destroyed but was not specified in --target list.
 Either include resource in --target list or pass --target-dependents to proceed.`)
 }
+
+func GetTargetPatternMatchedNothingError() *Diag {
+	return newError("", 2015, "Target pattern '%v' did not match any resources in the stack.")
+}
@@ -34,6 +34,9 @@ type Analyzer interface {
 	// Analyze analyzes a single resource object, and returns any errors that it finds.
 	// Is called before the resource is modified.
 	Analyze(r AnalyzerResource) ([]AnalyzeDiagnostic, error)
+	// Remediate is given the opportunity to transform a single resource object's inputs, and returns the remediations
+	// that were applied, in order. Is called before the resource's inputs are checked by its provider.
+	Remediate(r AnalyzerResource) ([]Remediation, error)
 	// AnalyzeStack analyzes all resources after a successful preview or update.
 	// Is called after all resources have been processed, and all changes applied.
 	AnalyzeStack(resources []AnalyzerStackResource) ([]AnalyzeDiagnostic, error)
@@ -94,6 +97,15 @@ type AnalyzeDiagnostic struct {
 	URN               resource.URN
 }
 
+// Remediation indicates that a resource remediation took place, and contains the resulting inputs.
+type Remediation struct {
+	PolicyName        string
+	PolicyPackName    string
+	PolicyPackVersion string
+	Description       string
+	Properties        resource.PropertyMap
+}
+
 // AnalyzerInfo provides metadata about a PolicyPack inside an analyzer.
 type AnalyzerInfo struct {
 	Name           string
@@ -22,6 +22,7 @@ import (
 	"reflect"
 	"sort"
 	"strings"
+	"sync"
 
 	"github.com/blang/semver"
 	pbempty "github.com/golang/protobuf/ptypes/empty"
@@ -46,6 +47,10 @@ type analyzer struct {
 	plug    *plugin
 	client  pulumirpc.AnalyzerClient
 	version string
+
+	policyConfig   map[string]AnalyzerPolicyConfig // the config passed to Configure, if any.
+	remediatesOnce sync.Once
+	remediates     bool // true if any of the analyzer's policies is enforced at the Remediate level.
 }
 
 var _ Analyzer = (*analyzer)(nil)
@@ -201,6 +206,77 @@ func (a *analyzer) Analyze(r AnalyzerResource) ([]AnalyzeDiagnostic, error) {
 	return diags, nil
 }
 
+// Remediate is given the opportunity to transform a single resource object's inputs, and returns the remediations
+// that were applied, in order.
+func (a *analyzer) Remediate(r AnalyzerResource) ([]Remediation, error) {
+	urn, t, name, props := r.URN, r.Type, r.Name, r.Properties
+
+	label := fmt.Sprintf("%s.Remediate(%s)", a.label(), t)
+
+	// Most policy packs declare no remediations, so avoid a round trip to the plugin for every resource.
+	a.remediatesOnce.Do(func() {
+		info, err := a.GetAnalyzerInfo()
+		a.remediates = err != nil || hasRemediations(info, a.policyConfig)
+	})
+	if !a.remediates {
+		logging.V(7).Infof("%s skipped, no policies are enforced at the remediate level", label)
+		return nil, nil
+	}
+
+	logging.V(7).Infof("%s executing (#props=%d)", label, len(props))
+	mprops, err := MarshalProperties(props,
+		MarshalOptions{KeepUnknowns: true, KeepSecrets: true, SkipInternalKeys: true})
+	if err != nil {
+		return nil, err
+	}
+
+	provider, err := marshalProvider(r.Provider)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := a.client.Remediate(a.ctx.Request(), &pulumirpc.AnalyzeRequest{
+		Urn:        string(urn),
+		Type:       string(t),
+		Name:       string(name),
+		Properties: mprops,
+		Options:    marshalResourceOptions(r.Options),
+		Provider:   provider,
+	})
+	if err != nil {
+		rpcError := rpcerror.Convert(err)
+		logging.V(7).Infof("%s failed: err=%v", label, rpcError)
+		return nil, rpcError
+	}
+
+	remediations := resp.GetRemediations()
+	logging.V(7).Infof("%s success: remediations=#%d", label, len(remediations))
+
+	results := make([]Remediation, len(remediations))
+	for i, r := range remediations {
+		tprops, err := UnmarshalProperties(r.GetProperties(),
+			MarshalOptions{KeepUnknowns: true, KeepSecrets: true, SkipInternalKeys: true})
+		if err != nil {
+			return nil, errors.Wrap(err, "unmarshalling remediated properties")
+		}
+
+		// The version from PulumiPolicy.yaml is used, if set, over the version from the remediation.
+		policyPackVersion := r.GetPolicyPackVersion()
+		if a.version != "" {
+			policyPackVersion = a.version
+		}
+
+		results[i] = Remediation{
+			PolicyName:        r.GetPolicyName(),
+			PolicyPackName:    r.GetPolicyPackName(),
+			PolicyPackVersion: policyPackVersion,
+			Description:       r.GetDescription(),
+			Properties:        tprops,
+		}
+	}
+	return results, nil
+}
+
 // AnalyzeStack analyzes all resources in a stack at the end of the update operation.
 func (a *analyzer) AnalyzeStack(resources []AnalyzerStackResource) ([]AnalyzeDiagnostic, error) {
 	logging.V(7).Infof("%s.AnalyzeStack(#resources=%d) executing", a.label(), len(resources))
@@ -306,7 +382,7 @@ func (a *analyzer) GetAnalyzerInfo() (AnalyzerInfo, error) {
 			}
 			schema.Properties["enforcementLevel"] = JSONSchema{
 				"type": "string",
-				"enum": []string{"advisory", "mandatory", "disabled"},
+				"enum": []string{"advisory", "mandatory", "remediate", "disabled"},
 			}
 		}
 
@@ -384,6 +460,8 @@ func (a *analyzer) Configure(policyConfig map[string]AnalyzerPolicyConfig) error
 	label := fmt.Sprintf("%s.Configure(...)", a.label())
 	logging.V(7).Infof("%s executing", label)
 
+	a.policyConfig = policyConfig
+
 	if len(policyConfig) == 0 {
 		logging.V(7).Infof("%s returning early, no config specified", label)
 		return nil
@@ -412,6 +490,34 @@ func (a *analyzer) Configure(policyConfig map[string]AnalyzerPolicyConfig) error
 	return nil
 }
 
+// hasRemediations returns true if any of the analyzer's policies is enforced at the Remediate level, once the analyzer's
+// initial config and then the given config have been applied over the policies' own enforcement levels.
+func hasRemediations(info AnalyzerInfo, config map[string]AnalyzerPolicyConfig) bool {
+	levels := make(map[string]apitype.EnforcementLevel)
+	for _, p := range info.Policies {
+		levels[p.Name] = p.EnforcementLevel
+	}
+	for _, c := range []map[string]AnalyzerPolicyConfig{info.InitialConfig, config} {
+		if all, ok := c["all"]; ok && all.EnforcementLevel.IsValid() {
+			for name := range levels {
+				levels[name] = all.EnforcementLevel
+			}
+		}
+		for name, v := range c {
+			if _, ok := levels[name]; ok && v.EnforcementLevel.IsValid() {
+				levels[name] = v.EnforcementLevel
+			}
+		}
+	}
+
+	for _, level := range levels {
+		if level == apitype.Remediate {
+			return true
+		}
+	}
+	return false
+}
+
 // Close tears down the underlying plugin RPC connection and process.
 func (a *analyzer) Close() error {
 	return a.plug.Close()
@@ -471,6 +577,8 @@ func marshalEnforcementLevel(el apitype.EnforcementLevel) pulumirpc.EnforcementL
 		return pulumirpc.EnforcementLevel_MANDATORY
 	case apitype.Disabled:
 		return pulumirpc.EnforcementLevel_DISABLED
+	case apitype.Remediate:
+		return pulumirpc.EnforcementLevel_REMEDIATE
 	}
 	contract.Failf("Unrecognized enforcement level %s", el)
 	return 0
@@ -590,6 +698,8 @@ func convertEnforcementLevel(el pulumirpc.EnforcementLevel) (apitype.Enforcement
 		return apitype.Mandatory, nil
 	case pulumirpc.EnforcementLevel_DISABLED:
 		return apitype.Disabled, nil
+	case pulumirpc.EnforcementLevel_REMEDIATE:
+		return apitype.Remediate, nil
 
 	default:
 		return "", fmt.Errorf("Invalid enforcement level %d", el)
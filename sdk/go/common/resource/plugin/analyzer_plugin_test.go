@@ -0,0 +1,43 @@
+package plugin
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pulumi/pulumi/sdk/v2/go/common/apitype"
+)
+
+func TestHasRemediations(t *testing.T) {
+	info := AnalyzerInfo{
+		Policies: []AnalyzerPolicyInfo{
+			{Name: "a", EnforcementLevel: apitype.Advisory},
+			{Name: "b", EnforcementLevel: apitype.Mandatory},
+		},
+	}
+	assert.False(t, hasRemediations(info, nil))
+
+	// A declared remediate level can be overridden by config.
+	info.Policies[1].EnforcementLevel = apitype.Remediate
+	assert.True(t, hasRemediations(info, nil))
+	assert.False(t, hasRemediations(info, map[string]AnalyzerPolicyConfig{
+		"b": {EnforcementLevel: apitype.Disabled},
+	}))
+	info.Policies[1].EnforcementLevel = apitype.Mandatory
+
+	// Initial config and config can both set a policy to remediate, and config has the final say.
+	info.InitialConfig = map[string]AnalyzerPolicyConfig{"a": {EnforcementLevel: apitype.Remediate}}
+	assert.True(t, hasRemediations(info, nil))
+	assert.False(t, hasRemediations(info, map[string]AnalyzerPolicyConfig{
+		"all": {EnforcementLevel: apitype.Advisory},
+	}))
+	info.InitialConfig = nil
+	assert.True(t, hasRemediations(info, map[string]AnalyzerPolicyConfig{
+		"b": {EnforcementLevel: apitype.Remediate},
+	}))
+
+	// Config for unknown policies is ignored.
+	assert.False(t, hasRemediations(info, map[string]AnalyzerPolicyConfig{
+		"c": {EnforcementLevel: apitype.Remediate},
+	}))
+}
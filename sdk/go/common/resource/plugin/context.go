@@ -31,6 +31,10 @@ type Context struct {
 	Host       Host      // the host that can be used to fetch providers.
 	Pwd        string    // the working directory to spawn all plugins in.
 
+	// Env holds additional environment variables for all plugins spawned in this context. These take precedence over
+	// the variables that plugins inherit from the current process.
+	Env map[string]string
+
 	tracingSpan opentracing.Span // the OpenTracing span to parent requests within.
 }
 
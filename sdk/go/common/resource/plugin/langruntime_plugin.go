@@ -75,6 +75,16 @@ func NewLanguageRuntime(host Host, ctx *Context, runtime string,
 	}, nil
 }
 
+// NewLanguageRuntimeClient creates a language runtime that communicates with an existing language host over the given
+// gRPC client. The caller retains ownership of the underlying connection.
+func NewLanguageRuntimeClient(ctx *Context, runtime string, client pulumirpc.LanguageRuntimeClient) LanguageRuntime {
+	return &langhost{
+		ctx:     ctx,
+		runtime: runtime,
+		client:  client,
+	}
+}
+
 func (h *langhost) Runtime() string { return h.runtime }
 
 // GetRequiredPlugins computes the complete set of anticipated plugins required by a program.
@@ -194,5 +204,8 @@ func (h *langhost) GetPluginInfo() (workspace.PluginInfo, error) {
 
 // Close tears down the underlying plugin RPC connection and process.
 func (h *langhost) Close() error {
+	if h.plug == nil {
+		return nil
+	}
 	return h.plug.Close()
 }
@@ -84,7 +84,7 @@ func newPlugin(ctx *Context, pwd, bin, prefix string, args, env []string) (*plug
 	}
 
 	// Try to execute the binary.
-	plug, err := execPlugin(bin, args, pwd, env)
+	plug, err := execPlugin(bin, args, pwd, ctx.environ(env))
 	if err != nil {
 		return nil, errors.Wrapf(err, "failed to load plugin %s", bin)
 	}
@@ -235,6 +235,25 @@ func newPlugin(ctx *Context, pwd, bin, prefix string, args, env []string) (*plug
 	return plug, nil
 }
 
+// environ returns the environment for a plugin spawned in this context given the environment the plugin asked for,
+// which is the current process's environment if nil.
+func (ctx *Context) environ(env []string) []string {
+	if ctx == nil || len(ctx.Env) == 0 {
+		return env
+	}
+	if env == nil {
+		env = os.Environ()
+	}
+
+	// Later entries take precedence over earlier ones with the same key, so the context's variables are appended.
+	result := make([]string, len(env), len(env)+len(ctx.Env))
+	copy(result, env)
+	for k, v := range ctx.Env {
+		result = append(result, k+"="+v)
+	}
+	return result
+}
+
 // execPlugin starts the plugin executable.
 func execPlugin(bin string, pluginArgs []string, pwd string, env []string) (*plugin, error) {
 	var args []string
@@ -0,0 +1,26 @@
+package plugin
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestContextEnviron(t *testing.T) {
+	// Without additional variables, the requested environment is used as-is.
+	ctx := &Context{}
+	assert.Nil(t, ctx.environ(nil))
+	assert.Equal(t, []string{"A=1"}, ctx.environ([]string{"A=1"}))
+
+	// Additional variables follow the requested environment so that they take precedence.
+	ctx.Env = map[string]string{"A": "2"}
+	assert.Equal(t, []string{"A=1", "A=2"}, ctx.environ([]string{"A=1"}))
+
+	// A nil environment means the current process's environment.
+	assert.NoError(t, os.Setenv("PULUMI_TEST_CONTEXT_ENVIRON", "process"))
+	defer func() { assert.NoError(t, os.Unsetenv("PULUMI_TEST_CONTEXT_ENVIRON")) }()
+	env := ctx.environ(nil)
+	assert.Contains(t, env, "PULUMI_TEST_CONTEXT_ENVIRON=process")
+	assert.Equal(t, "A=2", env[len(env)-1])
+}
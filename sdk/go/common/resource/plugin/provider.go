@@ -18,6 +18,7 @@ import (
 	"io"
 
 	"github.com/pulumi/pulumi/sdk/v2/go/common/resource"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/resource/config"
 	"github.com/pulumi/pulumi/sdk/v2/go/common/tokens"
 	"github.com/pulumi/pulumi/sdk/v2/go/common/util/contract"
 	"github.com/pulumi/pulumi/sdk/v2/go/common/workspace"
@@ -72,6 +73,10 @@ type Provider interface {
 		ignoreChanges []string) (resource.PropertyMap, resource.Status, error)
 	// Delete tears down an existing resource.
 	Delete(urn resource.URN, id resource.ID, props resource.PropertyMap, timeout float64) (resource.Status, error)
+	// Construct creates a new component resource. The provider registers the component and its children with the
+	// resource monitor described by the given ConstructInfo.
+	Construct(info ConstructInfo, typ tokens.Type, name tokens.QName, parent resource.URN, inputs resource.PropertyMap,
+		options ConstructOptions) (ConstructResult, error)
 	// Invoke dynamically executes a built-in function in the provider.
 	Invoke(tok tokens.ModuleMember, args resource.PropertyMap) (resource.PropertyMap, []CheckFailure, error)
 	// StreamInvoke dynamically executes a built-in function in the provider, which returns a stream
@@ -91,6 +96,32 @@ type Provider interface {
 	SignalCancellation() error
 }
 
+// ConstructInfo contains all of the information required to register resources as part of a call to Construct.
+type ConstructInfo struct {
+	Project        string                // the project name housing the program being run.
+	Stack          string                // the stack name being evaluated.
+	Config         map[config.Key]string // the configuration variables to apply before running.
+	DryRun         bool                  // true if we are performing a dry-run (preview).
+	Parallel       int                   // the degree of parallelism for resource operations (<=1 for serial).
+	MonitorAddress string                // the RPC address to the host resource monitor.
+}
+
+// ConstructOptions captures options for a call to Construct.
+type ConstructOptions struct {
+	Aliases              []resource.URN                          // the aliases of the component.
+	Dependencies         []resource.URN                          // the resources the component depends on.
+	Protect              bool                                    // true if the component should be protected.
+	Providers            map[string]string                       // the providers to use for the component's children.
+	PropertyDependencies map[resource.PropertyKey][]resource.URN // the dependencies of each of the component's inputs.
+}
+
+// ConstructResult is the result of a call to Construct.
+type ConstructResult struct {
+	URN                resource.URN                            // the URN of the component.
+	Outputs            resource.PropertyMap                    // the outputs of the component.
+	OutputDependencies map[resource.PropertyKey][]resource.URN // the dependencies of each of the component's outputs.
+}
+
 // CheckFailure indicates that a call to check failed; it contains the property and reason for the failure.
 type CheckFailure struct {
 	Property resource.PropertyKey // the property that failed checking.
@@ -141,6 +172,20 @@ func (d DiffKind) IsReplace() bool {
 	}
 }
 
+// AsReplace returns the replacement variant of the diff kind.
+func (d DiffKind) AsReplace() DiffKind {
+	switch d {
+	case DiffAdd:
+		return DiffAddReplace
+	case DiffDelete:
+		return DiffDeleteReplace
+	case DiffUpdate:
+		return DiffUpdateReplace
+	default:
+		return d
+	}
+}
+
 const (
 	// DiffAdd indicates that the property was added.
 	DiffAdd DiffKind = 0
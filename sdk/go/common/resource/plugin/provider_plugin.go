@@ -957,6 +957,108 @@ func (p *provider) Delete(urn resource.URN, id resource.ID, props resource.Prope
 	return resource.StatusOK, nil
 }
 
+// Construct creates a new component resource.
+func (p *provider) Construct(info ConstructInfo, typ tokens.Type, name tokens.QName, parent resource.URN,
+	inputs resource.PropertyMap, options ConstructOptions) (ConstructResult, error) {
+
+	contract.Assert(typ != "")
+	contract.Assert(name != "")
+	contract.Assert(inputs != nil)
+
+	label := fmt.Sprintf("%s.Construct(%s, %s, %s)", p.label(), typ, name, parent)
+	logging.V(7).Infof("%s executing (#inputs=%v)", label, len(inputs))
+
+	// Get the RPC client and ensure it's configured.
+	client, err := p.getClient()
+	if err != nil {
+		return ConstructResult{}, err
+	}
+
+	// We should only be calling {Construct,Update,Delete} if the provider is fully configured.
+	contract.Assert(p.cfgknown)
+
+	minputs, err := MarshalProperties(inputs, MarshalOptions{
+		Label:        fmt.Sprintf("%s.inputs", label),
+		KeepUnknowns: true,
+		KeepSecrets:  p.acceptSecrets,
+	})
+	if err != nil {
+		return ConstructResult{}, err
+	}
+
+	// Marshal the aliases, dependencies, and property dependencies.
+	aliases := make([]string, len(options.Aliases))
+	for i, alias := range options.Aliases {
+		aliases[i] = string(alias)
+	}
+	dependencies := make([]string, len(options.Dependencies))
+	for i, dep := range options.Dependencies {
+		dependencies[i] = string(dep)
+	}
+	inputDependencies := make(map[string]*pulumirpc.ConstructRequest_PropertyDependencies)
+	for k, deps := range options.PropertyDependencies {
+		urns := make([]string, len(deps))
+		for i, urn := range deps {
+			urns[i] = string(urn)
+		}
+		inputDependencies[string(k)] = &pulumirpc.ConstructRequest_PropertyDependencies{Urns: urns}
+	}
+
+	// Marshal the config.
+	config := make(map[string]string)
+	for k, v := range info.Config {
+		config[k.String()] = v
+	}
+
+	resp, err := client.Construct(p.ctx.Request(), &pulumirpc.ConstructRequest{
+		Project:           info.Project,
+		Stack:             info.Stack,
+		Config:            config,
+		DryRun:            info.DryRun,
+		Parallel:          int32(info.Parallel),
+		MonitorEndpoint:   info.MonitorAddress,
+		Type:              string(typ),
+		Name:              string(name),
+		Parent:            string(parent),
+		Inputs:            minputs,
+		InputDependencies: inputDependencies,
+		Protect:           options.Protect,
+		Providers:         options.Providers,
+		Aliases:           aliases,
+		Dependencies:      dependencies,
+	})
+	if err != nil {
+		rpcError := rpcerror.Convert(err)
+		logging.V(7).Infof("%s failed: %v", label, rpcError.Message())
+		return ConstructResult{}, rpcError
+	}
+
+	outputs, err := UnmarshalProperties(resp.GetState(), MarshalOptions{
+		Label:        fmt.Sprintf("%s.outputs", label),
+		KeepUnknowns: info.DryRun,
+		KeepSecrets:  true,
+	})
+	if err != nil {
+		return ConstructResult{}, err
+	}
+
+	outputDependencies := make(map[resource.PropertyKey][]resource.URN)
+	for k, rpcDeps := range resp.GetStateDependencies() {
+		urns := make([]resource.URN, len(rpcDeps.Urns))
+		for i, d := range rpcDeps.Urns {
+			urns[i] = resource.URN(d)
+		}
+		outputDependencies[resource.PropertyKey(k)] = urns
+	}
+
+	logging.V(7).Infof("%s success: #outputs=%d", label, len(outputs))
+	return ConstructResult{
+		URN:                resource.URN(resp.GetUrn()),
+		Outputs:            outputs,
+		OutputDependencies: outputDependencies,
+	}, nil
+}
+
 // Invoke dynamically executes a built-in function in the provider.
 func (p *provider) Invoke(tok tokens.ModuleMember, args resource.PropertyMap) (resource.PropertyMap,
 	[]CheckFailure, error) {
@@ -41,6 +41,7 @@ type PropertyPath []interface{}
 // - root["key with a ."]
 // - ["root key with \"escaped\" quotes"].nested
 // - ["root key with a ."][100]
+// - root.array[*].nested
 func ParsePropertyPath(path string) (PropertyPath, error) {
 	// We interpret the grammar above a little loosely in order to keep things simple. Specifically, we will accept
 	// something close to the following:
@@ -82,11 +83,15 @@ func ParsePropertyPath(path string) (PropertyPath, error) {
 					return nil, errors.New("missing closing bracket in array index")
 				}
 
-				index, err := strconv.ParseInt(path[1:rbracket], 10, 0)
-				if err != nil {
-					return nil, errors.Wrap(err, "invalid array index")
+				if path[1:rbracket] == "*" {
+					pathElement, path = "*", path[rbracket:]
+				} else {
+					index, err := strconv.ParseInt(path[1:rbracket], 10, 0)
+					if err != nil {
+						return nil, errors.Wrap(err, "invalid array index")
+					}
+					pathElement, path = int(index), path[rbracket:]
 				}
-				pathElement, path = int(index), path[rbracket:]
 			}
 			elements, path = append(elements, pathElement), path[1:]
 		default:
@@ -258,3 +263,17 @@ func (p PropertyPath) Delete(dest PropertyValue) bool {
 	return true
 
 }
+
+// Contains returns true if the receiver is a prefix of the given path, i.e. if the value located by the given path is
+// the value located by the receiver or is nested within it. A "*" element in the receiver matches any element.
+func (p PropertyPath) Contains(other PropertyPath) bool {
+	if len(other) < len(p) {
+		return false
+	}
+	for i, e := range p {
+		if e != "*" && e != other[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -170,3 +170,27 @@ func TestPropertyPath(t *testing.T) {
 		})
 	}
 }
+
+func TestPropertyPathContains(t *testing.T) {
+	cases := []struct {
+		p, other string
+		contains bool
+	}{
+		{"root", "root", true},
+		{"root", "root.nested", true},
+		{"root", "rooted", false},
+		{"root.nested", "root", false},
+		{"root.array[0]", "root.array[0].nested", true},
+		{"root.array[0]", "root.array[1].nested", false},
+		{"root.array[*].nested", "root.array[1].nested", true},
+		{"root.*.nest", "root.double.nest", true},
+		{"root.*.nest", "root.double.other", false},
+	}
+	for _, c := range cases {
+		p, err := ParsePropertyPath(c.p)
+		assert.NoError(t, err)
+		other, err := ParsePropertyPath(c.other)
+		assert.NoError(t, err)
+		assert.Equal(t, c.contains, p.Contains(other), "%s contains %s", c.p, c.other)
+	}
+}
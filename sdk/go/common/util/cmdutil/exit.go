@@ -120,7 +120,7 @@ func RunResultFunc(run func(cmd *cobra.Command, args []string) result.Result) fu
 			// to quit at this point (with an error code so no one thinks we succeeded).  Bailing
 			// always indicates a failure, just one we don't need to print a message for.
 			if res.IsBail() {
-				os.Exit(-1)
+				os.Exit(ExitCode(res))
 				return
 			}
 
@@ -140,6 +140,29 @@ func RunResultFunc(run func(cmd *cobra.Command, args []string) result.Result) fu
 	}
 }
 
+// exitCodeResult is a bail result that requests a specific process exit code.
+type exitCodeResult struct {
+	code int
+}
+
+func (r *exitCodeResult) Error() error { return nil }
+func (r *exitCodeResult) IsBail() bool { return true }
+
+// BailWithExitCode produces a bail Result that causes [RunResultFunc] to exit with the given exit code rather
+// than the standard error exit code. This lets commands report outcomes that callers need to distinguish from
+// failures, e.g. `pulumi drift` finding drift.
+func BailWithExitCode(code int) result.Result {
+	return &exitCodeResult{code: code}
+}
+
+// ExitCode returns the exit code that [RunResultFunc] uses for the given bail result.
+func ExitCode(res result.Result) int {
+	if r, ok := res.(*exitCodeResult); ok {
+		return r.code
+	}
+	return -1
+}
+
 // Exit exits with a given error.
 func Exit(err error) {
 	ExitError(errorMessage(err))
@@ -0,0 +1,208 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package workspace
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/pkg/errors"
+	yaml "gopkg.in/yaml.v2"
+
+	"github.com/pulumi/pulumi/sdk/v2/go/common/util/contract"
+)
+
+// PluginLock pins the tarballs of a single version of a plugin by their SHA-256 checksums.
+type PluginLock struct {
+	// Kind is the kind of the plugin.
+	Kind PluginKind `yaml:"kind"`
+	// Name is the name of the plugin.
+	Name string `yaml:"name"`
+	// Version is the version of the plugin.
+	Version string `yaml:"version"`
+	// Checksums maps each platform, e.g. "linux-amd64", to the hex-encoded SHA-256 checksum of the plugin's tarball
+	// for that platform.
+	Checksums map[string]string `yaml:"checksums"`
+}
+
+// LockFile is the contents of a project's Pulumi.lock file, which pins the plugins used by the project.
+type LockFile struct {
+	// Plugins are the pinned plugins, sorted by kind, name, and version.
+	Plugins []PluginLock `yaml:"plugins"`
+}
+
+// LockFilePath returns the path of the lock file for the project whose Pulumi.yaml file is at the given path.
+func LockFilePath(projectPath string) string {
+	return filepath.Join(filepath.Dir(projectPath), PluginLockFile)
+}
+
+// DetectLockFilePathFrom locates the lock file of the closest project from the given path, searching "upwards" in the
+// directory hierarchy. If no project is found, an empty path is returned. The lock file itself need not exist.
+func DetectLockFilePathFrom(path string) (string, error) {
+	projectPath, err := DetectProjectPathFrom(path)
+	if err != nil || projectPath == "" {
+		return "", err
+	}
+	return LockFilePath(projectPath), nil
+}
+
+// FindLockFiles returns the paths of the lock files in the given directory and its subdirectories. Dependency and
+// version control directories, which never contain project lock files of their own, are not searched.
+func FindLockFiles(root string) ([]string, error) {
+	var paths []string
+	err := filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			switch info.Name() {
+			case ".git", ".hg", "node_modules", "venv", ".venv", "vendor":
+				return filepath.SkipDir
+			}
+		} else if info.Name() == PluginLockFile {
+			paths = append(paths, path)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return paths, nil
+}
+
+// LoadLockFile reads the lock file at the given path. A missing lock file is treated as an empty one.
+func LoadLockFile(path string) (*LockFile, error) {
+	contract.Require(path != "", "path")
+
+	b, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return &LockFile{}, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	var lock LockFile
+	if err = yaml.Unmarshal(b, &lock); err != nil {
+		return nil, errors.Wrapf(err, "could not read %s", path)
+	}
+	return &lock, nil
+}
+
+// Save writes the lock file to the given path.
+func (lock *LockFile) Save(path string) error {
+	contract.Require(path != "", "path")
+
+	sort.Slice(lock.Plugins, func(i, j int) bool {
+		pi, pj := lock.Plugins[i], lock.Plugins[j]
+		if pi.Kind != pj.Kind {
+			return pi.Kind < pj.Kind
+		}
+		if pi.Name != pj.Name {
+			return pi.Name < pj.Name
+		}
+		return pi.Version < pj.Version
+	})
+
+	b, err := yaml.Marshal(lock)
+	if err != nil {
+		return err
+	}
+
+	//nolint: gosec
+	return ioutil.WriteFile(path, b, 0644)
+}
+
+// find returns the entry for the given plugin, or nil if the plugin is not pinned.
+func (lock *LockFile) find(info PluginInfo) *PluginLock {
+	if info.Version == nil {
+		return nil
+	}
+	version := info.Version.String()
+	for i, p := range lock.Plugins {
+		if p.Kind == info.Kind && p.Name == info.Name && p.Version == version {
+			return &lock.Plugins[i]
+		}
+	}
+	return nil
+}
+
+// References returns true if the lock file pins the given plugin.
+func (lock *LockFile) References(info PluginInfo) bool {
+	return lock.find(info) != nil
+}
+
+// Checksum returns the pinned checksum of the given plugin's tarball for the current platform, or the empty string if
+// there is none.
+func (lock *LockFile) Checksum(info PluginInfo) string {
+	platform, err := PluginPlatform()
+	if err != nil {
+		return ""
+	}
+	if p := lock.find(info); p != nil {
+		return p.Checksums[platform]
+	}
+	return ""
+}
+
+// SetChecksum pins the given plugin's tarball for the current platform to the given checksum. It returns true if the
+// lock file changed. Plugins without a version cannot be pinned.
+func (lock *LockFile) SetChecksum(info PluginInfo, checksum string) bool {
+	platform, err := PluginPlatform()
+	if err != nil || info.Version == nil {
+		return false
+	}
+
+	p := lock.find(info)
+	if p == nil {
+		lock.Plugins = append(lock.Plugins, PluginLock{
+			Kind:    info.Kind,
+			Name:    info.Name,
+			Version: info.Version.String(),
+		})
+		p = &lock.Plugins[len(lock.Plugins)-1]
+	}
+	if p.Checksums == nil {
+		p.Checksums = make(map[string]string)
+	}
+	if p.Checksums[platform] == checksum {
+		return false
+	}
+	p.Checksums[platform] = checksum
+	return true
+}
+
+// Retain removes the entries for any plugins that are not in the given list.
+func (lock *LockFile) Retain(plugins []PluginInfo) {
+	retained := lock.Plugins[:0]
+	for _, p := range lock.Plugins {
+		for _, info := range plugins {
+			if info.Version != nil && p.Kind == info.Kind && p.Name == info.Name && p.Version == info.Version.String() {
+				retained = append(retained, p)
+				break
+			}
+		}
+	}
+	lock.Plugins = retained
+}
+
+// PluginChecksum returns the hex-encoded SHA-256 checksum of a plugin tarball.
+func PluginChecksum(tarball []byte) string {
+	sum := sha256.Sum256(tarball)
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,159 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package workspace
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/blang/semver"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLockFileRoundtrip(t *testing.T) {
+	dir, err := ioutil.TempDir("", "lockfile")
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, PluginLockFile)
+
+	// A missing lock file is empty.
+	lock, err := LoadLockFile(path)
+	assert.NoError(t, err)
+	assert.Empty(t, lock.Plugins)
+
+	v1, v2 := semver.MustParse("1.0.0"), semver.MustParse("2.0.0")
+	aws := PluginInfo{Kind: ResourcePlugin, Name: "aws", Version: &v1}
+	gcp := PluginInfo{Kind: ResourcePlugin, Name: "gcp", Version: &v2}
+
+	assert.True(t, lock.SetChecksum(gcp, "def"))
+	assert.True(t, lock.SetChecksum(aws, "abc"))
+	assert.False(t, lock.SetChecksum(aws, "abc"))
+	assert.False(t, lock.SetChecksum(PluginInfo{Kind: ResourcePlugin, Name: "unversioned"}, "abc"))
+	assert.NoError(t, lock.Save(path))
+
+	lock, err = LoadLockFile(path)
+	assert.NoError(t, err)
+	if assert.Len(t, lock.Plugins, 2) {
+		assert.Equal(t, "aws", lock.Plugins[0].Name)
+	}
+	assert.Equal(t, "abc", lock.Checksum(aws))
+	assert.Equal(t, "", lock.Checksum(PluginInfo{Kind: ResourcePlugin, Name: "aws", Version: &v2}))
+
+	lock.Retain([]PluginInfo{gcp})
+	assert.Equal(t, "", lock.Checksum(aws))
+	assert.Equal(t, "def", lock.Checksum(gcp))
+}
+
+func TestInstallChecksumMismatch(t *testing.T) {
+	home, err := ioutil.TempDir("", "pulumi-home")
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	defer os.RemoveAll(home)
+	oldHome := os.Getenv(PulumiHomeEnvVar)
+	assert.NoError(t, os.Setenv(PulumiHomeEnvVar, home))
+	defer func() { assert.NoError(t, os.Setenv(PulumiHomeEnvVar, oldHome)) }()
+
+	v := semver.MustParse("1.0.0")
+	info := PluginInfo{Kind: ResourcePlugin, Name: "aws", Version: &v, Checksum: PluginChecksum([]byte("expected"))}
+	err = info.Install(ioutil.NopCloser(bytes.NewReader([]byte("tampered"))))
+	if assert.IsType(t, &ChecksumMismatchError{}, err) {
+		assert.Equal(t, PluginChecksum([]byte("tampered")), err.(*ChecksumMismatchError).Actual)
+	}
+	assert.False(t, HasPlugin(info))
+}
+
+func TestInstallRecordsChecksum(t *testing.T) {
+	home, err := ioutil.TempDir("", "pulumi-home")
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	defer os.RemoveAll(home)
+	oldHome := os.Getenv(PulumiHomeEnvVar)
+	assert.NoError(t, os.Setenv(PulumiHomeEnvVar, home))
+	defer func() { assert.NoError(t, os.Setenv(PulumiHomeEnvVar, oldHome)) }()
+
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	assert.NoError(t, tar.NewWriter(gz).Close())
+	assert.NoError(t, gz.Close())
+	tarball := buf.Bytes()
+
+	v := semver.MustParse("1.0.0")
+	info := PluginInfo{Kind: ResourcePlugin, Name: "aws", Version: &v}
+	assert.NoError(t, info.Install(ioutil.NopCloser(bytes.NewReader(tarball))))
+
+	dir, err := info.DirPath()
+	assert.NoError(t, err)
+	checksum, err := InstalledPluginChecksum(dir)
+	assert.NoError(t, err)
+	assert.Equal(t, PluginChecksum(tarball), checksum)
+
+	// Plugins installed without a recorded checksum report none.
+	checksum, err = InstalledPluginChecksum(home)
+	assert.NoError(t, err)
+	assert.Equal(t, "", checksum)
+}
+
+func TestFindLockFiles(t *testing.T) {
+	root, err := ioutil.TempDir("", "lockfiles")
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	defer os.RemoveAll(root)
+
+	for _, dir := range []string{"a", filepath.Join("b", "c"), filepath.Join("a", "node_modules", "d")} {
+		assert.NoError(t, os.MkdirAll(filepath.Join(root, dir), 0700))
+		assert.NoError(t, (&LockFile{}).Save(filepath.Join(root, dir, PluginLockFile)))
+	}
+
+	paths, err := FindLockFiles(root)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{
+		filepath.Join(root, "a", PluginLockFile),
+		filepath.Join(root, "b", "c", PluginLockFile),
+	}, paths)
+}
+
+func TestPluginLastUsedTime(t *testing.T) {
+	dir, err := ioutil.TempDir("", "plugin")
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	defer os.RemoveAll(dir)
+
+	// Using a plugin records the time at which it was used.
+	markPluginUsed(dir)
+	var info PluginInfo
+	assert.NoError(t, info.SetFileMetadata(dir))
+	assert.WithinDuration(t, time.Now(), info.LastUsedTime, time.Minute)
+
+	past := time.Now().Add(-48 * time.Hour)
+	assert.NoError(t, os.Chtimes(filepath.Join(dir, pluginLastUsedFile), past, past))
+	assert.NoError(t, info.SetFileMetadata(dir))
+	assert.WithinDuration(t, past, info.LastUsedTime, time.Second)
+
+	markPluginUsed(dir)
+	assert.NoError(t, info.SetFileMetadata(dir))
+	assert.WithinDuration(t, time.Now(), info.LastUsedTime, time.Minute)
+}
@@ -39,16 +39,24 @@ const (
 	GitDir = ".git"
 	// HistoryDir is the name of the directory that holds historical information for projects.
 	HistoryDir = "history"
+	// JournalDir is the name of the directory that holds snapshot journals for self-managed backends.
+	JournalDir = "journals"
+	// LockDir is the name of the directory that holds advisory stack locks for self-managed backends.
+	LockDir = "locks"
 	// PluginDir is the name of the directory containing plugins.
 	PluginDir = "plugins"
 	// PolicyDir is the name of the directory that holds policy packs.
 	PolicyDir = "policies"
 	// StackDir is the name of the directory that holds stack information for projects.
 	StackDir = "stacks"
+	// TagDir is the name of the directory that holds stack tags for self-managed backends.
+	TagDir = "tags"
 	// TemplateDir is the name of the directory containing templates.
 	TemplateDir = "templates"
 	// TemplatePolicyDir is the name of the directory containing templates for Policy Packs.
 	TemplatePolicyDir = "templates-policy"
+	// TemplateSourceDir is the name of the directory containing clones of registered template sources.
+	TemplateSourceDir = "template-sources"
 	// WorkspaceDir is the name of the directory that holds workspace information for projects.
 	WorkspaceDir = "workspaces"
 
@@ -57,8 +65,12 @@ const (
 
 	// ProjectFile is the base name of a project file.
 	ProjectFile = "Pulumi"
+	// PluginLockFile is the name of the file next to a project file that pins the checksums of the project's plugins.
+	PluginLockFile = "Pulumi.lock"
 	// RepoFile is the name of the file that holds information specific to the entire repository.
 	RepoFile = "settings.json"
+	// TemplateSourcesFile is the name of the file in the Pulumi home directory that registers template sources.
+	TemplateSourcesFile = "template-sources.json"
 	// WorkspaceFile is the name of the file that holds workspace information.
 	WorkspaceFile = "workspace.json"
 	// CachedVersionFile is the name of the file we use to store when we last checked if the CLI was out of date
@@ -0,0 +1,118 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package workspace
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"io"
+	"io/ioutil"
+	"path"
+	"regexp"
+
+	"github.com/blang/semver"
+	"github.com/pkg/errors"
+)
+
+// pluginTarballRegexp matches plugin tarball names: pulumi-KIND-NAME-vVERSION-PLATFORM.tar.gz.
+var pluginTarballRegexp = regexp.MustCompile(
+	"^pulumi-(?P<Kind>[a-z]+)-" + // KIND
+		"(?P<Name>[a-zA-Z0-9-]*[a-zA-Z0-9])-" + // NAME
+		"v(?P<Version>[0-9]+\\.[0-9]+\\.[0-9]+[^/]*)-" + // VERSION
+		"(?P<Platform>[a-z]+-[a-z0-9]+)\\.tar\\.gz$") // PLATFORM
+
+// ParsePluginTarballName extracts the plugin and platform from the name of a plugin tarball, as produced by
+// PluginInfo.TarballName.
+func ParsePluginTarballName(name string) (PluginInfo, string, error) {
+	match := pluginTarballRegexp.FindStringSubmatch(name)
+	if match == nil || !IsPluginKind(match[1]) {
+		return PluginInfo{}, "", errors.Errorf("%s is not a plugin tarball", name)
+	}
+	version, err := semver.ParseTolerant(match[3])
+	if err != nil {
+		return PluginInfo{}, "", errors.Wrapf(err, "%s has an invalid plugin version", name)
+	}
+	return PluginInfo{Kind: PluginKind(match[1]), Name: match[2], Version: &version}, match[4], nil
+}
+
+// PluginBundleWriter writes a plugin bundle: a gzipped tar archive holding one plugin tarball per plugin, named as by
+// PluginInfo.TarballName. Bundles are used to seed the plugin cache of machines that cannot download plugins.
+type PluginBundleWriter struct {
+	gz *gzip.Writer
+	tw *tar.Writer
+}
+
+// NewPluginBundleWriter returns a writer that writes a plugin bundle to w. The bundle is complete once Close has been
+// called.
+func NewPluginBundleWriter(w io.Writer) *PluginBundleWriter {
+	gz := gzip.NewWriter(w)
+	return &PluginBundleWriter{gz: gz, tw: tar.NewWriter(gz)}
+}
+
+// Add adds the given plugin's tarball for the given platform to the bundle.
+func (bw *PluginBundleWriter) Add(info PluginInfo, platform string, tarball []byte) error {
+	hdr := &tar.Header{
+		Name:     info.TarballName(platform),
+		Mode:     0644,
+		Size:     int64(len(tarball)),
+		Typeflag: tar.TypeReg,
+	}
+	if err := bw.tw.WriteHeader(hdr); err != nil {
+		return err
+	}
+	_, err := bw.tw.Write(tarball)
+	return err
+}
+
+// Close finishes writing the bundle. It does not close the underlying writer.
+func (bw *PluginBundleWriter) Close() error {
+	if err := bw.tw.Close(); err != nil {
+		return err
+	}
+	return bw.gz.Close()
+}
+
+// ReadPluginBundle reads a plugin bundle written by a PluginBundleWriter, calling fn with each plugin, the platform it
+// was built for, and its tarball.
+func ReadPluginBundle(r io.Reader, fn func(info PluginInfo, platform string, tarball []byte) error) error {
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return errors.Wrap(err, "reading plugin bundle")
+	}
+	tr := tar.NewReader(gz)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		} else if err != nil {
+			return errors.Wrap(err, "reading plugin bundle")
+		}
+		if hdr.Typeflag != tar.TypeReg {
+			continue
+		}
+
+		info, platform, err := ParsePluginTarballName(path.Base(hdr.Name))
+		if err != nil {
+			return err
+		}
+		tarball, err := ioutil.ReadAll(tr)
+		if err != nil {
+			return errors.Wrapf(err, "reading %s from plugin bundle", hdr.Name)
+		}
+		if err = fn(info, platform, tarball); err != nil {
+			return err
+		}
+	}
+}
@@ -0,0 +1,124 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package workspace
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/blang/semver"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParsePluginTarballName(t *testing.T) {
+	v := semver.MustParse("1.2.3-alpha.1")
+	info := PluginInfo{Kind: ResourcePlugin, Name: "azure-nextgen", Version: &v}
+
+	parsed, platform, err := ParsePluginTarballName(info.TarballName("linux-amd64"))
+	assert.NoError(t, err)
+	assert.Equal(t, "linux-amd64", platform)
+	assert.Equal(t, info.Kind, parsed.Kind)
+	assert.Equal(t, info.Name, parsed.Name)
+	assert.Equal(t, info.Version.String(), parsed.Version.String())
+
+	_, _, err = ParsePluginTarballName("pulumi-bogus-aws-v1.0.0-linux-amd64.tar.gz")
+	assert.Error(t, err)
+	_, _, err = ParsePluginTarballName("README.md")
+	assert.Error(t, err)
+}
+
+func TestPluginBundleRoundtrip(t *testing.T) {
+	v1, v2 := semver.MustParse("1.0.0"), semver.MustParse("2.0.0")
+	aws := PluginInfo{Kind: ResourcePlugin, Name: "aws", Version: &v1}
+	policy := PluginInfo{Kind: AnalyzerPlugin, Name: "policy", Version: &v2}
+
+	var buf bytes.Buffer
+	bw := NewPluginBundleWriter(&buf)
+	assert.NoError(t, bw.Add(aws, "linux-amd64", []byte("aws tarball")))
+	assert.NoError(t, bw.Add(policy, "darwin-amd64", []byte("policy tarball")))
+	assert.NoError(t, bw.Close())
+
+	var names, platforms, tarballs []string
+	err := ReadPluginBundle(&buf, func(info PluginInfo, platform string, tarball []byte) error {
+		names = append(names, info.String())
+		platforms = append(platforms, platform)
+		tarballs = append(tarballs, string(tarball))
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{aws.String(), policy.String()}, names)
+	assert.Equal(t, []string{"linux-amd64", "darwin-amd64"}, platforms)
+	assert.Equal(t, []string{"aws tarball", "policy tarball"}, tarballs)
+}
+
+func TestDownloadFromMirror(t *testing.T) {
+	platform, err := PluginPlatform()
+	if err != nil {
+		t.Skip(err)
+	}
+
+	empty, err := ioutil.TempDir("", "empty-mirror")
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	defer os.RemoveAll(empty)
+	mirror, err := ioutil.TempDir("", "mirror")
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	defer os.RemoveAll(mirror)
+
+	v := semver.MustParse("1.0.0")
+	info := PluginInfo{Kind: ResourcePlugin, Name: "aws", Version: &v}
+	err = ioutil.WriteFile(filepath.Join(mirror, info.TarballName(platform)), []byte("tarball"), 0600)
+	assert.NoError(t, err)
+
+	// Sources are tried in order, so the plugin is found in the second mirror. The default server is tried last.
+	oldSources := os.Getenv(PluginSourcesEnvVar)
+	assert.NoError(t, os.Setenv(PluginSourcesEnvVar, empty+", file://"+filepath.ToSlash(mirror)))
+	defer func() { assert.NoError(t, os.Setenv(PluginSourcesEnvVar, oldSources)) }()
+	assert.Equal(t, []string{empty, "file://" + filepath.ToSlash(mirror), defaultPluginServerURL},
+		info.PluginSources())
+
+	// The default server can be left out, which also keeps the rest of this test off the network.
+	oldSourcesOnly := os.Getenv(PluginSourcesOnlyEnvVar)
+	assert.NoError(t, os.Setenv(PluginSourcesOnlyEnvVar, "true"))
+	defer func() { assert.NoError(t, os.Setenv(PluginSourcesOnlyEnvVar, oldSourcesOnly)) }()
+	assert.Equal(t, []string{empty, "file://" + filepath.ToSlash(mirror)}, info.PluginSources())
+
+	r, size, err := info.Download()
+	if assert.NoError(t, err) {
+		defer r.Close()
+		b, err := ioutil.ReadAll(r)
+		assert.NoError(t, err)
+		assert.Equal(t, "tarball", string(b))
+		assert.Equal(t, int64(len(b)), size)
+	}
+
+	// A plugin missing from every source reports a failure.
+	missing := PluginInfo{Kind: ResourcePlugin, Name: "gcp", Version: &v}
+	_, _, err = missing.Download()
+	assert.Error(t, err)
+}
+
+func TestFileURLPath(t *testing.T) {
+	assert.Equal(t, "C:/plugins", fileURLPath("/C:/plugins", windowsGOOS))
+	assert.Equal(t, "/plugins", fileURLPath("/plugins", windowsGOOS))
+	assert.Equal(t, "/C:/plugins", fileURLPath("/C:/plugins", "linux"))
+	assert.Equal(t, "/opt/plugins", fileURLPath("/opt/plugins", "linux"))
+}
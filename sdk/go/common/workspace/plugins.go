@@ -27,6 +27,7 @@ import (
 	"regexp"
 	"runtime"
 	"sort"
+	"strconv"
 	"strings"
 	"time"
 
@@ -45,6 +46,27 @@ import (
 
 const (
 	windowsGOOS = "windows"
+
+	// PluginSourcesEnvVar is the environment variable that lists additional locations from which plugins may be
+	// downloaded, separated by commas. Each location is an HTTP(S) server, a file:// URL, or a local directory that
+	// holds plugin tarballs named as by PluginInfo.TarballName. The default location hosted by Pulumi is tried after
+	// these unless PluginSourcesOnlyEnvVar is set.
+	PluginSourcesEnvVar = "PULUMI_PLUGIN_SOURCES"
+
+	// PluginSourcesOnlyEnvVar is the environment variable that, when set to true, restricts plugin downloads to the
+	// locations listed in PluginSourcesEnvVar (and the plugin's own server, if any), e.g. for air-gapped machines.
+	PluginSourcesOnlyEnvVar = "PULUMI_PLUGIN_SOURCES_ONLY"
+
+	// pluginLastUsedFile is the name of the marker file, inside a plugin's directory, whose modification time records
+	// the last time the plugin was used.
+	pluginLastUsedFile = ".pulumi-last-used"
+
+	// pluginChecksumFile is the name of the file, inside a plugin's directory, that records the checksum of the
+	// tarball from which the plugin was installed.
+	pluginChecksumFile = ".pulumi-checksum"
+
+	// defaultPluginServerURL is the location from which plugins are downloaded when no other source is configured.
+	defaultPluginServerURL = "https://get.pulumi.com/releases/plugins"
 )
 
 var (
@@ -75,6 +97,20 @@ func (err *MissingError) Error() string {
 		err.Info.Kind, err.Info.String())
 }
 
+// ChecksumMismatchError is returned when installing a plugin whose tarball does not match the plugin's checksum.
+type ChecksumMismatchError struct {
+	// Info contains information about the plugin that was being installed.
+	Info PluginInfo
+	// Actual is the checksum of the tarball.
+	Actual string
+}
+
+func (err *ChecksumMismatchError) Error() string {
+	return fmt.Sprintf("checksum mismatch for %s plugin %s: expected %s, got %s; if the plugin was intentionally "+
+		"changed, run `pulumi plugin lock` to update %s",
+		err.Info.Kind, err.Info.String(), err.Info.Checksum, err.Actual, PluginLockFile)
+}
+
 // PluginInfo provides basic information about a plugin.  Each plugin gets installed into a system-wide
 // location, by default `~/.pulumi/plugins/<kind>-<name>-<version>/`.  A plugin may contain multiple files,
 // however the primary loadable executable must be named `pulumi-<kind>-<name>`.
@@ -87,6 +123,7 @@ type PluginInfo struct {
 	InstallTime  time.Time       // the time the plugin was installed.
 	LastUsedTime time.Time       // the last time the plugin was used.
 	ServerURL    string          // an optional server to use when downloading this plugin.
+	Checksum     string          // an optional SHA-256 checksum that the plugin's tarball must match.
 }
 
 // Dir gets the expected plugin directory for this plugin.
@@ -166,40 +203,157 @@ func (info *PluginInfo) SetFileMetadata(path string) error {
 		info.InstallTime = tinfo.BirthTime()
 	}
 
-	info.LastUsedTime = tinfo.AccessTime()
+	// Prefer the last-used time recorded by GetPluginPath, since access times are often not maintained.
+	if used, err := os.Stat(filepath.Join(path, pluginLastUsedFile)); err == nil {
+		info.LastUsedTime = used.ModTime()
+	} else {
+		info.LastUsedTime = tinfo.AccessTime()
+	}
 	return nil
 }
 
-// Download fetches an io.ReadCloser for this plugin and also returns the size of the response (if known).
-func (info PluginInfo) Download() (io.ReadCloser, int64, error) {
-	// Figure out the OS/ARCH pair for the download URL.
+// InstalledPluginChecksum returns the checksum of the tarball from which the plugin in the given directory of the
+// plugin cache was installed, or the empty string if it is unknown, e.g. because the plugin was installed by an older
+// version of Pulumi.
+func InstalledPluginChecksum(dir string) (string, error) {
+	b, err := ioutil.ReadFile(filepath.Join(dir, pluginChecksumFile))
+	if os.IsNotExist(err) {
+		return "", nil
+	} else if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(b)), nil
+}
+
+// markPluginUsed records that the plugin installed in the given directory was just used, by touching a marker file
+// inside of it. Failures are logged and otherwise ignored, since the time is only used to report and prune plugins.
+func markPluginUsed(dir string) {
+	path := filepath.Join(dir, pluginLastUsedFile)
+	now := time.Now()
+	err := os.Chtimes(path, now, now)
+	if os.IsNotExist(err) {
+		err = ioutil.WriteFile(path, nil, 0600)
+	}
+	if err != nil {
+		logging.V(7).Infof("could not record last use of plugin in %s: %v", dir, err)
+	}
+}
+
+// PluginPlatform returns the OS/ARCH pair, e.g. "linux-amd64", for which plugins are downloaded on this machine.
+func PluginPlatform() (string, error) {
 	var os string
 	switch runtime.GOOS {
 	case "darwin", "linux", "windows":
 		os = runtime.GOOS
 	default:
-		return nil, -1, errors.Errorf("unsupported plugin OS: %s", runtime.GOOS)
+		return "", errors.Errorf("unsupported plugin OS: %s", runtime.GOOS)
 	}
 	var arch string
 	switch runtime.GOARCH {
 	case "amd64":
 		arch = runtime.GOARCH
 	default:
-		return nil, -1, errors.Errorf("unsupported plugin architecture: %s", runtime.GOARCH)
+		return "", errors.Errorf("unsupported plugin architecture: %s", runtime.GOARCH)
 	}
+	return os + "-" + arch, nil
+}
+
+// TarballName returns the name of this plugin's tarball for the given platform, e.g.
+// "pulumi-resource-aws-v1.0.0-linux-amd64.tar.gz". Plugin servers, mirrors, and bundles all use this naming scheme.
+func (info PluginInfo) TarballName(platform string) string {
+	return fmt.Sprintf("pulumi-%s-%s-v%s-%s.tar.gz", info.Kind, info.Name, info.Version, platform)
+}
 
-	// If the plugin has a server, associated with it, download from there.  Otherwise use the "default" location, which
-	// is hosted by Pulumi.
-	serverURL := info.ServerURL
-	if serverURL == "" {
-		serverURL = "https://get.pulumi.com/releases/plugins"
+// PluginSources returns the locations from which this plugin may be downloaded, in the order in which they are tried.
+// The plugin's own server, if any, comes first, followed by the sources listed in the PULUMI_PLUGIN_SOURCES
+// environment variable and finally the default location hosted by Pulumi. The default location is omitted if
+// PULUMI_PLUGIN_SOURCES_ONLY is true and at least one other source is configured.
+func (info PluginInfo) PluginSources() []string {
+	var sources []string
+	if info.ServerURL != "" {
+		sources = append(sources, info.ServerURL)
+	}
+	for _, source := range strings.Split(os.Getenv(PluginSourcesEnvVar), ",") {
+		if source = strings.TrimSpace(source); source != "" {
+			sources = append(sources, source)
+		}
+	}
+	if sourcesOnly, _ := strconv.ParseBool(os.Getenv(PluginSourcesOnlyEnvVar)); len(sources) == 0 || !sourcesOnly {
+		sources = append(sources, defaultPluginServerURL)
 	}
+	return sources
+}
+
+// Download fetches an io.ReadCloser for this plugin and also returns the size of the response (if known). Each of the
+// plugin's sources is tried in turn until one of them has the plugin.
+func (info PluginInfo) Download() (io.ReadCloser, int64, error) {
+	// Figure out the OS/ARCH pair for the download URL.
+	platform, err := PluginPlatform()
+	if err != nil {
+		return nil, -1, err
+	}
+	tarball := info.TarballName(platform)
+
+	sources := info.PluginSources()
+	var errs []string
+	for _, source := range sources {
+		r, size, err := downloadPluginFrom(source, tarball)
+		if err == nil {
+			return r, size, nil
+		}
+		logging.V(7).Infof("could not download plugin %s from %s: %v", info, source, err)
+		errs = append(errs, err.Error())
+	}
+	if len(errs) == 1 {
+		return nil, -1, errors.New(errs[0])
+	}
+	return nil, -1, errors.Errorf("could not download plugin from any of %d sources: %s",
+		len(sources), strings.Join(errs, "; "))
+}
+
+// downloadPluginFrom fetches the named tarball from a single plugin source. A source is either an HTTP(S) server, a
+// file:// URL, or a local directory; the latter two are expected to contain the tarballs directly.
+func downloadPluginFrom(source, tarball string) (io.ReadCloser, int64, error) {
+	dir := source
+	if u, err := url.Parse(source); err == nil {
+		switch u.Scheme {
+		case "http", "https":
+			return downloadPluginFromServer(source, tarball)
+		case "file":
+			dir = filepath.FromSlash(fileURLPath(u.Path, runtime.GOOS))
+		}
+	}
+
+	path := filepath.Join(dir, tarball)
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, -1, errors.Wrapf(err, "fetching plugin from %s", dir)
+	}
+	stat, err := f.Stat()
+	if err != nil {
+		contract.IgnoreClose(f)
+		return nil, -1, err
+	}
+	return f, stat.Size(), nil
+}
+
+// fileURLPath returns the local path named by the path of a file:// URL. On Windows, a URL such as file:///C:/plugins
+// has the path "/C:/plugins", whose leading slash must be dropped to get a valid path.
+func fileURLPath(urlPath, goos string) string {
+	if goos == windowsGOOS && len(urlPath) >= 3 && urlPath[0] == '/' && urlPath[2] == ':' {
+		if drive := urlPath[1]; ('a' <= drive && drive <= 'z') || ('A' <= drive && drive <= 'Z') {
+			return urlPath[1:]
+		}
+	}
+	return urlPath
+}
+
+// downloadPluginFromServer fetches the named tarball from a plugin server.
+func downloadPluginFromServer(serverURL, tarball string) (io.ReadCloser, int64, error) {
 	serverURL = strings.TrimSuffix(serverURL, "/")
 
 	// URL escape the path value to ensure we have the correct path for S3/CloudFront.
-	endpoint := fmt.Sprintf("%s/%s",
-		serverURL,
-		url.QueryEscape(fmt.Sprintf("pulumi-%s-%s-v%s-%s-%s.tar.gz", info.Kind, info.Name, info.Version, os, arch)))
+	endpoint := fmt.Sprintf("%s/%s", serverURL, url.QueryEscape(tarball))
 
 	req, err := http.NewRequest("GET", endpoint, nil)
 	if err != nil {
@@ -215,13 +369,15 @@ func (info PluginInfo) Download() (io.ReadCloser, int64, error) {
 	}
 
 	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		contract.IgnoreClose(resp.Body)
 		return nil, -1, errors.Errorf("%d HTTP error fetching plugin from %s", resp.StatusCode, endpoint)
 	}
 
 	return resp.Body, resp.ContentLength, nil
 }
 
-// Install installs a plugin's tarball into the cache.  It validates that plugin names are in the expected format.
+// Install installs a plugin's tarball into the cache.  It validates that plugin names are in the expected format and,
+// if the plugin has a checksum, that the tarball matches it.
 func (info PluginInfo) Install(tarball io.ReadCloser) error {
 	// Fetch the directory into which we will expand this tarball, and create it.
 	finalDir, err := info.DirPath()
@@ -254,8 +410,17 @@ func (info PluginInfo) Install(tarball io.ReadCloser) error {
 		if err != nil {
 			return err
 		}
+		checksum := PluginChecksum(tarballBytes)
+		if info.Checksum != "" && checksum != info.Checksum {
+			return &ChecksumMismatchError{Info: info, Actual: checksum}
+		}
+
+		if err := archive.UnTGZ(tarballBytes, tempDir); err != nil {
+			return err
+		}
 
-		return archive.UnTGZ(tarballBytes, tempDir)
+		// Record the tarball's checksum so that the installed plugin can later be pinned or verified by lock files.
+		return ioutil.WriteFile(filepath.Join(tempDir, pluginChecksumFile), []byte(checksum), 0600)
 	})()
 	if err != nil {
 		return err
@@ -517,6 +682,7 @@ func GetPluginPath(kind PluginKind, name string, version *semver.Version) (strin
 		}
 
 		logging.V(6).Infof("GetPluginPath(%s, %s, %v): found in cache at %s", kind, name, version, matchPath)
+		markPluginUsed(matchDir)
 		return matchDir, matchPath, nil
 	}
 
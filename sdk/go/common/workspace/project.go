@@ -19,6 +19,7 @@ import (
 	"io/ioutil"
 	"os"
 	"path/filepath"
+	"regexp"
 
 	"github.com/pkg/errors"
 
@@ -41,6 +42,68 @@ type ProjectTemplate struct {
 	Config map[string]ProjectTemplateConfigValue `json:"config,omitempty" yaml:"config,omitempty"`
 	// Important indicates the template is important and should be listed by default.
 	Important bool `json:"important,omitempty" yaml:"important,omitempty"`
+	// Parameters are optional values, prompted for in order, that are substituted into the template's files.
+	Parameters []ProjectTemplateParameter `json:"parameters,omitempty" yaml:"parameters,omitempty"`
+}
+
+// Validate checks that the template's parameters are well-formed.
+func (t *ProjectTemplate) Validate() error {
+	names := make(map[string]bool)
+	for _, param := range t.Parameters {
+		if !projectTemplateParameterNameRegexp.MatchString(param.Name) {
+			return errors.Errorf("template parameter name '%s' must start with a letter or underscore and contain "+
+				"only alphanumerics and underscores", param.Name)
+		}
+		if param.Name == "PROJECT" || param.Name == "DESCRIPTION" {
+			return errors.Errorf("template parameter name '%s' is reserved", param.Name)
+		}
+		if names[param.Name] {
+			return errors.Errorf("template parameter '%s' is declared more than once", param.Name)
+		}
+		names[param.Name] = true
+
+		if param.Pattern != "" {
+			if _, err := regexp.Compile(param.Pattern); err != nil {
+				return errors.Wrapf(err, "template parameter '%s' has an invalid pattern", param.Name)
+			}
+			if param.Default != "" {
+				if err := param.ValidateValue(param.Default); err != nil {
+					return errors.Wrapf(err, "template parameter '%s' has an invalid default", param.Name)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// projectTemplateParameterNameRegexp matches valid template parameter names.
+var projectTemplateParameterNameRegexp = regexp.MustCompile("^[A-Za-z_][A-Za-z0-9_]*$")
+
+// ProjectTemplateParameter is a value that is substituted for each occurrence of ${NAME} in a template's files.
+type ProjectTemplateParameter struct {
+	// Name is the name of the parameter.
+	Name string `json:"name" yaml:"name"`
+	// Description is an optional description for the parameter, which is used to prompt for its value.
+	Description string `json:"description,omitempty" yaml:"description,omitempty"`
+	// Default is an optional default value for the parameter.
+	Default string `json:"default,omitempty" yaml:"default,omitempty"`
+	// Pattern is an optional regular expression that the entire value must match.
+	Pattern string `json:"pattern,omitempty" yaml:"pattern,omitempty"`
+}
+
+// ValidateValue checks that the given value is acceptable for the parameter.
+func (p ProjectTemplateParameter) ValidateValue(value string) error {
+	if p.Pattern == "" {
+		return nil
+	}
+	re, err := regexp.Compile("^(?:" + p.Pattern + ")$")
+	if err != nil {
+		return errors.Wrapf(err, "invalid pattern for template parameter '%s'", p.Name)
+	}
+	if !re.MatchString(value) {
+		return errors.Errorf("'%s' does not match the pattern %s", value, p.Pattern)
+	}
+	return nil
 }
 
 // ProjectTemplateConfigValue is a config value included in the project template manifest.
@@ -59,6 +122,32 @@ type ProjectBackend struct {
 	URL string `json:"url,omitempty" yaml:"url,omitempty"`
 }
 
+// ProjectParallelism limits the number of resource operations that may run concurrently for particular provider
+// packages or resource types, in addition to the overall limit set by `--parallel`.
+type ProjectParallelism struct {
+	// Providers maps provider package names, e.g. "kubernetes", to the maximum number of concurrent operations on the
+	// package's resources.
+	Providers map[string]int `json:"providers,omitempty" yaml:"providers,omitempty"`
+	// Types maps resource type tokens, e.g. "aws:s3/bucket:Bucket", to the maximum number of concurrent operations on
+	// resources of that type.
+	Types map[string]int `json:"types,omitempty" yaml:"types,omitempty"`
+}
+
+// Validate checks that each of the limits is at least 1.
+func (p *ProjectParallelism) Validate() error {
+	for pkg, limit := range p.Providers {
+		if limit < 1 {
+			return errors.Errorf("parallelism limit for provider '%s' must be at least 1", pkg)
+		}
+	}
+	for typ, limit := range p.Types {
+		if limit < 1 {
+			return errors.Errorf("parallelism limit for type '%s' must be at least 1", typ)
+		}
+	}
+	return nil
+}
+
 // Project is a Pulumi project manifest.
 //
 // We explicitly add yaml tags (instead of using the default behavior from https://github.com/ghodss/yaml which works
@@ -91,6 +180,9 @@ type Project struct {
 
 	// Backend is an optional backend configuration
 	Backend *ProjectBackend `json:"backend,omitempty" yaml:"backend,omitempty"`
+
+	// Parallelism optionally limits the concurrency of operations on particular providers' resources.
+	Parallelism *ProjectParallelism `json:"parallelism,omitempty" yaml:"parallelism,omitempty"`
 }
 
 func (proj *Project) Validate() error {
@@ -100,6 +192,16 @@ func (proj *Project) Validate() error {
 	if proj.Runtime.Name() == "" {
 		return errors.New("project is missing a 'runtime' attribute")
 	}
+	if proj.Parallelism != nil {
+		if err := proj.Parallelism.Validate(); err != nil {
+			return err
+		}
+	}
+	if proj.Template != nil {
+		if err := proj.Template.Validate(); err != nil {
+			return err
+		}
+	}
 
 	return nil
 }
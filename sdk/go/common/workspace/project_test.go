@@ -36,3 +36,39 @@ func TestProjectRuntimeInfoRoundtripYAML(t *testing.T) {
 	doTest(yaml.Marshal, yaml.Unmarshal)
 	doTest(json.Marshal, json.Unmarshal)
 }
+
+func TestProjectValidateParallelism(t *testing.T) {
+	proj := &Project{
+		Name:    "test",
+		Runtime: NewProjectRuntimeInfo("nodejs", nil),
+		Parallelism: &ProjectParallelism{
+			Providers: map[string]int{"aws": 2},
+			Types:     map[string]int{"aws:s3/bucket:Bucket": 1},
+		},
+	}
+	assert.NoError(t, proj.Validate())
+
+	proj.Parallelism.Types["aws:s3/bucket:Bucket"] = 0
+	assert.EqualError(t, proj.Validate(), "parallelism limit for type 'aws:s3/bucket:Bucket' must be at least 1")
+}
+
+func TestProjectValidateTemplateParameters(t *testing.T) {
+	proj := &Project{
+		Name:    "test",
+		Runtime: NewProjectRuntimeInfo("nodejs", nil),
+		Template: &ProjectTemplate{
+			Parameters: []ProjectTemplateParameter{{Name: "REGION", Default: "us-west-2", Pattern: "[a-z]+-[a-z]+-[0-9]"}},
+		},
+	}
+	assert.NoError(t, proj.Validate())
+
+	proj.Template.Parameters[0].Default = "mars"
+	assert.EqualError(t, proj.Validate(),
+		"template parameter 'REGION' has an invalid default: 'mars' does not match the pattern [a-z]+-[a-z]+-[0-9]")
+
+	proj.Template.Parameters[0] = ProjectTemplateParameter{Name: "PROJECT"}
+	assert.EqualError(t, proj.Validate(), "template parameter name 'PROJECT' is reserved")
+
+	proj.Template.Parameters = []ProjectTemplateParameter{{Name: "A"}, {Name: "A"}}
+	assert.EqualError(t, proj.Validate(), "template parameter 'A' is declared more than once")
+}
@@ -0,0 +1,187 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package workspace
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/pkg/errors"
+	"gopkg.in/src-d/go-git.v4/plumbing"
+
+	"github.com/pulumi/pulumi/sdk/v2/go/common/util/gitutil"
+)
+
+// TemplateSource is a repository of project templates registered in addition to the built-in Pulumi templates. The
+// templates of a source are named "SOURCE/TEMPLATE", e.g. "acme/webapp".
+type TemplateSource struct {
+	// Name is the name of the source, which qualifies the names of its templates.
+	Name string `json:"name"`
+	// URL is the URL of a git repository holding the templates. Exactly one of URL and Path must be set.
+	URL string `json:"url,omitempty"`
+	// Branch is the branch of the git repository to use. If empty, the repository's default branch is used.
+	Branch string `json:"branch,omitempty"`
+	// Path is the path to a local directory holding the templates.
+	Path string `json:"path,omitempty"`
+	// SubDirectory is the directory within the repository or local directory that holds the templates.
+	SubDirectory string `json:"subDirectory,omitempty"`
+}
+
+// TemplateSettings holds the template sources registered on this machine.
+type TemplateSettings struct {
+	// Sources are the registered template sources, in the order in which their templates are listed.
+	Sources []TemplateSource `json:"sources,omitempty"`
+}
+
+// templateSourceNameRegexp matches valid template source names.
+var templateSourceNameRegexp = regexp.MustCompile("^[a-zA-Z0-9_.-]+$")
+
+// Validate checks that the template source is well-formed.
+func (source TemplateSource) Validate() error {
+	if !templateSourceNameRegexp.MatchString(source.Name) {
+		return errors.Errorf("template source name '%s' may only contain alphanumerics, hyphens, underscores, "+
+			"and periods", source.Name)
+	}
+	if (source.URL == "") == (source.Path == "") {
+		return errors.Errorf("template source '%s' must have exactly one of 'url' and 'path'", source.Name)
+	}
+	if source.Branch != "" && source.URL == "" {
+		return errors.Errorf("template source '%s' has a 'branch' but no 'url'", source.Name)
+	}
+	return nil
+}
+
+// GetTemplateSettingsPath returns the path to the file that registers template sources on this machine.
+func GetTemplateSettingsPath() (string, error) {
+	return GetPulumiPath(TemplateSourcesFile)
+}
+
+// GetTemplateSettings returns the template sources registered on this machine. If no file registers any sources, an
+// empty set of settings is returned.
+func GetTemplateSettings() (TemplateSettings, error) {
+	path, err := GetTemplateSettingsPath()
+	if err != nil {
+		return TemplateSettings{}, err
+	}
+
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return TemplateSettings{}, nil
+		}
+		return TemplateSettings{}, errors.Wrapf(err, "reading '%s'", path)
+	}
+
+	var settings TemplateSettings
+	if err = json.Unmarshal(b, &settings); err != nil {
+		return TemplateSettings{}, errors.Wrapf(err, "unmarshalling '%s'", path)
+	}
+
+	names := make(map[string]bool)
+	for _, source := range settings.Sources {
+		if err = source.Validate(); err != nil {
+			return TemplateSettings{}, errors.Wrapf(err, "invalid template source in '%s'", path)
+		}
+		if names[source.Name] {
+			return TemplateSettings{}, errors.Errorf("template source '%s' is registered more than once in '%s'",
+				source.Name, path)
+		}
+		names[source.Name] = true
+	}
+	return settings, nil
+}
+
+// Source returns the registered template source with the given name, if any.
+func (settings TemplateSettings) Source(name string) (TemplateSource, bool) {
+	for _, source := range settings.Sources {
+		if source.Name == name {
+			return source, true
+		}
+	}
+	return TemplateSource{}, false
+}
+
+// Retrieve retrieves the template repository for the source. A git source is cloned into the Pulumi home directory
+// and updated unless offline is set, in which case the existing clone is used.
+func (source TemplateSource) Retrieve(offline bool) (TemplateRepository, error) {
+	root := source.Path
+	if source.URL != "" {
+		var err error
+		if root, err = GetPulumiPath(TemplateSourceDir, source.Name); err != nil {
+			return TemplateRepository{}, err
+		}
+
+		if !offline {
+			ref := plumbing.HEAD
+			if source.Branch != "" {
+				ref = plumbing.NewBranchReferenceName(source.Branch)
+			}
+			if err = os.MkdirAll(root, 0700); err != nil {
+				return TemplateRepository{}, err
+			}
+			if err = gitutil.GitCloneOrPull(source.URL, ref, root, true /*shallow*/); err != nil {
+				return TemplateRepository{}, errors.Wrapf(err, "retrieving template source '%s'", source.Name)
+			}
+		} else if _, err = os.Stat(root); err != nil {
+			return TemplateRepository{}, errors.Errorf("template source '%s' has not been retrieved; "+
+				"rerun without --offline", source.Name)
+		}
+	}
+
+	subDir := root
+	if source.SubDirectory != "" {
+		subDir = filepath.Join(root, filepath.FromSlash(source.SubDirectory))
+	}
+	return TemplateRepository{
+		Root:         root,
+		SubDirectory: subDir,
+		ShouldDelete: false,
+		Source:       source.Name,
+	}, nil
+}
+
+// retrieveSourceTemplates retrieves the "template repository" for a template of a registered source, given a name of
+// the form "SOURCE/TEMPLATE". It returns false if the name does not refer to a registered source.
+func retrieveSourceTemplates(templateName string, offline bool) (TemplateRepository, bool, error) {
+	parts := strings.SplitN(templateName, "/", 2)
+	if len(parts) != 2 || parts[1] == "" {
+		return TemplateRepository{}, false, nil
+	}
+
+	settings, err := GetTemplateSettings()
+	if err != nil {
+		return TemplateRepository{}, false, err
+	}
+	source, ok := settings.Source(parts[0])
+	if !ok {
+		return TemplateRepository{}, false, nil
+	}
+
+	repo, err := source.Retrieve(offline)
+	if err != nil {
+		return TemplateRepository{}, true, err
+	}
+
+	templateDir := repo.SubDirectory
+	repo.SubDirectory = filepath.Join(templateDir, filepath.FromSlash(parts[1]))
+	if _, err = os.Stat(repo.SubDirectory); os.IsNotExist(err) {
+		return TemplateRepository{}, true, newTemplateNotFoundError(templateDir, parts[1])
+	}
+	return repo, true, nil
+}
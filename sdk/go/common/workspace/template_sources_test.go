@@ -0,0 +1,119 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package workspace
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const webappTemplate = `name: webapp
+runtime: nodejs
+description: A web application
+template:
+  description: An Acme web application
+  parameters:
+  - name: TEAM
+    description: The owning team
+    default: platform
+    pattern: "[a-z]+"
+`
+
+func TestRetrieveSourceTemplates(t *testing.T) {
+	home, err := ioutil.TempDir("", "pulumi-home")
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	defer os.RemoveAll(home)
+	oldHome := os.Getenv(PulumiHomeEnvVar)
+	assert.NoError(t, os.Setenv(PulumiHomeEnvVar, home))
+	defer func() { assert.NoError(t, os.Setenv(PulumiHomeEnvVar, oldHome)) }()
+
+	// Register a local directory of templates as the "acme" source.
+	sourceDir, err := ioutil.TempDir("", "acme-templates")
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	defer os.RemoveAll(sourceDir)
+	webappDir := filepath.Join(sourceDir, "templates", "webapp")
+	assert.NoError(t, os.MkdirAll(webappDir, 0700))
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(webappDir, "Pulumi.yaml"), []byte(webappTemplate), 0600))
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(webappDir, "index.ts"), []byte("team: ${TEAM} ${OTHER}"), 0600))
+
+	settings := TemplateSettings{Sources: []TemplateSource{{Name: "acme", Path: sourceDir, SubDirectory: "templates"}}}
+	b, err := json.Marshal(settings)
+	assert.NoError(t, err)
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(home, TemplateSourcesFile), b, 0600))
+
+	// The source's templates are listed with qualified names.
+	loaded, err := GetTemplateSettings()
+	assert.NoError(t, err)
+	source, ok := loaded.Source("acme")
+	assert.True(t, ok)
+	repo, err := source.Retrieve(false)
+	assert.NoError(t, err)
+	templates, err := repo.Templates()
+	assert.NoError(t, err)
+	if assert.Len(t, templates, 1) {
+		assert.Equal(t, "acme/webapp", templates[0].Name)
+		assert.Equal(t, "An Acme web application", templates[0].Description)
+	}
+
+	// A single template is retrieved by its qualified name.
+	repo, err = RetrieveTemplates("acme/webapp", false, TemplateKindPulumiProject)
+	assert.NoError(t, err)
+	templates, err = repo.Templates()
+	assert.NoError(t, err)
+	if !assert.Len(t, templates, 1) {
+		t.FailNow()
+	}
+	template := templates[0]
+	assert.Equal(t, "acme/webapp", template.Name)
+	if assert.Len(t, template.Parameters, 1) {
+		assert.Equal(t, "platform", template.Parameters[0].Default)
+		assert.NoError(t, template.Parameters[0].ValidateValue("data"))
+		assert.Error(t, template.Parameters[0].ValidateValue("Data Team"))
+	}
+
+	_, err = RetrieveTemplates("acme/webap", false, TemplateKindPulumiProject)
+	assert.EqualError(t, err, "template 'webap' not found\n\nDid you mean this?\n\twebapp\n")
+
+	// Parameters are substituted into the template's files.
+	dest, err := ioutil.TempDir("", "webapp")
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	defer os.RemoveAll(dest)
+	err = CopyTemplateFilesWithParameters(template.Dir, dest, false, "myapp", "My app",
+		map[string]string{"TEAM": "data"})
+	assert.NoError(t, err)
+	b, err = ioutil.ReadFile(filepath.Join(dest, "index.ts"))
+	assert.NoError(t, err)
+	assert.Equal(t, "team: data ${OTHER}", string(b))
+}
+
+func TestTemplateSourceValidate(t *testing.T) {
+	assert.NoError(t, TemplateSource{Name: "acme", URL: "https://example.com/templates.git", Branch: "main"}.Validate())
+	assert.NoError(t, TemplateSource{Name: "acme", Path: "/templates"}.Validate())
+	assert.Error(t, TemplateSource{Name: "acme/templates", Path: "/templates"}.Validate())
+	assert.Error(t, TemplateSource{Name: "acme"}.Validate())
+	assert.Error(t, TemplateSource{Name: "acme", URL: "https://example.com/templates.git", Path: "/t"}.Validate())
+	assert.Error(t, TemplateSource{Name: "acme", Path: "/templates", Branch: "main"}.Validate())
+}
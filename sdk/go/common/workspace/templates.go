@@ -67,6 +67,7 @@ type TemplateRepository struct {
 	Root         string // The full path to the root directory of the repository.
 	SubDirectory string // The full path to the sub directory within the repository.
 	ShouldDelete bool   // Whether the root directory should be deleted.
+	Source       string // The name of the registered template source, if any, which qualifies template names.
 }
 
 // Delete deletes the template repository.
@@ -96,7 +97,7 @@ func (repo TemplateRepository) Templates() ([]Template, error) {
 	if err != nil && !os.IsNotExist(err) {
 		return nil, err
 	} else if err == nil {
-		return []Template{template}, nil
+		return []Template{repo.qualify(template)}, nil
 	}
 
 	// Otherwise, read all subdirectories to find the ones
@@ -120,13 +121,21 @@ func (repo TemplateRepository) Templates() ([]Template, error) {
 			if err != nil && !os.IsNotExist(err) {
 				return nil, err
 			} else if err == nil {
-				result = append(result, template)
+				result = append(result, repo.qualify(template))
 			}
 		}
 	}
 	return result, nil
 }
 
+// qualify prefixes the name of a template from a registered template source with the name of the source.
+func (repo TemplateRepository) qualify(template Template) Template {
+	if repo.Source != "" {
+		template.Name = repo.Source + "/" + template.Name
+	}
+	return template
+}
+
 // PolicyTemplates lists the policy templates in the repository.
 func (repo TemplateRepository) PolicyTemplates() ([]PolicyPackTemplate, error) {
 	path := repo.SubDirectory
@@ -185,6 +194,7 @@ type Template struct {
 	Quickstart  string                                // Optional text to be displayed after template creation.
 	Config      map[string]ProjectTemplateConfigValue // Optional template config.
 	Important   bool                                  // Indicates whether the template should be listed by default.
+	Parameters  []ProjectTemplateParameter            // Optional parameters substituted into the template's files.
 
 	ProjectName        string // Name of the project.
 	ProjectDescription string // Optional description of the project.
@@ -239,6 +249,11 @@ func RetrieveTemplates(templateNamePathOrURL string, offline bool,
 	if isTemplateFileOrDirectory(templateNamePathOrURL) {
 		return retrieveFileTemplates(templateNamePathOrURL)
 	}
+	if templateKind == TemplateKindPulumiProject {
+		if repo, ok, err := retrieveSourceTemplates(templateNamePathOrURL, offline); ok || err != nil {
+			return repo, err
+		}
+	}
 	return retrievePulumiTemplates(templateNamePathOrURL, offline, templateKind)
 }
 
@@ -394,6 +409,7 @@ func LoadTemplate(path string) (Template, error) {
 		template.Quickstart = proj.Template.Quickstart
 		template.Config = proj.Template.Config
 		template.Important = proj.Template.Important
+		template.Parameters = proj.Template.Parameters
 	}
 	if proj.Description != nil {
 		template.ProjectDescription = *proj.Description
@@ -426,6 +442,14 @@ func CopyTemplateFilesDryRun(sourceDir, destDir, projectName string) error {
 func CopyTemplateFiles(
 	sourceDir, destDir string, force bool, projectName string, projectDescription string) error {
 
+	return CopyTemplateFilesWithParameters(sourceDir, destDir, force, projectName, projectDescription, nil)
+}
+
+// CopyTemplateFilesWithParameters copies a template to a destination directory, replacing each ${NAME} in the
+// contents of the template's files with the value of the template parameter NAME.
+func CopyTemplateFilesWithParameters(sourceDir, destDir string, force bool, projectName string,
+	projectDescription string, parameters map[string]string) error {
+
 	return walkFiles(sourceDir, destDir, projectName,
 		func(info os.FileInfo, source string, dest string) error {
 			if info.IsDir() {
@@ -443,7 +467,7 @@ func CopyTemplateFiles(
 			result := b
 			if !isBinary(b) {
 				transformed := transform(string(b), projectName, projectDescription)
-				result = []byte(transformed)
+				result = []byte(transformParameters(transformed, parameters))
 			}
 
 			// Write to the destination file.
@@ -709,6 +733,23 @@ func transform(content string, projectName string, projectDescription string) st
 	return content
 }
 
+// templateParameterRegexp matches references to template parameters, e.g. ${REGION}.
+var templateParameterRegexp = regexp.MustCompile(`\$\{([A-Za-z_][A-Za-z0-9_]*)\}`)
+
+// transformParameters returns a new string with each reference to a template parameter replaced by the parameter's
+// value. References to unknown parameters are left as-is, and values are not themselves transformed.
+func transformParameters(content string, parameters map[string]string) string {
+	if len(parameters) == 0 {
+		return content
+	}
+	return templateParameterRegexp.ReplaceAllStringFunc(content, func(ref string) string {
+		if value, ok := parameters[ref[2:len(ref)-1]]; ok {
+			return value
+		}
+		return ref
+	})
+}
+
 // writeAllBytes writes the bytes to the specified file, with an option to overwrite.
 func writeAllBytes(filename string, bytes []byte, overwrite bool) error {
 	flag := os.O_WRONLY | os.O_CREATE
@@ -0,0 +1,468 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package policy
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	pbempty "github.com/golang/protobuf/ptypes/empty"
+	pbstruct "github.com/golang/protobuf/ptypes/struct"
+	"github.com/pkg/errors"
+
+	"github.com/pulumi/pulumi/sdk/v2/go/common/resource"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/resource/plugin"
+	pulumirpc "github.com/pulumi/pulumi/sdk/v2/proto/go"
+)
+
+// analyzer serves a policy pack over the analyzer gRPC interface.
+type analyzer struct {
+	pack *PolicyPack
+
+	configLock sync.RWMutex
+	config     map[string]policyConfig // the configuration of each policy, keyed by policy name.
+}
+
+// policyConfig is the configuration of a single policy, as set by the engine.
+type policyConfig struct {
+	enforcementLevel EnforcementLevel
+	properties       map[string]interface{}
+}
+
+// NewAnalyzer returns an analyzer gRPC server that runs the given policy pack. Most policy packs should use Main
+// instead, which serves the analyzer as a plugin.
+func NewAnalyzer(pack *PolicyPack) (pulumirpc.AnalyzerServer, error) {
+	if err := validatePolicyPack(pack); err != nil {
+		return nil, err
+	}
+	return &analyzer{pack: pack, config: map[string]policyConfig{}}, nil
+}
+
+// validatePolicyPack checks that the policy pack is well-formed.
+func validatePolicyPack(pack *PolicyPack) error {
+	if pack == nil || pack.Name == "" {
+		return errors.New("a policy pack must have a name")
+	}
+	if err := validateEnforcementLevel(pack.EnforcementLevel); err != nil {
+		return errors.Wrapf(err, "policy pack '%s'", pack.Name)
+	}
+
+	names := map[string]bool{}
+	for _, p := range pack.Policies {
+		switch p := p.(type) {
+		case *ResourceValidationPolicy:
+			if p.Validate == nil && p.Remediate == nil {
+				return errors.Errorf("policy '%s' must have a Validate or Remediate function", p.Name)
+			}
+		case *StackValidationPolicy:
+			if p.Validate == nil {
+				return errors.Errorf("policy '%s' must have a Validate function", p.Name)
+			}
+		default:
+			return errors.Errorf("unsupported policy type %T", p)
+		}
+
+		info := p.info()
+		if info.Name == "" {
+			return errors.New("a policy must have a name")
+		}
+		if names[info.Name] {
+			return errors.Errorf("duplicate policy name '%s'", info.Name)
+		}
+		names[info.Name] = true
+		if err := validateEnforcementLevel(info.EnforcementLevel); err != nil {
+			return errors.Wrapf(err, "policy '%s'", info.Name)
+		}
+	}
+	return nil
+}
+
+func validateEnforcementLevel(level EnforcementLevel) error {
+	if level != "" && !level.IsValid() {
+		return errors.Errorf("invalid enforcement level '%s'", level)
+	}
+	return nil
+}
+
+// enforcementLevel returns the effective enforcement level of the given policy, taking into account any configured
+// level and the pack's default.
+func (a *analyzer) enforcementLevel(info *policyInfo) EnforcementLevel {
+	a.configLock.RLock()
+	defer a.configLock.RUnlock()
+
+	if c, ok := a.config[info.Name]; ok && c.enforcementLevel != "" {
+		return c.enforcementLevel
+	}
+	if info.EnforcementLevel != "" {
+		return info.EnforcementLevel
+	}
+	if a.pack.EnforcementLevel != "" {
+		return a.pack.EnforcementLevel
+	}
+	return Advisory
+}
+
+// policyProperties returns the configured properties of the given policy.
+func (a *analyzer) policyProperties(info *policyInfo) map[string]interface{} {
+	a.configLock.RLock()
+	defer a.configLock.RUnlock()
+
+	return a.config[info.Name].properties
+}
+
+// runPolicy runs the given policy function, collecting any violations it reports. A panic in the policy is reported
+// as an error rather than taking down the plugin.
+func (a *analyzer) runPolicy(info *policyInfo, level EnforcementLevel, defaultURN resource.URN,
+	run func(report ReportViolation)) (diags []*pulumirpc.AnalyzeDiagnostic, err error) {
+
+	defer func() {
+		if r := recover(); r != nil {
+			err = errors.Errorf("policy '%s' panicked: %v", info.Name, r)
+		}
+	}()
+
+	run(func(message string, urn resource.URN) {
+		if urn == "" {
+			urn = defaultURN
+		}
+		diags = append(diags, &pulumirpc.AnalyzeDiagnostic{
+			PolicyName:        info.Name,
+			PolicyPackName:    a.pack.Name,
+			PolicyPackVersion: a.pack.Version,
+			Description:       info.Description,
+			Message:           message,
+			EnforcementLevel:  marshalEnforcementLevel(level),
+			Urn:               string(urn),
+		})
+	})
+	return diags, nil
+}
+
+// Analyze runs the pack's resource validation policies against a single resource.
+func (a *analyzer) Analyze(ctx context.Context, req *pulumirpc.AnalyzeRequest) (*pulumirpc.AnalyzeResponse, error) {
+	res, err := unmarshalResource(req.GetType(), req.GetUrn(), req.GetName(), req.GetProperties(), req.GetOptions(),
+		req.GetProvider())
+	if err != nil {
+		return nil, err
+	}
+
+	var diags []*pulumirpc.AnalyzeDiagnostic
+	for _, p := range a.pack.Policies {
+		policy, ok := p.(*ResourceValidationPolicy)
+		if !ok {
+			continue
+		}
+		info := p.info()
+		level := a.enforcementLevel(info)
+		if level == Disabled || policy.Validate == nil {
+			continue
+		}
+		if level == Remediate {
+			// Remediations are applied by the Remediate call. Policies that cannot remediate are enforced instead.
+			if policy.Remediate != nil {
+				continue
+			}
+			level = Mandatory
+		}
+
+		args := ResourceValidationArgs{Resource: res, config: a.policyProperties(info)}
+		policyDiags, err := a.runPolicy(info, level, res.URN, func(report ReportViolation) {
+			policy.Validate(args, report)
+		})
+		if err != nil {
+			return nil, err
+		}
+		diags = append(diags, policyDiags...)
+	}
+
+	return &pulumirpc.AnalyzeResponse{Diagnostics: diags}, nil
+}
+
+// Remediate runs the remediations of the pack's resource validation policies whose enforcement level is Remediate
+// against a single resource. Each remediation sees the inputs produced by the previous one, and only remediations that
+// change the inputs are returned.
+func (a *analyzer) Remediate(ctx context.Context,
+	req *pulumirpc.AnalyzeRequest) (*pulumirpc.RemediateResponse, error) {
+
+	res, err := unmarshalResource(req.GetType(), req.GetUrn(), req.GetName(), req.GetProperties(), req.GetOptions(),
+		req.GetProvider())
+	if err != nil {
+		return nil, err
+	}
+
+	var remediations []*pulumirpc.Remediation
+	for _, p := range a.pack.Policies {
+		policy, ok := p.(*ResourceValidationPolicy)
+		if !ok || policy.Remediate == nil {
+			continue
+		}
+		info := p.info()
+		if a.enforcementLevel(info) != Remediate {
+			continue
+		}
+
+		args := ResourceValidationArgs{Resource: res, config: a.policyProperties(info)}
+		props, err := a.runRemediation(info, func() (resource.PropertyMap, error) {
+			return policy.Remediate(args)
+		})
+		if err != nil {
+			return nil, err
+		}
+		if props == nil || props.DeepEquals(res.Props) {
+			continue
+		}
+
+		mprops, err := plugin.MarshalProperties(props, unmarshalOptions)
+		if err != nil {
+			return nil, errors.Wrapf(err, "marshaling remediated properties of policy '%s'", info.Name)
+		}
+		remediations = append(remediations, &pulumirpc.Remediation{
+			PolicyName:        info.Name,
+			PolicyPackName:    a.pack.Name,
+			PolicyPackVersion: a.pack.Version,
+			Description:       info.Description,
+			Properties:        mprops,
+		})
+		res.Props = props
+	}
+
+	return &pulumirpc.RemediateResponse{Remediations: remediations}, nil
+}
+
+// runRemediation runs the given remediation function. As with runPolicy, a panic is reported as an error.
+func (a *analyzer) runRemediation(info *policyInfo,
+	run func() (resource.PropertyMap, error)) (props resource.PropertyMap, err error) {
+
+	defer func() {
+		if r := recover(); r != nil {
+			err = errors.Errorf("policy '%s' panicked: %v", info.Name, r)
+		}
+	}()
+
+	props, err = run()
+	if err != nil {
+		return nil, errors.Wrapf(err, "policy '%s' failed to remediate", info.Name)
+	}
+	return props, nil
+}
+
+// AnalyzeStack runs the pack's stack validation policies against all of the resources in a stack.
+func (a *analyzer) AnalyzeStack(ctx context.Context,
+	req *pulumirpc.AnalyzeStackRequest) (*pulumirpc.AnalyzeResponse, error) {
+
+	resources := make([]Resource, len(req.GetResources()))
+	for i, r := range req.GetResources() {
+		res, err := unmarshalResource(r.GetType(), r.GetUrn(), r.GetName(), r.GetProperties(), r.GetOptions(),
+			r.GetProvider())
+		if err != nil {
+			return nil, err
+		}
+		res.Parent = resource.URN(r.GetParent())
+		for _, dep := range r.GetDependencies() {
+			res.Dependencies = append(res.Dependencies, resource.URN(dep))
+		}
+		resources[i] = res
+	}
+
+	var diags []*pulumirpc.AnalyzeDiagnostic
+	for _, p := range a.pack.Policies {
+		policy, ok := p.(*StackValidationPolicy)
+		if !ok {
+			continue
+		}
+		info := p.info()
+		level := a.enforcementLevel(info)
+		if level == Disabled {
+			continue
+		}
+
+		args := StackValidationArgs{Resources: resources, config: a.policyProperties(info)}
+		policyDiags, err := a.runPolicy(info, level, "", func(report ReportViolation) {
+			policy.Validate(args, report)
+		})
+		if err != nil {
+			return nil, err
+		}
+		diags = append(diags, policyDiags...)
+	}
+
+	return &pulumirpc.AnalyzeResponse{Diagnostics: diags}, nil
+}
+
+// GetAnalyzerInfo returns metadata about the policy pack and its policies.
+func (a *analyzer) GetAnalyzerInfo(ctx context.Context, req *pbempty.Empty) (*pulumirpc.AnalyzerInfo, error) {
+	policies := make([]*pulumirpc.PolicyInfo, len(a.pack.Policies))
+	for i, p := range a.pack.Policies {
+		info := p.info()
+
+		var schema *pulumirpc.PolicyConfigSchema
+		if info.ConfigSchema != nil {
+			props, err := marshalMap(info.ConfigSchema.Properties)
+			if err != nil {
+				return nil, errors.Wrapf(err, "marshaling config schema of policy '%s'", info.Name)
+			}
+			schema = &pulumirpc.PolicyConfigSchema{
+				Properties: props,
+				Required:   info.ConfigSchema.Required,
+			}
+		}
+
+		policies[i] = &pulumirpc.PolicyInfo{
+			Name:             info.Name,
+			Description:      info.Description,
+			EnforcementLevel: marshalEnforcementLevel(a.enforcementLevel(info)),
+			ConfigSchema:     schema,
+		}
+	}
+
+	return &pulumirpc.AnalyzerInfo{
+		Name:           a.pack.Name,
+		Version:        a.pack.Version,
+		Policies:       policies,
+		SupportsConfig: true,
+	}, nil
+}
+
+// GetPluginInfo returns generic information about this plugin.
+func (a *analyzer) GetPluginInfo(ctx context.Context, req *pbempty.Empty) (*pulumirpc.PluginInfo, error) {
+	return &pulumirpc.PluginInfo{Version: a.pack.Version}, nil
+}
+
+// Configure sets the enforcement level and properties of the pack's policies.
+func (a *analyzer) Configure(ctx context.Context, req *pulumirpc.ConfigureAnalyzerRequest) (*pbempty.Empty, error) {
+	config := map[string]policyConfig{}
+	for name, c := range req.GetPolicyConfig() {
+		level, err := unmarshalEnforcementLevel(c.GetEnforcementLevel())
+		if err != nil {
+			return nil, errors.Wrapf(err, "configuring policy '%s'", name)
+		}
+		props, err := unmarshalMap(c.GetProperties())
+		if err != nil {
+			return nil, errors.Wrapf(err, "configuring policy '%s'", name)
+		}
+		config[name] = policyConfig{enforcementLevel: level, properties: props}
+	}
+
+	a.configLock.Lock()
+	defer a.configLock.Unlock()
+	a.config = config
+
+	return &pbempty.Empty{}, nil
+}
+
+var unmarshalOptions = plugin.MarshalOptions{KeepUnknowns: true, KeepSecrets: true}
+
+func unmarshalResource(t, urn, name string, props *pbstruct.Struct, opts *pulumirpc.AnalyzerResourceOptions,
+	provider *pulumirpc.AnalyzerProviderResource) (Resource, error) {
+
+	properties, err := plugin.UnmarshalProperties(props, unmarshalOptions)
+	if err != nil {
+		return Resource{}, errors.Wrapf(err, "unmarshaling properties of '%s'", urn)
+	}
+
+	res := Resource{
+		Type:    t,
+		URN:     resource.URN(urn),
+		Name:    name,
+		Props:   properties,
+		Options: unmarshalResourceOptions(opts),
+	}
+
+	if provider != nil {
+		providerProps, err := plugin.UnmarshalProperties(provider.GetProperties(), unmarshalOptions)
+		if err != nil {
+			return Resource{}, errors.Wrapf(err, "unmarshaling provider properties of '%s'", urn)
+		}
+		res.Provider = &ProviderResource{
+			Type:  provider.GetType(),
+			URN:   resource.URN(provider.GetUrn()),
+			Name:  provider.GetName(),
+			Props: providerProps,
+		}
+	}
+
+	return res, nil
+}
+
+func unmarshalResourceOptions(opts *pulumirpc.AnalyzerResourceOptions) ResourceOptions {
+	if opts == nil {
+		return ResourceOptions{}
+	}
+
+	result := ResourceOptions{
+		Protect:                 opts.GetProtect(),
+		IgnoreChanges:           opts.GetIgnoreChanges(),
+		AdditionalSecretOutputs: opts.GetAdditionalSecretOutputs(),
+	}
+	if opts.GetDeleteBeforeReplaceDefined() {
+		deleteBeforeReplace := opts.GetDeleteBeforeReplace()
+		result.DeleteBeforeReplace = &deleteBeforeReplace
+	}
+	for _, alias := range opts.GetAliases() {
+		result.Aliases = append(result.Aliases, resource.URN(alias))
+	}
+	if timeouts := opts.GetCustomTimeouts(); timeouts != nil {
+		result.CustomTimeouts = resource.CustomTimeouts{
+			Create: timeouts.GetCreate(),
+			Update: timeouts.GetUpdate(),
+			Delete: timeouts.GetDelete(),
+		}
+	}
+	return result
+}
+
+func marshalMap(m map[string]interface{}) (*pbstruct.Struct, error) {
+	return plugin.MarshalProperties(resource.NewPropertyMapFromMap(m), plugin.MarshalOptions{})
+}
+
+func unmarshalMap(s *pbstruct.Struct) (map[string]interface{}, error) {
+	if s == nil {
+		return nil, nil
+	}
+	props, err := plugin.UnmarshalProperties(s, plugin.MarshalOptions{})
+	if err != nil {
+		return nil, err
+	}
+	return props.Mappable(), nil
+}
+
+func marshalEnforcementLevel(level EnforcementLevel) pulumirpc.EnforcementLevel {
+	switch level {
+	case Mandatory:
+		return pulumirpc.EnforcementLevel_MANDATORY
+	case Remediate:
+		return pulumirpc.EnforcementLevel_REMEDIATE
+	case Disabled:
+		return pulumirpc.EnforcementLevel_DISABLED
+	default:
+		return pulumirpc.EnforcementLevel_ADVISORY
+	}
+}
+
+func unmarshalEnforcementLevel(level pulumirpc.EnforcementLevel) (EnforcementLevel, error) {
+	switch level {
+	case pulumirpc.EnforcementLevel_ADVISORY:
+		return Advisory, nil
+	case pulumirpc.EnforcementLevel_MANDATORY:
+		return Mandatory, nil
+	case pulumirpc.EnforcementLevel_REMEDIATE:
+		return Remediate, nil
+	case pulumirpc.EnforcementLevel_DISABLED:
+		return Disabled, nil
+	default:
+		return "", fmt.Errorf("invalid enforcement level %d", level)
+	}
+}
@@ -0,0 +1,198 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package policy is the Go SDK for authoring Pulumi policy packs. A policy pack is a Go program whose main function
+// declares a PolicyPack and passes it to Main, which serves the pack's policies to the Pulumi engine as an analyzer
+// plugin. Policy packs written with this package use the `go` runtime in their PulumiPolicy.yaml file, and may be
+// run with `pulumi up --policy-pack <dir>` or published with `pulumi policy publish`.
+package policy
+
+import (
+	"encoding/json"
+
+	"github.com/pulumi/pulumi/sdk/v2/go/common/apitype"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/resource"
+)
+
+// EnforcementLevel indicates how a policy violation is enforced: "advisory" violations are reported, "mandatory"
+// violations block the deployment, "remediate" policies fix up resources rather than reporting violations, and
+// "disabled" policies are not run.
+type EnforcementLevel = apitype.EnforcementLevel
+
+const (
+	// Advisory is an enforcement level where the violation is reported but does not block the deployment.
+	Advisory = apitype.Advisory
+	// Mandatory is an enforcement level that blocks the deployment if the policy is violated.
+	Mandatory = apitype.Mandatory
+	// Remediate is an enforcement level that applies a policy's remediation to the resource's inputs. Policies without
+	// a remediation are enforced as if they were Mandatory.
+	Remediate = apitype.Remediate
+	// Disabled is an enforcement level that disables the policy.
+	Disabled = apitype.Disabled
+)
+
+// PolicyPack is a named collection of policies.
+type PolicyPack struct {
+	// Name is the name of the policy pack. It must be unique within an organization.
+	Name string
+	// Version is the version of the policy pack. The version in PulumiPolicy.yaml, if any, takes precedence.
+	Version string
+	// EnforcementLevel is the default enforcement level of the pack's policies. Defaults to Advisory.
+	EnforcementLevel EnforcementLevel
+	// Policies are the policies in the pack. Each is either a *ResourceValidationPolicy or a
+	// *StackValidationPolicy.
+	Policies []Policy
+}
+
+// Policy is a single policy within a policy pack.
+type Policy interface {
+	info() *policyInfo
+}
+
+// policyInfo holds the metadata shared by all kinds of policies.
+type policyInfo struct {
+	Name             string
+	Description      string
+	EnforcementLevel EnforcementLevel
+	ConfigSchema     *ConfigSchema
+}
+
+// ConfigSchema describes the configuration that a policy accepts, as JSON schema.
+type ConfigSchema struct {
+	// Properties maps each configuration property to the JSON schema that describes it, e.g.
+	// `map[string]interface{}{"type": "integer", "minimum": 1}`.
+	Properties map[string]interface{}
+	// Required lists the configuration properties that must be set.
+	Required []string
+}
+
+// ReportViolation reports a policy violation with the given message. For resource validation policies the URN may
+// be empty, in which case the violation is reported against the resource being validated.
+type ReportViolation func(message string, urn resource.URN)
+
+// ResourceValidationPolicy validates each resource as it is registered, before it is created or updated.
+type ResourceValidationPolicy struct {
+	// Name is the name of the policy, which must be unique within its policy pack.
+	Name string
+	// Description is a brief description of the policy rule, e.g. "S3 buckets must be encrypted".
+	Description string
+	// EnforcementLevel is the enforcement level of the policy. Defaults to the pack's enforcement level.
+	EnforcementLevel EnforcementLevel
+	// ConfigSchema describes the policy's configuration, if it accepts any.
+	ConfigSchema *ConfigSchema
+	// Validate validates a single resource, reporting any violations.
+	Validate func(args ResourceValidationArgs, reportViolation ReportViolation)
+	// Remediate, if set, returns the resource's inputs transformed to comply with the policy, or nil if the resource
+	// needs no changes. It is only run when the policy's enforcement level is Remediate, in which case it is run
+	// instead of Validate.
+	Remediate func(args ResourceValidationArgs) (resource.PropertyMap, error)
+}
+
+func (p *ResourceValidationPolicy) info() *policyInfo {
+	return &policyInfo{p.Name, p.Description, p.EnforcementLevel, p.ConfigSchema}
+}
+
+// StackValidationPolicy validates all of the resources in a stack at the end of a preview or update.
+type StackValidationPolicy struct {
+	// Name is the name of the policy, which must be unique within its policy pack.
+	Name string
+	// Description is a brief description of the policy rule, e.g. "At most 10 instances".
+	Description string
+	// EnforcementLevel is the enforcement level of the policy. Defaults to the pack's enforcement level.
+	EnforcementLevel EnforcementLevel
+	// ConfigSchema describes the policy's configuration, if it accepts any.
+	ConfigSchema *ConfigSchema
+	// Validate validates the stack's resources, reporting any violations.
+	Validate func(args StackValidationArgs, reportViolation ReportViolation)
+}
+
+func (p *StackValidationPolicy) info() *policyInfo {
+	return &policyInfo{p.Name, p.Description, p.EnforcementLevel, p.ConfigSchema}
+}
+
+// ResourceOptions are the options with which a resource was registered.
+type ResourceOptions struct {
+	Protect                 bool
+	IgnoreChanges           []string
+	DeleteBeforeReplace     *bool
+	AdditionalSecretOutputs []string
+	Aliases                 []resource.URN
+	CustomTimeouts          resource.CustomTimeouts
+}
+
+// ProviderResource describes the provider of a resource.
+type ProviderResource struct {
+	Type  string
+	URN   resource.URN
+	Name  string
+	Props resource.PropertyMap
+}
+
+// Resource is a resource that is being validated.
+type Resource struct {
+	// Type is the resource's type token, e.g. "aws:s3/bucket:Bucket".
+	Type string
+	// URN is the resource's URN.
+	URN resource.URN
+	// Name is the resource's name.
+	Name string
+	// Props are the resource's properties: its inputs when validating a single resource, and its outputs when
+	// validating a stack. Unknown values, which may appear during previews, and secrets are preserved.
+	Props resource.PropertyMap
+	// Options are the options with which the resource was registered.
+	Options ResourceOptions
+	// Provider is the resource's provider, if known.
+	Provider *ProviderResource
+	// Parent is the URN of the resource's parent, if any. It is only set when validating a stack.
+	Parent resource.URN
+	// Dependencies are the URNs of the resources on which this resource depends. They are only set when validating
+	// a stack.
+	Dependencies []resource.URN
+}
+
+// ResourceValidationArgs are the arguments passed to a resource validation policy.
+type ResourceValidationArgs struct {
+	Resource
+
+	config map[string]interface{}
+}
+
+// GetConfig decodes the policy's configuration into the given value, as if by json.Unmarshal.
+func (args ResourceValidationArgs) GetConfig(v interface{}) error {
+	return decodeConfig(args.config, v)
+}
+
+// StackValidationArgs are the arguments passed to a stack validation policy.
+type StackValidationArgs struct {
+	// Resources are all of the resources in the stack.
+	Resources []Resource
+
+	config map[string]interface{}
+}
+
+// GetConfig decodes the policy's configuration into the given value, as if by json.Unmarshal.
+func (args StackValidationArgs) GetConfig(v interface{}) error {
+	return decodeConfig(args.config, v)
+}
+
+func decodeConfig(config map[string]interface{}, v interface{}) error {
+	if config == nil {
+		config = map[string]interface{}{}
+	}
+	bytes, err := json.Marshal(config)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(bytes, v)
+}
@@ -0,0 +1,249 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package policy
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	pbempty "github.com/golang/protobuf/ptypes/empty"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pulumi/pulumi/sdk/v2/go/common/resource"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/resource/plugin"
+	pulumirpc "github.com/pulumi/pulumi/sdk/v2/proto/go"
+)
+
+func testPolicyPack() *PolicyPack {
+	return &PolicyPack{
+		Name:    "test-pack",
+		Version: "1.0.0",
+		Policies: []Policy{
+			&ResourceValidationPolicy{
+				Name:             "no-public-buckets",
+				Description:      "Buckets must not be public.",
+				EnforcementLevel: Mandatory,
+				Validate: func(args ResourceValidationArgs, reportViolation ReportViolation) {
+					if args.Type == "test:index:Bucket" && args.Props["acl"].DeepEquals(
+						resource.NewStringProperty("public-read")) {
+						reportViolation("Bucket is public.", "")
+					}
+				},
+			},
+			&StackValidationPolicy{
+				Name:        "max-buckets",
+				Description: "Limits the number of buckets.",
+				ConfigSchema: &ConfigSchema{
+					Properties: map[string]interface{}{
+						"max": map[string]interface{}{"type": "integer"},
+					},
+				},
+				Validate: func(args StackValidationArgs, reportViolation ReportViolation) {
+					var config struct {
+						Max int `json:"max"`
+					}
+					if err := args.GetConfig(&config); err != nil {
+						panic(err)
+					}
+					if config.Max > 0 && len(args.Resources) > config.Max {
+						reportViolation(fmt.Sprintf("Too many buckets: %d.", len(args.Resources)), "")
+					}
+				},
+			},
+		},
+	}
+}
+
+func TestNewAnalyzerValidatesPack(t *testing.T) {
+	_, err := NewAnalyzer(&PolicyPack{})
+	assert.Error(t, err)
+
+	_, err = NewAnalyzer(&PolicyPack{
+		Name: "pack",
+		Policies: []Policy{
+			&ResourceValidationPolicy{Name: "a", Validate: func(ResourceValidationArgs, ReportViolation) {}},
+			&StackValidationPolicy{Name: "a", Validate: func(StackValidationArgs, ReportViolation) {}},
+		},
+	})
+	assert.EqualError(t, err, "duplicate policy name 'a'")
+
+	_, err = NewAnalyzer(&PolicyPack{
+		Name:     "pack",
+		Policies: []Policy{&ResourceValidationPolicy{Name: "a", EnforcementLevel: "strict"}},
+	})
+	assert.Error(t, err)
+
+	_, err = NewAnalyzer(testPolicyPack())
+	assert.NoError(t, err)
+}
+
+func TestAnalyze(t *testing.T) {
+	server, err := NewAnalyzer(testPolicyPack())
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	props, err := plugin.MarshalProperties(resource.PropertyMap{
+		"acl": resource.NewStringProperty("public-read"),
+	}, plugin.MarshalOptions{KeepUnknowns: true, KeepSecrets: true})
+	assert.NoError(t, err)
+
+	urn := "urn:pulumi:stack::project::test:index:Bucket::b"
+	resp, err := server.Analyze(context.Background(), &pulumirpc.AnalyzeRequest{
+		Type:       "test:index:Bucket",
+		Urn:        urn,
+		Name:       "b",
+		Properties: props,
+	})
+	assert.NoError(t, err)
+	if assert.Len(t, resp.Diagnostics, 1) {
+		diag := resp.Diagnostics[0]
+		assert.Equal(t, "no-public-buckets", diag.PolicyName)
+		assert.Equal(t, "test-pack", diag.PolicyPackName)
+		assert.Equal(t, "Bucket is public.", diag.Message)
+		assert.Equal(t, pulumirpc.EnforcementLevel_MANDATORY, diag.EnforcementLevel)
+		assert.Equal(t, urn, diag.Urn)
+	}
+
+	// Disabling the policy through configuration skips it.
+	_, err = server.Configure(context.Background(), &pulumirpc.ConfigureAnalyzerRequest{
+		PolicyConfig: map[string]*pulumirpc.PolicyConfig{
+			"no-public-buckets": {EnforcementLevel: pulumirpc.EnforcementLevel_DISABLED},
+		},
+	})
+	assert.NoError(t, err)
+	resp, err = server.Analyze(context.Background(), &pulumirpc.AnalyzeRequest{
+		Type:       "test:index:Bucket",
+		Urn:        urn,
+		Properties: props,
+	})
+	assert.NoError(t, err)
+	assert.Empty(t, resp.Diagnostics)
+}
+
+func TestAnalyzeStackWithConfig(t *testing.T) {
+	server, err := NewAnalyzer(testPolicyPack())
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	req := &pulumirpc.AnalyzeStackRequest{
+		Resources: []*pulumirpc.AnalyzerResource{
+			{Type: "test:index:Bucket", Urn: "urn:pulumi:stack::project::test:index:Bucket::a"},
+			{Type: "test:index:Bucket", Urn: "urn:pulumi:stack::project::test:index:Bucket::b"},
+		},
+	}
+
+	// Without configuration, the policy does not report anything.
+	resp, err := server.AnalyzeStack(context.Background(), req)
+	assert.NoError(t, err)
+	assert.Empty(t, resp.Diagnostics)
+
+	config, err := marshalMap(map[string]interface{}{"max": 1})
+	assert.NoError(t, err)
+	_, err = server.Configure(context.Background(), &pulumirpc.ConfigureAnalyzerRequest{
+		PolicyConfig: map[string]*pulumirpc.PolicyConfig{
+			"max-buckets": {EnforcementLevel: pulumirpc.EnforcementLevel_ADVISORY, Properties: config},
+		},
+	})
+	assert.NoError(t, err)
+
+	resp, err = server.AnalyzeStack(context.Background(), req)
+	assert.NoError(t, err)
+	if assert.Len(t, resp.Diagnostics, 1) {
+		assert.Equal(t, "Too many buckets: 2.", resp.Diagnostics[0].Message)
+		assert.Equal(t, pulumirpc.EnforcementLevel_ADVISORY, resp.Diagnostics[0].EnforcementLevel)
+	}
+}
+
+func TestGetAnalyzerInfo(t *testing.T) {
+	server, err := NewAnalyzer(testPolicyPack())
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	info, err := server.GetAnalyzerInfo(context.Background(), &pbempty.Empty{})
+	assert.NoError(t, err)
+	assert.Equal(t, "test-pack", info.Name)
+	assert.Equal(t, "1.0.0", info.Version)
+	assert.True(t, info.SupportsConfig)
+	if assert.Len(t, info.Policies, 2) {
+		assert.Equal(t, pulumirpc.EnforcementLevel_MANDATORY, info.Policies[0].EnforcementLevel)
+		assert.Nil(t, info.Policies[0].ConfigSchema)
+		assert.Equal(t, pulumirpc.EnforcementLevel_ADVISORY, info.Policies[1].EnforcementLevel)
+		if assert.NotNil(t, info.Policies[1].ConfigSchema) {
+			schema, err := unmarshalMap(info.Policies[1].ConfigSchema.Properties)
+			assert.NoError(t, err)
+			assert.Equal(t, map[string]interface{}{"max": map[string]interface{}{"type": "integer"}}, schema)
+		}
+	}
+}
+
+func TestRemediate(t *testing.T) {
+	server, err := NewAnalyzer(&PolicyPack{
+		Name:    "test-pack",
+		Version: "1.0.0",
+		Policies: []Policy{
+			&ResourceValidationPolicy{
+				Name:             "private-buckets",
+				EnforcementLevel: Remediate,
+				Remediate: func(args ResourceValidationArgs) (resource.PropertyMap, error) {
+					if args.Props["acl"].DeepEquals(resource.NewStringProperty("private")) {
+						return nil, nil
+					}
+					props := args.Props.Copy()
+					props["acl"] = resource.NewStringProperty("private")
+					return props, nil
+				},
+			},
+		},
+	})
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	props, err := plugin.MarshalProperties(resource.PropertyMap{
+		"acl": resource.NewStringProperty("public-read"),
+	}, plugin.MarshalOptions{KeepUnknowns: true, KeepSecrets: true})
+	assert.NoError(t, err)
+
+	req := &pulumirpc.AnalyzeRequest{
+		Type:       "test:index:Bucket",
+		Urn:        "urn:pulumi:stack::project::test:index:Bucket::b",
+		Properties: props,
+	}
+	resp, err := server.Remediate(context.Background(), req)
+	assert.NoError(t, err)
+	if assert.Len(t, resp.Remediations, 1) {
+		remediation := resp.Remediations[0]
+		assert.Equal(t, "private-buckets", remediation.PolicyName)
+		assert.Equal(t, "test-pack", remediation.PolicyPackName)
+		remediated, err := plugin.UnmarshalProperties(remediation.Properties, plugin.MarshalOptions{})
+		assert.NoError(t, err)
+		assert.Equal(t, resource.NewStringProperty("private"), remediated["acl"])
+	}
+
+	// Remediations are not applied unless the policy's enforcement level is Remediate.
+	_, err = server.Configure(context.Background(), &pulumirpc.ConfigureAnalyzerRequest{
+		PolicyConfig: map[string]*pulumirpc.PolicyConfig{
+			"private-buckets": {EnforcementLevel: pulumirpc.EnforcementLevel_ADVISORY},
+		},
+	})
+	assert.NoError(t, err)
+	resp, err = server.Remediate(context.Background(), req)
+	assert.NoError(t, err)
+	assert.Empty(t, resp.Remediations)
+}
@@ -0,0 +1,97 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// pulumi-analyzer-policy-go boots policy packs written in Go. The engine launches it in the policy pack's directory
+// with the engine's address, the policy pack's directory, and the runtime options from PulumiPolicy.yaml as flags.
+// It runs the policy pack program, either as a prebuilt binary or via 'go run', which in turn serves the analyzer
+// gRPC interface using the github.com/pulumi/pulumi/sdk/v2/go/policy package and writes its port to stdout.
+package main
+
+import (
+	"flag"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"syscall"
+
+	"github.com/pkg/errors"
+
+	"github.com/pulumi/pulumi/sdk/v2/go/common/util/cmdutil"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/util/executable"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/util/logging"
+)
+
+func findPolicyPack(dir, binary string) (*exec.Cmd, error) {
+	// We default to execution via `go run`. The policy pack may opt in to using a prebuilt binary by specifying
+	// runtime.options.binary in its PulumiPolicy.yaml.
+	if binary != "" {
+		program, err := executable.FindExecutable(binary)
+		if err != nil {
+			return nil, errors.Wrap(err, "expected to find prebuilt executable")
+		}
+		return exec.Command(program), nil
+	}
+
+	logging.V(5).Infof("No prebuilt executable specified, attempting invocation via 'go run'")
+	program, err := executable.FindExecutable("go")
+	if err != nil {
+		return nil, errors.Wrap(err, "problem executing policy pack (could not find 'go')")
+	}
+
+	dir, err = filepath.Abs(dir)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to determine policy pack directory")
+	}
+	goFileSearchPattern := filepath.Join(dir, "*.go")
+	if matches, err := filepath.Glob(goFileSearchPattern); err != nil || len(matches) == 0 {
+		return nil, errors.Errorf("Failed to find go files for 'go run' matching %s", goFileSearchPattern)
+	}
+
+	return exec.Command(program, "run", dir), nil
+}
+
+func main() {
+	// The engine passes the positional arguments before the runtime options, so they are parsed separately.
+	if len(os.Args) < 3 {
+		cmdutil.Exit(errors.New("usage: pulumi-analyzer-policy-go <engine-address> <policy-pack-dir> [options]"))
+	}
+	engineAddress, dir := os.Args[1], os.Args[2]
+
+	flags := flag.NewFlagSet("pulumi-analyzer-policy-go", flag.ExitOnError)
+	binary := flags.String("binary", "", "A relative or an absolute path to a precompiled policy pack to execute")
+	if err := flags.Parse(os.Args[3:]); err != nil {
+		cmdutil.Exit(err)
+	}
+
+	logging.InitLogging(false, 0, false)
+
+	cmd, err := findPolicyPack(dir, *binary)
+	if err != nil {
+		cmdutil.Exit(err)
+	}
+	cmd.Args = append(cmd.Args, engineAddress)
+	cmd.Dir = dir
+	cmd.Stdin, cmd.Stdout, cmd.Stderr = os.Stdin, os.Stdout, os.Stderr
+
+	if err := cmd.Run(); err != nil {
+		if exiterr, ok := err.(*exec.ExitError); ok {
+			// The policy pack has already reported its error, so just propagate its exit code.
+			if status, stok := exiterr.Sys().(syscall.WaitStatus); stok {
+				os.Exit(status.ExitStatus())
+			}
+			cmdutil.Exit(errors.Wrapf(exiterr, "policy pack exited unexpectedly"))
+		}
+		cmdutil.Exit(errors.Wrapf(err, "problem executing policy pack"))
+	}
+}
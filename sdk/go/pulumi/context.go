@@ -327,7 +327,7 @@ func (ctx *Context) ReadResource(
 		var state *structpb.Struct
 		var err error
 		defer func() {
-			res.resolve(ctx.DryRun(), err, inputs, urn, resID, state)
+			res.resolve(ctx.DryRun(), err, inputs, urn, resID, state, nil)
 			ctx.endRPC(err)
 		}()
 
@@ -394,6 +394,12 @@ func (ctx *Context) ReadResource(
 //
 func (ctx *Context) RegisterResource(
 	t, name string, props Input, resource Resource, opts ...ResourceOption) error {
+
+	return ctx.registerResource(t, name, props, resource, false /*remote*/, opts...)
+}
+
+func (ctx *Context) registerResource(
+	t, name string, props Input, resource Resource, remote bool, opts ...ResourceOption) error {
 	if t == "" {
 		return errors.New("resource type argument cannot be empty")
 	} else if name == "" {
@@ -453,9 +459,10 @@ func (ctx *Context) RegisterResource(
 		var urn, resID string
 		var inputs *resourceInputs
 		var state *structpb.Struct
+		var deps map[string][]Resource
 		var err error
 		defer func() {
-			res.resolve(ctx.DryRun(), err, inputs, urn, resID, state)
+			res.resolve(ctx.DryRun(), err, inputs, urn, resID, state, deps)
 			ctx.endRPC(err)
 		}()
 
@@ -465,6 +472,16 @@ func (ctx *Context) RegisterResource(
 			return
 		}
 
+		// Remote components are constructed by their provider, which needs to know about all of the providers that
+		// the component's children should use.
+		var providerRefs map[string]string
+		if remote {
+			providerRefs, err = ctx.resolveProviderReferences(providers)
+			if err != nil {
+				return
+			}
+		}
+
 		logging.V(9).Infof("RegisterResource(%s, %s): Goroutine spawned, RPC call being made", t, name)
 		resp, err := ctx.monitor.RegisterResource(ctx.ctx, &pulumirpc.RegisterResourceRequest{
 			Type:                    t,
@@ -480,10 +497,14 @@ func (ctx *Context) RegisterResource(
 			ImportId:                inputs.importID,
 			CustomTimeouts:          inputs.customTimeouts,
 			IgnoreChanges:           inputs.ignoreChanges,
+			ReplaceOnChanges:        inputs.replaceOnChanges,
+			RetainOnDelete:          inputs.retainOnDelete,
 			Aliases:                 inputs.aliases,
 			AcceptSecrets:           true,
 			AdditionalSecretOutputs: inputs.additionalSecretOutputs,
 			Version:                 inputs.version,
+			Remote:                  remote,
+			Providers:               providerRefs,
 		})
 		if err != nil {
 			logging.V(9).Infof("RegisterResource(%s, %s): error: %v", t, name, err)
@@ -493,6 +514,14 @@ func (ctx *Context) RegisterResource(
 		if resp != nil {
 			urn, resID = resp.Urn, resp.Id
 			state = resp.Object
+
+			// Remote components report the resources on which each of their outputs depend.
+			deps = make(map[string][]Resource, len(resp.GetPropertyDependencies()))
+			for k, propertyDeps := range resp.GetPropertyDependencies() {
+				for _, depURN := range propertyDeps.GetUrns() {
+					deps[k] = append(deps[k], newDependencyResource(URN(depURN)))
+				}
+			}
 		}
 	}()
 
@@ -505,6 +534,15 @@ func (ctx *Context) RegisterComponentResource(
 	return ctx.RegisterResource(t, name, nil, resource, opts...)
 }
 
+// RegisterRemoteComponentResource registers a component resource that is constructed by the provider for its type's
+// package rather than by this program. This allows components authored in one language to be used from another. The
+// component's outputs are resolved from the state returned by the provider.
+func (ctx *Context) RegisterRemoteComponentResource(
+	t, name string, props Input, resource ComponentResource, opts ...ResourceOption) error {
+
+	return ctx.registerResource(t, name, props, resource, true /*remote*/, opts...)
+}
+
 // resourceState contains the results of a resource registration operation.
 type resourceState struct {
 	outputs         map[string]Output
@@ -705,9 +743,10 @@ func makeResourceState(t, name string, resourceV Resource, providers map[string]
 	return state
 }
 
-// resolve resolves the resource outputs using the given error and/or values.
+// resolve resolves the resource outputs using the given error and/or values. Each output also depends on the
+// resources listed for it in deps, if any.
 func (state *resourceState) resolve(dryrun bool, err error, inputs *resourceInputs, urn, id string,
-	result *structpb.Struct) {
+	result *structpb.Struct, deps map[string][]Resource) {
 
 	var inprops resource.PropertyMap
 	if inputs != nil {
@@ -765,6 +804,8 @@ func (state *resourceState) resolve(dryrun bool, err error, inputs *resourceInpu
 			known = !dryrun
 		}
 
+		output.getState().addDependencies(deps[k]...)
+
 		// Allocate storage for the unmarshalled output.
 		dest := reflect.New(output.ElementType()).Elem()
 		secret, err := unmarshalOutput(v, dest)
@@ -789,6 +830,8 @@ type resourceInputs struct {
 	importID                string
 	customTimeouts          *pulumirpc.RegisterResourceRequest_CustomTimeouts
 	ignoreChanges           []string
+	replaceOnChanges        []string
+	retainOnDelete          bool
 	aliases                 []string
 	additionalSecretOutputs []string
 	version                 string
@@ -874,6 +917,8 @@ func (ctx *Context) prepareResourceInputs(props Input, t string,
 		importID:                string(importID),
 		customTimeouts:          getTimeouts(opts.CustomTimeouts),
 		ignoreChanges:           ignoreChanges,
+		replaceOnChanges:        opts.ReplaceOnChanges,
+		retainOnDelete:          opts.RetainOnDelete,
 		aliases:                 aliases,
 		additionalSecretOutputs: additionalSecretOutputs,
 		version:                 version,
@@ -959,6 +1004,19 @@ func (ctx *Context) resolveProviderReference(provider ProviderResource) (string,
 	return string(urn) + "::" + string(id), nil
 }
 
+// resolveProviderReferences resolves each of the given providers to a reference, keyed by package.
+func (ctx *Context) resolveProviderReferences(providers map[string]ProviderResource) (map[string]string, error) {
+	refs := make(map[string]string, len(providers))
+	for pkg, provider := range providers {
+		ref, err := ctx.resolveProviderReference(provider)
+		if err != nil {
+			return nil, err
+		}
+		refs[pkg] = ref
+	}
+	return refs, nil
+}
+
 // noMoreRPCs is a sentinel value used to stop subsequent RPCs from occurring.
 const noMoreRPCs = -1
 
@@ -0,0 +1,221 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pulumi
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/pulumi/pulumi/sdk/v2/go/common/resource"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/resource/plugin"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/util/contract"
+	pulumirpc "github.com/pulumi/pulumi/sdk/v2/proto/go"
+)
+
+// ConstructResult is the result of a call to a ConstructFunc.
+type ConstructResult struct {
+	// URN is the URN of the constructed component resource.
+	URN URNInput
+	// State is the output state of the constructed component resource.
+	State Input
+}
+
+// ConstructFunc constructs a component resource of the given type and name. The inputs are the component's input
+// properties, each of which carries the dependencies of the corresponding property in the caller's program. The
+// options are the component's parent, aliases, dependencies, protection bit, and providers. Resources registered by
+// the function with the given context are registered with the caller's deployment.
+type ConstructFunc func(ctx *Context, typ, name string, inputs Map, options ResourceOption) (*ConstructResult, error)
+
+// Construct implements the Construct RPC of a resource provider using the given ConstructFunc. The engine address is
+// the address of the engine that started the provider, and is used for logging.
+func Construct(ctx context.Context, req *pulumirpc.ConstructRequest, engineAddr string,
+	constructF ConstructFunc) (*pulumirpc.ConstructResponse, error) {
+
+	return construct(ctx, req, RunInfo{
+		Project:     req.GetProject(),
+		Stack:       req.GetStack(),
+		Config:      req.GetConfig(),
+		Parallel:    int(req.GetParallel()),
+		DryRun:      req.GetDryRun(),
+		MonitorAddr: req.GetMonitorEndpoint(),
+		EngineAddr:  engineAddr,
+	}, constructF)
+}
+
+// construct implements Construct using a context created from the given run info.
+func construct(ctx context.Context, req *pulumirpc.ConstructRequest, info RunInfo,
+	constructF ConstructFunc) (*pulumirpc.ConstructResponse, error) {
+
+	pulumiCtx, err := NewContext(ctx, info)
+	if err != nil {
+		return nil, fmt.Errorf("constructing run context: %w", err)
+	}
+	defer contract.IgnoreClose(pulumiCtx)
+
+	inputs, err := constructInputs(req)
+	if err != nil {
+		return nil, err
+	}
+
+	options, err := constructOptions(req)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := constructF(pulumiCtx, req.GetType(), req.GetName(), inputs, options)
+	if err != nil {
+		pulumiCtx.waitForRPCs()
+		return nil, err
+	}
+
+	// Wait for the component's URN and state to resolve, then for any outstanding registrations to complete.
+	var urn URN
+	if result.URN != nil {
+		urn, _, _, err = result.URN.ToURNOutput().awaitURN(ctx)
+		if err != nil {
+			pulumiCtx.waitForRPCs()
+			return nil, err
+		}
+	}
+
+	var state resource.PropertyMap
+	var stateDeps map[string][]URN
+	if result.State != nil {
+		state, stateDeps, _, err = marshalInputs(result.State)
+		if err != nil {
+			pulumiCtx.waitForRPCs()
+			return nil, fmt.Errorf("marshaling state: %w", err)
+		}
+	}
+
+	pulumiCtx.waitForRPCs()
+	if pulumiCtx.rpcError != nil {
+		return nil, pulumiCtx.rpcError
+	}
+
+	rpcState, err := plugin.MarshalProperties(state, plugin.MarshalOptions{KeepUnknowns: true, KeepSecrets: true})
+	if err != nil {
+		return nil, fmt.Errorf("marshaling state: %w", err)
+	}
+
+	rpcStateDeps := make(map[string]*pulumirpc.ConstructResponse_PropertyDependencies)
+	for k, deps := range stateDeps {
+		urns := make([]string, len(deps))
+		for i, d := range deps {
+			urns[i] = string(d)
+		}
+		sort.Strings(urns)
+		rpcStateDeps[k] = &pulumirpc.ConstructResponse_PropertyDependencies{Urns: urns}
+	}
+
+	return &pulumirpc.ConstructResponse{
+		Urn:               string(urn),
+		State:             rpcState,
+		StateDependencies: rpcStateDeps,
+	}, nil
+}
+
+// constructInputs converts the inputs of a construct request into outputs that depend on the resources on which
+// their corresponding properties depend.
+func constructInputs(req *pulumirpc.ConstructRequest) (Map, error) {
+	props, err := plugin.UnmarshalProperties(req.GetInputs(), plugin.MarshalOptions{
+		KeepUnknowns: true,
+		KeepSecrets:  true,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("unmarshaling inputs: %w", err)
+	}
+
+	inputs := Map{}
+	for k, v := range props {
+		var deps []Resource
+		if inputDeps, ok := req.GetInputDependencies()[string(k)]; ok {
+			for _, urn := range inputDeps.GetUrns() {
+				deps = append(deps, newDependencyResource(URN(urn)))
+			}
+		}
+
+		value, secret, err := unmarshalPropertyValue(v)
+		if err != nil {
+			return nil, fmt.Errorf("unmarshaling input %s: %w", k, err)
+		}
+
+		output := newOutput(anyOutputType, deps...)
+		output.getState().resolve(value, !v.ContainsUnknowns(), secret)
+		inputs[string(k)] = output
+	}
+	return inputs, nil
+}
+
+// constructOptions converts the options of a construct request into resource options.
+func constructOptions(req *pulumirpc.ConstructRequest) (ResourceOption, error) {
+	var parent Resource
+	if req.GetParent() != "" {
+		parent = newDependencyResource(URN(req.GetParent()))
+	}
+
+	aliases := make([]Alias, len(req.GetAliases()))
+	for i, urn := range req.GetAliases() {
+		aliases[i] = Alias{URN: URN(urn)}
+	}
+
+	dependsOn := make([]Resource, len(req.GetDependencies()))
+	for i, urn := range req.GetDependencies() {
+		dependsOn[i] = newDependencyResource(URN(urn))
+	}
+
+	providers := make(map[string]ProviderResource, len(req.GetProviders()))
+	for pkg, ref := range req.GetProviders() {
+		provider, err := newDependencyProviderResource(ref)
+		if err != nil {
+			return nil, err
+		}
+		providers[pkg] = provider
+	}
+
+	return resourceOption(func(ro *resourceOptions) {
+		ro.Parent = parent
+		ro.Aliases = aliases
+		ro.DependsOn = dependsOn
+		ro.Protect = req.GetProtect()
+		ro.Providers = providers
+	}), nil
+}
+
+// newDependencyResource returns a resource that refers to an existing resource by its URN. It is used to represent
+// the parent and dependencies of a component that is constructed on behalf of another program.
+func newDependencyResource(urn URN) Resource {
+	var res ResourceState
+	res.urn = urn.ToURNOutput()
+	return &res
+}
+
+// newDependencyProviderResource returns a provider resource that refers to an existing provider by its reference,
+// which is of the form `<URN>::<ID>`.
+func newDependencyProviderResource(ref string) (ProviderResource, error) {
+	idx := strings.LastIndex(ref, "::")
+	if idx == -1 {
+		return nil, fmt.Errorf("expected '::' in provider reference '%s'", ref)
+	}
+	urn, id := URN(ref[:idx]), ID(ref[idx+2:])
+
+	var res ProviderResourceState
+	res.urn = urn.ToURNOutput()
+	res.id = id.ToIDOutput()
+	res.pkg = string(resource.URN(urn).Type().Name())
+	return &res, nil
+}
@@ -0,0 +1,211 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pulumi
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pulumi/pulumi/sdk/v2/go/common/resource"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/resource/plugin"
+	pulumirpc "github.com/pulumi/pulumi/sdk/v2/proto/go"
+)
+
+const (
+	testDependencyURN = "urn:pulumi:stack::project::test:resource:type::dep"
+	testParentURN     = "urn:pulumi:stack::project::test:component:type::parent"
+	testProviderRef   = "urn:pulumi:stack::project::pulumi:providers:test::prov::some-id"
+)
+
+type testComponent struct {
+	ResourceState
+}
+
+func marshalConstructInputs(t *testing.T, props resource.PropertyMap) *pulumirpc.ConstructRequest {
+	inputs, err := plugin.MarshalProperties(props, plugin.MarshalOptions{KeepUnknowns: true, KeepSecrets: true})
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	return &pulumirpc.ConstructRequest{
+		Inputs: inputs,
+		InputDependencies: map[string]*pulumirpc.ConstructRequest_PropertyDependencies{
+			"foo": {Urns: []string{testDependencyURN}},
+		},
+	}
+}
+
+func awaitDependencyURNs(t *testing.T, out Output) []URN {
+	var urns []URN
+	for _, dep := range out.getState().dependencies() {
+		urn, _, _, err := dep.URN().awaitURN(context.Background())
+		assert.NoError(t, err)
+		urns = append(urns, urn)
+	}
+	return urns
+}
+
+func TestConstructInputs(t *testing.T) {
+	req := marshalConstructInputs(t, resource.PropertyMap{
+		"foo":     resource.NewStringProperty("bar"),
+		"secret":  resource.MakeSecret(resource.NewStringProperty("shh")),
+		"unknown": resource.MakeComputed(resource.NewStringProperty("")),
+	})
+
+	inputs, err := constructInputs(req)
+	assert.NoError(t, err)
+	assert.Len(t, inputs, 3)
+
+	// Each input carries the dependencies of its property.
+	foo := inputs["foo"].(Output)
+	v, known, secret, err := await(foo)
+	assert.NoError(t, err)
+	assert.True(t, known)
+	assert.False(t, secret)
+	assert.Equal(t, "bar", v)
+	assert.Equal(t, []URN{testDependencyURN}, awaitDependencyURNs(t, foo))
+
+	v, known, secret, err = await(inputs["secret"].(Output))
+	assert.NoError(t, err)
+	assert.True(t, known)
+	assert.True(t, secret)
+	assert.Equal(t, "shh", v)
+	assert.Empty(t, awaitDependencyURNs(t, inputs["secret"].(Output)))
+
+	_, known, _, err = await(inputs["unknown"].(Output))
+	assert.NoError(t, err)
+	assert.False(t, known)
+}
+
+func TestConstructOptions(t *testing.T) {
+	options, err := constructOptions(&pulumirpc.ConstructRequest{
+		Parent:       testParentURN,
+		Aliases:      []string{"urn:pulumi:stack::project::test:component:type::old"},
+		Dependencies: []string{testDependencyURN},
+		Protect:      true,
+		Providers:    map[string]string{"test": testProviderRef},
+	})
+	assert.NoError(t, err)
+
+	var opts resourceOptions
+	options.applyResourceOption(&opts)
+
+	if assert.NotNil(t, opts.Parent) {
+		urn, _, _, err := opts.Parent.URN().awaitURN(context.Background())
+		assert.NoError(t, err)
+		assert.Equal(t, URN(testParentURN), urn)
+	}
+	assert.Equal(t, []Alias{{URN: URN("urn:pulumi:stack::project::test:component:type::old")}}, opts.Aliases)
+	if assert.Len(t, opts.DependsOn, 1) {
+		urn, _, _, err := opts.DependsOn[0].URN().awaitURN(context.Background())
+		assert.NoError(t, err)
+		assert.Equal(t, URN(testDependencyURN), urn)
+	}
+	assert.True(t, opts.Protect)
+
+	if assert.Contains(t, opts.Providers, "test") {
+		provider := opts.Providers["test"]
+		assert.Equal(t, "test", provider.getPackage())
+
+		urn, _, _, err := provider.URN().awaitURN(context.Background())
+		assert.NoError(t, err)
+		assert.Equal(t, URN("urn:pulumi:stack::project::pulumi:providers:test::prov"), urn)
+
+		id, _, _, err := provider.ID().awaitID(context.Background())
+		assert.NoError(t, err)
+		assert.Equal(t, ID("some-id"), id)
+	}
+
+	// Provider references must be of the form `<URN>::<ID>`.
+	_, err = constructOptions(&pulumirpc.ConstructRequest{
+		Providers: map[string]string{"test": "not-a-reference"},
+	})
+	assert.Error(t, err)
+}
+
+func TestConstruct(t *testing.T) {
+	var childInputs resource.PropertyMap
+	mocks := &testMonitor{
+		NewResourceF: func(typeToken, name string, inputs resource.PropertyMap,
+			provider, id string) (string, resource.PropertyMap, error) {
+
+			if name == "child" {
+				childInputs = inputs
+				return "child-id", inputs, nil
+			}
+			return "", resource.PropertyMap{}, nil
+		},
+	}
+
+	req := marshalConstructInputs(t, resource.PropertyMap{"foo": resource.NewStringProperty("bar")})
+	req.Type, req.Name, req.Parent = "test:component:type", "comp", testParentURN
+
+	info := RunInfo{}
+	WithMocks("project", "stack", mocks)(&info)
+
+	resp, err := construct(context.Background(), req, info,
+		func(ctx *Context, typ, name string, inputs Map, options ResourceOption) (*ConstructResult, error) {
+			assert.Equal(t, "test:component:type", typ)
+			assert.Equal(t, "comp", name)
+
+			var comp testComponent
+			if err := ctx.RegisterComponentResource(typ, name, &comp, options); err != nil {
+				return nil, err
+			}
+
+			var child testResource3
+			err := ctx.RegisterResource("test:resource:type", "child", Map{"foo": inputs["foo"]}, &child, Parent(&comp))
+			if err != nil {
+				return nil, err
+			}
+
+			return &ConstructResult{
+				URN:   comp.URN(),
+				State: Map{"foo": inputs["foo"], "childId": child.ID()},
+			}, nil
+		})
+	assert.NoError(t, err)
+
+	// The child was registered with the component's inputs.
+	assert.Equal(t, resource.PropertyMap{"foo": resource.NewStringProperty("bar")}, childInputs)
+
+	// The component's URN reflects the parent in the request.
+	assert.Equal(t, "urn:pulumi:stack::project::test:component:type$test:component:type::comp", resp.GetUrn())
+
+	state, err := plugin.UnmarshalProperties(resp.GetState(), plugin.MarshalOptions{KeepSecrets: true})
+	assert.NoError(t, err)
+	assert.Equal(t, resource.PropertyMap{
+		"foo":     resource.NewStringProperty("bar"),
+		"childId": resource.NewStringProperty("child-id"),
+	}, state)
+
+	// Each output depends on the resources on which its value depends.
+	assert.Equal(t, []string{testDependencyURN}, resp.GetStateDependencies()["foo"].GetUrns())
+	assert.Equal(t,
+		[]string{"urn:pulumi:stack::project::test:component:type$test:component:type$test:resource:type::child"},
+		resp.GetStateDependencies()["childId"].GetUrns())
+}
+
+func TestConstructError(t *testing.T) {
+	info := RunInfo{}
+	WithMocks("project", "stack", &testMonitor{})(&info)
+
+	_, err := construct(context.Background(), &pulumirpc.ConstructRequest{}, info,
+		func(ctx *Context, typ, name string, inputs Map, options ResourceOption) (*ConstructResult, error) {
+			return nil, assert.AnError
+		})
+	assert.Equal(t, assert.AnError, err)
+}
@@ -173,6 +173,12 @@ type resourceOptions struct {
 	CustomTimeouts *CustomTimeouts
 	// Ignore changes to any of the specified properties.
 	IgnoreChanges []string
+	// ReplaceOnChanges is an optional list of property paths that, when changed, force this resource to be replaced
+	// rather than updated in place.
+	ReplaceOnChanges []string
+	// RetainOnDelete, when set to true, causes this resource to be removed from the stack rather than deleted when it
+	// is deleted or replaced.
+	RetainOnDelete bool
 	// Aliases is an optional list of identifiers used to find and use existing resources.
 	Aliases []Alias
 	// AdditionalSecretOutputs is an optional list of output properties to mark as secret.
@@ -335,6 +341,22 @@ func IgnoreChanges(o []string) ResourceOption {
 	})
 }
 
+// ReplaceOnChanges forces this resource to be replaced rather than updated in place when any of the specified
+// properties change.
+func ReplaceOnChanges(o []string) ResourceOption {
+	return resourceOption(func(ro *resourceOptions) {
+		ro.ReplaceOnChanges = append(ro.ReplaceOnChanges, o...)
+	})
+}
+
+// RetainOnDelete, when set to true, causes this resource to be removed from the stack rather than deleted when it is
+// deleted or replaced. The underlying cloud resource is left in place.
+func RetainOnDelete(o bool) ResourceOption {
+	return resourceOption(func(ro *resourceOptions) {
+		ro.RetainOnDelete = o
+	})
+}
+
 // Aliases applies a list of identifiers to find and use existing resources.
 func Aliases(o []Alias) ResourceOption {
 	return resourceOption(func(ro *resourceOptions) {
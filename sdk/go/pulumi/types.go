@@ -96,9 +96,24 @@ func (o *OutputState) dependencies() []Resource {
 	if o == nil {
 		return nil
 	}
+
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
 	return o.deps
 }
 
+// addDependencies records additional resources on which this output depends. It must be called before the output is
+// resolved.
+func (o *OutputState) addDependencies(deps ...Resource) {
+	if o == nil || len(deps) == 0 {
+		return
+	}
+
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+	o.deps = append(o.deps, deps...)
+}
+
 func (o *OutputState) fulfill(value interface{}, known, secret bool, err error) {
 	o.fulfillValue(reflect.ValueOf(value), known, secret, err)
 }
@@ -179,7 +179,7 @@ export abstract class Resource {
      * @internal
      */
     // tslint:disable-next-line:variable-name
-    private readonly __providers: Record<string, ProviderResource>;
+    readonly __providers: Record<string, ProviderResource>;
 
     public static isInstance(obj: any): obj is Resource {
         return utils.isInstance<Resource>(obj, "__pulumiResource");
@@ -207,8 +207,17 @@ export abstract class Resource {
      * @param custom True to indicate that this is a custom resource, managed by a plugin.
      * @param props The arguments to use to populate the new resource.
      * @param opts A bag of options that control this resource's behavior.
-     */
-    constructor(t: string, name: string, custom: boolean, props: Inputs = {}, opts: ResourceOptions = {}) {
+     * @param remote True if this is a component resource that is constructed by its provider plugin.
+     * @param dependency True if this is a synthetic resource used internally for dependency tracking.
+     */
+    constructor(t: string, name: string, custom: boolean, props: Inputs = {}, opts: ResourceOptions = {},
+                remote: boolean = false, dependency: boolean = false) {
+        if (dependency) {
+            this.__protect = false;
+            this.__providers = {};
+            return;
+        }
+
         if (opts.parent && !Resource.isInstance(opts.parent)) {
             throw new Error(`Resource parent is not a valid Resource: ${opts.parent}`);
         }
@@ -325,7 +334,7 @@ export abstract class Resource {
             // resource's properties will be resolved asynchronously after the operation completes, so
             // that dependent computations resolve normally.  If we are just planning, on the other
             // hand, values will never resolve.
-            registerResource(this, t, name, custom, props, opts);
+            registerResource(this, t, name, custom, remote, props, opts);
         }
     }
 }
@@ -679,13 +688,14 @@ export abstract class CustomResource extends Resource {
      * @param name The _unique_ name of the resource.
      * @param props The arguments to use to populate the new resource.
      * @param opts A bag of options that control this resource's behavior.
+     * @param dependency True if this is a synthetic resource used internally for dependency tracking.
      */
-    constructor(t: string, name: string, props?: Inputs, opts: CustomResourceOptions = {}) {
+    constructor(t: string, name: string, props?: Inputs, opts: CustomResourceOptions = {}, dependency: boolean = false) {
         if ((<ComponentResourceOptions>opts).providers) {
             throw new ResourceError("Do not supply 'providers' option to a CustomResource. Did you mean 'provider' instead?", opts.parent);
         }
 
-        super(t, name, true, props, opts);
+        super(t, name, true, props, opts, false /*remote*/, dependency);
         this.__pulumiCustomResource = true;
         this.__pulumiType = t;
     }
@@ -738,6 +748,18 @@ export abstract class ProviderResource extends CustomResource {
     }
 }
 
+/**
+ * DependencyResource is a resource that stands in for a resource that was registered elsewhere, such as a child of
+ * a remote component, so that the outputs that depend on it carry that dependency.  It is never registered itself.
+ * @internal
+ */
+export class DependencyResource extends CustomResource {
+    constructor(urn: URN) {
+        super("", "", {}, {}, /*dependency:*/ true);
+        (<any>this).urn = new Output(<any>this, Promise.resolve(urn), Promise.resolve(true), Promise.resolve(false), Promise.resolve([]));
+    }
+}
+
 /**
  * ComponentResource is a resource that aggregates one or more other child resources into a higher
  * level abstraction. The component resource itself is a resource, but does not require custom CRUD
@@ -759,6 +781,13 @@ export class ComponentResource<TData = any> extends Resource {
     // tslint:disable-next-line:variable-name
     private __registered = false;
 
+    /**
+     * True if this component is constructed by its provider plugin rather than by this program.
+     * @internal
+     */
+    // tslint:disable-next-line:variable-name
+    public readonly __remote: boolean;
+
     /**
      * Returns true if the given object is an instance of CustomResource.  This is designed to work even when
      * multiple copies of the Pulumi SDK have been loaded into the same process.
@@ -778,8 +807,9 @@ export class ComponentResource<TData = any> extends Resource {
      * @param name The _unique_ name of the resource.
      * @param args Information passed to [initialize] method.
      * @param opts A bag of options that control this resource's behavior.
+     * @param remote True if this component is constructed by the provider plugin for its package.
      */
-    constructor(type: string, name: string, args: Inputs = {}, opts: ComponentResourceOptions = {}) {
+    constructor(type: string, name: string, args: Inputs = {}, opts: ComponentResourceOptions = {}, remote: boolean = false) {
         // Explicitly ignore the props passed in.  We allow them for back compat reasons.  However,
         // we explicitly do not want to pass them along to the engine.  The ComponentResource acts
         // only as a container for other resources.  Another way to think about this is that a normal
@@ -788,8 +818,13 @@ export class ComponentResource<TData = any> extends Resource {
         // for a component resource.  The component is just used for organizational purposes and does
         // not correspond to a real piece of cloud infrastructure.  As such, changes to it *itself*
         // do not have any effect on the cloud side of things at all.
-        super(type, name, /*custom:*/ false, /*props:*/ {}, opts);
-        this.__data = this.initializeAndRegisterOutputs(args);
+        //
+        // Remote components are the exception: their provider constructs them from their args and
+        // registers their children and outputs itself.
+        super(type, name, /*custom:*/ false, /*props:*/ remote ? args : {}, opts, remote);
+        this.__remote = remote;
+        this.__registered = remote;
+        this.__data = remote ? Promise.resolve(<TData>{}) : this.initializeAndRegisterOutputs(args);
     }
 
     /** @internal */
@@ -24,6 +24,7 @@ import {
     createUrn,
     CustomResource,
     CustomResourceOptions,
+    DependencyResource,
     ID,
     ProviderResource,
     Resource,
@@ -69,6 +70,8 @@ interface ResourceResolverOperation {
     parentURN: URN | undefined;
     // A provider reference, fully resolved, if any.
     providerRef: string | undefined;
+    // A map from package name to provider reference for a remote component's children, fully resolved.
+    providerRefs: Map<string, string>;
     // All serialized properties, fully awaited, serialized, and ready to go.
     serializedProps: Record<string, any>;
     // A set of URNs that this resource is directly dependent upon.  These will all be URNs of
@@ -98,7 +101,7 @@ export function readResource(res: Resource, t: string, name: string, props: Inpu
     log.debug(`Reading resource: id=${Output.isInstance(id) ? "Output<T>" : id}, t=${t}, name=${name}`);
 
     const monitor = getMonitor();
-    const resopAsync = prepareResource(label, res, true, props, opts);
+    const resopAsync = prepareResource(label, res, true, false, props, opts);
 
     const preallocError = new Error();
     debuggablePromise(resopAsync.then(async (resop) => {
@@ -149,7 +152,7 @@ export function readResource(res: Resource, t: string, name: string, props: Inpu
             // Now resolve everything: the URN, the ID (supplied as input), and the output properties.
             resop.resolveURN(resp.getUrn());
             resop.resolveID!(resolvedID, resolvedID !== undefined);
-            await resolveOutputs(res, t, name, props, resp.getProperties(), resop.resolvers);
+            await resolveOutputs(res, t, name, props, resp.getProperties(), {}, resop.resolvers);
         });
     }), label);
 }
@@ -159,13 +162,13 @@ export function readResource(res: Resource, t: string, name: string, props: Inpu
  * URN and the ID that will resolve after the deployment has completed.  All properties will be initialized to property
  * objects that the registration operation will resolve at the right time (or remain unresolved for deployments).
  */
-export function registerResource(res: Resource, t: string, name: string, custom: boolean,
+export function registerResource(res: Resource, t: string, name: string, custom: boolean, remote: boolean,
                                  props: Inputs, opts: ResourceOptions): void {
     const label = `resource:${name}[${t}]`;
-    log.debug(`Registering resource: t=${t}, name=${name}, custom=${custom}`);
+    log.debug(`Registering resource: t=${t}, name=${name}, custom=${custom}, remote=${remote}`);
 
     const monitor = getMonitor();
-    const resopAsync = prepareResource(label, res, custom, props, opts);
+    const resopAsync = prepareResource(label, res, custom, remote, props, opts);
 
     // In order to present a useful stack trace if an error does occur, we preallocate potential
     // errors here. V8 captures a stack trace at the moment an Error is created and this stack
@@ -194,6 +197,7 @@ export function registerResource(res: Resource, t: string, name: string, custom:
         req.setAliasesList(resop.aliases);
         req.setImportid(resop.import || "");
         req.setSupportspartialvalues(true);
+        req.setRemote(remote);
 
         const customTimeouts = new resproto.RegisterResourceRequest.CustomTimeouts();
         if (opts.customTimeouts != null) {
@@ -210,6 +214,11 @@ export function registerResource(res: Resource, t: string, name: string, custom:
             propertyDependencies.set(key, deps);
         }
 
+        const providerRefs = req.getProvidersMap();
+        for (const [pkg, ref] of resop.providerRefs) {
+            providerRefs.set(pkg, ref);
+        }
+
         // Now run the operation, serializing the invocation if necessary.
         const opLabel = `monitor.registerResource(${label})`;
         runAsyncResourceOp(opLabel, async () => {
@@ -242,6 +251,7 @@ export function registerResource(res: Resource, t: string, name: string, custom:
                     getUrn: () => mockurn,
                     getId: () => undefined,
                     getObject: () => req.getObject(),
+                    getPropertydependenciesMap: () => undefined,
                 };
             }
 
@@ -254,8 +264,17 @@ export function registerResource(res: Resource, t: string, name: string, custom:
                 resop.resolveID(id, id !== undefined);
             }
 
+            // Remote components report the resources on which each of their outputs depend.
+            const deps: Record<string, Resource[]> = {};
+            const rpcDeps = resp.getPropertydependenciesMap();
+            if (rpcDeps) {
+                rpcDeps.forEach((propertyDeps: any, k: string) => {
+                    deps[k] = propertyDeps.getUrnsList().map((urn: URN) => new DependencyResource(urn));
+                });
+            }
+
             // Now resolve the output properties.
-            await resolveOutputs(res, t, name, props, resp.getObject(), resop.resolvers);
+            await resolveOutputs(res, t, name, props, resp.getObject(), deps, resop.resolvers);
         });
     }), label);
 }
@@ -264,7 +283,7 @@ export function registerResource(res: Resource, t: string, name: string, custom:
  * Prepares for an RPC that will manufacture a resource, and hence deals with input and output
  * properties.
  */
-async function prepareResource(label: string, res: Resource, custom: boolean,
+async function prepareResource(label: string, res: Resource, custom: boolean, remote: boolean,
                                props: Inputs, opts: ResourceOptions): Promise<ResourceResolverOperation> {
 
     // Simply initialize the URN property and get prepared to resolve it later on.
@@ -328,6 +347,15 @@ async function prepareResource(label: string, res: Resource, custom: boolean,
         providerRef = await ProviderResource.register(opts.provider);
     }
 
+    // Remote components are constructed by their provider, which needs to know about all of the providers that the
+    // component's children should use.
+    const providerRefs = new Map<string, string>();
+    if (remote) {
+        for (const pkg of Object.keys(res.__providers)) {
+            providerRefs.set(pkg, (await ProviderResource.register(res.__providers[pkg]))!);
+        }
+    }
+
     // Collect the URNs for explicit/implicit dependencies for the engine so that it can understand
     // the dependency graph and optimize operations accordingly.
 
@@ -365,6 +393,7 @@ async function prepareResource(label: string, res: Resource, custom: boolean,
         serializedProps: serializedProps,
         parentURN: parentURN,
         providerRef: providerRef,
+        providerRefs: providerRefs,
         allDirectDependencyURNs: allDirectDependencyURNs,
         propertyToDirectDependencyURNs: propertyToDirectDependencyURNs,
         aliases: aliases,
@@ -401,7 +430,10 @@ async function getAllTransitivelyReferencedCustomResourceURNs(resources: Set<Res
     // [Comp1, Cust1, Comp2, Cust2, Cust3]
     const transitivelyReachableResources = await getTransitivelyReferencedChildResourcesOfComponentResources(resources);
 
-    const transitivelyReachableCustomResources = [...transitivelyReachableResources].filter(r => CustomResource.isInstance(r));
+    // Remote components are included as well: their children are registered by their provider, so this program cannot
+    // walk them.
+    const transitivelyReachableCustomResources = [...transitivelyReachableResources].filter(
+        r => CustomResource.isInstance(r) || (ComponentResource.isInstance(r) && r.__remote));
     const promises = transitivelyReachableCustomResources.map(r => r.urn.promise());
     const urns = await Promise.all(promises);
     return new Set<string>(urns);
@@ -473,8 +505,8 @@ async function gatherExplicitDependencies(
 /**
  * Finishes a resource creation RPC operation by resolving its outputs to the resulting RPC payload.
  */
-async function resolveOutputs(res: Resource, t: string, name: string,
-                              props: Inputs, outputs: any, resolvers: OutputResolvers): Promise<void> {
+async function resolveOutputs(res: Resource, t: string, name: string, props: Inputs, outputs: any,
+                              deps: Record<string, Resource[]>, resolvers: OutputResolvers): Promise<void> {
     // Produce a combined set of property states, starting with inputs and then applying
     // outputs.  If the same property exists in the inputs and outputs states, the output wins.
     const allProps: Record<string, any> = {};
@@ -498,7 +530,7 @@ async function resolveOutputs(res: Resource, t: string, name: string,
         }
     }
 
-    resolveProperties(res, resolvers, t, name, allProps);
+    resolveProperties(res, resolvers, t, name, allProps, deps);
 }
 
 /**
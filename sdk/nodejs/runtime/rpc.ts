@@ -21,7 +21,7 @@ import { excessiveDebugOutput, isDryRun, monitorSupportsSecrets } from "./settin
 
 const gstruct = require("google-protobuf/google/protobuf/struct_pb.js");
 
-export type OutputResolvers = Record<string, (value: any, isStable: boolean, isSecret: boolean) => void>;
+export type OutputResolvers = Record<string, (value: any, isStable: boolean, isSecret: boolean, deps?: Resource[]) => void>;
 
 /**
  * transferProperties mutates the 'onto' resource so that it has Promise-valued properties for all
@@ -51,11 +51,13 @@ export function transferProperties(onto: Resource, label: string, props: Inputs)
         let resolveValue: (v: any) => void;
         let resolveIsKnown: (v: boolean) => void;
         let resolveIsSecret: (v: boolean) => void;
+        let resolveDeps: (v: Resource[]) => void;
 
-        resolvers[k] = (v: any, isKnown: boolean, isSecret: boolean) => {
+        resolvers[k] = (v: any, isKnown: boolean, isSecret: boolean, deps: Resource[] = []) => {
             resolveValue(v);
             resolveIsKnown(isKnown);
             resolveIsSecret(isSecret);
+            resolveDeps(deps);
         };
 
         const propString = Output.isInstance(props[k]) ? "Output<T>" : `${props[k]}`;
@@ -70,7 +72,9 @@ export function transferProperties(onto: Resource, label: string, props: Inputs)
             debuggablePromise(
                 new Promise<boolean>(resolve => resolveIsSecret = resolve),
                 `transferIsSecret(${label}, ${k}, ${props[k]})`),
-            Promise.resolve(onto));
+            debuggablePromise(
+                new Promise<Resource[]>(resolve => resolveDeps = resolve).then(deps => [onto, ...deps]),
+                `transferDeps(${label}, ${k}, ${propString})`));
     }
 
     return resolvers;
@@ -141,10 +145,13 @@ export function deserializeProperties(outputsStruct: any): any {
  * NOTE: it is imperative that the properties in `allProps` were produced by `deserializeProperties` in order for
  * output properties to work correctly w.r.t. knowns/unknowns: this function assumes that any undefined value in
  * `allProps`represents an unknown value that was returned by an engine operation.
+ *
+ * `deps` optionally maps property keys to additional resources on which those properties depend, as reported for the
+ * outputs of remote components.
  */
 export function resolveProperties(
-    res: Resource, resolvers: Record<string, (v: any, isKnown: boolean, isSecret: boolean) => void>,
-    t: string, name: string, allProps: any): void {
+    res: Resource, resolvers: OutputResolvers,
+    t: string, name: string, allProps: any, deps: Record<string, Resource[]> = {}): void {
 
     // Now go ahead and resolve all properties present in the inputs and outputs set.
     for (const k of Object.keys(allProps)) {
@@ -183,7 +190,7 @@ export function resolveProperties(
             // If the value the engine handed back is or contains an unknown value, the resolver will mark its value as
             // unknown automatically, so we just pass true for isKnown here. Note that unknown values will only be
             // present during previews (i.e. isDryRun() will be true).
-            resolve(value, /*isKnown*/ true, isSecret);
+            resolve(value, /*isKnown*/ true, isSecret, deps[k]);
         }
         catch (err) {
             throw new Error(
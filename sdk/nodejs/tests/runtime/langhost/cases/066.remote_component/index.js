@@ -0,0 +1,32 @@
+// Test that remote components send their inputs and providers to the engine, and that their outputs depend on the
+// resources that the engine reports.
+
+let assert = require("assert");
+let pulumi = require("../../../../../");
+
+class Provider extends pulumi.ProviderResource {
+    constructor(name, opts) {
+        super("test", name, {}, opts);
+    }
+}
+
+class RemoteComponent extends pulumi.ComponentResource {
+    constructor(name, args, opts) {
+        super("test:index:RemoteComponent", name, { foo: args.foo, childId: undefined }, opts, true);
+    }
+}
+
+class CustResource extends pulumi.CustomResource {
+    constructor(name, args, opts) {
+        super("test:index:CustResource", name, args, opts);
+    }
+}
+
+const provider = new Provider("prov");
+const comp = new RemoteComponent("comp", { foo: "bar" }, { providers: [provider] });
+comp.childId.apply(childId => {
+    assert.strictEqual(childId, "child-id");
+});
+
+// The custom resource depends on the child that the engine reported for the component's output.
+new CustResource("cust", { childId: comp.childId });
@@ -53,7 +53,8 @@ interface RunCase {
     };
     registerResource?: (ctx: any, dryrun: boolean, t: string, name: string, res: any, dependencies?: string[],
                         custom?: boolean, protect?: boolean, parent?: string, provider?: string,
-                        propertyDeps?: any, ignoreChanges?: string[], version?: string, importID?: string) => { urn: URN | undefined, id: ID | undefined, props: any | undefined };
+                        propertyDeps?: any, ignoreChanges?: string[], version?: string, importID?: string,
+                        remote?: boolean, providers?: any) => { urn: URN | undefined, id: ID | undefined, props: any | undefined, propertyDeps?: any };
     registerResourceOutputs?: (ctx: any, dryrun: boolean, urn: URN,
                                t: string, name: string, res: any, outputs: any | undefined) => void;
     log?: (ctx: any, severity: any, message: string, urn: URN, streamId: number) => void;
@@ -1154,6 +1155,41 @@ describe("rpc", () => {
                 };
             },
         },
+        // A remote component's inputs and providers are sent to the engine, and its outputs depend on the
+        // resources that the engine reports for them.
+        "remote_component": {
+            program: path.join(base, "066.remote_component"),
+            expectResourceCount: 3,
+            registerResource: (ctx: any, dryrun: boolean, t: string, name: string, res: any, dependencies?: string[],
+                               custom?: boolean, protect?: boolean, parent?: string, provider?: string,
+                               propertyDeps?: any, ignoreChanges?: string[], version?: string, importID?: string,
+                               remote?: boolean, providers?: any) => {
+                switch (t) {
+                    case "pulumi:providers:test":
+                        assert.strictEqual(remote, false);
+                        return { urn: makeUrn(t, name), id: name, props: {} };
+                    case "test:index:RemoteComponent":
+                        assert.strictEqual(custom, false);
+                        assert.strictEqual(remote, true);
+                        assert.deepEqual(res, { foo: "bar" });
+                        assert.deepEqual(providers, { test: "pulumi:providers:test::prov::prov" });
+                        return {
+                            urn: makeUrn(t, name),
+                            id: undefined,
+                            props: { foo: "bar", childId: "child-id" },
+                            propertyDeps: { childId: ["test:index:Child::child"] },
+                        };
+                    case "test:index:CustResource":
+                        assert.strictEqual(remote, false);
+                        assert.deepEqual(res, { childId: "child-id" });
+                        assert.deepEqual(propertyDeps,
+                            { childId: ["test:index:Child::child", "test:index:RemoteComponent::comp"] });
+                        return { urn: makeUrn(t, name), id: name, props: res };
+                    default:
+                        throw new Error(`unexpected resource type ${t}`);
+                }
+            },
+        },
     };
 
     for (const casename of Object.keys(cases)) {
@@ -1227,11 +1263,25 @@ describe("rpc", () => {
                                     }, {});
                                 const version: string = req.getVersion();
                                 const importID: string = req.getImportid();
-                                const { urn, id, props } = opts.registerResource(ctx, dryrun, t, name, res, deps,
-                                    custom, protect, parent, provider, propertyDeps, ignoreChanges, version, importID);
+                                const remote: boolean = req.getRemote();
+                                const providers: any = Array.from(req.getProvidersMap().entries())
+                                    .reduce((o: any, [key, value]: any) => {
+                                        return { ...o, [key]: value };
+                                    }, {});
+                                const { urn, id, props, propertyDeps: outputDeps } = opts.registerResource(ctx, dryrun,
+                                    t, name, res, deps, custom, protect, parent, provider, propertyDeps, ignoreChanges,
+                                    version, importID, remote, providers);
                                 resp.setUrn(urn);
                                 resp.setId(id);
                                 resp.setObject(gstruct.Struct.fromJavaScript(props));
+                                if (outputDeps) {
+                                    const outputDepsMap = resp.getPropertydependenciesMap();
+                                    for (const key of Object.keys(outputDeps)) {
+                                        const urns = new resproto.RegisterResourceResponse.PropertyDependencies();
+                                        urns.setUrnsList(outputDeps[key]);
+                                        outputDepsMap.set(key, urns);
+                                    }
+                                }
                                 if (urn) {
                                     regs[urn] = { t: t, name: name, props: props };
                                 }
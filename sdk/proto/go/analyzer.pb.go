@@ -33,18 +33,21 @@ const (
 	EnforcementLevel_ADVISORY  EnforcementLevel = 0
 	EnforcementLevel_MANDATORY EnforcementLevel = 1
 	EnforcementLevel_DISABLED  EnforcementLevel = 2
+	EnforcementLevel_REMEDIATE EnforcementLevel = 3
 )
 
 var EnforcementLevel_name = map[int32]string{
 	0: "ADVISORY",
 	1: "MANDATORY",
 	2: "DISABLED",
+	3: "REMEDIATE",
 }
 
 var EnforcementLevel_value = map[string]int32{
 	"ADVISORY":  0,
 	"MANDATORY": 1,
 	"DISABLED":  2,
+	"REMEDIATE": 3,
 }
 
 func (x EnforcementLevel) String() string {
@@ -959,6 +962,118 @@ func (m *ConfigureAnalyzerRequest) GetPolicyConfig() map[string]*PolicyConfig {
 	return nil
 }
 
+// Remediation describes how a single policy transformed a resource's inputs.
+type Remediation struct {
+	PolicyName           string          `protobuf:"bytes,1,opt,name=policyName,proto3" json:"policyName,omitempty"`
+	PolicyPackName       string          `protobuf:"bytes,2,opt,name=policyPackName,proto3" json:"policyPackName,omitempty"`
+	PolicyPackVersion    string          `protobuf:"bytes,3,opt,name=policyPackVersion,proto3" json:"policyPackVersion,omitempty"`
+	Description          string          `protobuf:"bytes,4,opt,name=description,proto3" json:"description,omitempty"`
+	Properties           *_struct.Struct `protobuf:"bytes,5,opt,name=properties,proto3" json:"properties,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
+}
+
+func (m *Remediation) Reset()         { *m = Remediation{} }
+func (m *Remediation) String() string { return proto.CompactTextString(m) }
+func (*Remediation) ProtoMessage()    {}
+func (*Remediation) Descriptor() ([]byte, []int) {
+	return fileDescriptor_fadbb7eccb91f143, []int{13}
+}
+
+func (m *Remediation) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Remediation.Unmarshal(m, b)
+}
+func (m *Remediation) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Remediation.Marshal(b, m, deterministic)
+}
+func (m *Remediation) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Remediation.Merge(m, src)
+}
+func (m *Remediation) XXX_Size() int {
+	return xxx_messageInfo_Remediation.Size(m)
+}
+func (m *Remediation) XXX_DiscardUnknown() {
+	xxx_messageInfo_Remediation.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Remediation proto.InternalMessageInfo
+
+func (m *Remediation) GetPolicyName() string {
+	if m != nil {
+		return m.PolicyName
+	}
+	return ""
+}
+
+func (m *Remediation) GetPolicyPackName() string {
+	if m != nil {
+		return m.PolicyPackName
+	}
+	return ""
+}
+
+func (m *Remediation) GetPolicyPackVersion() string {
+	if m != nil {
+		return m.PolicyPackVersion
+	}
+	return ""
+}
+
+func (m *Remediation) GetDescription() string {
+	if m != nil {
+		return m.Description
+	}
+	return ""
+}
+
+func (m *Remediation) GetProperties() *_struct.Struct {
+	if m != nil {
+		return m.Properties
+	}
+	return nil
+}
+
+// RemediateResponse contains the remediations that were applied to a resource, in the order they were applied.
+type RemediateResponse struct {
+	Remediations         []*Remediation `protobuf:"bytes,1,rep,name=remediations,proto3" json:"remediations,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
+}
+
+func (m *RemediateResponse) Reset()         { *m = RemediateResponse{} }
+func (m *RemediateResponse) String() string { return proto.CompactTextString(m) }
+func (*RemediateResponse) ProtoMessage()    {}
+func (*RemediateResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_fadbb7eccb91f143, []int{14}
+}
+
+func (m *RemediateResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RemediateResponse.Unmarshal(m, b)
+}
+func (m *RemediateResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RemediateResponse.Marshal(b, m, deterministic)
+}
+func (m *RemediateResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RemediateResponse.Merge(m, src)
+}
+func (m *RemediateResponse) XXX_Size() int {
+	return xxx_messageInfo_RemediateResponse.Size(m)
+}
+func (m *RemediateResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_RemediateResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RemediateResponse proto.InternalMessageInfo
+
+func (m *RemediateResponse) GetRemediations() []*Remediation {
+	if m != nil {
+		return m.Remediations
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterEnum("pulumirpc.EnforcementLevel", EnforcementLevel_name, EnforcementLevel_value)
 	proto.RegisterType((*AnalyzeRequest)(nil), "pulumirpc.AnalyzeRequest")
@@ -977,84 +1092,90 @@ func init() {
 	proto.RegisterType((*PolicyConfigSchema)(nil), "pulumirpc.PolicyConfigSchema")
 	proto.RegisterType((*PolicyConfig)(nil), "pulumirpc.PolicyConfig")
 	proto.RegisterType((*ConfigureAnalyzerRequest)(nil), "pulumirpc.ConfigureAnalyzerRequest")
+	proto.RegisterType((*Remediation)(nil), "pulumirpc.Remediation")
+	proto.RegisterType((*RemediateResponse)(nil), "pulumirpc.RemediateResponse")
 	proto.RegisterMapType((map[string]*PolicyConfig)(nil), "pulumirpc.ConfigureAnalyzerRequest.PolicyConfigEntry")
 }
 
 func init() { proto.RegisterFile("analyzer.proto", fileDescriptor_fadbb7eccb91f143) }
 
 var fileDescriptor_fadbb7eccb91f143 = []byte{
-	// 1130 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe4, 0x57, 0x51, 0x6f, 0x1b, 0x45,
-	0x10, 0xce, 0xd9, 0x69, 0x62, 0x8f, 0x1d, 0xd7, 0xd9, 0x42, 0x73, 0xbd, 0x86, 0x2a, 0xba, 0x22,
-	0x88, 0x2a, 0x70, 0xa9, 0x11, 0xa2, 0x20, 0x0a, 0x38, 0x71, 0x14, 0x05, 0x85, 0xc6, 0xac, 0xab,
-	0xaa, 0x79, 0xbc, 0x9e, 0xc7, 0xee, 0x2a, 0xe7, 0xbb, 0xeb, 0xde, 0x5e, 0x24, 0xf3, 0xc8, 0x23,
-	0x12, 0x12, 0x7f, 0x80, 0x7f, 0xc1, 0xff, 0xe0, 0x85, 0xdf, 0xc0, 0x33, 0x3f, 0x01, 0xed, 0xee,
-	0x9d, 0x73, 0xe7, 0x3b, 0xbb, 0x55, 0x84, 0xc4, 0x03, 0x6f, 0x3b, 0xb3, 0xdf, 0xcc, 0xee, 0x7c,
-	0xf3, 0xdd, 0xac, 0x0d, 0x2d, 0xc7, 0x77, 0xbc, 0xd9, 0x8f, 0xc8, 0x3b, 0x21, 0x0f, 0x44, 0x40,
-	0xea, 0x61, 0xec, 0xc5, 0x53, 0xc6, 0x43, 0xd7, 0x6a, 0x86, 0x5e, 0x3c, 0x61, 0xbe, 0xde, 0xb0,
-	0xee, 0x4e, 0x82, 0x60, 0xe2, 0xe1, 0x43, 0x65, 0xbd, 0x8c, 0xc7, 0x0f, 0x71, 0x1a, 0x8a, 0x59,
-	0xb2, 0xb9, 0xbb, 0xb8, 0x19, 0x09, 0x1e, 0xbb, 0x42, 0xef, 0xda, 0x3f, 0x55, 0xa0, 0xd5, 0xd3,
-	0xc7, 0x50, 0x7c, 0x1d, 0x63, 0x24, 0x08, 0x81, 0x75, 0x31, 0x0b, 0xd1, 0x34, 0xf6, 0x8c, 0xfd,
-	0x3a, 0x55, 0x6b, 0xf2, 0x39, 0x40, 0xc8, 0x83, 0x10, 0xb9, 0x60, 0x18, 0x99, 0x95, 0x3d, 0x63,
-	0xbf, 0xd1, 0xdd, 0xe9, 0xe8, 0xcc, 0x9d, 0x34, 0x73, 0x67, 0xa8, 0x32, 0xd3, 0x0c, 0x94, 0xb4,
-	0xa1, 0x1a, 0x73, 0xdf, 0xac, 0xaa, 0x5c, 0x72, 0x29, 0xd3, 0xfb, 0xce, 0x14, 0xcd, 0x75, 0x9d,
-	0x5e, 0xae, 0xc9, 0x57, 0xb0, 0x19, 0x84, 0x82, 0x05, 0x7e, 0x64, 0xde, 0x50, 0xb9, 0xed, 0xce,
-	0xbc, 0xd6, 0x4e, 0x72, 0x3d, 0x4e, 0x31, 0x0a, 0x62, 0xee, 0xe2, 0x99, 0x46, 0xd2, 0x34, 0x84,
-	0x7c, 0x03, 0xb5, 0x90, 0x07, 0x97, 0x6c, 0x84, 0xdc, 0xdc, 0x50, 0xe1, 0xf7, 0x4b, 0xc2, 0x07,
-	0x09, 0x24, 0x4d, 0x43, 0xe7, 0x41, 0xf6, 0x6f, 0xeb, 0xd0, 0x5e, 0x3c, 0xe5, 0xff, 0x47, 0x03,
-	0xb9, 0x0d, 0x1b, 0xa1, 0xc3, 0xd1, 0x17, 0xe6, 0xa6, 0xba, 0x54, 0x62, 0x11, 0x1b, 0x9a, 0x23,
-	0x0c, 0xd1, 0x1f, 0xa1, 0xef, 0xca, 0xba, 0x6b, 0x7b, 0xd5, 0xfd, 0x3a, 0xcd, 0xf9, 0x08, 0x83,
-	0x77, 0x92, 0x72, 0x67, 0xfd, 0x2c, 0xb6, 0xbe, 0x57, 0xdd, 0x6f, 0x74, 0x3f, 0x5b, 0x51, 0x47,
-	0x67, 0x50, 0x12, 0x77, 0xe4, 0x0b, 0x3e, 0xa3, 0xa5, 0x29, 0xad, 0x10, 0xee, 0x2c, 0x0d, 0x91,
-	0x44, 0x5f, 0xe0, 0x2c, 0x69, 0x9a, 0x5c, 0x92, 0x27, 0x70, 0xe3, 0xd2, 0xf1, 0x62, 0x4c, 0xda,
-	0xf5, 0x61, 0x39, 0x27, 0x85, 0x74, 0x54, 0x47, 0x7d, 0x59, 0x79, 0x6c, 0xd8, 0x7f, 0x56, 0x61,
-	0x67, 0x09, 0xfd, 0xc4, 0x84, 0x4d, 0xd9, 0x78, 0x74, 0x85, 0x3a, 0xb4, 0x46, 0x53, 0x93, 0xbc,
-	0x0f, 0x5b, 0x6c, 0xe2, 0x07, 0x1c, 0x0f, 0x5f, 0x39, 0xfe, 0x44, 0xe9, 0x45, 0xf2, 0x96, 0x77,
-	0x92, 0x4f, 0xe0, 0xd6, 0x08, 0x3d, 0x14, 0x78, 0x80, 0xe3, 0x80, 0x23, 0xc5, 0xd0, 0x73, 0x5c,
-	0x54, 0x4a, 0xa9, 0xd1, 0xb2, 0x2d, 0xf2, 0x35, 0x58, 0x25, 0xee, 0x3e, 0x8e, 0x99, 0x8f, 0x23,
-	0xa5, 0xa7, 0x1a, 0x5d, 0x81, 0x20, 0x8f, 0x61, 0xc7, 0x19, 0x8d, 0x98, 0xbc, 0xbe, 0xe3, 0x0d,
-	0xd1, 0xe5, 0x28, 0xce, 0x62, 0x11, 0xc6, 0x42, 0xaa, 0x4e, 0xde, 0x70, 0xd9, 0xb6, 0xac, 0xd5,
-	0xf1, 0x98, 0x13, 0x61, 0x64, 0x6e, 0x28, 0x64, 0x6a, 0x92, 0x73, 0x68, 0xb9, 0x71, 0x24, 0x82,
-	0xe9, 0x33, 0x36, 0xc5, 0x40, 0xa6, 0xda, 0x54, 0x6c, 0x3f, 0x7a, 0xb3, 0x80, 0x3b, 0x87, 0xb9,
-	0x40, 0xba, 0x90, 0xc8, 0x7a, 0x01, 0xad, 0x3c, 0x42, 0xea, 0xd4, 0xe5, 0xe8, 0x08, 0xfd, 0x6d,
-	0x1a, 0x34, 0xb1, 0xa4, 0x3f, 0x0e, 0x47, 0xd2, 0x5f, 0xd1, 0x7e, 0x6d, 0x49, 0xbf, 0xa6, 0x43,
-	0xb1, 0x6a, 0xd0, 0xc4, 0xb2, 0x7f, 0x31, 0xc0, 0x5c, 0xf6, 0x59, 0xfc, 0x07, 0x9f, 0xbf, 0xdd,
-	0x85, 0xdd, 0x55, 0x8a, 0x94, 0x31, 0x31, 0xf7, 0x23, 0xd3, 0x50, 0xdc, 0xab, 0xb5, 0x3d, 0x80,
-	0x5b, 0x49, 0xcc, 0x50, 0x38, 0xee, 0x45, 0x3a, 0xc3, 0xbf, 0x80, 0x3a, 0x4f, 0x2a, 0xd1, 0xf8,
-	0x46, 0xf7, 0xee, 0x8a, 0x56, 0xd0, 0x2b, 0xb4, 0xfd, 0x03, 0xdc, 0x9c, 0x3f, 0x08, 0x51, 0x18,
-	0xf8, 0x91, 0x54, 0x5c, 0x63, 0xc4, 0x9c, 0x89, 0x1f, 0x44, 0x82, 0xb9, 0x5a, 0xc7, 0x8d, 0xee,
-	0x6e, 0x31, 0x5f, 0x7f, 0x0e, 0xa2, 0xd9, 0x00, 0xfb, 0xf7, 0x0a, 0x6c, 0x17, 0x20, 0xe4, 0x1e,
-	0x40, 0x18, 0x78, 0xcc, 0x9d, 0x3d, 0x95, 0x44, 0x68, 0x9e, 0x33, 0x1e, 0xf2, 0x01, 0xb4, 0xb4,
-	0x35, 0x70, 0xdc, 0x0b, 0x85, 0xa9, 0x28, 0xcc, 0x82, 0x97, 0x7c, 0x04, 0xdb, 0x57, 0x9e, 0xe7,
-	0xc8, 0x23, 0x16, 0xa4, 0x54, 0x17, 0x37, 0xc8, 0x1e, 0x34, 0x46, 0x18, 0xb9, 0x9c, 0x29, 0xf5,
-	0x25, 0xfc, 0x67, 0x5d, 0x52, 0xe5, 0x53, 0x8c, 0x22, 0x67, 0x82, 0x6a, 0x0a, 0xd7, 0x69, 0x6a,
-	0x2a, 0x4d, 0x38, 0x93, 0x54, 0xfc, 0x6a, 0x4d, 0x8e, 0xa1, 0x8d, 0xfe, 0x38, 0xe0, 0x2e, 0x4e,
-	0xd1, 0x17, 0xa7, 0x78, 0x89, 0x9e, 0xd2, 0x7e, 0x2b, 0x47, 0xf8, 0xd1, 0x02, 0x84, 0x16, 0x82,
-	0x52, 0x8d, 0xd4, 0xe6, 0x1a, 0xb1, 0xff, 0xaa, 0x40, 0x33, 0xed, 0xd4, 0x89, 0x3f, 0x0e, 0xe6,
-	0xa2, 0x31, 0x32, 0x6f, 0x86, 0xac, 0x87, 0x45, 0xa1, 0xe7, 0xcc, 0x32, 0x14, 0x65, 0x5d, 0xe4,
-	0x11, 0xd4, 0x14, 0x0d, 0x52, 0xb3, 0x55, 0xd5, 0xba, 0x77, 0x33, 0x37, 0x1b, 0x28, 0x86, 0x64,
-	0x7a, 0x3a, 0x87, 0x49, 0x0a, 0x2e, 0x13, 0x22, 0x35, 0x41, 0xa9, 0x29, 0x9b, 0x12, 0xc5, 0x61,
-	0x18, 0x70, 0x11, 0x1d, 0x06, 0xfe, 0x98, 0x4d, 0x14, 0x47, 0x35, 0xba, 0xe0, 0x25, 0x03, 0xd8,
-	0x62, 0x3e, 0x13, 0xcc, 0xf1, 0x12, 0xd8, 0x86, 0x3a, 0xf9, 0x41, 0x89, 0x08, 0xe5, 0xd9, 0x9d,
-	0x93, 0x2c, 0x58, 0x4f, 0xff, 0x7c, 0x02, 0xeb, 0x1c, 0x48, 0x11, 0x54, 0x32, 0xef, 0x3f, 0xce,
-	0xcf, 0xfb, 0x9d, 0x42, 0xad, 0x3a, 0x3c, 0x3b, 0xdf, 0x7f, 0xae, 0x00, 0x5c, 0xf1, 0x70, 0x4d,
-	0x9a, 0x17, 0x84, 0x55, 0x5d, 0x29, 0xac, 0xf5, 0xbc, 0xb0, 0xca, 0x44, 0x74, 0xe3, 0x3a, 0x22,
-	0xea, 0x41, 0xd3, 0x55, 0xe5, 0x0d, 0xdd, 0x57, 0x38, 0x75, 0x92, 0xdf, 0x01, 0xef, 0x2d, 0xe1,
-	0x40, 0x83, 0x68, 0x2e, 0xc4, 0x66, 0x40, 0x8a, 0x98, 0x85, 0xd1, 0x67, 0xbc, 0xfd, 0xe8, 0xb3,
-	0xa0, 0xc6, 0xf1, 0x75, 0xcc, 0x38, 0x8e, 0x92, 0x07, 0x70, 0x6e, 0xdb, 0xbf, 0x1a, 0xd0, 0xcc,
-	0x9e, 0x55, 0xca, 0x83, 0x71, 0x1d, 0x1e, 0xae, 0x3b, 0xa9, 0xed, 0x3f, 0x0c, 0x30, 0xf5, 0x65,
-	0x62, 0x8e, 0x57, 0x63, 0x52, 0x4f, 0xd5, 0x73, 0x68, 0x86, 0x99, 0xeb, 0x26, 0x83, 0x35, 0xfb,
-	0xe3, 0x66, 0x59, 0x68, 0x8e, 0x76, 0x2d, 0xef, 0x5c, 0x2a, 0xeb, 0x05, 0x6c, 0x17, 0x20, 0xff,
-	0x8a, 0xb8, 0x1f, 0x3c, 0x81, 0xf6, 0x22, 0x61, 0xa4, 0x09, 0xb5, 0x5e, 0xff, 0xf9, 0xc9, 0xf0,
-	0x8c, 0x9e, 0xb7, 0xd7, 0xc8, 0x16, 0xd4, 0xbf, 0xef, 0x3d, 0xed, 0xf7, 0x9e, 0x49, 0xd3, 0x90,
-	0x9b, 0xfd, 0x93, 0x61, 0xef, 0xe0, 0xf4, 0xa8, 0xdf, 0xae, 0x74, 0xff, 0xae, 0x40, 0x2d, 0x2d,
-	0x86, 0x1c, 0xc0, 0x66, 0xb2, 0x26, 0x77, 0x8a, 0x5f, 0x72, 0x52, 0xab, 0x65, 0x95, 0x6d, 0xe9,
-	0xa7, 0xc4, 0x5e, 0x23, 0xa7, 0xf3, 0xa1, 0xa6, 0x5e, 0x2c, 0x72, 0xaf, 0x88, 0xce, 0x3e, 0x65,
-	0x6f, 0xc8, 0xd6, 0x87, 0x9b, 0xc7, 0x28, 0x72, 0x53, 0xf2, 0x76, 0xa1, 0xcf, 0x47, 0xf2, 0xef,
-	0x90, 0xb5, 0xb3, 0x64, 0xf6, 0xd8, 0x6b, 0xe4, 0x5b, 0xd8, 0x3a, 0x46, 0x31, 0x50, 0xff, 0xa9,
-	0x56, 0xe6, 0xc8, 0x4d, 0xce, 0x39, 0xdc, 0x5e, 0x23, 0xdf, 0x41, 0x7d, 0xde, 0x7b, 0x72, 0xff,
-	0x2d, 0x14, 0x61, 0x2d, 0x39, 0xc2, 0x5e, 0x7b, 0xb9, 0xa1, 0x3c, 0x9f, 0xfe, 0x13, 0x00, 0x00,
-	0xff, 0xff, 0x13, 0xd0, 0x20, 0xeb, 0x00, 0x0e, 0x00, 0x00,
+	// 1196 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe5, 0x57, 0xdd, 0x8e, 0xdb, 0x44,
+	0x14, 0x5e, 0x27, 0xbb, 0x9b, 0xe4, 0x24, 0x9b, 0x66, 0xa7, 0xb0, 0x49, 0xdd, 0xa5, 0x5a, 0xb9,
+	0x08, 0x50, 0x05, 0x29, 0x0d, 0x42, 0x94, 0x8a, 0xbf, 0xec, 0x26, 0x54, 0x5b, 0x6d, 0xbb, 0x61,
+	0xb2, 0xaa, 0xd8, 0x4b, 0xd7, 0x99, 0x4d, 0xad, 0x3a, 0xb6, 0x19, 0x8f, 0x57, 0x0a, 0x97, 0x5c,
+	0x22, 0x21, 0xf1, 0x02, 0xbc, 0x05, 0x17, 0xbc, 0x05, 0x37, 0x5c, 0x73, 0xc9, 0x73, 0x30, 0x3f,
+	0xb6, 0x63, 0xc7, 0x4e, 0xba, 0x5a, 0x21, 0x81, 0xc4, 0xdd, 0xcc, 0x99, 0xef, 0x9c, 0x99, 0xf3,
+	0xcd, 0x37, 0xe7, 0xd8, 0xd0, 0x34, 0x5d, 0xd3, 0x99, 0x7f, 0x4f, 0x68, 0xd7, 0xa7, 0x1e, 0xf3,
+	0x50, 0xcd, 0x0f, 0x9d, 0x70, 0x66, 0x53, 0xdf, 0xd2, 0x1b, 0xbe, 0x13, 0x4e, 0x6d, 0x57, 0x2d,
+	0xe8, 0xb7, 0xa7, 0x9e, 0x37, 0x75, 0xc8, 0x7d, 0x39, 0x7b, 0x11, 0x5e, 0xdc, 0x27, 0x33, 0x9f,
+	0xcd, 0xa3, 0xc5, 0xfd, 0xe5, 0xc5, 0x80, 0xd1, 0xd0, 0x62, 0x6a, 0xd5, 0xf8, 0xa1, 0x04, 0xcd,
+	0xbe, 0xda, 0x06, 0x93, 0xef, 0x42, 0x12, 0x30, 0x84, 0x60, 0x93, 0xcd, 0x7d, 0xd2, 0xd1, 0x0e,
+	0xb4, 0xf7, 0x6a, 0x58, 0x8e, 0xd1, 0x27, 0x00, 0x1c, 0xef, 0x13, 0xca, 0x6c, 0x12, 0x74, 0x4a,
+	0x7c, 0xa5, 0xde, 0x6b, 0x77, 0x55, 0xe4, 0x6e, 0x1c, 0xb9, 0x3b, 0x96, 0x91, 0x71, 0x0a, 0x8a,
+	0x5a, 0x50, 0x0e, 0xa9, 0xdb, 0x29, 0xcb, 0x58, 0x62, 0x28, 0xc2, 0xbb, 0xe6, 0x8c, 0x74, 0x36,
+	0x55, 0x78, 0x31, 0x46, 0x9f, 0x41, 0xc5, 0xf3, 0x99, 0xed, 0xb9, 0x41, 0x67, 0x4b, 0xc6, 0x36,
+	0xba, 0x49, 0xae, 0xdd, 0xe8, 0x78, 0x14, 0x93, 0xc0, 0x0b, 0xa9, 0x45, 0x4e, 0x15, 0x12, 0xc7,
+	0x2e, 0xe8, 0x4b, 0xa8, 0xf2, 0x1d, 0x2f, 0xed, 0x09, 0xa1, 0x9d, 0x6d, 0xe9, 0x7e, 0xb7, 0xc0,
+	0x7d, 0x14, 0x41, 0xe2, 0x30, 0x38, 0x71, 0x32, 0x7e, 0xd9, 0x84, 0xd6, 0xf2, 0x2e, 0xff, 0x3f,
+	0x1a, 0xd0, 0x1e, 0x6c, 0xfb, 0x26, 0x25, 0x2e, 0xeb, 0x54, 0xe4, 0xa1, 0xa2, 0x19, 0x32, 0xa0,
+	0x31, 0x21, 0x3e, 0x71, 0x27, 0xc4, 0xb5, 0x44, 0xde, 0xd5, 0x83, 0x32, 0x5f, 0xcd, 0xd8, 0x90,
+	0x0d, 0x6f, 0x44, 0xe9, 0xce, 0x07, 0x69, 0x6c, 0x8d, 0x63, 0xeb, 0xbd, 0x8f, 0xd7, 0xe4, 0xd1,
+	0x1d, 0x15, 0xf8, 0x0d, 0x5d, 0x46, 0xe7, 0xb8, 0x30, 0xa4, 0xee, 0xc3, 0xad, 0x95, 0x2e, 0x82,
+	0xe8, 0x57, 0x64, 0x1e, 0x5d, 0x9a, 0x18, 0xa2, 0xcf, 0x61, 0xeb, 0xd2, 0x74, 0x42, 0x12, 0x5d,
+	0xd7, 0xbb, 0xc5, 0x9c, 0xe4, 0xc2, 0x61, 0xe5, 0xf5, 0xa8, 0xf4, 0x50, 0x33, 0xfe, 0x28, 0x43,
+	0x7b, 0x05, 0xfd, 0xa8, 0x03, 0x15, 0x71, 0xf1, 0xc4, 0x62, 0x72, 0xd3, 0x2a, 0x8e, 0xa7, 0xe8,
+	0x6d, 0xd8, 0xb1, 0xa7, 0xae, 0x47, 0xc9, 0xd1, 0x4b, 0xd3, 0x9d, 0x4a, 0xbd, 0x08, 0xde, 0xb2,
+	0x46, 0xf4, 0x21, 0xdc, 0x9c, 0x10, 0x87, 0x30, 0x72, 0x48, 0x2e, 0xb8, 0x19, 0x13, 0xdf, 0x31,
+	0x2d, 0x22, 0x95, 0x52, 0xc5, 0x45, 0x4b, 0xe8, 0x0b, 0xd0, 0x0b, 0xcc, 0x03, 0x72, 0x61, 0xbb,
+	0x64, 0x22, 0xf5, 0x54, 0xc5, 0x6b, 0x10, 0xe8, 0x21, 0xb4, 0xcd, 0xc9, 0xc4, 0x16, 0xc7, 0x37,
+	0x9d, 0x31, 0xb1, 0x28, 0x61, 0xa7, 0x21, 0xf3, 0x43, 0x26, 0x54, 0x27, 0x4e, 0xb8, 0x6a, 0x59,
+	0xe4, 0x6a, 0x3a, 0xb6, 0x19, 0xf0, 0x5c, 0xb6, 0x25, 0x32, 0x9e, 0xa2, 0x73, 0x68, 0x5a, 0x61,
+	0xc0, 0xbc, 0xd9, 0x99, 0x3d, 0x23, 0x9e, 0x08, 0x55, 0x91, 0x6c, 0x3f, 0x78, 0xbd, 0x80, 0xbb,
+	0x47, 0x19, 0x47, 0xbc, 0x14, 0x48, 0xff, 0x16, 0x9a, 0x59, 0x84, 0xd0, 0x29, 0x3f, 0x95, 0xc9,
+	0xd4, 0xdb, 0xd4, 0x70, 0x34, 0x13, 0xf6, 0xd0, 0x9f, 0x08, 0x7b, 0x49, 0xd9, 0xd5, 0x4c, 0xd8,
+	0x15, 0x1d, 0x92, 0x55, 0x6e, 0x57, 0x33, 0xe3, 0x27, 0x0d, 0x3a, 0xab, 0x9e, 0xc5, 0xbf, 0xf0,
+	0xfc, 0x8d, 0x1e, 0xec, 0xaf, 0x53, 0xa4, 0xf0, 0xe1, 0xae, 0x01, 0x3f, 0x92, 0xe0, 0x5e, 0x8e,
+	0x8d, 0x11, 0xdc, 0x8c, 0x7c, 0xc6, 0xcc, 0xb4, 0x5e, 0xc5, 0x35, 0xfc, 0x53, 0xa8, 0xd1, 0x28,
+	0x13, 0x85, 0xaf, 0xf7, 0x6e, 0xaf, 0xb9, 0x0a, 0xbc, 0x40, 0x1b, 0xdf, 0xc0, 0x8d, 0xa4, 0x21,
+	0x04, 0x3e, 0xbf, 0x20, 0xa1, 0xb8, 0xfa, 0xc4, 0x36, 0xb9, 0x6c, 0x03, 0x66, 0x5b, 0x4a, 0xc7,
+	0xf5, 0xde, 0x7e, 0x3e, 0xde, 0x20, 0x01, 0xe1, 0xb4, 0x83, 0xf1, 0x6b, 0x09, 0x76, 0x73, 0x10,
+	0x74, 0x87, 0xb3, 0xe9, 0x39, 0xb6, 0x35, 0x7f, 0x26, 0x88, 0x50, 0x3c, 0xa7, 0x2c, 0xe8, 0x1d,
+	0x68, 0xaa, 0xd9, 0x88, 0x27, 0x26, 0x31, 0x25, 0x89, 0x59, 0xb2, 0xa2, 0xf7, 0x61, 0x77, 0x61,
+	0x79, 0x4e, 0x68, 0xc0, 0x55, 0x15, 0x51, 0x9d, 0x5f, 0x40, 0x07, 0x3c, 0x17, 0x12, 0x58, 0xd4,
+	0x96, 0xea, 0x8b, 0xf8, 0x4f, 0x9b, 0x84, 0xca, 0x67, 0x24, 0x08, 0xcc, 0x29, 0x91, 0x55, 0x98,
+	0xab, 0x3c, 0x9a, 0x4a, 0x4d, 0x98, 0xd3, 0x58, 0xfc, 0x72, 0x8c, 0x1e, 0x43, 0x8b, 0xb8, 0xfc,
+	0x95, 0x59, 0x64, 0xc6, 0x6b, 0xe5, 0x09, 0xb9, 0x24, 0x8e, 0xd4, 0x7e, 0x33, 0x43, 0xf8, 0x70,
+	0x09, 0x82, 0x73, 0x4e, 0xb1, 0x46, 0xaa, 0x89, 0x46, 0x8c, 0xbf, 0x4a, 0xd0, 0x88, 0x6f, 0xea,
+	0x98, 0xc3, 0x13, 0xd1, 0x68, 0xa9, 0x9e, 0x21, 0xf2, 0xb1, 0x03, 0xfe, 0xc0, 0xe7, 0x29, 0x8a,
+	0xd2, 0x26, 0xf4, 0x80, 0xf7, 0x05, 0x41, 0x83, 0xd0, 0x6c, 0x59, 0x5e, 0xdd, 0x9b, 0xa9, 0x93,
+	0x8d, 0x24, 0x43, 0x22, 0x3c, 0x4e, 0x60, 0x82, 0x82, 0xcb, 0x88, 0x48, 0x45, 0x50, 0x3c, 0x15,
+	0x97, 0x12, 0x84, 0xbe, 0xef, 0x51, 0x16, 0x1c, 0x79, 0xee, 0x85, 0x3d, 0x95, 0x1c, 0x55, 0xf1,
+	0x92, 0x15, 0x8d, 0x78, 0xf1, 0x73, 0x79, 0x11, 0x31, 0x9d, 0x08, 0xb6, 0x2d, 0x77, 0xbe, 0x57,
+	0x20, 0x42, 0xb1, 0x77, 0xf7, 0x38, 0x0d, 0x56, 0xd5, 0x3f, 0x1b, 0x40, 0x3f, 0x07, 0x94, 0x07,
+	0x15, 0xd4, 0xfb, 0x0f, 0xb2, 0xf5, 0xbe, 0x9d, 0xcb, 0x55, 0xb9, 0xa7, 0xeb, 0xfb, 0x8f, 0x25,
+	0x80, 0x05, 0x0f, 0xd7, 0xa4, 0x79, 0x49, 0x58, 0xe5, 0xb5, 0xc2, 0xda, 0xcc, 0x0a, 0xab, 0x48,
+	0x44, 0x5b, 0xd7, 0x11, 0x51, 0x1f, 0x1a, 0x96, 0x4c, 0x6f, 0x6c, 0xbd, 0x24, 0x33, 0x33, 0xfa,
+	0x0e, 0x78, 0x6b, 0x05, 0x07, 0x0a, 0x84, 0x33, 0x2e, 0x86, 0x0d, 0x28, 0x8f, 0x59, 0x2a, 0x7d,
+	0xda, 0xd5, 0x4b, 0x9f, 0x0e, 0x55, 0xca, 0x8b, 0x92, 0x4d, 0x79, 0x6f, 0x52, 0x0d, 0x30, 0x99,
+	0x1b, 0x3f, 0x6b, 0xd0, 0x48, 0xef, 0x55, 0xc8, 0x83, 0x76, 0x1d, 0x1e, 0xae, 0x5b, 0xa9, 0x8d,
+	0xdf, 0x79, 0x4f, 0x50, 0x87, 0x09, 0x29, 0x59, 0x94, 0x49, 0x55, 0x55, 0xcf, 0xa1, 0xe1, 0xa7,
+	0x8e, 0x1b, 0x15, 0xd6, 0xf4, 0xc7, 0xcd, 0x2a, 0xd7, 0x0c, 0xed, 0x4a, 0xde, 0x99, 0x50, 0xbc,
+	0xcb, 0xed, 0xe6, 0x20, 0xff, 0x8c, 0xb8, 0xff, 0xd4, 0xa0, 0x8e, 0x39, 0x35, 0xbc, 0x20, 0x4b,
+	0x15, 0xfe, 0x57, 0xcb, 0x6e, 0xf6, 0xca, 0xb6, 0xae, 0x7e, 0x65, 0xa7, 0xb0, 0x1b, 0xe7, 0xb7,
+	0x68, 0x59, 0x8f, 0xa0, 0x41, 0x17, 0x49, 0xc7, 0x3d, 0x70, 0x2f, 0xc5, 0x57, 0x8a, 0x13, 0x9c,
+	0xc1, 0xde, 0x3b, 0x81, 0xd6, 0xb2, 0xc4, 0x50, 0x03, 0xaa, 0xfd, 0xc1, 0xf3, 0xe3, 0xf1, 0x29,
+	0x3e, 0x6f, 0x6d, 0xa0, 0x1d, 0xa8, 0x3d, 0xed, 0x3f, 0x1b, 0xf4, 0xcf, 0xc4, 0x54, 0x13, 0x8b,
+	0x83, 0xe3, 0x71, 0xff, 0xf0, 0x64, 0x38, 0x68, 0x95, 0xc4, 0x22, 0x1e, 0x3e, 0x1d, 0x0e, 0x8e,
+	0xfb, 0x67, 0xc3, 0x56, 0xb9, 0xf7, 0x5b, 0x99, 0xbb, 0x46, 0x6a, 0x40, 0x87, 0x50, 0x89, 0xc6,
+	0xe8, 0x56, 0xbe, 0x14, 0x46, 0x62, 0xd1, 0xf5, 0xa2, 0x25, 0x95, 0x98, 0xb1, 0x81, 0xbe, 0xe6,
+	0xf1, 0xe3, 0x7c, 0xd7, 0x45, 0xd9, 0x2f, 0x48, 0x36, 0x1d, 0xe7, 0x24, 0xe9, 0x2e, 0xf2, 0xd3,
+	0x01, 0xdd, 0xc9, 0x87, 0x4a, 0x7f, 0x53, 0xbc, 0xe6, 0x54, 0x03, 0xb8, 0xf1, 0x98, 0xb0, 0x4c,
+	0xbb, 0xda, 0xcb, 0xdd, 0xde, 0x50, 0xfc, 0x97, 0xea, 0xed, 0x15, 0x4d, 0x80, 0x47, 0xf9, 0x0a,
+	0x76, 0x78, 0x94, 0x91, 0xfc, 0xb9, 0x5d, 0x1b, 0x23, 0xd3, 0xc2, 0x12, 0x38, 0x8f, 0xf0, 0x04,
+	0x6a, 0xc9, 0x23, 0x44, 0x77, 0xaf, 0xf0, 0x34, 0xf5, 0x15, 0x5b, 0x18, 0x1b, 0x2f, 0xb6, 0xa5,
+	0xe5, 0xa3, 0xbf, 0x01, 0xc5, 0x8d, 0xeb, 0x9e, 0x89, 0x0f, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -1072,6 +1193,10 @@ type AnalyzerClient interface {
 	// Analyze analyzes a single resource object, and returns any errors that it finds.
 	// Called with the "inputs" to the resource, before it is updated.
 	Analyze(ctx context.Context, in *AnalyzeRequest, opts ...grpc.CallOption) (*AnalyzeResponse, error)
+	// Remediate optionally transforms a single resource object's inputs. Called with the "inputs" to the
+	// resource, before they are checked by the resource's provider, and returns the inputs as modified by each
+	// policy whose enforcement level is "remediate".
+	Remediate(ctx context.Context, in *AnalyzeRequest, opts ...grpc.CallOption) (*RemediateResponse, error)
 	// AnalyzeStack analyzes all resources within a stack, at the end of a successful
 	// preview or update. The provided resources are the "outputs", after any mutations
 	// have taken place.
@@ -1101,6 +1226,15 @@ func (c *analyzerClient) Analyze(ctx context.Context, in *AnalyzeRequest, opts .
 	return out, nil
 }
 
+func (c *analyzerClient) Remediate(ctx context.Context, in *AnalyzeRequest, opts ...grpc.CallOption) (*RemediateResponse, error) {
+	out := new(RemediateResponse)
+	err := c.cc.Invoke(ctx, "/pulumirpc.Analyzer/Remediate", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *analyzerClient) AnalyzeStack(ctx context.Context, in *AnalyzeStackRequest, opts ...grpc.CallOption) (*AnalyzeResponse, error) {
 	out := new(AnalyzeResponse)
 	err := c.cc.Invoke(ctx, "/pulumirpc.Analyzer/AnalyzeStack", in, out, opts...)
@@ -1142,6 +1276,10 @@ type AnalyzerServer interface {
 	// Analyze analyzes a single resource object, and returns any errors that it finds.
 	// Called with the "inputs" to the resource, before it is updated.
 	Analyze(context.Context, *AnalyzeRequest) (*AnalyzeResponse, error)
+	// Remediate optionally transforms a single resource object's inputs. Called with the "inputs" to the
+	// resource, before they are checked by the resource's provider, and returns the inputs as modified by each
+	// policy whose enforcement level is "remediate".
+	Remediate(context.Context, *AnalyzeRequest) (*RemediateResponse, error)
 	// AnalyzeStack analyzes all resources within a stack, at the end of a successful
 	// preview or update. The provided resources are the "outputs", after any mutations
 	// have taken place.
@@ -1161,6 +1299,9 @@ type UnimplementedAnalyzerServer struct {
 func (*UnimplementedAnalyzerServer) Analyze(ctx context.Context, req *AnalyzeRequest) (*AnalyzeResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Analyze not implemented")
 }
+func (*UnimplementedAnalyzerServer) Remediate(ctx context.Context, req *AnalyzeRequest) (*RemediateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Remediate not implemented")
+}
 func (*UnimplementedAnalyzerServer) AnalyzeStack(ctx context.Context, req *AnalyzeStackRequest) (*AnalyzeResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method AnalyzeStack not implemented")
 }
@@ -1196,6 +1337,24 @@ func _Analyzer_Analyze_Handler(srv interface{}, ctx context.Context, dec func(in
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Analyzer_Remediate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AnalyzeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AnalyzerServer).Remediate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pulumirpc.Analyzer/Remediate",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AnalyzerServer).Remediate(ctx, req.(*AnalyzeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Analyzer_AnalyzeStack_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(AnalyzeStackRequest)
 	if err := dec(in); err != nil {
@@ -1276,6 +1435,10 @@ var _Analyzer_serviceDesc = grpc.ServiceDesc{
 			MethodName: "Analyze",
 			Handler:    _Analyzer_Analyze_Handler,
 		},
+		{
+			MethodName: "Remediate",
+			Handler:    _Analyzer_Remediate_Handler,
+		},
 		{
 			MethodName: "AnalyzeStack",
 			Handler:    _Analyzer_AnalyzeStack_Handler,
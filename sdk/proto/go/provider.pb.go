@@ -1310,6 +1310,297 @@ func (m *ErrorResourceInitFailed) GetInputs() *_struct.Struct {
 	return nil
 }
 
+// ConstructRequest is the request to construct a component resource. The provider registers the component and its
+// children with the resource monitor at monitorEndpoint.
+type ConstructRequest struct {
+	Project              string                                            `protobuf:"bytes,1,opt,name=project,proto3" json:"project,omitempty"`
+	Stack                string                                            `protobuf:"bytes,2,opt,name=stack,proto3" json:"stack,omitempty"`
+	Config               map[string]string                                 `protobuf:"bytes,3,rep,name=config,proto3" json:"config,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	DryRun               bool                                              `protobuf:"varint,4,opt,name=dryRun,proto3" json:"dryRun,omitempty"`
+	Parallel             int32                                             `protobuf:"varint,5,opt,name=parallel,proto3" json:"parallel,omitempty"`
+	MonitorEndpoint      string                                            `protobuf:"bytes,6,opt,name=monitorEndpoint,proto3" json:"monitorEndpoint,omitempty"`
+	Type                 string                                            `protobuf:"bytes,7,opt,name=type,proto3" json:"type,omitempty"`
+	Name                 string                                            `protobuf:"bytes,8,opt,name=name,proto3" json:"name,omitempty"`
+	Parent               string                                            `protobuf:"bytes,9,opt,name=parent,proto3" json:"parent,omitempty"`
+	Inputs               *_struct.Struct                                   `protobuf:"bytes,10,opt,name=inputs,proto3" json:"inputs,omitempty"`
+	InputDependencies    map[string]*ConstructRequest_PropertyDependencies `protobuf:"bytes,11,rep,name=inputDependencies,proto3" json:"inputDependencies,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	Protect              bool                                              `protobuf:"varint,12,opt,name=protect,proto3" json:"protect,omitempty"`
+	Providers            map[string]string                                 `protobuf:"bytes,13,rep,name=providers,proto3" json:"providers,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	Aliases              []string                                          `protobuf:"bytes,14,rep,name=aliases,proto3" json:"aliases,omitempty"`
+	Dependencies         []string                                          `protobuf:"bytes,15,rep,name=dependencies,proto3" json:"dependencies,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                                          `json:"-"`
+	XXX_unrecognized     []byte                                            `json:"-"`
+	XXX_sizecache        int32                                             `json:"-"`
+}
+
+func (m *ConstructRequest) Reset()         { *m = ConstructRequest{} }
+func (m *ConstructRequest) String() string { return proto.CompactTextString(m) }
+func (*ConstructRequest) ProtoMessage()    {}
+func (*ConstructRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_c6a9f3c02af3d1c8, []int{21}
+}
+
+func (m *ConstructRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ConstructRequest.Unmarshal(m, b)
+}
+func (m *ConstructRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ConstructRequest.Marshal(b, m, deterministic)
+}
+func (m *ConstructRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ConstructRequest.Merge(m, src)
+}
+func (m *ConstructRequest) XXX_Size() int {
+	return xxx_messageInfo_ConstructRequest.Size(m)
+}
+func (m *ConstructRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ConstructRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ConstructRequest proto.InternalMessageInfo
+
+func (m *ConstructRequest) GetProject() string {
+	if m != nil {
+		return m.Project
+	}
+	return ""
+}
+
+func (m *ConstructRequest) GetStack() string {
+	if m != nil {
+		return m.Stack
+	}
+	return ""
+}
+
+func (m *ConstructRequest) GetConfig() map[string]string {
+	if m != nil {
+		return m.Config
+	}
+	return nil
+}
+
+func (m *ConstructRequest) GetDryRun() bool {
+	if m != nil {
+		return m.DryRun
+	}
+	return false
+}
+
+func (m *ConstructRequest) GetParallel() int32 {
+	if m != nil {
+		return m.Parallel
+	}
+	return 0
+}
+
+func (m *ConstructRequest) GetMonitorEndpoint() string {
+	if m != nil {
+		return m.MonitorEndpoint
+	}
+	return ""
+}
+
+func (m *ConstructRequest) GetType() string {
+	if m != nil {
+		return m.Type
+	}
+	return ""
+}
+
+func (m *ConstructRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *ConstructRequest) GetParent() string {
+	if m != nil {
+		return m.Parent
+	}
+	return ""
+}
+
+func (m *ConstructRequest) GetInputs() *_struct.Struct {
+	if m != nil {
+		return m.Inputs
+	}
+	return nil
+}
+
+func (m *ConstructRequest) GetInputDependencies() map[string]*ConstructRequest_PropertyDependencies {
+	if m != nil {
+		return m.InputDependencies
+	}
+	return nil
+}
+
+func (m *ConstructRequest) GetProtect() bool {
+	if m != nil {
+		return m.Protect
+	}
+	return false
+}
+
+func (m *ConstructRequest) GetProviders() map[string]string {
+	if m != nil {
+		return m.Providers
+	}
+	return nil
+}
+
+func (m *ConstructRequest) GetAliases() []string {
+	if m != nil {
+		return m.Aliases
+	}
+	return nil
+}
+
+func (m *ConstructRequest) GetDependencies() []string {
+	if m != nil {
+		return m.Dependencies
+	}
+	return nil
+}
+
+// PropertyDependencies describes the resources that a particular property depends on.
+type ConstructRequest_PropertyDependencies struct {
+	Urns                 []string `protobuf:"bytes,1,rep,name=urns,proto3" json:"urns,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ConstructRequest_PropertyDependencies) Reset()         { *m = ConstructRequest_PropertyDependencies{} }
+func (m *ConstructRequest_PropertyDependencies) String() string { return proto.CompactTextString(m) }
+func (*ConstructRequest_PropertyDependencies) ProtoMessage()    {}
+func (*ConstructRequest_PropertyDependencies) Descriptor() ([]byte, []int) {
+	return fileDescriptor_c6a9f3c02af3d1c8, []int{21, 0}
+}
+
+func (m *ConstructRequest_PropertyDependencies) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ConstructRequest_PropertyDependencies.Unmarshal(m, b)
+}
+func (m *ConstructRequest_PropertyDependencies) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ConstructRequest_PropertyDependencies.Marshal(b, m, deterministic)
+}
+func (m *ConstructRequest_PropertyDependencies) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ConstructRequest_PropertyDependencies.Merge(m, src)
+}
+func (m *ConstructRequest_PropertyDependencies) XXX_Size() int {
+	return xxx_messageInfo_ConstructRequest_PropertyDependencies.Size(m)
+}
+func (m *ConstructRequest_PropertyDependencies) XXX_DiscardUnknown() {
+	xxx_messageInfo_ConstructRequest_PropertyDependencies.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ConstructRequest_PropertyDependencies proto.InternalMessageInfo
+
+func (m *ConstructRequest_PropertyDependencies) GetUrns() []string {
+	if m != nil {
+		return m.Urns
+	}
+	return nil
+}
+
+// ConstructResponse is the response from constructing a component resource.
+type ConstructResponse struct {
+	Urn                  string                                             `protobuf:"bytes,1,opt,name=urn,proto3" json:"urn,omitempty"`
+	State                *_struct.Struct                                    `protobuf:"bytes,2,opt,name=state,proto3" json:"state,omitempty"`
+	StateDependencies    map[string]*ConstructResponse_PropertyDependencies `protobuf:"bytes,3,rep,name=stateDependencies,proto3" json:"stateDependencies,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}                                           `json:"-"`
+	XXX_unrecognized     []byte                                             `json:"-"`
+	XXX_sizecache        int32                                              `json:"-"`
+}
+
+func (m *ConstructResponse) Reset()         { *m = ConstructResponse{} }
+func (m *ConstructResponse) String() string { return proto.CompactTextString(m) }
+func (*ConstructResponse) ProtoMessage()    {}
+func (*ConstructResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_c6a9f3c02af3d1c8, []int{22}
+}
+
+func (m *ConstructResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ConstructResponse.Unmarshal(m, b)
+}
+func (m *ConstructResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ConstructResponse.Marshal(b, m, deterministic)
+}
+func (m *ConstructResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ConstructResponse.Merge(m, src)
+}
+func (m *ConstructResponse) XXX_Size() int {
+	return xxx_messageInfo_ConstructResponse.Size(m)
+}
+func (m *ConstructResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ConstructResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ConstructResponse proto.InternalMessageInfo
+
+func (m *ConstructResponse) GetUrn() string {
+	if m != nil {
+		return m.Urn
+	}
+	return ""
+}
+
+func (m *ConstructResponse) GetState() *_struct.Struct {
+	if m != nil {
+		return m.State
+	}
+	return nil
+}
+
+func (m *ConstructResponse) GetStateDependencies() map[string]*ConstructResponse_PropertyDependencies {
+	if m != nil {
+		return m.StateDependencies
+	}
+	return nil
+}
+
+// PropertyDependencies describes the resources that a particular property depends on.
+type ConstructResponse_PropertyDependencies struct {
+	Urns                 []string `protobuf:"bytes,1,rep,name=urns,proto3" json:"urns,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ConstructResponse_PropertyDependencies) Reset() {
+	*m = ConstructResponse_PropertyDependencies{}
+}
+func (m *ConstructResponse_PropertyDependencies) String() string { return proto.CompactTextString(m) }
+func (*ConstructResponse_PropertyDependencies) ProtoMessage()    {}
+func (*ConstructResponse_PropertyDependencies) Descriptor() ([]byte, []int) {
+	return fileDescriptor_c6a9f3c02af3d1c8, []int{22, 0}
+}
+
+func (m *ConstructResponse_PropertyDependencies) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ConstructResponse_PropertyDependencies.Unmarshal(m, b)
+}
+func (m *ConstructResponse_PropertyDependencies) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ConstructResponse_PropertyDependencies.Marshal(b, m, deterministic)
+}
+func (m *ConstructResponse_PropertyDependencies) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ConstructResponse_PropertyDependencies.Merge(m, src)
+}
+func (m *ConstructResponse_PropertyDependencies) XXX_Size() int {
+	return xxx_messageInfo_ConstructResponse_PropertyDependencies.Size(m)
+}
+func (m *ConstructResponse_PropertyDependencies) XXX_DiscardUnknown() {
+	xxx_messageInfo_ConstructResponse_PropertyDependencies.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ConstructResponse_PropertyDependencies proto.InternalMessageInfo
+
+func (m *ConstructResponse_PropertyDependencies) GetUrns() []string {
+	if m != nil {
+		return m.Urns
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterEnum("pulumirpc.PropertyDiff_Kind", PropertyDiff_Kind_name, PropertyDiff_Kind_value)
 	proto.RegisterEnum("pulumirpc.DiffResponse_DiffChanges", DiffResponse_DiffChanges_name, DiffResponse_DiffChanges_value)
@@ -1337,92 +1628,120 @@ func init() {
 	proto.RegisterType((*UpdateResponse)(nil), "pulumirpc.UpdateResponse")
 	proto.RegisterType((*DeleteRequest)(nil), "pulumirpc.DeleteRequest")
 	proto.RegisterType((*ErrorResourceInitFailed)(nil), "pulumirpc.ErrorResourceInitFailed")
+	proto.RegisterType((*ConstructRequest)(nil), "pulumirpc.ConstructRequest")
+	proto.RegisterMapType((map[string]string)(nil), "pulumirpc.ConstructRequest.ConfigEntry")
+	proto.RegisterMapType((map[string]*ConstructRequest_PropertyDependencies)(nil), "pulumirpc.ConstructRequest.InputDependenciesEntry")
+	proto.RegisterMapType((map[string]string)(nil), "pulumirpc.ConstructRequest.ProvidersEntry")
+	proto.RegisterType((*ConstructRequest_PropertyDependencies)(nil), "pulumirpc.ConstructRequest.PropertyDependencies")
+	proto.RegisterType((*ConstructResponse)(nil), "pulumirpc.ConstructResponse")
+	proto.RegisterMapType((map[string]*ConstructResponse_PropertyDependencies)(nil), "pulumirpc.ConstructResponse.StateDependenciesEntry")
+	proto.RegisterType((*ConstructResponse_PropertyDependencies)(nil), "pulumirpc.ConstructResponse.PropertyDependencies")
 }
 
 func init() { proto.RegisterFile("provider.proto", fileDescriptor_c6a9f3c02af3d1c8) }
 
 var fileDescriptor_c6a9f3c02af3d1c8 = []byte{
-	// 1274 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xc4, 0x57, 0xdd, 0x72, 0xdb, 0x44,
-	0x14, 0xb6, 0x2c, 0xc7, 0x89, 0x8f, 0x7f, 0xaa, 0x2e, 0xd0, 0xba, 0x6e, 0x2e, 0x3c, 0x82, 0x0b,
-	0x43, 0xc1, 0xe9, 0xa4, 0x17, 0xd0, 0x4e, 0x3b, 0x25, 0x89, 0x9d, 0x36, 0xd3, 0x36, 0x0d, 0x4a,
-	0xcb, 0xcf, 0x55, 0x51, 0xa5, 0xb5, 0xa3, 0xb1, 0x2d, 0x89, 0xd5, 0xca, 0x4c, 0xb8, 0xe6, 0x82,
-	0x57, 0xe0, 0x09, 0xb8, 0x62, 0x98, 0xe1, 0x09, 0xb8, 0xe7, 0x19, 0x78, 0x04, 0xde, 0x81, 0xd9,
-	0x1f, 0xc9, 0xbb, 0xb6, 0x93, 0x3a, 0xa1, 0x03, 0x77, 0x3a, 0x7b, 0xce, 0xee, 0x39, 0xdf, 0xb7,
-	0x67, 0xbf, 0x5d, 0x41, 0x23, 0x26, 0xd1, 0x34, 0xf0, 0x31, 0xe9, 0xc6, 0x24, 0xa2, 0x11, 0xaa,
-	0xc4, 0xe9, 0x38, 0x9d, 0x04, 0x24, 0xf6, 0x5a, 0xb5, 0x78, 0x9c, 0x0e, 0x83, 0x50, 0x38, 0x5a,
-	0x37, 0x87, 0x51, 0x34, 0x1c, 0xe3, 0x2d, 0x6e, 0xbd, 0x4e, 0x07, 0x5b, 0x78, 0x12, 0xd3, 0x53,
-	0xe9, 0xdc, 0x9c, 0x77, 0x26, 0x94, 0xa4, 0x1e, 0x15, 0x5e, 0xfb, 0x63, 0xb0, 0x1e, 0x61, 0x7a,
-	0xec, 0x9d, 0xe0, 0x89, 0xeb, 0xe0, 0xef, 0x52, 0x9c, 0x50, 0xd4, 0x84, 0xf5, 0x29, 0x26, 0x49,
-	0x10, 0x85, 0x4d, 0xa3, 0x6d, 0x74, 0xd6, 0x9c, 0xcc, 0xb4, 0x6f, 0xc1, 0x55, 0x25, 0x3a, 0x89,
-	0xa3, 0x30, 0xc1, 0xe8, 0x1a, 0x94, 0x13, 0x3e, 0xc2, 0xa3, 0x2b, 0x8e, 0xb4, 0xec, 0xbf, 0x0d,
-	0xb0, 0xf6, 0xa2, 0x70, 0x10, 0x0c, 0x53, 0x82, 0xb3, 0xb5, 0x1f, 0x43, 0x65, 0xea, 0x92, 0xc0,
-	0x7d, 0x3d, 0xc6, 0x49, 0xd3, 0x68, 0x9b, 0x9d, 0xea, 0xf6, 0x47, 0xdd, 0x1c, 0x57, 0x77, 0x3e,
-	0xbe, 0xfb, 0x65, 0x16, 0xdc, 0x0f, 0x29, 0x39, 0x75, 0x66, 0x93, 0xd1, 0x2d, 0x28, 0xb9, 0x64,
-	0x98, 0x34, 0x8b, 0x6d, 0xa3, 0x53, 0xdd, 0xbe, 0xde, 0x15, 0x30, 0xbb, 0x19, 0xcc, 0xee, 0x31,
-	0x87, 0xe9, 0xf0, 0x20, 0xf4, 0x01, 0xd4, 0x5d, 0xcf, 0xc3, 0x31, 0x3d, 0xc6, 0x1e, 0xc1, 0x34,
-	0x69, 0x9a, 0x6d, 0xa3, 0xb3, 0xe1, 0xe8, 0x83, 0xad, 0xfb, 0xd0, 0xd0, 0xf3, 0x21, 0x0b, 0xcc,
-	0x11, 0x3e, 0x95, 0xc0, 0xd8, 0x27, 0x7a, 0x17, 0xd6, 0xa6, 0xee, 0x38, 0xc5, 0x3c, 0x6f, 0xc5,
-	0x11, 0xc6, 0xbd, 0xe2, 0x67, 0x86, 0x7d, 0x17, 0xae, 0x2a, 0xe5, 0x4b, 0x72, 0x16, 0x12, 0x1b,
-	0x4b, 0x12, 0xdb, 0xbf, 0x1b, 0x70, 0x23, 0x9f, 0xdb, 0x27, 0x24, 0x22, 0xcf, 0x82, 0x24, 0x09,
-	0xc2, 0xe1, 0x13, 0x7c, 0x9a, 0xa0, 0x2f, 0xa0, 0x3a, 0x99, 0x99, 0x92, 0xb5, 0xad, 0x65, 0xac,
-	0xcd, 0x4f, 0xed, 0xce, 0xbe, 0x1d, 0x75, 0x8d, 0xd6, 0x2e, 0xc0, 0xcc, 0x85, 0x10, 0x94, 0x42,
-	0x77, 0x82, 0x25, 0x4c, 0xfe, 0x8d, 0xda, 0x50, 0xf5, 0x71, 0xe2, 0x91, 0x20, 0xa6, 0xac, 0x11,
-	0x04, 0x5a, 0x75, 0xc8, 0xfe, 0xd1, 0x80, 0xfa, 0x41, 0x38, 0x8d, 0x46, 0xf9, 0xe6, 0x5a, 0x60,
-	0xd2, 0x68, 0x94, 0xb1, 0x45, 0xa3, 0xd1, 0xc5, 0x36, 0xa9, 0x05, 0x1b, 0x59, 0xc7, 0xf3, 0xfd,
-	0xa9, 0x38, 0xb9, 0xad, 0xf6, 0x64, 0x89, 0xbb, 0xf2, 0x9e, 0x9c, 0x42, 0x23, 0xab, 0x42, 0x72,
-	0xbe, 0x05, 0x65, 0x82, 0x69, 0x4a, 0x44, 0xfb, 0x9e, 0x93, 0x56, 0x86, 0xa1, 0x3b, 0xb0, 0x31,
-	0x70, 0x83, 0x71, 0x4a, 0x30, 0xab, 0xd4, 0xe4, 0x53, 0x14, 0x76, 0x4f, 0xb0, 0x37, 0xda, 0x17,
-	0x7e, 0x27, 0x0f, 0xb4, 0x7f, 0x80, 0x1a, 0xf7, 0x28, 0xe0, 0xb3, 0x94, 0x15, 0x87, 0x7d, 0x32,
-	0xf0, 0xd1, 0xd8, 0x7f, 0x33, 0x78, 0x16, 0xc4, 0x82, 0x43, 0xfc, 0xbd, 0x68, 0xcc, 0xf3, 0x82,
-	0x59, 0x90, 0x9d, 0x42, 0x5d, 0xe6, 0x9e, 0x41, 0x0e, 0xc2, 0x38, 0x95, 0xfd, 0x75, 0x1e, 0x64,
-	0x11, 0x76, 0x39, 0xc8, 0xbb, 0x12, 0xb2, 0xf4, 0xc8, 0x0d, 0x8b, 0x31, 0xa1, 0xd9, 0x11, 0xc9,
-	0x6d, 0xa6, 0x0a, 0x04, 0xbb, 0x49, 0xde, 0x3a, 0xd2, 0xb2, 0x7f, 0x33, 0xa0, 0xda, 0x0b, 0x06,
-	0x83, 0x8c, 0xb6, 0x06, 0x14, 0x03, 0x5f, 0xce, 0x2e, 0x06, 0x7e, 0x46, 0x63, 0x71, 0x91, 0x46,
-	0xf3, 0x22, 0x34, 0x96, 0x56, 0xa0, 0x91, 0x1d, 0xce, 0x60, 0x18, 0x46, 0x04, 0xef, 0x9d, 0xb8,
-	0xe1, 0x10, 0x27, 0xcd, 0xb5, 0xb6, 0xd9, 0xa9, 0x38, 0xfa, 0xa0, 0xfd, 0x87, 0x01, 0xb5, 0x23,
-	0x09, 0x8b, 0x55, 0x8e, 0x6e, 0x43, 0x69, 0x14, 0x84, 0xa2, 0xe8, 0xc6, 0xf6, 0xa6, 0xc2, 0x9b,
-	0x1a, 0xd6, 0x7d, 0x12, 0x84, 0xbe, 0xc3, 0x23, 0xd1, 0x26, 0x54, 0x38, 0xef, 0x6c, 0x9c, 0x43,
-	0xdb, 0x70, 0x66, 0x03, 0xf6, 0xb7, 0x50, 0x62, 0xb1, 0x68, 0x1d, 0xcc, 0x9d, 0x5e, 0xcf, 0x2a,
-	0xa0, 0x2b, 0x50, 0xdd, 0xe9, 0xf5, 0x5e, 0x39, 0xfd, 0xa3, 0xa7, 0x3b, 0x7b, 0x7d, 0xcb, 0x40,
-	0x00, 0xe5, 0x5e, 0xff, 0x69, 0xff, 0x45, 0xdf, 0x2a, 0x22, 0x04, 0x0d, 0xf1, 0x9d, 0xfb, 0x4d,
-	0xe6, 0x7f, 0x79, 0xd4, 0xdb, 0x79, 0xd1, 0xb7, 0x4a, 0xcc, 0x2f, 0xbe, 0x73, 0xff, 0x9a, 0xfd,
-	0x97, 0x09, 0x35, 0x41, 0xba, 0xec, 0x97, 0x16, 0x6c, 0x10, 0x1c, 0x8f, 0x5d, 0x4f, 0xaa, 0x70,
-	0xc5, 0xc9, 0x6d, 0x76, 0xd4, 0x12, 0x2a, 0x04, 0xba, 0xc8, 0x5d, 0x99, 0x89, 0x6e, 0xc3, 0x3b,
-	0x3e, 0x1e, 0x63, 0x8a, 0x77, 0xf1, 0x20, 0x62, 0x22, 0xc7, 0x67, 0x48, 0x2d, 0x5d, 0xe6, 0x42,
-	0x0f, 0x60, 0xdd, 0x93, 0xdc, 0x96, 0x38, 0x5b, 0xef, 0x2b, 0x6c, 0xa9, 0x15, 0x71, 0x43, 0x32,
-	0xee, 0x64, 0x73, 0x98, 0xd8, 0xfa, 0xc1, 0x60, 0x90, 0x6d, 0x8c, 0x30, 0xd0, 0x33, 0xa8, 0xf9,
-	0x98, 0xba, 0xc1, 0x18, 0xfb, 0x9c, 0xd0, 0x32, 0xef, 0xdf, 0x0f, 0xcf, 0x5c, 0x59, 0x89, 0x15,
-	0xb7, 0x88, 0x36, 0x1d, 0x75, 0xe0, 0xca, 0x89, 0x9b, 0xa8, 0x51, 0xcd, 0x75, 0x8e, 0x68, 0x7e,
-	0xb8, 0xf5, 0x35, 0x5c, 0x5d, 0x58, 0x6c, 0xc9, 0x15, 0xf1, 0x89, 0x7a, 0x45, 0xe8, 0x07, 0x4b,
-	0x6d, 0x10, 0xf5, 0xee, 0x78, 0x20, 0x0e, 0x85, 0x24, 0x00, 0x59, 0x50, 0xeb, 0x1d, 0xec, 0xef,
-	0xbf, 0x7a, 0x79, 0xf8, 0xe4, 0xf0, 0xf9, 0x57, 0x87, 0x56, 0x01, 0xd5, 0xa1, 0xc2, 0x47, 0x0e,
-	0x9f, 0x1f, 0xb2, 0x86, 0xc8, 0xcc, 0xe3, 0xe7, 0xcf, 0xfa, 0x56, 0xd1, 0xa6, 0x50, 0xdf, 0x23,
-	0xd8, 0xa5, 0xf8, 0x6c, 0x31, 0xfa, 0x14, 0x40, 0x9e, 0xcd, 0x00, 0xbf, 0x51, 0x92, 0x94, 0x50,
-	0xd6, 0x0e, 0x34, 0x98, 0xe0, 0x28, 0xa5, 0x7c, 0xa3, 0x0d, 0x27, 0x33, 0xed, 0x6f, 0xa0, 0x91,
-	0x65, 0x95, 0x6d, 0x35, 0x7f, 0x98, 0x2f, 0x9b, 0xd4, 0xfe, 0xd9, 0x80, 0xaa, 0x83, 0x5d, 0x7f,
-	0x75, 0x95, 0xd0, 0x53, 0x99, 0xab, 0xe3, 0x9b, 0x49, 0x67, 0x69, 0x25, 0xe9, 0xb4, 0x7f, 0x32,
-	0xa0, 0x26, 0x6a, 0x7b, 0xcb, 0xa8, 0x95, 0x52, 0xcc, 0xd5, 0x4a, 0xf9, 0xd3, 0x80, 0xfa, 0xcb,
-	0xd8, 0x57, 0x36, 0xfe, 0xff, 0x94, 0x53, 0xa5, 0x53, 0xd6, 0xb4, 0x4e, 0x59, 0x14, 0xda, 0xf2,
-	0x32, 0xa1, 0x3d, 0x80, 0x46, 0x06, 0x46, 0x32, 0xab, 0x33, 0x69, 0xac, 0xde, 0x3f, 0xec, 0x6d,
-	0xd2, 0xe3, 0x7a, 0xf4, 0x1f, 0x74, 0x90, 0x82, 0xbb, 0xa4, 0x9f, 0x90, 0x5f, 0x0d, 0xb8, 0xce,
-	0xdf, 0x64, 0x0e, 0x4e, 0xa2, 0x94, 0x78, 0xf8, 0x20, 0x0c, 0xe8, 0x3e, 0x17, 0x90, 0xb7, 0xd7,
-	0x35, 0x4d, 0x58, 0x17, 0x77, 0x2b, 0x2b, 0x9a, 0xeb, 0xb5, 0x34, 0x2f, 0xdc, 0xda, 0xdb, 0xbf,
-	0xac, 0x83, 0x95, 0x95, 0x7a, 0x94, 0x3d, 0xbd, 0x1e, 0x43, 0x25, 0x7f, 0xf4, 0xa3, 0x9b, 0x8a,
-	0x98, 0xcd, 0xff, 0x38, 0xb4, 0x36, 0x97, 0x3b, 0xc5, 0x66, 0xda, 0x05, 0xb4, 0x0b, 0x55, 0xfe,
-	0x7e, 0x10, 0xef, 0x55, 0xb4, 0xf0, 0xe2, 0xc8, 0xd6, 0x69, 0x2e, 0x3a, 0xf2, 0x35, 0x1e, 0x02,
-	0x70, 0xa5, 0x14, 0x4b, 0x5c, 0x5b, 0x10, 0x7d, 0xb1, 0xc2, 0xf5, 0x33, 0x2e, 0x03, 0xbb, 0xc0,
-	0xe0, 0xe4, 0xef, 0x65, 0x0d, 0xce, 0xfc, 0xbf, 0x87, 0x06, 0x67, 0xe1, 0x65, 0xcf, 0x4b, 0x29,
-	0x8b, 0x97, 0x27, 0x52, 0x0b, 0xd6, 0x9e, 0xc4, 0xad, 0x1b, 0x4b, 0x3c, 0xf9, 0x02, 0x8f, 0xa0,
-	0x76, 0x4c, 0x09, 0x76, 0x27, 0xff, 0x6a, 0x99, 0xdb, 0x06, 0xba, 0x0f, 0x6b, 0x9c, 0xa7, 0xcb,
-	0x51, 0x7a, 0x17, 0x4a, 0xfc, 0x22, 0xbc, 0x04, 0x99, 0x0f, 0xa1, 0x2c, 0xae, 0x00, 0xad, 0x76,
-	0xed, 0x2e, 0xd2, 0x6a, 0xd7, 0xef, 0x0b, 0x91, 0x9b, 0x69, 0xa9, 0x96, 0x5b, 0x11, 0x7e, 0x2d,
-	0xb7, 0x2a, 0xba, 0x22, 0xb7, 0x90, 0x0b, 0x2d, 0xb7, 0x26, 0x87, 0x5a, 0x6e, 0x5d, 0x5b, 0xec,
-	0x02, 0xba, 0x0f, 0x65, 0xa1, 0x11, 0xda, 0x02, 0x9a, 0x6c, 0xb4, 0xae, 0x2d, 0x1c, 0x99, 0x3e,
-	0xfb, 0xb7, 0xb6, 0x0b, 0xe8, 0x1e, 0x94, 0xf7, 0xdc, 0xd0, 0xc3, 0x63, 0x74, 0x46, 0xcc, 0x39,
-	0x73, 0x3f, 0x87, 0xfa, 0x23, 0x4c, 0x8f, 0xf8, 0x3f, 0xfc, 0x41, 0x38, 0x88, 0xce, 0x5c, 0xe2,
-	0x3d, 0xf5, 0xed, 0x90, 0x87, 0xdb, 0x85, 0xd7, 0x65, 0x1e, 0x78, 0xe7, 0x9f, 0x00, 0x00, 0x00,
-	0xff, 0xff, 0x22, 0xcd, 0xb2, 0x66, 0x24, 0x10, 0x00, 0x00,
+	// 1597 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xc5, 0x58, 0xcd, 0x72, 0x1b, 0x45,
+	0x10, 0x8e, 0x7e, 0x6d, 0xb5, 0x64, 0x45, 0x1e, 0x82, 0xa3, 0x28, 0x3e, 0xa4, 0x16, 0xaa, 0x08,
+	0x09, 0x91, 0x83, 0x73, 0x80, 0xa4, 0x92, 0x0a, 0x71, 0x24, 0x07, 0x57, 0x12, 0xc7, 0xac, 0x09,
+	0x3f, 0xa7, 0x64, 0xb3, 0x1a, 0xc9, 0x8b, 0xa5, 0xdd, 0x65, 0x7f, 0x94, 0x32, 0x67, 0x0e, 0xbc,
+	0x02, 0x0f, 0x41, 0x51, 0xc5, 0x13, 0x70, 0xe7, 0x15, 0xe0, 0x11, 0x38, 0x50, 0xbc, 0x00, 0x3d,
+	0x7f, 0xeb, 0x19, 0x49, 0xb6, 0x65, 0x93, 0x82, 0xdb, 0xf4, 0x74, 0x4f, 0xff, 0x7c, 0xd3, 0xd3,
+	0xd3, 0x33, 0x50, 0x0f, 0xa3, 0x60, 0xec, 0xf5, 0x68, 0xd4, 0xc6, 0x41, 0x12, 0x90, 0x4a, 0x98,
+	0x0e, 0xd3, 0x91, 0x17, 0x85, 0x6e, 0xab, 0x16, 0x0e, 0xd3, 0x81, 0xe7, 0x0b, 0x46, 0xeb, 0xf2,
+	0x20, 0x08, 0x06, 0x43, 0xba, 0xc6, 0xa9, 0x57, 0x69, 0x7f, 0x8d, 0x8e, 0xc2, 0xe4, 0x40, 0x32,
+	0x57, 0x27, 0x99, 0x71, 0x12, 0xa5, 0x6e, 0x22, 0xb8, 0xd6, 0x07, 0xd0, 0x78, 0x44, 0x93, 0x5d,
+	0x77, 0x8f, 0x8e, 0x1c, 0x9b, 0x7e, 0x9b, 0xd2, 0x38, 0x21, 0x4d, 0x58, 0x18, 0xd3, 0x28, 0xf6,
+	0x02, 0xbf, 0x99, 0xbb, 0x92, 0xbb, 0x5a, 0xb2, 0x15, 0x69, 0x5d, 0x87, 0x65, 0x4d, 0x3a, 0x0e,
+	0x03, 0x3f, 0xa6, 0x64, 0x05, 0xca, 0x31, 0x9f, 0xe1, 0xd2, 0x15, 0x5b, 0x52, 0xd6, 0x9f, 0x39,
+	0x68, 0x3c, 0x0c, 0xfc, 0xbe, 0x37, 0x48, 0x23, 0xaa, 0x74, 0x7f, 0x0a, 0x95, 0xb1, 0x13, 0x79,
+	0xce, 0xab, 0x21, 0x8d, 0x51, 0xbe, 0x70, 0xb5, 0xba, 0x7e, 0xad, 0x9d, 0xc5, 0xd5, 0x9e, 0x94,
+	0x6f, 0x7f, 0xa1, 0x84, 0xbb, 0x7e, 0x12, 0x1d, 0xd8, 0x87, 0x8b, 0xc9, 0x75, 0x28, 0x3a, 0xd1,
+	0x20, 0x6e, 0xe6, 0xd1, 0x68, 0x75, 0xfd, 0x62, 0x5b, 0x84, 0xd9, 0x56, 0x61, 0xb6, 0x77, 0x79,
+	0x98, 0x36, 0x17, 0x22, 0xef, 0xc2, 0x92, 0xe3, 0xba, 0x34, 0x4c, 0x76, 0xa9, 0x1b, 0xd1, 0x24,
+	0x6e, 0x16, 0x70, 0xd5, 0xa2, 0x6d, 0x4e, 0xb6, 0xee, 0x42, 0xdd, 0xb4, 0x47, 0x1a, 0x50, 0xd8,
+	0xa7, 0x07, 0x32, 0x30, 0x36, 0x24, 0x17, 0xa0, 0x34, 0x76, 0x86, 0x29, 0xe5, 0x76, 0x2b, 0xb6,
+	0x20, 0xee, 0xe4, 0x3f, 0xce, 0x59, 0xb7, 0x61, 0x59, 0x73, 0x5f, 0x82, 0x33, 0x65, 0x38, 0x37,
+	0xc3, 0xb0, 0xf5, 0x4b, 0x0e, 0x2e, 0x65, 0x6b, 0xbb, 0x51, 0x14, 0x44, 0x4f, 0xbd, 0x38, 0xf6,
+	0xfc, 0xc1, 0x63, 0x7a, 0x10, 0x93, 0xcf, 0xa0, 0x3a, 0x3a, 0x24, 0x25, 0x6a, 0x6b, 0xb3, 0x50,
+	0x9b, 0x5c, 0xda, 0x3e, 0x1c, 0xdb, 0xba, 0x8e, 0xd6, 0x06, 0xc0, 0x21, 0x8b, 0x10, 0x28, 0xfa,
+	0xce, 0x88, 0xca, 0x30, 0xf9, 0x98, 0x5c, 0x81, 0x6a, 0x8f, 0xc6, 0x6e, 0xe4, 0x85, 0x09, 0x4b,
+	0x04, 0x11, 0xad, 0x3e, 0x65, 0x7d, 0x9f, 0x83, 0xa5, 0x2d, 0x7f, 0x1c, 0xec, 0x67, 0x9b, 0x8b,
+	0x68, 0x25, 0xc1, 0xbe, 0x42, 0x0b, 0x87, 0xa7, 0xdb, 0xa4, 0x16, 0x2c, 0xaa, 0x8c, 0xe7, 0xfb,
+	0x53, 0xb1, 0x33, 0x5a, 0xcf, 0xc9, 0x22, 0x67, 0x65, 0x39, 0x39, 0x86, 0xba, 0xf2, 0x42, 0x62,
+	0xbe, 0x06, 0x65, 0x44, 0x35, 0x8d, 0x44, 0xfa, 0x1e, 0x63, 0x56, 0x8a, 0x91, 0x5b, 0xb0, 0xd8,
+	0x77, 0xbc, 0x21, 0x02, 0xc8, 0x3c, 0x2d, 0xf0, 0x25, 0x1a, 0xba, 0x7b, 0xd4, 0xdd, 0xdf, 0x14,
+	0x7c, 0x3b, 0x13, 0xb4, 0xbe, 0x83, 0x1a, 0xe7, 0x68, 0xc1, 0x2b, 0x93, 0x18, 0x3c, 0x53, 0x8b,
+	0xc1, 0x07, 0xc3, 0xde, 0xc9, 0xc1, 0x33, 0x21, 0x26, 0xec, 0xd3, 0xd7, 0x22, 0x31, 0x8f, 0x13,
+	0x66, 0x42, 0x56, 0x0a, 0x4b, 0xd2, 0xf6, 0x61, 0xc8, 0x9e, 0x1f, 0xa6, 0x32, 0xbf, 0x8e, 0x0b,
+	0x59, 0x88, 0x9d, 0x2d, 0xe4, 0x0d, 0x19, 0xb2, 0xe4, 0xc8, 0x0d, 0x0b, 0x69, 0x94, 0xa8, 0x23,
+	0x92, 0xd1, 0xac, 0x2a, 0x44, 0xd4, 0x89, 0xb3, 0xd4, 0x91, 0x94, 0xf5, 0x73, 0x0e, 0xaa, 0x1d,
+	0xaf, 0xdf, 0x57, 0xb0, 0xd5, 0x21, 0xef, 0xf5, 0xe4, 0x6a, 0x1c, 0x29, 0x18, 0xf3, 0xd3, 0x30,
+	0x16, 0x4e, 0x03, 0x63, 0x71, 0x0e, 0x18, 0xd9, 0xe1, 0xf4, 0x06, 0x7e, 0x10, 0xd1, 0x87, 0x7b,
+	0x8e, 0x3f, 0x40, 0x24, 0x4a, 0x88, 0x44, 0xc5, 0x36, 0x27, 0xad, 0x5f, 0x73, 0x50, 0xdb, 0x91,
+	0x61, 0x31, 0xcf, 0xc9, 0x4d, 0x28, 0xee, 0x7b, 0xbe, 0x70, 0xba, 0xbe, 0xbe, 0xaa, 0xe1, 0xa6,
+	0x8b, 0xb5, 0x1f, 0xa3, 0x8c, 0xcd, 0x25, 0xc9, 0x2a, 0x54, 0x38, 0xee, 0x6c, 0x9e, 0x87, 0xb6,
+	0x68, 0x1f, 0x4e, 0x58, 0x2f, 0xa1, 0xc8, 0x64, 0xc9, 0x02, 0x14, 0x1e, 0x74, 0x3a, 0x8d, 0x73,
+	0xe4, 0x3c, 0x54, 0x71, 0xf0, 0xc2, 0xee, 0xee, 0x3c, 0x79, 0xf0, 0xb0, 0xdb, 0xc8, 0x11, 0x80,
+	0x72, 0xa7, 0xfb, 0xa4, 0xfb, 0x79, 0xb7, 0x91, 0xc7, 0xc3, 0x5a, 0x17, 0xe3, 0x8c, 0x5f, 0x60,
+	0xfc, 0xe7, 0x3b, 0x9d, 0x07, 0xc8, 0x2f, 0x32, 0xbe, 0x18, 0x67, 0xfc, 0x92, 0xf5, 0x47, 0x01,
+	0x6a, 0x02, 0x74, 0x99, 0x2f, 0xb8, 0x73, 0x11, 0x0d, 0x87, 0x8e, 0x2b, 0xab, 0x30, 0xee, 0x9c,
+	0xa2, 0xd9, 0x51, 0x8b, 0x13, 0x51, 0xa0, 0xf3, 0x9c, 0xa5, 0x48, 0x0c, 0xfc, 0xad, 0x1e, 0x1d,
+	0xd2, 0x84, 0x6e, 0xd0, 0x7e, 0xc0, 0x8a, 0x1c, 0x5f, 0x21, 0x6b, 0xe9, 0x2c, 0x16, 0xb9, 0x07,
+	0x0b, 0xae, 0xc4, 0xb6, 0xc8, 0xd1, 0x7a, 0x47, 0x43, 0x4b, 0xf7, 0x88, 0x13, 0x12, 0x71, 0x5b,
+	0xad, 0x61, 0xc5, 0xb6, 0x87, 0xf3, 0x6a, 0x63, 0x04, 0x41, 0x9e, 0x42, 0xad, 0x47, 0x13, 0xcc,
+	0x41, 0xda, 0xe3, 0x80, 0x96, 0x79, 0xfe, 0xbe, 0x7f, 0xa4, 0x66, 0x4d, 0x56, 0xdc, 0x22, 0xc6,
+	0x72, 0x72, 0x15, 0xce, 0xef, 0x39, 0xb1, 0x2e, 0xd5, 0x5c, 0xe0, 0x11, 0x4d, 0x4e, 0xb7, 0xbe,
+	0x82, 0xe5, 0x29, 0x65, 0x33, 0xae, 0x88, 0x1b, 0xfa, 0x15, 0x61, 0x1e, 0x2c, 0x3d, 0x41, 0xf4,
+	0xbb, 0xe3, 0x9e, 0x38, 0x14, 0x12, 0x00, 0xd4, 0x59, 0xeb, 0x6c, 0x6d, 0x6e, 0xbe, 0x78, 0xbe,
+	0xfd, 0x78, 0xfb, 0xd9, 0x97, 0xdb, 0x98, 0x12, 0x4b, 0x50, 0xe1, 0x33, 0xdb, 0xcf, 0xb6, 0x59,
+	0x42, 0x28, 0x72, 0xf7, 0xd9, 0x53, 0xcc, 0x09, 0x2b, 0xc1, 0x7a, 0x80, 0xe7, 0x2b, 0xa1, 0x47,
+	0x17, 0xa3, 0x8f, 0x00, 0xe4, 0xd9, 0xf4, 0xe8, 0x89, 0x25, 0x49, 0x13, 0x65, 0xe9, 0x90, 0x78,
+	0x23, 0x1a, 0xa4, 0x09, 0xdf, 0xe8, 0x9c, 0xad, 0x48, 0xeb, 0x6b, 0xa8, 0x2b, 0xab, 0x32, 0xad,
+	0x26, 0x0f, 0xf3, 0x59, 0x8d, 0x5a, 0x3f, 0x62, 0x95, 0xb0, 0xa9, 0xd3, 0x9b, 0xbf, 0x4a, 0x98,
+	0xa6, 0x0a, 0xf3, 0xc7, 0x77, 0x58, 0x3a, 0x8b, 0x73, 0x95, 0x4e, 0xeb, 0x07, 0xac, 0x07, 0xc2,
+	0xb7, 0x37, 0x1c, 0xb5, 0xe6, 0x4a, 0x61, 0x3e, 0x57, 0x7e, 0xc3, 0x2b, 0xf8, 0x79, 0xd8, 0xd3,
+	0x36, 0xfe, 0xff, 0x2c, 0xa7, 0x5a, 0xa6, 0x94, 0x8c, 0x4c, 0x99, 0x2e, 0xb4, 0xe5, 0x59, 0x85,
+	0x76, 0x0b, 0x2b, 0x97, 0x0c, 0x46, 0x22, 0x6b, 0x22, 0x99, 0x9b, 0x3f, 0x7f, 0x58, 0x6f, 0xd2,
+	0xe1, 0xf5, 0xe8, 0x3f, 0xc8, 0x20, 0x2d, 0xee, 0xa2, 0x79, 0x42, 0x7e, 0xca, 0xc1, 0x45, 0xde,
+	0x93, 0x61, 0x44, 0x41, 0x1a, 0xb9, 0x74, 0xcb, 0xf7, 0x92, 0x4d, 0x5e, 0x40, 0xde, 0x5c, 0xd6,
+	0xa0, 0x79, 0x71, 0xb7, 0x32, 0xa7, 0x79, 0xbd, 0x96, 0xe4, 0xe9, 0x53, 0xfb, 0xef, 0x32, 0x6f,
+	0xd9, 0xc5, 0x0b, 0x41, 0x7b, 0x0e, 0xa0, 0xfc, 0x37, 0xd4, 0x4d, 0xa4, 0xb7, 0x8a, 0x64, 0xe5,
+	0x19, 0xaf, 0x06, 0x77, 0x5f, 0xf5, 0xc2, 0x9c, 0x20, 0xf7, 0xa1, 0xec, 0xf2, 0x86, 0x94, 0xbb,
+	0x53, 0x5d, 0x7f, 0xcf, 0xec, 0x54, 0x0d, 0xe5, 0xb2, 0x75, 0x15, 0x65, 0x59, 0x2e, 0x63, 0xad,
+	0x43, 0x0f, 0xc9, 0x54, 0xb4, 0x7a, 0x8b, 0xb6, 0xa4, 0x78, 0xbb, 0xe1, 0x44, 0xce, 0x70, 0x48,
+	0x87, 0x3c, 0xc1, 0x4a, 0x76, 0x46, 0xb3, 0x22, 0x3e, 0x0a, 0x10, 0xdc, 0x20, 0xea, 0xfa, 0xbd,
+	0x30, 0xf0, 0xfc, 0x04, 0x73, 0x8c, 0x39, 0x35, 0x39, 0xcd, 0x9a, 0xdd, 0xe4, 0x20, 0xa4, 0xbc,
+	0xc6, 0x63, 0xb3, 0xcb, 0xc6, 0x59, 0x03, 0xbc, 0xa8, 0x35, 0xc0, 0xe8, 0x05, 0x6a, 0xa7, 0xa8,
+	0xa8, 0x22, 0x1a, 0x18, 0x41, 0x69, 0xa0, 0xc2, 0x7c, 0xad, 0xd6, 0x4b, 0x58, 0x16, 0x77, 0x3d,
+	0x0d, 0xa9, 0xdf, 0xa3, 0xbe, 0xcb, 0xf6, 0xb7, 0xca, 0xa1, 0x59, 0x3f, 0x0e, 0x9a, 0xad, 0xc9,
+	0x45, 0x02, 0xa5, 0x69, 0x65, 0x72, 0x87, 0x12, 0xb6, 0x43, 0x35, 0x8e, 0x98, 0x22, 0xd9, 0x73,
+	0x4b, 0xb5, 0xd0, 0x71, 0x73, 0x69, 0xd6, 0x73, 0xcb, 0xb4, 0xb9, 0xa3, 0x84, 0xe5, 0x73, 0x2b,
+	0x5b, 0xcc, 0x6c, 0x38, 0x43, 0xcf, 0x89, 0xd1, 0xf7, 0xba, 0xc8, 0x32, 0x49, 0x12, 0x8b, 0x5d,
+	0xc7, 0x5a, 0x68, 0xe7, 0x39, 0xdb, 0x98, 0x6b, 0x5d, 0x83, 0x0b, 0xd9, 0xd5, 0xa7, 0x7b, 0x8e,
+	0xc0, 0xe3, 0xd1, 0x53, 0x3d, 0x08, 0x1f, 0xb7, 0x6e, 0x43, 0x55, 0xcb, 0x8a, 0xd3, 0x3c, 0xc1,
+	0x5a, 0x63, 0x58, 0x99, 0x8d, 0xda, 0x0c, 0x2d, 0x9b, 0xe6, 0x2d, 0x7d, 0xf3, 0x04, 0x58, 0xa6,
+	0x7c, 0xd7, 0xed, 0xe2, 0xc3, 0xd1, 0x44, 0xee, 0x54, 0x0f, 0xc7, 0xdf, 0xf3, 0xfc, 0xe5, 0xa8,
+	0x4c, 0xca, 0xda, 0x37, 0x7d, 0x85, 0xdf, 0xe0, 0xc7, 0x2d, 0xa1, 0x27, 0x15, 0x07, 0x21, 0x45,
+	0x1c, 0x58, 0xe6, 0x03, 0x23, 0xef, 0xc4, 0x91, 0xbc, 0x35, 0x3b, 0x58, 0xd9, 0x30, 0xed, 0x4e,
+	0xae, 0x92, 0x89, 0x37, 0xa5, 0xed, 0x54, 0xdb, 0xfa, 0x1a, 0x56, 0x66, 0x2b, 0x9e, 0x81, 0xd5,
+	0x23, 0x73, 0x6f, 0x3e, 0x3c, 0xd6, 0xdd, 0x13, 0x36, 0x67, 0xfd, 0xaf, 0x05, 0x68, 0xa8, 0xfa,
+	0xab, 0x76, 0x89, 0x1d, 0x8c, 0xec, 0x27, 0x83, 0x5c, 0xd6, 0xf4, 0x4f, 0xfe, 0x86, 0xb4, 0x56,
+	0x67, 0x33, 0x85, 0x71, 0xeb, 0x1c, 0xd9, 0xc0, 0x74, 0x65, 0x8f, 0x22, 0x91, 0xb3, 0x64, 0xea,
+	0x19, 0xa5, 0xf4, 0x34, 0xa7, 0x19, 0x99, 0x8e, 0xfb, 0x00, 0xbc, 0xfd, 0x93, 0xf5, 0x6f, 0xaa,
+	0x93, 0x15, 0x1a, 0x2e, 0x1e, 0xd1, 0xe1, 0xa2, 0x02, 0x0c, 0x27, 0xfb, 0x04, 0x30, 0xc2, 0x99,
+	0xfc, 0x50, 0x31, 0xc2, 0x99, 0xfa, 0xae, 0xe0, 0xae, 0x94, 0xc5, 0x73, 0x9a, 0xe8, 0x0e, 0x1b,
+	0xef, 0xfc, 0xd6, 0xa5, 0x19, 0x9c, 0x4c, 0xc1, 0x23, 0xa8, 0x61, 0x1e, 0x52, 0x67, 0xf4, 0xaf,
+	0xd4, 0xdc, 0xcc, 0x91, 0xbb, 0x50, 0xe2, 0x38, 0x9d, 0x0d, 0xd2, 0xdb, 0x50, 0xe4, 0xdd, 0xfd,
+	0x19, 0xc0, 0x44, 0x08, 0x44, 0x5f, 0x6b, 0xf8, 0x6e, 0x34, 0xd8, 0x86, 0xef, 0x66, 0x13, 0x2c,
+	0x6c, 0xb3, 0x06, 0xd1, 0xb0, 0xad, 0x75, 0xb3, 0x86, 0x6d, 0xbd, 0x93, 0x14, 0xb6, 0x45, 0x0f,
+	0x64, 0xd8, 0x36, 0x7a, 0x3c, 0xc3, 0xb6, 0xd9, 0x30, 0xa1, 0x82, 0xbb, 0xf8, 0x54, 0xe4, 0x8d,
+	0x8f, 0xa1, 0xc0, 0xe8, 0x85, 0x5a, 0x2b, 0x53, 0x95, 0xa3, 0xcb, 0x3e, 0x0c, 0xb3, 0x3c, 0x12,
+	0x07, 0x6c, 0x32, 0x8f, 0x8c, 0x92, 0x38, 0x99, 0x47, 0xe6, 0x99, 0x44, 0x4d, 0x77, 0x10, 0x44,
+	0xc7, 0x77, 0xf1, 0x6a, 0x3e, 0xc2, 0xda, 0x31, 0x5e, 0x7c, 0x02, 0x4b, 0x78, 0xd2, 0x76, 0xf8,
+	0x17, 0xe7, 0x96, 0xdf, 0x0f, 0x8e, 0x54, 0xf1, 0xb6, 0xfe, 0xb4, 0xca, 0xc4, 0xad, 0x73, 0xaf,
+	0xca, 0x5c, 0xf0, 0xd6, 0x3f, 0x88, 0x52, 0x74, 0xb9, 0x43, 0x15, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -1468,6 +1787,8 @@ type ResourceProviderClient interface {
 	Update(ctx context.Context, in *UpdateRequest, opts ...grpc.CallOption) (*UpdateResponse, error)
 	// Delete tears down an existing resource with the given ID.  If it fails, the resource is assumed to still exist.
 	Delete(ctx context.Context, in *DeleteRequest, opts ...grpc.CallOption) (*empty.Empty, error)
+	// Construct creates a new instance of the provided component resource and returns its state.
+	Construct(ctx context.Context, in *ConstructRequest, opts ...grpc.CallOption) (*ConstructResponse, error)
 	// Cancel signals the provider to abort all outstanding resource operations.
 	Cancel(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*empty.Empty, error)
 	// GetPluginInfo returns generic information about this plugin, like its version.
@@ -1613,6 +1934,15 @@ func (c *resourceProviderClient) Delete(ctx context.Context, in *DeleteRequest,
 	return out, nil
 }
 
+func (c *resourceProviderClient) Construct(ctx context.Context, in *ConstructRequest, opts ...grpc.CallOption) (*ConstructResponse, error) {
+	out := new(ConstructResponse)
+	err := c.cc.Invoke(ctx, "/pulumirpc.ResourceProvider/Construct", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *resourceProviderClient) Cancel(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*empty.Empty, error) {
 	out := new(empty.Empty)
 	err := c.cc.Invoke(ctx, "/pulumirpc.ResourceProvider/Cancel", in, out, opts...)
@@ -1664,6 +1994,8 @@ type ResourceProviderServer interface {
 	Update(context.Context, *UpdateRequest) (*UpdateResponse, error)
 	// Delete tears down an existing resource with the given ID.  If it fails, the resource is assumed to still exist.
 	Delete(context.Context, *DeleteRequest) (*empty.Empty, error)
+	// Construct creates a new instance of the provided component resource and returns its state.
+	Construct(context.Context, *ConstructRequest) (*ConstructResponse, error)
 	// Cancel signals the provider to abort all outstanding resource operations.
 	Cancel(context.Context, *empty.Empty) (*empty.Empty, error)
 	// GetPluginInfo returns generic information about this plugin, like its version.
@@ -1710,6 +2042,9 @@ func (*UnimplementedResourceProviderServer) Update(ctx context.Context, req *Upd
 func (*UnimplementedResourceProviderServer) Delete(ctx context.Context, req *DeleteRequest) (*empty.Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Delete not implemented")
 }
+func (*UnimplementedResourceProviderServer) Construct(ctx context.Context, req *ConstructRequest) (*ConstructResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Construct not implemented")
+}
 func (*UnimplementedResourceProviderServer) Cancel(ctx context.Context, req *empty.Empty) (*empty.Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Cancel not implemented")
 }
@@ -1940,6 +2275,24 @@ func _ResourceProvider_Delete_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ResourceProvider_Construct_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ConstructRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ResourceProviderServer).Construct(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pulumirpc.ResourceProvider/Construct",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ResourceProviderServer).Construct(ctx, req.(*ConstructRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _ResourceProvider_Cancel_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(empty.Empty)
 	if err := dec(in); err != nil {
@@ -2024,6 +2377,10 @@ var _ResourceProvider_serviceDesc = grpc.ServiceDesc{
 			MethodName: "Delete",
 			Handler:    _ResourceProvider_Delete_Handler,
 		},
+		{
+			MethodName: "Construct",
+			Handler:    _ResourceProvider_Construct_Handler,
+		},
 		{
 			MethodName: "Cancel",
 			Handler:    _ResourceProvider_Cancel_Handler,
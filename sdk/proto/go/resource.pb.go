@@ -295,6 +295,10 @@ type RegisterResourceRequest struct {
 	CustomTimeouts             *RegisterResourceRequest_CustomTimeouts                  `protobuf:"bytes,17,opt,name=customTimeouts,proto3" json:"customTimeouts,omitempty"`
 	DeleteBeforeReplaceDefined bool                                                     `protobuf:"varint,18,opt,name=deleteBeforeReplaceDefined,proto3" json:"deleteBeforeReplaceDefined,omitempty"`
 	SupportsPartialValues      bool                                                     `protobuf:"varint,19,opt,name=supportsPartialValues,proto3" json:"supportsPartialValues,omitempty"`
+	ReplaceOnChanges           []string                                                 `protobuf:"bytes,20,rep,name=replaceOnChanges,proto3" json:"replaceOnChanges,omitempty"`
+	RetainOnDelete             bool                                                     `protobuf:"varint,21,opt,name=retainOnDelete,proto3" json:"retainOnDelete,omitempty"`
+	Remote                     bool                                                     `protobuf:"varint,22,opt,name=remote,proto3" json:"remote,omitempty"`
+	Providers                  map[string]string                                        `protobuf:"bytes,23,rep,name=providers,proto3" json:"providers,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
 	XXX_NoUnkeyedLiteral       struct{}                                                 `json:"-"`
 	XXX_unrecognized           []byte                                                   `json:"-"`
 	XXX_sizecache              int32                                                    `json:"-"`
@@ -458,6 +462,34 @@ func (m *RegisterResourceRequest) GetSupportsPartialValues() bool {
 	return false
 }
 
+func (m *RegisterResourceRequest) GetReplaceOnChanges() []string {
+	if m != nil {
+		return m.ReplaceOnChanges
+	}
+	return nil
+}
+
+func (m *RegisterResourceRequest) GetRetainOnDelete() bool {
+	if m != nil {
+		return m.RetainOnDelete
+	}
+	return false
+}
+
+func (m *RegisterResourceRequest) GetRemote() bool {
+	if m != nil {
+		return m.Remote
+	}
+	return false
+}
+
+func (m *RegisterResourceRequest) GetProviders() map[string]string {
+	if m != nil {
+		return m.Providers
+	}
+	return nil
+}
+
 // PropertyDependencies describes the resources that a particular property depends on.
 type RegisterResourceRequest_PropertyDependencies struct {
 	Urns                 []string `protobuf:"bytes,1,rep,name=urns,proto3" json:"urns,omitempty"`
@@ -563,14 +595,15 @@ func (m *RegisterResourceRequest_CustomTimeouts) GetDelete() string {
 // RegisterResourceResponse is returned by the engine after a resource has finished being initialized.  It includes the
 // auto-assigned URN, the provider-assigned ID, and any other properties initialized by the engine.
 type RegisterResourceResponse struct {
-	Urn                  string          `protobuf:"bytes,1,opt,name=urn,proto3" json:"urn,omitempty"`
-	Id                   string          `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
-	Object               *_struct.Struct `protobuf:"bytes,3,opt,name=object,proto3" json:"object,omitempty"`
-	Stable               bool            `protobuf:"varint,4,opt,name=stable,proto3" json:"stable,omitempty"`
-	Stables              []string        `protobuf:"bytes,5,rep,name=stables,proto3" json:"stables,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
-	XXX_unrecognized     []byte          `json:"-"`
-	XXX_sizecache        int32           `json:"-"`
+	Urn                  string                                                    `protobuf:"bytes,1,opt,name=urn,proto3" json:"urn,omitempty"`
+	Id                   string                                                    `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
+	Object               *_struct.Struct                                           `protobuf:"bytes,3,opt,name=object,proto3" json:"object,omitempty"`
+	Stable               bool                                                      `protobuf:"varint,4,opt,name=stable,proto3" json:"stable,omitempty"`
+	Stables              []string                                                  `protobuf:"bytes,5,rep,name=stables,proto3" json:"stables,omitempty"`
+	PropertyDependencies map[string]*RegisterResourceResponse_PropertyDependencies `protobuf:"bytes,6,rep,name=propertyDependencies,proto3" json:"propertyDependencies,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}                                                  `json:"-"`
+	XXX_unrecognized     []byte                                                    `json:"-"`
+	XXX_sizecache        int32                                                     `json:"-"`
 }
 
 func (m *RegisterResourceResponse) Reset()         { *m = RegisterResourceResponse{} }
@@ -633,6 +666,57 @@ func (m *RegisterResourceResponse) GetStables() []string {
 	return nil
 }
 
+func (m *RegisterResourceResponse) GetPropertyDependencies() map[string]*RegisterResourceResponse_PropertyDependencies {
+	if m != nil {
+		return m.PropertyDependencies
+	}
+	return nil
+}
+
+// PropertyDependencies describes the resources that a particular property depends on.
+type RegisterResourceResponse_PropertyDependencies struct {
+	Urns                 []string `protobuf:"bytes,1,rep,name=urns,proto3" json:"urns,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RegisterResourceResponse_PropertyDependencies) Reset() {
+	*m = RegisterResourceResponse_PropertyDependencies{}
+}
+func (m *RegisterResourceResponse_PropertyDependencies) String() string {
+	return proto.CompactTextString(m)
+}
+func (*RegisterResourceResponse_PropertyDependencies) ProtoMessage() {}
+func (*RegisterResourceResponse_PropertyDependencies) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d1b72f771c35e3b8, []int{5, 0}
+}
+
+func (m *RegisterResourceResponse_PropertyDependencies) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RegisterResourceResponse_PropertyDependencies.Unmarshal(m, b)
+}
+func (m *RegisterResourceResponse_PropertyDependencies) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RegisterResourceResponse_PropertyDependencies.Marshal(b, m, deterministic)
+}
+func (m *RegisterResourceResponse_PropertyDependencies) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RegisterResourceResponse_PropertyDependencies.Merge(m, src)
+}
+func (m *RegisterResourceResponse_PropertyDependencies) XXX_Size() int {
+	return xxx_messageInfo_RegisterResourceResponse_PropertyDependencies.Size(m)
+}
+func (m *RegisterResourceResponse_PropertyDependencies) XXX_DiscardUnknown() {
+	xxx_messageInfo_RegisterResourceResponse_PropertyDependencies.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RegisterResourceResponse_PropertyDependencies proto.InternalMessageInfo
+
+func (m *RegisterResourceResponse_PropertyDependencies) GetUrns() []string {
+	if m != nil {
+		return m.Urns
+	}
+	return nil
+}
+
 // RegisterResourceOutputsRequest adds extra resource outputs created by the program after registration has occurred.
 type RegisterResourceOutputsRequest struct {
 	Urn                  string          `protobuf:"bytes,1,opt,name=urn,proto3" json:"urn,omitempty"`
@@ -688,72 +772,81 @@ func init() {
 	proto.RegisterType((*ReadResourceResponse)(nil), "pulumirpc.ReadResourceResponse")
 	proto.RegisterType((*RegisterResourceRequest)(nil), "pulumirpc.RegisterResourceRequest")
 	proto.RegisterMapType((map[string]*RegisterResourceRequest_PropertyDependencies)(nil), "pulumirpc.RegisterResourceRequest.PropertyDependenciesEntry")
+	proto.RegisterMapType((map[string]string)(nil), "pulumirpc.RegisterResourceRequest.ProvidersEntry")
 	proto.RegisterType((*RegisterResourceRequest_PropertyDependencies)(nil), "pulumirpc.RegisterResourceRequest.PropertyDependencies")
 	proto.RegisterType((*RegisterResourceRequest_CustomTimeouts)(nil), "pulumirpc.RegisterResourceRequest.CustomTimeouts")
 	proto.RegisterType((*RegisterResourceResponse)(nil), "pulumirpc.RegisterResourceResponse")
+	proto.RegisterMapType((map[string]*RegisterResourceResponse_PropertyDependencies)(nil), "pulumirpc.RegisterResourceResponse.PropertyDependenciesEntry")
+	proto.RegisterType((*RegisterResourceResponse_PropertyDependencies)(nil), "pulumirpc.RegisterResourceResponse.PropertyDependencies")
 	proto.RegisterType((*RegisterResourceOutputsRequest)(nil), "pulumirpc.RegisterResourceOutputsRequest")
 }
 
 func init() { proto.RegisterFile("resource.proto", fileDescriptor_d1b72f771c35e3b8) }
 
 var fileDescriptor_d1b72f771c35e3b8 = []byte{
-	// 882 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x56, 0x51, 0x6f, 0x23, 0x35,
-	0x10, 0xbe, 0x4d, 0x7a, 0x69, 0x32, 0xed, 0xa5, 0xc5, 0x2d, 0xa9, 0x6f, 0x41, 0xa5, 0x2c, 0x3c,
-	0x04, 0x1e, 0xd2, 0xbb, 0x82, 0x74, 0x07, 0x42, 0x20, 0x71, 0x77, 0xa0, 0x7b, 0x38, 0x71, 0x6c,
-	0x11, 0x02, 0x24, 0x90, 0xdc, 0xdd, 0x69, 0x6e, 0xe9, 0x66, 0x6d, 0x6c, 0x6f, 0xa5, 0xbc, 0xf1,
-	0xca, 0xaf, 0xe0, 0x2f, 0xf1, 0x5b, 0xf8, 0x05, 0xc8, 0x5e, 0x3b, 0x64, 0xb3, 0x9b, 0x36, 0x70,
-	0x6f, 0x9e, 0x6f, 0xc6, 0xb3, 0xf6, 0x37, 0xdf, 0x8c, 0x17, 0x86, 0x12, 0x15, 0x2f, 0x65, 0x82,
-	0x13, 0x21, 0xb9, 0xe6, 0x64, 0x20, 0xca, 0xbc, 0x9c, 0x65, 0x52, 0x24, 0xe1, 0x5b, 0x53, 0xce,
-	0xa7, 0x39, 0x9e, 0x5a, 0xc7, 0x45, 0x79, 0x79, 0x8a, 0x33, 0xa1, 0xe7, 0x55, 0x5c, 0xf8, 0xf6,
-	0xaa, 0x53, 0x69, 0x59, 0x26, 0xda, 0x79, 0x87, 0x42, 0xf2, 0xeb, 0x2c, 0x45, 0x59, 0xd9, 0xd1,
-	0x18, 0x46, 0xe7, 0xa5, 0x10, 0x5c, 0x6a, 0xf5, 0x15, 0x32, 0x5d, 0x4a, 0x8c, 0xf1, 0xb7, 0x12,
-	0x95, 0x26, 0x43, 0xe8, 0x64, 0x29, 0x0d, 0x4e, 0x82, 0xf1, 0x20, 0xee, 0x64, 0x69, 0xf4, 0x09,
-	0x1c, 0x35, 0x22, 0x95, 0xe0, 0x85, 0x42, 0x72, 0x0c, 0xf0, 0x8a, 0x29, 0xe7, 0xb5, 0x5b, 0xfa,
-	0xf1, 0x12, 0x12, 0xfd, 0xdd, 0x81, 0x83, 0x18, 0x59, 0x1a, 0xbb, 0x1b, 0xad, 0xf9, 0x04, 0x21,
-	0xb0, 0xa5, 0xe7, 0x02, 0x69, 0xc7, 0x22, 0x76, 0x6d, 0xb0, 0x82, 0xcd, 0x90, 0x76, 0x2b, 0xcc,
-	0xac, 0xc9, 0x08, 0x7a, 0x82, 0x49, 0x2c, 0x34, 0xdd, 0xb2, 0xa8, 0xb3, 0xc8, 0x23, 0x00, 0x21,
-	0xb9, 0x40, 0xa9, 0x33, 0x54, 0xf4, 0xee, 0x49, 0x30, 0xde, 0x39, 0x3b, 0x9a, 0x54, 0x7c, 0x4c,
-	0x3c, 0x1f, 0x93, 0x73, 0xcb, 0x47, 0xbc, 0x14, 0x4a, 0x22, 0xd8, 0x4d, 0x51, 0x60, 0x91, 0x62,
-	0x91, 0x98, 0xad, 0xbd, 0x93, 0xee, 0x78, 0x10, 0xd7, 0x30, 0x12, 0x42, 0xdf, 0x73, 0x47, 0xb7,
-	0xed, 0x67, 0x17, 0x36, 0xa1, 0xb0, 0x7d, 0x8d, 0x52, 0x65, 0xbc, 0xa0, 0x7d, 0xeb, 0xf2, 0x26,
-	0x79, 0x1f, 0xee, 0xb1, 0x24, 0x41, 0xa1, 0xcf, 0x31, 0x91, 0xa8, 0x15, 0x1d, 0x58, 0x76, 0xea,
-	0x20, 0x79, 0x0c, 0x47, 0x2c, 0x4d, 0x33, 0x9d, 0xf1, 0x82, 0xe5, 0x15, 0xf8, 0x4d, 0xa9, 0x45,
-	0xa9, 0x15, 0x05, 0x7b, 0x94, 0x75, 0x6e, 0xf3, 0x65, 0x96, 0x67, 0x4c, 0xa1, 0xa2, 0x3b, 0x36,
-	0xd2, 0x9b, 0x11, 0x83, 0xc3, 0x3a, 0xe7, 0xae, 0x58, 0xfb, 0xd0, 0x2d, 0x65, 0xe1, 0x58, 0x37,
-	0xcb, 0x15, 0xda, 0x3a, 0x1b, 0xd3, 0x16, 0xfd, 0xd5, 0x87, 0xa3, 0x18, 0xa7, 0x99, 0xd2, 0x28,
-	0x57, 0x6b, 0xeb, 0x6b, 0x19, 0xb4, 0xd4, 0xb2, 0xd3, 0x5a, 0xcb, 0x6e, 0xad, 0x96, 0x23, 0xe8,
-	0x25, 0xa5, 0xd2, 0x7c, 0x66, 0x6b, 0xdc, 0x8f, 0x9d, 0x45, 0x4e, 0xa1, 0xc7, 0x2f, 0x7e, 0xc5,
-	0x44, 0xdf, 0x56, 0x5f, 0x17, 0x66, 0x18, 0x32, 0x2e, 0xb3, 0xa3, 0x67, 0x33, 0x79, 0xb3, 0x51,
-	0xf5, 0xed, 0x5b, 0xaa, 0xde, 0x5f, 0xa9, 0xba, 0x80, 0x43, 0x47, 0xc6, 0xfc, 0xe9, 0x72, 0x9e,
-	0xc1, 0x49, 0x77, 0xbc, 0x73, 0xf6, 0xd9, 0x64, 0xd1, 0xb0, 0x93, 0x35, 0x24, 0x4d, 0x5e, 0xb6,
-	0x6c, 0x7f, 0x56, 0x68, 0x39, 0x8f, 0x5b, 0x33, 0x93, 0x07, 0x70, 0x90, 0x62, 0x8e, 0x1a, 0xbf,
-	0xc4, 0x4b, 0x6e, 0x1a, 0x50, 0xe4, 0x2c, 0x41, 0x0a, 0xf6, 0x5e, 0x6d, 0xae, 0x65, 0x65, 0xee,
-	0x34, 0x94, 0x99, 0x4d, 0x0b, 0x2e, 0xf1, 0xc9, 0x2b, 0x56, 0x4c, 0x51, 0xd1, 0x5d, 0x7b, 0xfd,
-	0x3a, 0xd8, 0xd4, 0xef, 0xbd, 0xff, 0xa8, 0xdf, 0xe1, 0xc6, 0xfa, 0xdd, 0xab, 0xe9, 0xd7, 0x30,
-	0x9f, 0xcd, 0xcc, 0xf8, 0x78, 0x9e, 0xd2, 0xfd, 0x8a, 0x79, 0x6f, 0x93, 0x1f, 0x61, 0x58, 0xc9,
-	0xe1, 0xbb, 0x6c, 0x86, 0xdc, 0x7c, 0xe6, 0x0d, 0x2b, 0x86, 0x87, 0x1b, 0x70, 0xfe, 0xa4, 0xb6,
-	0x31, 0x5e, 0x49, 0x44, 0x3e, 0x87, 0xb0, 0x85, 0xc7, 0xa7, 0x78, 0x99, 0x15, 0x98, 0x52, 0x62,
-	0x6f, 0x7f, 0x43, 0x04, 0xf9, 0x18, 0xde, 0x54, 0x6e, 0x4c, 0xbe, 0x64, 0x52, 0x67, 0x2c, 0xff,
-	0x9e, 0xe5, 0x25, 0x2a, 0x7a, 0x60, 0xb7, 0xb6, 0x3b, 0xc3, 0x0f, 0xe1, 0xb0, 0x4d, 0x0b, 0xa6,
-	0x63, 0x4a, 0x59, 0x28, 0x1a, 0x58, 0x6e, 0xec, 0x3a, 0xfc, 0x01, 0x86, 0xf5, 0x3b, 0xd8, 0x5e,
-	0x91, 0xc8, 0xb4, 0xef, 0x36, 0x67, 0x19, 0xbc, 0x14, 0xa9, 0xc1, 0xab, 0x8e, 0x73, 0x96, 0xc1,
-	0xab, 0x1b, 0xf8, 0x9e, 0xab, 0xac, 0xf0, 0xf7, 0x00, 0xee, 0xaf, 0x95, 0xa4, 0x19, 0x1c, 0x57,
-	0x38, 0xf7, 0x83, 0xe3, 0x0a, 0xe7, 0xe4, 0x05, 0xdc, 0xbd, 0x36, 0xe7, 0x77, 0x33, 0xe3, 0xd1,
-	0xff, 0x54, 0x7c, 0x5c, 0x65, 0xf9, 0xb4, 0xf3, 0x38, 0x88, 0xfe, 0x0c, 0x80, 0x36, 0xf7, 0xae,
-	0x1d, 0x5d, 0xd5, 0x0b, 0xd2, 0x59, 0xbc, 0x20, 0xff, 0x4e, 0x87, 0xee, 0x66, 0xd3, 0x61, 0x04,
-	0x3d, 0xa5, 0xd9, 0x45, 0x8e, 0x7e, 0xcc, 0x54, 0x96, 0xd1, 0x65, 0xb5, 0x32, 0xef, 0x88, 0xd5,
-	0xa5, 0x33, 0x23, 0x84, 0xe3, 0xd5, 0x03, 0x3a, 0x31, 0xfb, 0xd1, 0xd7, 0x3c, 0xe6, 0x43, 0xd8,
-	0xe6, 0xae, 0x1f, 0x6e, 0x19, 0xaf, 0x3e, 0xee, 0xec, 0x8f, 0x2d, 0xd8, 0xf3, 0xf9, 0x5f, 0xf0,
-	0x22, 0xd3, 0x5c, 0x92, 0x9f, 0x60, 0x6f, 0xe5, 0x09, 0x26, 0xef, 0x2e, 0x71, 0xde, 0xfe, 0x90,
-	0x87, 0xd1, 0x4d, 0x21, 0x15, 0xb3, 0xd1, 0x1d, 0xf2, 0x05, 0xf4, 0x9e, 0x17, 0xd7, 0xfc, 0x0a,
-	0x09, 0x5d, 0x8a, 0xaf, 0x20, 0x9f, 0xe9, 0x7e, 0x8b, 0x67, 0x91, 0xe0, 0x6b, 0xd8, 0x3d, 0xd7,
-	0x12, 0xd9, 0xec, 0xb5, 0xd2, 0x3c, 0x08, 0xc8, 0xb7, 0xb0, 0xbb, 0xfc, 0x70, 0x91, 0xe3, 0x9a,
-	0xac, 0x1a, 0x7f, 0x11, 0xe1, 0x3b, 0x6b, 0xfd, 0x8b, 0xb3, 0xfd, 0x0c, 0xfb, 0xab, 0x35, 0x23,
-	0xd1, 0xed, 0x6a, 0x0d, 0xdf, 0xbb, 0x31, 0x66, 0x91, 0xfe, 0x97, 0xe6, 0x33, 0xe8, 0xe7, 0xdb,
-	0x07, 0x37, 0x64, 0xa8, 0xcb, 0x26, 0x1c, 0x35, 0x34, 0xf1, 0xcc, 0xfc, 0xd6, 0x45, 0x77, 0x2e,
-	0x7a, 0x16, 0xf9, 0xe8, 0x9f, 0x00, 0x00, 0x00, 0xff, 0xff, 0x00, 0xa9, 0x5c, 0x60, 0x13, 0x0a,
-	0x00, 0x00,
+	// 987 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x56, 0x4f, 0x6f, 0x1b, 0x45,
+	0x14, 0x8f, 0xed, 0xd4, 0x89, 0x5f, 0x52, 0x27, 0x4c, 0x5c, 0x7b, 0xba, 0xa0, 0x60, 0x16, 0x84,
+	0x4c, 0x0f, 0x4e, 0x1b, 0x90, 0x1a, 0x50, 0x01, 0x89, 0xa6, 0xa0, 0x1e, 0x4a, 0xc2, 0x06, 0x21,
+	0x40, 0x02, 0x69, 0xb2, 0xfb, 0xe2, 0x2e, 0xb1, 0x77, 0xb6, 0x33, 0xb3, 0x91, 0x7c, 0x83, 0x23,
+	0x5f, 0x8b, 0x2b, 0xdf, 0x86, 0x4f, 0x80, 0x66, 0x66, 0xc7, 0xdd, 0xb5, 0xd7, 0x89, 0xd3, 0xde,
+	0xe6, 0xfd, 0x9d, 0x99, 0xdf, 0xfb, 0xbd, 0x37, 0x03, 0x6d, 0x81, 0x92, 0x67, 0x22, 0xc4, 0x61,
+	0x2a, 0xb8, 0xe2, 0xa4, 0x95, 0x66, 0xe3, 0x6c, 0x12, 0x8b, 0x34, 0xf4, 0xde, 0x1d, 0x71, 0x3e,
+	0x1a, 0xe3, 0x81, 0x31, 0x9c, 0x67, 0x17, 0x07, 0x38, 0x49, 0xd5, 0xd4, 0xfa, 0x79, 0xef, 0xcd,
+	0x1b, 0xa5, 0x12, 0x59, 0xa8, 0x72, 0x6b, 0x3b, 0x15, 0xfc, 0x2a, 0x8e, 0x50, 0x58, 0xd9, 0x1f,
+	0x40, 0xf7, 0x2c, 0x4b, 0x53, 0x2e, 0x94, 0xfc, 0x16, 0x99, 0xca, 0x04, 0x06, 0xf8, 0x2a, 0x43,
+	0xa9, 0x48, 0x1b, 0xea, 0x71, 0x44, 0x6b, 0xfd, 0xda, 0xa0, 0x15, 0xd4, 0xe3, 0xc8, 0xff, 0x1c,
+	0x7a, 0x0b, 0x9e, 0x32, 0xe5, 0x89, 0x44, 0xb2, 0x0f, 0xf0, 0x92, 0xc9, 0xdc, 0x6a, 0x42, 0x36,
+	0x83, 0x82, 0xc6, 0xff, 0xaf, 0x0e, 0x7b, 0x01, 0xb2, 0x28, 0xc8, 0x6f, 0xb4, 0x64, 0x0b, 0x42,
+	0x60, 0x5d, 0x4d, 0x53, 0xa4, 0x75, 0xa3, 0x31, 0x6b, 0xad, 0x4b, 0xd8, 0x04, 0x69, 0xc3, 0xea,
+	0xf4, 0x9a, 0x74, 0xa1, 0x99, 0x32, 0x81, 0x89, 0xa2, 0xeb, 0x46, 0x9b, 0x4b, 0xe4, 0x31, 0x40,
+	0x2a, 0x78, 0x8a, 0x42, 0xc5, 0x28, 0xe9, 0x9d, 0x7e, 0x6d, 0xb0, 0x75, 0xd8, 0x1b, 0x5a, 0x3c,
+	0x86, 0x0e, 0x8f, 0xe1, 0x99, 0xc1, 0x23, 0x28, 0xb8, 0x12, 0x1f, 0xb6, 0x23, 0x4c, 0x31, 0x89,
+	0x30, 0x09, 0x75, 0x68, 0xb3, 0xdf, 0x18, 0xb4, 0x82, 0x92, 0x8e, 0x78, 0xb0, 0xe9, 0xb0, 0xa3,
+	0x1b, 0x66, 0xdb, 0x99, 0x4c, 0x28, 0x6c, 0x5c, 0xa1, 0x90, 0x31, 0x4f, 0xe8, 0xa6, 0x31, 0x39,
+	0x91, 0x7c, 0x04, 0x77, 0x59, 0x18, 0x62, 0xaa, 0xce, 0x30, 0x14, 0xa8, 0x24, 0x6d, 0x19, 0x74,
+	0xca, 0x4a, 0x72, 0x04, 0x3d, 0x16, 0x45, 0xb1, 0x8a, 0x79, 0xc2, 0xc6, 0x56, 0x79, 0x92, 0xa9,
+	0x34, 0x53, 0x92, 0x82, 0x39, 0xca, 0x32, 0xb3, 0xde, 0x99, 0x8d, 0x63, 0x26, 0x51, 0xd2, 0x2d,
+	0xe3, 0xe9, 0x44, 0x9f, 0x41, 0xa7, 0x8c, 0x79, 0x5e, 0xac, 0x5d, 0x68, 0x64, 0x22, 0xc9, 0x51,
+	0xd7, 0xcb, 0x39, 0xd8, 0xea, 0x2b, 0xc3, 0xe6, 0xff, 0x0b, 0xd0, 0x0b, 0x70, 0x14, 0x4b, 0x85,
+	0x62, 0xbe, 0xb6, 0xae, 0x96, 0xb5, 0x8a, 0x5a, 0xd6, 0x2b, 0x6b, 0xd9, 0x28, 0xd5, 0xb2, 0x0b,
+	0xcd, 0x30, 0x93, 0x8a, 0x4f, 0x4c, 0x8d, 0x37, 0x83, 0x5c, 0x22, 0x07, 0xd0, 0xe4, 0xe7, 0x7f,
+	0x60, 0xa8, 0x6e, 0xaa, 0x6f, 0xee, 0xa6, 0x11, 0xd2, 0x26, 0x1d, 0xd1, 0x34, 0x99, 0x9c, 0xb8,
+	0x50, 0xf5, 0x8d, 0x1b, 0xaa, 0xbe, 0x39, 0x57, 0xf5, 0x14, 0x3a, 0x39, 0x18, 0xd3, 0xe3, 0x62,
+	0x9e, 0x56, 0xbf, 0x31, 0xd8, 0x3a, 0x7c, 0x32, 0x9c, 0x35, 0xec, 0x70, 0x09, 0x48, 0xc3, 0xd3,
+	0x8a, 0xf0, 0x67, 0x89, 0x12, 0xd3, 0xa0, 0x32, 0x33, 0x79, 0x08, 0x7b, 0x11, 0x8e, 0x51, 0xe1,
+	0x37, 0x78, 0xc1, 0x05, 0x06, 0x98, 0x8e, 0x59, 0x88, 0x14, 0xcc, 0xbd, 0xaa, 0x4c, 0x45, 0x66,
+	0x6e, 0x2d, 0x30, 0x33, 0x1e, 0x25, 0x5c, 0xe0, 0xd3, 0x97, 0x2c, 0x19, 0xa1, 0xa4, 0xdb, 0xe6,
+	0xfa, 0x65, 0xe5, 0x22, 0x7f, 0xef, 0xde, 0x92, 0xbf, 0xed, 0x95, 0xf9, 0xbb, 0x53, 0xe2, 0xaf,
+	0x46, 0x3e, 0x9e, 0xa4, 0x5c, 0xa8, 0xe7, 0x11, 0xdd, 0xb5, 0xc8, 0x3b, 0x99, 0xfc, 0x02, 0x6d,
+	0x4b, 0x87, 0x1f, 0xe3, 0x09, 0x72, 0xbd, 0xcd, 0x3b, 0x86, 0x0c, 0x8f, 0x56, 0xc0, 0xfc, 0x69,
+	0x29, 0x30, 0x98, 0x4b, 0x44, 0xbe, 0x02, 0xaf, 0x02, 0xc7, 0x63, 0xbc, 0x88, 0x13, 0x8c, 0x28,
+	0x31, 0xb7, 0xbf, 0xc6, 0x83, 0x7c, 0x06, 0xf7, 0x64, 0x3e, 0x26, 0x4f, 0x99, 0x50, 0x31, 0x1b,
+	0xff, 0xc4, 0xc6, 0x19, 0x4a, 0xba, 0x67, 0x42, 0xab, 0x8d, 0xe4, 0x01, 0xec, 0x0a, 0x9b, 0xe7,
+	0x24, 0x71, 0xf5, 0xe8, 0x18, 0x3c, 0x16, 0xf4, 0xe4, 0x63, 0xfd, 0x34, 0x28, 0x16, 0x27, 0x27,
+	0xc9, 0xb1, 0x39, 0x07, 0xbd, 0x67, 0x52, 0xcf, 0x69, 0x75, 0x07, 0x09, 0x9c, 0x70, 0x85, 0xb4,
+	0x6b, 0x3b, 0xc8, 0x4a, 0xe4, 0x04, 0x5a, 0x8e, 0xc2, 0x92, 0xf6, 0xfa, 0x8d, 0x15, 0x71, 0x3b,
+	0x75, 0x31, 0x96, 0xa0, 0xaf, 0x73, 0x78, 0x0f, 0xa0, 0x53, 0x45, 0x64, 0xdd, 0xee, 0x99, 0x48,
+	0x24, 0xad, 0x99, 0x8b, 0x98, 0xb5, 0xf7, 0x33, 0xb4, 0xcb, 0x05, 0x30, 0x8d, 0x2e, 0x90, 0x29,
+	0x37, 0x2a, 0x72, 0x49, 0xeb, 0xb3, 0x34, 0x62, 0xca, 0x8d, 0x8b, 0x5c, 0xd2, 0x7a, 0x0b, 0xbf,
+	0x1b, 0x18, 0x56, 0xf2, 0xfe, 0xac, 0xc1, 0xfd, 0xa5, 0xfd, 0xa4, 0xa7, 0xde, 0x25, 0x4e, 0xdd,
+	0xd4, 0xbb, 0xc4, 0x29, 0x79, 0x01, 0x77, 0xae, 0x34, 0xf8, 0xf9, 0xc0, 0x7b, 0xfc, 0x86, 0xed,
+	0x1a, 0xd8, 0x2c, 0x5f, 0xd4, 0x8f, 0x6a, 0xde, 0x13, 0x68, 0x97, 0x51, 0xaa, 0xd8, 0xb6, 0x53,
+	0xdc, 0xb6, 0x55, 0x88, 0xf6, 0xff, 0x69, 0x00, 0x5d, 0xdc, 0x79, 0xe9, 0xd4, 0xb6, 0x8f, 0x67,
+	0x7d, 0xf6, 0x78, 0xbe, 0x1e, 0x8c, 0x8d, 0xd5, 0x06, 0x63, 0x17, 0x9a, 0x52, 0xb1, 0xf3, 0x31,
+	0xba, 0x09, 0x6b, 0x25, 0xdd, 0x92, 0x76, 0xa5, 0x9f, 0x50, 0xd3, 0x92, 0xb9, 0x48, 0x5e, 0x2d,
+	0x19, 0x78, 0x4d, 0x43, 0xa2, 0x2f, 0xaf, 0x45, 0xd0, 0xde, 0xe3, 0xb6, 0x13, 0xef, 0x56, 0xdc,
+	0xfa, 0xeb, 0x96, 0x0c, 0xf8, 0xbe, 0xcc, 0x80, 0xa3, 0x37, 0x3d, 0x7f, 0xb1, 0x88, 0x08, 0xfb,
+	0xf3, 0xb1, 0xf9, 0xa8, 0x73, 0x0f, 0xe3, 0x62, 0x25, 0x1f, 0xc1, 0x06, 0xcf, 0xa7, 0xe5, 0x0d,
+	0x8f, 0xaf, 0xf3, 0x3b, 0xfc, 0x7b, 0x1d, 0x76, 0x5c, 0xfe, 0x17, 0x3c, 0x89, 0x15, 0x17, 0xe4,
+	0x57, 0xd8, 0x99, 0xfb, 0xa0, 0x91, 0x0f, 0x0a, 0x57, 0xaa, 0xfe, 0xe6, 0x79, 0xfe, 0x75, 0x2e,
+	0xf6, 0xd2, 0xfe, 0x1a, 0xf9, 0x1a, 0x9a, 0xcf, 0x93, 0x2b, 0x7e, 0x89, 0x84, 0x16, 0xfc, 0xad,
+	0xca, 0x65, 0xba, 0x5f, 0x61, 0x99, 0x25, 0xf8, 0x0e, 0xb6, 0xcf, 0x94, 0x40, 0x36, 0x79, 0xab,
+	0x34, 0x0f, 0x6b, 0xe4, 0x07, 0xd8, 0x2e, 0x7e, 0x6b, 0xc8, 0x7e, 0xa9, 0x6a, 0x0b, 0x7f, 0x4c,
+	0xef, 0xfd, 0xa5, 0xf6, 0xd9, 0xd9, 0x7e, 0x83, 0xdd, 0xf9, 0x9a, 0x11, 0xff, 0xe6, 0x71, 0xe0,
+	0x7d, 0xb8, 0x02, 0x61, 0xfc, 0x35, 0xf2, 0x3b, 0xf4, 0x96, 0x50, 0x82, 0x7c, 0x72, 0x4d, 0x86,
+	0x32, 0x6d, 0xbc, 0xee, 0x02, 0x27, 0x9e, 0xe9, 0x4f, 0xbf, 0xbf, 0x76, 0xde, 0x34, 0x9a, 0x4f,
+	0xff, 0x1f, 0x00, 0xb7, 0x9c, 0x3b, 0x4f, 0x31, 0x0c, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
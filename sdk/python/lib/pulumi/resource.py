@@ -15,6 +15,7 @@
 """The Resource module, containing all resource-related definitions."""
 from typing import Optional, List, Any, Mapping, Union, Callable, TYPE_CHECKING, cast
 
+import asyncio
 import copy
 
 from .runtime import known_types
@@ -594,7 +595,9 @@ class Resource:
                  name: str,
                  custom: bool,
                  props: Optional['Inputs'] = None,
-                 opts: Optional[ResourceOptions] = None) -> None:
+                 opts: Optional[ResourceOptions] = None,
+                 remote: bool = False,
+                 dependency: bool = False) -> None:
         """
         :param str t: The type of this resource.
         :param str name: The name of this resource.
@@ -602,7 +605,18 @@ class Resource:
         :param Optional[dict] props: An optional list of input properties to use as inputs for the resource.
         :param Optional[ResourceOptions] opts: Optional set of :class:`pulumi.ResourceOptions` to use for this
                resource.
+        :param bool remote: True if this is a remote component resource.
+        :param bool dependency: True if this is a synthetic resource used internally for dependency tracking.
         """
+
+        if dependency:
+            self._protect = False
+            self._providers = {}
+            self._transformations = []
+            self._aliases = []
+            self._name = name
+            return
+
         if props is None:
             props = {}
         if not t:
@@ -705,7 +719,7 @@ class Resource:
                     "Cannot read an existing resource unless it has a custom provider")
             read_resource(cast('CustomResource', self), t, name, props, opts)
         else:
-            register_resource(self, t, name, custom, props, opts)
+            register_resource(self, t, name, custom, remote, props, opts)
 
     @property
     def urn(self) -> 'Output[str]':
@@ -790,15 +804,17 @@ class CustomResource(Resource):
                  t: str,
                  name: str,
                  props: Optional[dict] = None,
-                 opts: Optional[ResourceOptions] = None) -> None:
+                 opts: Optional[ResourceOptions] = None,
+                 dependency: bool = False) -> None:
         """
         :param str t: The type of this resource.
         :param str name: The name of this resource.
         :param Optional[dict] props: An optional list of input properties to use as inputs for the resource.
         :param Optional[ResourceOptions] opts: Optional set of :class:`pulumi.ResourceOptions` to use for this
                resource.
+        :param bool dependency: True if this is a synthetic resource used internally for dependency tracking.
         """
-        Resource.__init__(self, t, name, True, props, opts)
+        Resource.__init__(self, t, name, True, props, opts, False, dependency)
         self.__pulumi_type = t
 
     @property
@@ -821,15 +837,17 @@ class ComponentResource(Resource):
                  t: str,
                  name: str,
                  props: Optional[dict] = None,
-                 opts: Optional[ResourceOptions] = None) -> None:
+                 opts: Optional[ResourceOptions] = None,
+                 remote: bool = False) -> None:
         """
         :param str t: The type of this resource.
         :param str name: The name of this resource.
         :param Optional[dict] props: An optional list of input properties to use as inputs for the resource.
         :param Optional[ResourceOptions] opts: Optional set of :class:`pulumi.ResourceOptions` to use for this
                resource.
+        :param bool remote: True if this is a remote component resource, which is constructed by its provider.
         """
-        Resource.__init__(self, t, name, False, props, opts)
+        Resource.__init__(self, t, name, False, props, opts, remote)
         self.id = None
 
     def register_outputs(self, outputs):
@@ -876,6 +894,25 @@ class ProviderResource(CustomResource):
         self.package = pkg
 
 
+class DependencyResource(CustomResource):
+    """
+    A DependencyResource is a resource that is used to indicate that an Output has a dependency on a particular
+    resource. These resources are only created when dealing with remote component resources.
+    """
+
+    def __init__(self, urn: str) -> None:
+        super().__init__("", "", {}, None, True)
+
+        from . import Output  # pylint: disable=import-outside-toplevel
+        urn_future: 'asyncio.Future[str]' = asyncio.Future()
+        urn_known: 'asyncio.Future[bool]' = asyncio.Future()
+        urn_secret: 'asyncio.Future[bool]' = asyncio.Future()
+        urn_future.set_result(urn)
+        urn_known.set_result(True)
+        urn_secret.set_result(False)
+        self.__dict__["urn"] = Output({self}, urn_future, urn_known, urn_secret)
+
+
 def export(name: str, value: Any):
     """
     Exports a named stack output.
@@ -24,6 +24,11 @@ class AnalyzerStub(object):
         request_serializer=analyzer__pb2.AnalyzeRequest.SerializeToString,
         response_deserializer=analyzer__pb2.AnalyzeResponse.FromString,
         )
+    self.Remediate = channel.unary_unary(
+        '/pulumirpc.Analyzer/Remediate',
+        request_serializer=analyzer__pb2.AnalyzeRequest.SerializeToString,
+        response_deserializer=analyzer__pb2.RemediateResponse.FromString,
+        )
     self.AnalyzeStack = channel.unary_unary(
         '/pulumirpc.Analyzer/AnalyzeStack',
         request_serializer=analyzer__pb2.AnalyzeStackRequest.SerializeToString,
@@ -61,6 +66,15 @@ class AnalyzerServicer(object):
     context.set_details('Method not implemented!')
     raise NotImplementedError('Method not implemented!')
 
+  def Remediate(self, request, context):
+    """Remediate optionally transforms a single resource object's inputs. Called with the "inputs" to the
+    resource, before they are checked by the resource's provider, and returns the inputs as modified by each
+    policy whose enforcement level is "remediate".
+    """
+    context.set_code(grpc.StatusCode.UNIMPLEMENTED)
+    context.set_details('Method not implemented!')
+    raise NotImplementedError('Method not implemented!')
+
   def AnalyzeStack(self, request, context):
     """AnalyzeStack analyzes all resources within a stack, at the end of a successful
     preview or update. The provided resources are the "outputs", after any mutations
@@ -99,6 +113,11 @@ def add_AnalyzerServicer_to_server(servicer, server):
           request_deserializer=analyzer__pb2.AnalyzeRequest.FromString,
           response_serializer=analyzer__pb2.AnalyzeResponse.SerializeToString,
       ),
+      'Remediate': grpc.unary_unary_rpc_method_handler(
+          servicer.Remediate,
+          request_deserializer=analyzer__pb2.AnalyzeRequest.FromString,
+          response_serializer=analyzer__pb2.RemediateResponse.SerializeToString,
+      ),
       'AnalyzeStack': grpc.unary_unary_rpc_method_handler(
           servicer.AnalyzeStack,
           request_deserializer=analyzer__pb2.AnalyzeStackRequest.FromString,
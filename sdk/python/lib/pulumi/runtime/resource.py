@@ -26,7 +26,7 @@ from .rpc_manager import RPC_MANAGER
 from ..metadata import get_project, get_stack
 
 if TYPE_CHECKING:
-    from .. import Resource, ResourceOptions, CustomResource, Inputs, Output
+    from .. import Resource, ResourceOptions, CustomResource, Inputs, Output, ProviderResource
 
 
 class ResourceResolverOperations(NamedTuple):
@@ -64,12 +64,18 @@ class ResourceResolverOperations(NamedTuple):
     A list of aliases applied to this resource.
     """
 
+    provider_refs: Dict[str, Optional[str]]
+    """
+    A map from package name to provider reference, used by remote components to construct their children.
+    """
+
 
 # Prepares for an RPC that will manufacture a resource, and hence deals with input and output properties.
 # pylint: disable=too-many-locals
 async def prepare_resource(res: 'Resource',
                            ty: str,
                            custom: bool,
+                           remote: bool,
                            props: 'Inputs',
                            opts: Optional['ResourceOptions']) -> ResourceResolverOperations:
     from .. import Output  # pylint: disable=import-outside-toplevel
@@ -99,13 +105,15 @@ async def prepare_resource(res: 'Resource',
     # Construct the provider reference, if we were given a provider to use.
     provider_ref = None
     if custom and opts is not None and opts.provider is not None:
-        provider = opts.provider
-
         # If we were given a provider, wait for it to resolve and construct a provider reference from it.
-        # A provider reference is a well-known string (two ::-separated values) that the engine interprets.
-        provider_urn = await provider.urn.future()
-        provider_id = await provider.id.future() or rpc.UNKNOWN
-        provider_ref = f"{provider_urn}::{provider_id}"
+        provider_ref = await _create_provider_ref(opts.provider)
+
+    # For remote resources, construct a provider reference for each of the providers that will be used by the
+    # resource's children.
+    provider_refs: Dict[str, Optional[str]] = {}
+    if remote:
+        for pkg, provider in res._providers.items():
+            provider_refs[pkg] = await _create_provider_ref(provider)
 
     dependencies = set(explicit_urn_dependencies)
     property_dependencies: Dict[str, List[Optional[str]]] = {}
@@ -135,9 +143,17 @@ async def prepare_resource(res: 'Resource',
         provider_ref,
         property_dependencies,
         aliases,
+        provider_refs,
     )
 
 
+async def _create_provider_ref(provider: 'ProviderResource') -> str:
+    # A provider reference is a well-known string (two ::-separated values) that the engine interprets.
+    provider_urn = await provider.urn.future()
+    provider_id = await provider.id.future() or rpc.UNKNOWN
+    return f"{provider_urn}::{provider_id}"
+
+
 def read_resource(res: 'CustomResource', ty: str, name: str, props: 'Inputs', opts: 'ResourceOptions') -> None:
     from .. import Output  # pylint: disable=import-outside-toplevel
     if opts.id is None:
@@ -192,7 +208,7 @@ def read_resource(res: 'CustomResource', ty: str, name: str, props: 'Inputs', op
     async def do_read():
         try:
             log.debug(f"preparing read: ty={ty}, name={name}, id={opts.id}")
-            resolver = await prepare_resource(res, ty, True, props, opts)
+            resolver = await prepare_resource(res, ty, True, False, props, opts)
 
             # Resolve the ID that we were given. Note that we are explicitly discarding the list of
             # dependencies returned to us from "serialize_property" (the second argument). This is
@@ -256,12 +272,18 @@ def read_resource(res: 'CustomResource', ty: str, name: str, props: 'Inputs', op
         log.debug(f"resource read successful: ty={ty}, urn={resp.urn}")
         resolve_urn(resp.urn)
         resolve_id(resolved_id, True, None)  # Read IDs are always known.
-        await rpc.resolve_outputs(res, resolver.serialized_props, resp.properties, resolvers)
+        await rpc.resolve_outputs(res, resolver.serialized_props, resp.properties, {}, resolvers)
 
     asyncio.ensure_future(RPC_MANAGER.do_rpc("read resource", do_read)())
 
 
-def register_resource(res: 'Resource', ty: str, name: str, custom: bool, props: 'Inputs', opts: Optional['ResourceOptions']) -> None:
+def register_resource(res: 'Resource',
+                      ty: str,
+                      name: str,
+                      custom: bool,
+                      remote: bool,
+                      props: 'Inputs',
+                      opts: Optional['ResourceOptions']) -> None:
     """
     Registers a new resource object with a given type t and name.  It returns the
     auto-generated URN and the ID that will resolve after the deployment has completed.  All
@@ -317,7 +339,7 @@ def register_resource(res: 'Resource', ty: str, name: str, custom: bool, props:
     async def do_register():
         try:
             log.debug(f"preparing resource registration: ty={ty}, name={name}")
-            resolver = await prepare_resource(res, ty, custom, props, opts)
+            resolver = await prepare_resource(res, ty, custom, remote, props, opts)
             log.debug(f"resource registration prepared: ty={ty}, name={name}")
 
             property_dependencies = {}
@@ -381,6 +403,8 @@ def register_resource(res: 'Resource', ty: str, name: str, custom: bool, props:
                 customTimeouts=custom_timeouts,
                 aliases=resolver.aliases,
                 supportsPartialValues=True,
+                remote=remote,
+                providers=resolver.provider_refs,
             )
 
             from ..resource import create_urn # pylint: disable=import-outside-toplevel
@@ -423,7 +447,14 @@ def register_resource(res: 'Resource', ty: str, name: str, custom: bool, props:
             is_known = bool(resp.id)
             resolve_id(resp.id, is_known, None)
 
-        await rpc.resolve_outputs(res, resolver.serialized_props, resp.object, resolvers)
+        # Remote components report the resources on which each of their outputs depend. Represent each of these
+        # resources as a DependencyResource so that the outputs carry the dependencies.
+        from ..resource import DependencyResource # pylint: disable=import-outside-toplevel
+        deps = {}
+        for key, property_deps in resp.propertyDependencies.items():
+            deps[key] = set(map(DependencyResource, property_deps.urns))
+
+        await rpc.resolve_outputs(res, resolver.serialized_props, resp.object, deps, resolvers)
 
     asyncio.ensure_future(RPC_MANAGER.do_rpc(
         "register resource", do_register)())
@@ -468,9 +499,11 @@ class RegisterResponse:
     urn: str
     id: str
     object: struct_pb2.Struct
+    propertyDependencies: Dict[str, Any]
 
     # pylint: disable=redefined-builtin
     def __init__(self, urn: str, id: str, object: struct_pb2.Struct):
         self.urn = urn
         self.id = id
         self.object = object
+        self.propertyDependencies = {}
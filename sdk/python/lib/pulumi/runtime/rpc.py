@@ -20,7 +20,7 @@ import asyncio
 import collections
 import functools
 import inspect
-from typing import List, Any, Callable, Dict, Mapping, Optional, Tuple, Union, TYPE_CHECKING, cast, get_type_hints
+from typing import List, Any, Callable, Dict, Mapping, Optional, Set, Tuple, Union, TYPE_CHECKING, cast, get_type_hints
 
 from google.protobuf import struct_pb2
 import six
@@ -319,17 +319,19 @@ def deserialize_property(value: Any, keep_unknowns: Optional[bool] = None) -> An
     return value
 
 
-Resolver = Callable[[Any, bool, bool, Optional[Exception]], None]
+Resolver = Callable[[Any, bool, bool, Optional[Set['Resource']], Optional[Exception]], None]
 """
-A Resolver is a function that takes four arguments:
+A Resolver is a function that takes five arguments:
     1. A value, which represents the "resolved" value of a particular output (from the engine)
     2. A boolean "is_known", which represents whether or not this value is known to have a particular value at this
        point in time (not always true for previews), and
     3. A boolean "is_secret", which represents whether or not this value is contains secret data, and
-    4. An exception, which (if provided) is an exception that occured when attempting to create the resource to whom
+    4. A set of resources, which (if provided) are the resources on which the value depends in addition to the
+       resource to whom this resolver belongs, and
+    5. An exception, which (if provided) is an exception that occured when attempting to create the resource to whom
        this resolver belongs.
 
-If argument 4 is not none, this output is considered to be abnormally resolved and attempts to await its future will
+If argument 5 is not none, this output is considered to be abnormally resolved and attempts to await its future will
 result in the exception being re-thrown.
 """
 
@@ -345,13 +347,17 @@ def transfer_properties(res: 'Resource', props: 'Inputs') -> Dict[str, Resolver]
         resolve_value: 'asyncio.Future' = asyncio.Future()
         resolve_is_known: 'asyncio.Future' = asyncio.Future()
         resolve_is_secret: 'asyncio.Future' = asyncio.Future()
+        resolve_deps: 'asyncio.Future' = asyncio.Future()
 
-        def do_resolve(value_fut: 'asyncio.Future',
+        def do_resolve(r: 'Resource',
+                       value_fut: 'asyncio.Future',
                        known_fut: 'asyncio.Future[bool]',
                        secret_fut: 'asyncio.Future[bool]',
+                       deps_fut: 'asyncio.Future[Set[Resource]]',
                        value: Any,
                        is_known: bool,
                        is_secret: bool,
+                       deps: Optional[Set['Resource']],
                        failed: Optional[Exception]):
             # Was an exception provided? If so, this is an abnormal (exceptional) resolution. Resolve the futures
             # using set_exception so that any attempts to wait for their resolution will also fail.
@@ -359,17 +365,19 @@ def transfer_properties(res: 'Resource', props: 'Inputs') -> Dict[str, Resolver]
                 value_fut.set_exception(failed)
                 known_fut.set_exception(failed)
                 secret_fut.set_exception(failed)
+                deps_fut.set_result({r})
             else:
                 value_fut.set_result(value)
                 known_fut.set_result(is_known)
                 secret_fut.set_result(is_secret)
+                deps_fut.set_result({r} | (deps or set()))
 
         # Important to note here is that the resolver's future is assigned to the resource object using the
         # name before translation. When properties are returned from the engine, we must first translate the name
         # using res.translate_output_property and then use *that* name to index into the resolvers table.
         log.debug(f"adding resolver {name}")
-        resolvers[name] = functools.partial(do_resolve, resolve_value, resolve_is_known, resolve_is_secret)
-        res.__dict__[name] = Output({res}, resolve_value, resolve_is_known, resolve_is_secret)
+        resolvers[name] = functools.partial(do_resolve, res, resolve_value, resolve_is_known, resolve_is_secret, resolve_deps)
+        res.__dict__[name] = Output(resolve_deps, resolve_value, resolve_is_known, resolve_is_secret)
 
     return resolvers
 
@@ -473,6 +481,7 @@ def contains_unknowns(val: Any) -> bool:
 async def resolve_outputs(res: 'Resource',
                           serialized_props: struct_pb2.Struct,
                           outputs: struct_pb2.Struct,
+                          deps: Mapping[str, Set['Resource']],
                           resolvers: Dict[str, Resolver]):
 
     # Produce a combined set of property states, starting with inputs and then applying
@@ -497,6 +506,9 @@ async def resolve_outputs(res: 'Resource',
                 # the user.
                 all_properties[translated_key] = translate_output_properties(deserialize_property(value), res.translate_output_property, types.get(key))
 
+    # Dependencies are keyed by the engine's property names, so translate those as well.
+    deps = {res.translate_output_property(key): value for key, value in deps.items()}
+
     for key, value in all_properties.items():
         # Skip "id" and "urn", since we handle those specially.
         if key in ["id", "urn"]:
@@ -535,18 +547,18 @@ async def resolve_outputs(res: 'Resource',
         if not settings.is_dry_run():
             # normal 'pulumi up'.  resolve the output with the value we got back
             # from the engine.  That output can always run its .apply calls.
-            resolve(value, True, is_secret, None)
+            resolve(value, True, is_secret, deps.get(key), None)
         else:
             # We're previewing. If the engine was able to give us a reasonable value back,
             # then use it. Otherwise, inform the Output that the value isn't known.
-            resolve(value, value is not None, is_secret, None)
+            resolve(value, value is not None, is_secret, deps.get(key), None)
 
     # `allProps` may not have contained a value for every resolver: for example, optional outputs may not be present.
     # We will resolve all of these values as `None`, and will mark the value as known if we are not running a
     # preview.
     for key, resolve in resolvers.items():
         if key not in all_properties:
-            resolve(None, not settings.is_dry_run(), False, None)
+            resolve(None, not settings.is_dry_run(), False, deps.get(key), None)
 
 
 def resolve_outputs_due_to_exception(resolvers: Dict[str, Resolver], exn: Exception):
@@ -559,4 +571,4 @@ def resolve_outputs_due_to_exception(resolvers: Dict[str, Resolver], exn: Except
     """
     for key, resolve in resolvers.items():
         log.debug(f"sending exception to resolver for {key}")
-        resolve(None, False, False, exn)
+        resolve(None, False, False, None, exn)
@@ -0,0 +1,37 @@
+# Copyright 2016-2020, Pulumi Corporation.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+from pulumi import ProviderResource, CustomResource, ComponentResource, ResourceOptions
+
+class Provider(ProviderResource):
+    def __init__(self, name, opts=None):
+        ProviderResource.__init__(self, "test", name, {}, opts)
+
+class RemoteComponent(ComponentResource):
+    def __init__(self, name, foo, opts=None):
+        ComponentResource.__init__(self, "test:index:RemoteComponent", name, {
+            "foo": foo,
+            "child_id": None,
+        }, opts, remote=True)
+
+class MyResource(CustomResource):
+    def __init__(self, name, child_id, opts=None):
+        CustomResource.__init__(self, "test:index:MyResource", name, {
+            "child_id": child_id,
+        }, opts)
+
+prov = Provider("prov")
+comp = RemoteComponent("comp", "bar", ResourceOptions(providers=[prov]))
+
+# Outputs of the remote component carry the dependencies reported by the engine.
+MyResource("res", comp.child_id)
@@ -0,0 +1,61 @@
+# Copyright 2016-2020, Pulumi Corporation.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+from os import path
+from ..util import LanghostTest
+
+
+class RemoteComponentTest(LanghostTest):
+    """
+    Tests that remote components are registered with their resolved providers, and that their outputs depend on the
+    resources reported by the engine.
+    """
+
+    def test_remote_component(self):
+        self.run_test(
+            program=path.join(self.base_path(), "remote_component"),
+            expected_resource_count=3)
+
+    def register_resource(self, _ctx, _dry_run, ty, name, resource, dependencies, _parent, custom, _protect,
+                          _provider, property_deps, _delete_before_replace, _ignore_changes, _version, _import,
+                          remote, providers):
+        if ty == "pulumi:providers:test":
+            self.assertFalse(remote)
+        elif ty == "test:index:RemoteComponent":
+            self.assertFalse(custom)
+            self.assertTrue(remote)
+            self.assertEqual("bar", resource["foo"])
+            self.assertDictEqual({"test": "pulumi:providers:test::prov::prov"}, providers)
+            return {
+                "urn": self.make_urn(ty, name),
+                "object": {
+                    "foo": "bar",
+                    "child_id": "child-id",
+                },
+                "property_dependencies": {
+                    "child_id": ["test:index:Child::child"],
+                },
+            }
+        elif ty == "test:index:MyResource":
+            self.assertFalse(remote)
+            self.assertDictEqual({"child_id": "child-id"}, resource)
+            expected_deps = ["test:index:Child::child", "test:index:RemoteComponent::comp"]
+            self.assertListEqual(expected_deps, dependencies)
+            self.assertDictEqual({"child_id": expected_deps}, property_deps)
+        else:
+            self.fail(f"unexpected resource type {ty}")
+
+        return {
+            "urn": self.make_urn(ty, name),
+            "id": name,
+        }
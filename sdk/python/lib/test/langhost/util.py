@@ -98,6 +98,8 @@ class LanghostMockResourceMonitor(proto.ResourceMonitorServicer):
         ignore_changes = sorted(list(request.ignoreChanges))
         version = request.version
         import_ = request.importId
+        remote = request.remote
+        providers = dict(request.providers)
 
         property_dependencies = {}
         for key, value in request.propertyDependencies.items():
@@ -106,7 +108,7 @@ class LanghostMockResourceMonitor(proto.ResourceMonitorServicer):
         outs = {}
         if type_ != "pulumi:pulumi:Stack":
             rrsig = signature(self.langhost_test.register_resource)
-            args = [context, self.dryrun, type_, name, props, deps, parent, custom, protect, provider, property_dependencies, delete_before_replace, ignore_changes, version, import_]
+            args = [context, self.dryrun, type_, name, props, deps, parent, custom, protect, provider, property_dependencies, delete_before_replace, ignore_changes, version, import_, remote, providers]
             outs = self.langhost_test.register_resource(*args[0:len(rrsig.parameters)])
             if outs.get("urn"):
                 urn = outs["urn"]
@@ -134,8 +136,13 @@ class LanghostMockResourceMonitor(proto.ResourceMonitorServicer):
             loop.close()
         else:
             obj_proto = None
+        output_property_dependencies = None
+        if "property_dependencies" in outs:
+            output_property_dependencies = {}
+            for key, urns in outs["property_dependencies"].items():
+                output_property_dependencies[key] = proto.RegisterResourceResponse.PropertyDependencies(urns=urns)
         return proto.RegisterResourceResponse(
-            urn=outs.get("urn"), id=outs.get("id"), object=obj_proto)
+            urn=outs.get("urn"), id=outs.get("id"), object=obj_proto, propertyDependencies=output_property_dependencies)
 
     def RegisterResourceOutputs(self, request, context):
         urn = request.urn